@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"user-svc/internal/adapters/repository/postgres"
+	"user-svc/internal/adapters/repository/search"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+	"user-svc/internal/shared/config"
+	"user-svc/internal/shared/cursor"
+	"user-svc/internal/shared/logger"
+	"user-svc/internal/shared/open_search"
+)
+
+func main() {
+	// Define command-line flags
+	file := flag.String("file", "", "Path to the RBAC manifest (.yaml, .yml or .json)")
+	apply := flag.Bool("apply", false, "Apply the plan instead of only showing it")
+	prune := flag.Bool("prune", false, "Remove roles, permissions and bindings the manifest does not declare")
+	dryRun := flag.Bool("dry-run", false, "Apply the plan in a transaction that is rolled back")
+	flag.Parse()
+
+	// Validate the manifest file flag
+	if *file == "" {
+		log.Fatal("Please provide the path of the manifest file")
+	}
+
+	manifest, err := readManifest(*file)
+	if err != nil {
+		log.Fatal("Failed to read the manifest:", err)
+	}
+
+	cfg := config.New()
+	repo := postgres.NewRepository(cfg)
+	openSearch := open_search.NewClient(cfg)
+	searchRepo := search.NewRepository(cfg, openSearch)
+
+	safeguardService := services.NewSafeguardService(cfg, repo)
+	accessImpactService := services.NewAccessImpactService(repo, repo, repo, repo, repo, repo, domain.NewRouteIndex())
+	userSearchService := services.NewUserSearchService(cfg, searchRepo, repo, repo, logger.NewLogger(cfg, openSearch))
+	roleService := services.NewRoleService(repo, safeguardService, accessImpactService, userSearchService, cursor.NewCodec(cfg))
+	roleConstraintService := services.NewRoleConstraintService(repo, roleService)
+	manifestService := services.NewManifestService(repo, repo, repo, repo, repo, repo, safeguardService, roleConstraintService, userSearchService)
+
+	options := &domain.ManifestOptions{Prune: *prune, DryRun: *dryRun}
+	var plan *domain.ManifestPlan
+	if *apply || *dryRun {
+		plan, err = manifestService.Apply(manifest, options)
+	} else {
+		plan, err = manifestService.Plan(manifest, options)
+	}
+	if err != nil {
+		log.Fatal("Failed to plan the manifest: ", err)
+	}
+
+	printPlan(plan)
+	switch {
+	case plan.Empty():
+		fmt.Println("No changes, the database matches the manifest.")
+	case *dryRun:
+		fmt.Println("Dry run completed successfully, no changes were committed.")
+	case *apply:
+		fmt.Println("Manifest applied successfully!")
+	default:
+		fmt.Println("Run again with -apply to apply these changes.")
+	}
+}
+
+func readManifest(path string) (*domain.Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest domain.Manifest
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &manifest)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &manifest)
+	default:
+		err = fmt.Errorf("unsupported manifest format %s", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
+func printPlan(plan *domain.ManifestPlan) {
+	for _, permission := range plan.CreatePermissions {
+		fmt.Printf("+ permission %s\n", permission.Name)
+	}
+	for _, role := range plan.CreateRoles {
+		fmt.Printf("+ role %s (active: %t)\n", role.Name, role.Active)
+	}
+	for _, role := range plan.UpdateRoles {
+		fmt.Printf("~ role %s (active: %t)\n", role.Name, role.Active)
+	}
+	for _, binding := range plan.AddRolePermissions {
+		fmt.Printf("+ role %s -> permission %s\n", binding.RoleName, binding.PermissionName)
+	}
+	for _, binding := range plan.AddUserRoles {
+		fmt.Printf("+ user %s -> role %s\n", binding.UserEmail, binding.RoleName)
+	}
+	for _, binding := range plan.RemoveUserRoles {
+		fmt.Printf("- user %s -> role %s\n", binding.UserEmail, binding.RoleName)
+	}
+	for _, binding := range plan.RemoveRolePermissions {
+		fmt.Printf("- role %s -> permission %s\n", binding.RoleName, binding.PermissionName)
+	}
+	for _, role := range plan.DeleteRoles {
+		fmt.Printf("- role %s\n", role.Name)
+	}
+	for _, permission := range plan.DeletePermissions {
+		fmt.Printf("- permission %s\n", permission.Name)
+	}
+}
@@ -0,0 +1,7 @@
+ALTER TABLE
+    roles
+    DROP COLUMN IF EXISTS system;
+
+ALTER TABLE
+    permissions
+    DROP COLUMN IF EXISTS system;
@@ -0,0 +1,30 @@
+ALTER TABLE
+    roles
+ADD
+    COLUMN IF NOT EXISTS system BOOLEAN DEFAULT FALSE NOT NULL;
+
+ALTER TABLE
+    permissions
+ADD
+    COLUMN IF NOT EXISTS system BOOLEAN DEFAULT FALSE NOT NULL;
+
+-- databases seeded before this migration already hold the built-in role and
+-- permissions, they are flagged the way the seeder flags them. Permissions
+-- registered later are flagged by the permission sync on startup.
+UPDATE
+    roles
+SET
+    system = TRUE
+WHERE
+    name = 'Admin';
+
+UPDATE
+    permissions
+SET
+    system = TRUE
+WHERE
+    name IN (
+        'List-User', 'View-User', 'Create-User', 'Update-User', 'Delete-User',
+        'List-Role', 'View-Role', 'Create-Role', 'Update-Role', 'Delete-Role',
+        'List-Permission', 'View-Permission', 'Create-Permission', 'Update-Permission', 'Delete-Permission'
+    );
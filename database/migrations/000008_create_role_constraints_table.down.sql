@@ -0,0 +1,2 @@
+drop table if exists role_constraint_role cascade;
+drop table if exists role_constraints cascade;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS role_constraints (
+    id UUID PRIMARY KEY NOT NULL,
+    name VARCHAR(255) NOT NULL CONSTRAINT role_constraints_name_unique UNIQUE,
+    type VARCHAR(50) NOT NULL,
+    max_count INTEGER NOT NULL,
+    created_at TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS role_constraint_role (
+    constraint_id UUID NOT NULL,
+    role_id       UUID NOT NULL,
+    PRIMARY KEY (constraint_id, role_id)
+);
+
+ALTER TABLE
+    role_constraint_role
+ADD
+    CONSTRAINT role_constraint_role_constraint_id_foreign FOREIGN KEY (constraint_id) REFERENCES role_constraints (id) ON DELETE CASCADE;
+
+ALTER TABLE
+    role_constraint_role
+ADD
+    CONSTRAINT role_constraint_role_role_id_foreign FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE;
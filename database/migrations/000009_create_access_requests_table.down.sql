@@ -0,0 +1,5 @@
+drop table if exists access_requests cascade;
+
+ALTER TABLE
+    user_role
+    DROP COLUMN IF EXISTS expires_at;
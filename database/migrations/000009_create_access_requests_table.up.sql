@@ -0,0 +1,31 @@
+ALTER TABLE
+    user_role
+ADD
+    COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS access_requests (
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID NOT NULL,
+    role_id UUID NOT NULL,
+    justification TEXT NOT NULL,
+    duration INTEGER,
+    status VARCHAR(20) NOT NULL,
+    reviewer_id UUID,
+    review_note TEXT,
+    reviewed_at TIMESTAMP,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS access_requests_status_index ON access_requests (status);
+
+ALTER TABLE
+    access_requests
+ADD
+    CONSTRAINT access_requests_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE
+    access_requests
+ADD
+    CONSTRAINT access_requests_role_id_foreign FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+drop table if exists group_role cascade;
+drop table if exists group_user cascade;
+drop table if exists groups cascade;
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS groups (
+    id UUID PRIMARY KEY NOT NULL,
+    name VARCHAR(255) NOT NULL CONSTRAINT groups_name_unique UNIQUE,
+    created_at TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS group_user (
+    group_id UUID NOT NULL,
+    user_id  UUID NOT NULL,
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS group_role (
+    group_id UUID NOT NULL,
+    role_id  UUID NOT NULL,
+    PRIMARY KEY (group_id, role_id)
+);
+
+ALTER TABLE
+    group_user
+ADD
+    CONSTRAINT group_user_group_id_foreign FOREIGN KEY (group_id) REFERENCES groups (id) ON DELETE CASCADE;
+
+ALTER TABLE
+    group_user
+ADD
+    CONSTRAINT group_user_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE
+    group_role
+ADD
+    CONSTRAINT group_role_group_id_foreign FOREIGN KEY (group_id) REFERENCES groups (id) ON DELETE CASCADE;
+
+ALTER TABLE
+    group_role
+ADD
+    CONSTRAINT group_role_role_id_foreign FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE;
@@ -0,0 +1,2 @@
+drop table if exists admin_scope_permission cascade;
+drop table if exists admin_scope_role cascade;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS admin_scope_role (
+    admin_role_id UUID NOT NULL,
+    role_id       UUID NOT NULL,
+    PRIMARY KEY (admin_role_id, role_id)
+);
+
+CREATE TABLE IF NOT EXISTS admin_scope_permission (
+    admin_role_id UUID NOT NULL,
+    permission_id UUID NOT NULL,
+    PRIMARY KEY (admin_role_id, permission_id)
+);
+
+ALTER TABLE
+    admin_scope_role
+ADD
+    CONSTRAINT admin_scope_role_admin_role_id_foreign FOREIGN KEY (admin_role_id) REFERENCES roles (id) ON DELETE CASCADE;
+
+ALTER TABLE
+    admin_scope_role
+ADD
+    CONSTRAINT admin_scope_role_role_id_foreign FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE;
+
+ALTER TABLE
+    admin_scope_permission
+ADD
+    CONSTRAINT admin_scope_permission_admin_role_id_foreign FOREIGN KEY (admin_role_id) REFERENCES roles (id) ON DELETE CASCADE;
+
+ALTER TABLE
+    admin_scope_permission
+ADD
+    CONSTRAINT admin_scope_permission_permission_id_foreign FOREIGN KEY (permission_id) REFERENCES permissions (id) ON DELETE CASCADE;
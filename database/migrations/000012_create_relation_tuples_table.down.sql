@@ -0,0 +1,2 @@
+drop table if exists relation_tuples cascade;
+drop sequence if exists relation_tuple_revision;
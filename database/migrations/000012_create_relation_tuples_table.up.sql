@@ -0,0 +1,21 @@
+CREATE SEQUENCE IF NOT EXISTS relation_tuple_revision;
+
+CREATE TABLE IF NOT EXISTS relation_tuples (
+    namespace         VARCHAR(255) NOT NULL,
+    object_id         VARCHAR(255) NOT NULL,
+    relation          VARCHAR(255) NOT NULL,
+    subject_namespace VARCHAR(255) NOT NULL,
+    subject_id        VARCHAR(255) NOT NULL,
+    subject_relation  VARCHAR(255) NOT NULL DEFAULT '',
+    created_revision  BIGINT NOT NULL,
+    deleted_revision  BIGINT,
+    created_at        TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS relation_tuples_live_unique
+    ON relation_tuples (namespace, object_id, relation, subject_namespace, subject_id, subject_relation)
+    WHERE deleted_revision IS NULL;
+
+CREATE INDEX IF NOT EXISTS relation_tuples_object_index ON relation_tuples (namespace, object_id, relation);
+
+CREATE INDEX IF NOT EXISTS relation_tuples_subject_index ON relation_tuples (subject_namespace, subject_id, subject_relation);
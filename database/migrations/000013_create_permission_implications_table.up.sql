@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS permission_implications (
+    permission_id         UUID NOT NULL,
+    implied_permission_id UUID NOT NULL,
+    PRIMARY KEY (permission_id, implied_permission_id)
+);
+
+ALTER TABLE
+    permission_implications
+ADD
+    CONSTRAINT permission_implications_permission_id_foreign FOREIGN KEY (permission_id) REFERENCES permissions (id) ON DELETE CASCADE;
+
+ALTER TABLE
+    permission_implications
+ADD
+    CONSTRAINT permission_implications_implied_permission_id_foreign FOREIGN KEY (implied_permission_id) REFERENCES permissions (id) ON DELETE CASCADE;
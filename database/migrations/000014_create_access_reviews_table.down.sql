@@ -0,0 +1,2 @@
+drop table if exists access_review_items cascade;
+drop table if exists access_reviews cascade;
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS access_reviews (
+    id UUID PRIMARY KEY NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_by UUID NOT NULL,
+    closed_by UUID,
+    due_at TIMESTAMP,
+    closed_at TIMESTAMP,
+    created_at TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+-- items snapshot the reviewed assignment, including names, and keep no
+-- reference to users or roles so the evidence outlives them
+CREATE TABLE IF NOT EXISTS access_review_items (
+    id UUID PRIMARY KEY NOT NULL,
+    review_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    user_email VARCHAR(255) NOT NULL,
+    role_id UUID NOT NULL,
+    role_name VARCHAR(255) NOT NULL,
+    reviewer_id UUID NOT NULL,
+    decision VARCHAR(20),
+    comment TEXT,
+    decided_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    revoke_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS access_review_items_review_id_index ON access_review_items (review_id);
+
+ALTER TABLE
+    access_review_items
+ADD
+    CONSTRAINT access_review_items_review_id_foreign FOREIGN KEY (review_id) REFERENCES access_reviews (id) ON DELETE CASCADE;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS permission_usage (
+    user_id       UUID NOT NULL,
+    permission_id UUID NOT NULL,
+    last_used_at  TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, permission_id)
+);
+
+ALTER TABLE
+    permission_usage
+ADD
+    CONSTRAINT permission_usage_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE
+    permission_usage
+ADD
+    CONSTRAINT permission_usage_permission_id_foreign FOREIGN KEY (permission_id) REFERENCES permissions (id) ON DELETE CASCADE;
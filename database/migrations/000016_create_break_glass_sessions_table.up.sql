@@ -0,0 +1,16 @@
+-- sessions keep no reference to users or roles so the audit trail outlives
+-- them, like access review items
+CREATE TABLE IF NOT EXISTS break_glass_sessions (
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID NOT NULL,
+    role_id UUID NOT NULL,
+    role_name VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    activated_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP,
+    ended_by UUID
+);
+
+CREATE INDEX IF NOT EXISTS break_glass_sessions_status_expires_at_index ON break_glass_sessions (status, expires_at);
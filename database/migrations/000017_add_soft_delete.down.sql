@@ -0,0 +1,26 @@
+-- rows still deleted are gone for good once the column is dropped
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+DELETE FROM roles WHERE deleted_at IS NOT NULL;
+DELETE FROM permissions WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS users_deleted_at_index;
+DROP INDEX IF EXISTS roles_deleted_at_index;
+DROP INDEX IF EXISTS permissions_deleted_at_index;
+
+DROP INDEX IF EXISTS users_email_unique;
+ALTER TABLE users ADD CONSTRAINT users_email_unique UNIQUE (email);
+
+DROP INDEX IF EXISTS roles_name_unique;
+ALTER TABLE roles ADD CONSTRAINT roles_name_unique UNIQUE (name);
+
+ALTER TABLE
+    users
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE
+    roles
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE
+    permissions
+    DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,26 @@
+ALTER TABLE
+    users
+ADD
+    COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+ALTER TABLE
+    roles
+ADD
+    COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+ALTER TABLE
+    permissions
+ADD
+    COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+-- a deleted user or role no longer holds on to its email or name, a restore
+-- is refused while another one uses it
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_unique;
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_unique ON users (email) WHERE deleted_at IS NULL;
+
+ALTER TABLE roles DROP CONSTRAINT IF EXISTS roles_name_unique;
+CREATE UNIQUE INDEX IF NOT EXISTS roles_name_unique ON roles (name) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS users_deleted_at_index ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS roles_deleted_at_index ON roles (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS permissions_deleted_at_index ON permissions (deleted_at) WHERE deleted_at IS NOT NULL;
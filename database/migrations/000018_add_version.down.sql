@@ -0,0 +1,11 @@
+ALTER TABLE
+    users
+    DROP COLUMN IF EXISTS version;
+
+ALTER TABLE
+    roles
+    DROP COLUMN IF EXISTS version;
+
+ALTER TABLE
+    permissions
+    DROP COLUMN IF EXISTS version;
@@ -0,0 +1,14 @@
+ALTER TABLE
+    users
+ADD
+    COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE
+    roles
+ADD
+    COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE
+    permissions
+ADD
+    COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,17 @@
+-- the report keeps one result per row, passwords are never part of it
+CREATE TABLE IF NOT EXISTS user_imports (
+    id UUID PRIMARY KEY NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by UUID NOT NULL,
+    total INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    created INTEGER NOT NULL DEFAULT 0,
+    invalid INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    rows JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP
+);
@@ -0,0 +1,7 @@
+drop table if exists user_attribute_definitions cascade;
+
+DROP INDEX IF EXISTS users_attributes_index;
+
+ALTER TABLE
+    users
+    DROP COLUMN IF EXISTS attributes;
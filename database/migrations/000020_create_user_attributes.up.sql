@@ -0,0 +1,18 @@
+ALTER TABLE
+    users
+ADD
+    COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS users_attributes_index ON users USING GIN (attributes);
+
+-- the schema of the attributes, a user only holds attributes defined here
+CREATE TABLE IF NOT EXISTS user_attribute_definitions (
+    name VARCHAR(63) PRIMARY KEY NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    rules TEXT NOT NULL DEFAULT '',
+    claim BOOLEAN NOT NULL DEFAULT FALSE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1 @@
+drop table if exists relation_tuple_store cascade;
@@ -0,0 +1,10 @@
+-- the revision of the last committed write, the sequence hands out revisions
+-- before their write commits so it cannot tell what reads may see
+CREATE TABLE IF NOT EXISTS relation_tuple_store (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    revision BIGINT NOT NULL
+);
+
+INSERT INTO relation_tuple_store (revision)
+SELECT CASE WHEN is_called THEN last_value ELSE 0 END FROM relation_tuple_revision
+ON CONFLICT DO NOTHING;
@@ -0,0 +1 @@
+drop table if exists break_glass_secrets cascade;
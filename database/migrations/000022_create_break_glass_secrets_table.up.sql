@@ -0,0 +1,13 @@
+-- every user activates break-glass with their own second factor, the secret
+-- goes with the user
+CREATE TABLE IF NOT EXISTS break_glass_secrets (
+    user_id UUID PRIMARY KEY NOT NULL,
+    secret VARCHAR(64) NOT NULL,
+    enrolled_by UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+ALTER TABLE
+    break_glass_secrets
+ADD
+    CONSTRAINT break_glass_secrets_user_id_foreign FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
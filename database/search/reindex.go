@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"user-svc/internal/adapters/repository/postgres"
+	"user-svc/internal/adapters/repository/search"
+	"user-svc/internal/core/services"
+	"user-svc/internal/shared/config"
+	"user-svc/internal/shared/logger"
+	"user-svc/internal/shared/open_search"
+)
+
+func main() {
+	cfg := config.New()
+	repo := postgres.NewRepository(cfg)
+	openSearch := open_search.NewClient(cfg)
+	searchRepo := search.NewRepository(cfg, openSearch)
+
+	userSearchService := services.NewUserSearchService(cfg, searchRepo, repo, repo, logger.NewLogger(cfg, openSearch))
+	indexed, err := userSearchService.Reindex()
+	if err != nil {
+		log.Fatalf("Failed to reindex users after %d were indexed: %v", indexed, err)
+	}
+	fmt.Printf("Reindexed %d users into %s successfully!\n", indexed, cfg.App.UserSearch.Index)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/jaswdr/faker"
 	"time"
+	"user-svc/internal/core/domain"
 )
 
 func (s *Seeder) permissionSeeder() (err error) {
@@ -16,20 +17,17 @@ func (s *Seeder) permissionSeeder() (err error) {
 
 	fake := faker.New()
 	now := time.Now()
-	permissions := []string{
-		"List-User", "View-User", "Create-User", "Update-User", "Delete-User",
-		"List-Role", "View-Role", "Create-Role", "Update-Role", "Delete-Role",
-		"List-Permission", "View-Permission", "Create-Permission", "Update-Permission", "Delete-Permission",
-	}
+	permissions := domain.RegisteredPermissions
 
 	for i := 0; i < len(permissions); i++ {
-		query := fmt.Sprintf("INSERT INTO %s (id, name, created_at, updated_at) values ($1, $2, $3, $4)", table)
+		// built-in permissions are referenced by the routes, so they are seeded as system permissions
+		query := fmt.Sprintf("INSERT INTO %s (id, name, system, created_at, updated_at) values ($1, $2, $3, $4, $5)", table)
 		stmt, err := s.db.Prepare(query)
 		if err != nil {
 			return err
 		}
 
-		_, err = stmt.Exec(fake.UUID().V4(), permissions[i], now, now)
+		_, err = stmt.Exec(fake.UUID().V4(), string(permissions[i]), true, now, now)
 		if err != nil {
 			return err
 		}
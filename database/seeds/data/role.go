@@ -17,15 +17,16 @@ func (s *Seeder) roleSeeder() (err error) {
 	fake := faker.New()
 	now := time.Now()
 	roles := []string{"Admin", "Manager", "User", "Guest"}
+	systemRoles := map[string]bool{"Admin": true}
 
 	for i := 0; i < len(roles); i++ {
-		query := fmt.Sprintf("INSERT INTO %s (id, name, active, created_at, updated_at) values ($1, $2, $3, $4, $5)", table)
+		query := fmt.Sprintf("INSERT INTO %s (id, name, active, system, created_at, updated_at) values ($1, $2, $3, $4, $5, $6)", table)
 		stmt, err := s.db.Prepare(query)
 		if err != nil {
 			return err
 		}
 
-		_, err = stmt.Exec(fake.UUID().V4(), roles[i], true, now, now)
+		_, err = stmt.Exec(fake.UUID().V4(), roles[i], true, systemRoles[roles[i]], now, now)
 		if err != nil {
 			return err
 		}
@@ -16,17 +16,29 @@ func (s *Seeder) rolePermissionSeeder() error {
 	// Define the roles and their corresponding permissions
 	rolePermissions := map[string][]string{
 		"Admin": {
-			"List-User", "View-User", "Create-User", "Update-User", "Delete-User",
+			"List-User", "View-User", "Create-User", "Update-User", "Delete-User", "Import-User", "Export-User",
+			"List-User-Attribute", "View-User-Attribute", "Create-User-Attribute", "Update-User-Attribute", "Delete-User-Attribute",
 			"List-Role", "View-Role", "Create-Role", "Update-Role", "Delete-Role",
+			"Update-Admin-Scope",
 			"List-Permission", "View-Permission", "Create-Permission", "Update-Permission", "Delete-Permission",
+			"View-Permission-Usage",
+			"List-Role-Constraint", "View-Role-Constraint", "Create-Role-Constraint", "Delete-Role-Constraint",
+			"List-Access-Request", "View-Access-Request", "Create-Access-Request", "Approve-Access-Request",
+			"List-Access-Review", "View-Access-Review", "Create-Access-Review", "Decide-Access-Review", "Close-Access-Review",
+			"List-Break-Glass", "View-Break-Glass", "Activate-Break-Glass", "End-Break-Glass", "Enroll-Break-Glass",
+			"List-Group", "View-Group", "Create-Group", "Update-Group", "Delete-Group",
+			"List-Relation", "Check-Relation", "Write-Relation",
 		},
 		"Manager": {
 			"List-User", "View-User", "Create-User", "Update-User",
 			"List-Role", "View-Role", "Create-Role", "Update-Role",
 			"List-Permission", "View-Permission",
+			"Create-Access-Request",
+			"List-Access-Review", "View-Access-Review", "Decide-Access-Review",
 		},
 		"User": {
 			"List-User", "View-User", "Create-User", "Update-User",
+			"Create-Access-Request",
 		},
 		"Guest": {
 			"View-User", "View-Role", "View-Permission",
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+	"user-svc/internal/shared/constants"
+)
+
+type AccessRequestHandler struct {
+	accessRequestService services.AccessRequestService
+}
+
+func NewAccessRequestHandler(accessRequestService services.AccessRequestService) *AccessRequestHandler {
+	return &AccessRequestHandler{
+		accessRequestService: accessRequestService,
+	}
+}
+
+func (h *AccessRequestHandler) CreateAccessRequest(c echo.Context) error {
+	var accessRequest domain.CreateAccessRequestRequest
+	if err := c.Bind(&accessRequest); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&accessRequest); err != nil {
+		return err
+	}
+
+	accessRequest.UserId = c.Get(constants.KeyUserID).(string)
+	result, err := h.accessRequestService.CreateAccessRequest(&accessRequest)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *AccessRequestHandler) ApproveAccessRequest(c echo.Context) error {
+	var accessRequest domain.ReviewAccessRequestRequest
+	if err := c.Bind(&accessRequest); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&accessRequest); err != nil {
+		return err
+	}
+
+	accessRequest.ReviewerId = c.Get(constants.KeyUserID).(string)
+	result, err := h.accessRequestService.ApproveAccessRequest(&accessRequest)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *AccessRequestHandler) RejectAccessRequest(c echo.Context) error {
+	var accessRequest domain.ReviewAccessRequestRequest
+	if err := c.Bind(&accessRequest); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&accessRequest); err != nil {
+		return err
+	}
+
+	accessRequest.ReviewerId = c.Get(constants.KeyUserID).(string)
+	result, err := h.accessRequestService.RejectAccessRequest(&accessRequest)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *AccessRequestHandler) RevokeAccessRequest(c echo.Context) error {
+	var accessRequest domain.ReviewAccessRequestRequest
+	if err := c.Bind(&accessRequest); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&accessRequest); err != nil {
+		return err
+	}
+
+	accessRequest.ReviewerId = c.Get(constants.KeyUserID).(string)
+	result, err := h.accessRequestService.RevokeAccessRequest(&accessRequest)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *AccessRequestHandler) AccessRequests(c echo.Context) error {
+	var accessRequest domain.GetAccessRequestsRequest
+	if err := c.Bind(&accessRequest); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&accessRequest); err != nil {
+		return err
+	}
+
+	result, err := h.accessRequestService.GetAccessRequests(&accessRequest)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *AccessRequestHandler) AccessRequest(c echo.Context) error {
+	var accessRequest domain.GetAccessRequestRequest
+	if err := c.Bind(&accessRequest); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&accessRequest); err != nil {
+		return err
+	}
+
+	result, err := h.accessRequestService.GetAccessRequest(accessRequest.Id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
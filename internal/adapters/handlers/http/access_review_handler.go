@@ -0,0 +1,120 @@
+package http
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+	"user-svc/internal/shared/constants"
+)
+
+type AccessReviewHandler struct {
+	accessReviewService services.AccessReviewService
+}
+
+func NewAccessReviewHandler(accessReviewService services.AccessReviewService) *AccessReviewHandler {
+	return &AccessReviewHandler{
+		accessReviewService: accessReviewService,
+	}
+}
+
+func (h *AccessReviewHandler) CreateAccessReview(c echo.Context) error {
+	var accessReview domain.CreateAccessReviewRequest
+	if err := c.Bind(&accessReview); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&accessReview); err != nil {
+		return err
+	}
+
+	accessReview.CreatedBy = c.Get(constants.KeyUserID).(string)
+	result, err := h.accessReviewService.CreateAccessReview(&accessReview)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *AccessReviewHandler) DecideAccessReviewItem(c echo.Context) error {
+	var decision domain.DecideAccessReviewItemRequest
+	if err := c.Bind(&decision); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&decision); err != nil {
+		return err
+	}
+
+	decision.ReviewerId = c.Get(constants.KeyUserID).(string)
+	result, err := h.accessReviewService.DecideAccessReviewItem(&decision)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *AccessReviewHandler) CloseAccessReview(c echo.Context) error {
+	var accessReview domain.CloseAccessReviewRequest
+	if err := c.Bind(&accessReview); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&accessReview); err != nil {
+		return err
+	}
+
+	accessReview.ActorId = c.Get(constants.KeyUserID).(string)
+	result, err := h.accessReviewService.CloseAccessReview(&accessReview)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *AccessReviewHandler) AccessReviews(c echo.Context) error {
+	result, err := h.accessReviewService.GetAccessReviews()
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *AccessReviewHandler) AccessReview(c echo.Context) error {
+	var accessReview domain.GetAccessReviewRequest
+	if err := c.Bind(&accessReview); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&accessReview); err != nil {
+		return err
+	}
+
+	result, err := h.accessReviewService.GetAccessReview(accessReview.Id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *AccessReviewHandler) ExportAccessReview(c echo.Context) error {
+	var accessReview domain.ExportAccessReviewRequest
+	if err := c.Bind(&accessReview); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&accessReview); err != nil {
+		return err
+	}
+
+	export, err := h.accessReviewService.ExportAccessReview(&accessReview)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", export.FileName))
+	return c.Blob(http.StatusOK, export.ContentType, export.Content)
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+)
+
+type AdminScopeHandler struct {
+	adminScopeService services.AdminScopeService
+}
+
+func NewAdminScopeHandler(adminScopeService services.AdminScopeService) *AdminScopeHandler {
+	return &AdminScopeHandler{
+		adminScopeService: adminScopeService,
+	}
+}
+
+func (h *AdminScopeHandler) UpdateAdminScope(c echo.Context) error {
+	var scope domain.UpdateAdminScopeRequest
+	if err := c.Bind(&scope); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&scope); err != nil {
+		return err
+	}
+
+	result, err := h.adminScopeService.UpdateAdminScope(&scope)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *AdminScopeHandler) AdminScope(c echo.Context) error {
+	var scope domain.GetAdminScopeRequest
+	if err := c.Bind(&scope); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&scope); err != nil {
+		return err
+	}
+
+	result, err := h.adminScopeService.GetAdminScope(scope.RoleId)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
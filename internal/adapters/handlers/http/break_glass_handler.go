@@ -0,0 +1,127 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+	"user-svc/internal/shared/constants"
+)
+
+type BreakGlassHandler struct {
+	breakGlassService services.BreakGlassService
+}
+
+func NewBreakGlassHandler(breakGlassService services.BreakGlassService) *BreakGlassHandler {
+	return &BreakGlassHandler{
+		breakGlassService: breakGlassService,
+	}
+}
+
+func (h *BreakGlassHandler) ActivateBreakGlass(c echo.Context) error {
+	var breakGlass domain.ActivateBreakGlassRequest
+	if err := c.Bind(&breakGlass); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&breakGlass); err != nil {
+		return err
+	}
+
+	breakGlass.UserId = c.Get(constants.KeyUserID).(string)
+	result, err := h.breakGlassService.ActivateBreakGlass(&breakGlass)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *BreakGlassHandler) EnrollBreakGlass(c echo.Context) error {
+	var enrollment domain.EnrollBreakGlassRequest
+	if err := c.Bind(&enrollment); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&enrollment); err != nil {
+		return err
+	}
+
+	enrollment.ActorId = c.Get(constants.KeyUserID).(string)
+	result, err := h.breakGlassService.EnrollBreakGlass(&enrollment)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *BreakGlassHandler) UnenrollBreakGlass(c echo.Context) error {
+	var enrollment domain.UnenrollBreakGlassRequest
+	if err := c.Bind(&enrollment); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&enrollment); err != nil {
+		return err
+	}
+
+	enrollment.ActorId = c.Get(constants.KeyUserID).(string)
+	result, err := h.breakGlassService.UnenrollBreakGlass(&enrollment)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *BreakGlassHandler) EndBreakGlass(c echo.Context) error {
+	var breakGlass domain.EndBreakGlassRequest
+	if err := c.Bind(&breakGlass); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&breakGlass); err != nil {
+		return err
+	}
+
+	breakGlass.ActorId = c.Get(constants.KeyUserID).(string)
+	result, err := h.breakGlassService.EndBreakGlass(&breakGlass)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *BreakGlassHandler) BreakGlassSessions(c echo.Context) error {
+	var breakGlass domain.GetBreakGlassSessionsRequest
+	if err := c.Bind(&breakGlass); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&breakGlass); err != nil {
+		return err
+	}
+
+	result, err := h.breakGlassService.GetBreakGlassSessions(&breakGlass)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *BreakGlassHandler) BreakGlassSession(c echo.Context) error {
+	var breakGlass domain.GetBreakGlassSessionRequest
+	if err := c.Bind(&breakGlass); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&breakGlass); err != nil {
+		return err
+	}
+
+	result, err := h.breakGlassService.GetBreakGlassSession(breakGlass.Id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,14 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"strconv"
+)
+
+// isDryRun reports whether the mutation was requested with ?dry_run=true. The
+// default binder only reads query parameters for GET and DELETE, so the flag
+// is read here for every method.
+func isDryRun(c echo.Context) bool {
+	dryRun, _ := strconv.ParseBool(c.QueryParam("dry_run"))
+	return dryRun
+}
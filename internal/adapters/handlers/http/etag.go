@@ -0,0 +1,47 @@
+package http
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"strings"
+	appError "user-svc/internal/shared/error"
+)
+
+// etag is the strong entity tag of a record at the given version.
+func etag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// setETag tags the response with the version of the record it carries.
+func setETag(c echo.Context, version int64) {
+	c.Response().Header().Set("ETag", etag(version))
+}
+
+// readIfMatch reads the versions a write is conditioned on. No header and *
+// leave the write unconditional. A list of which no tag is one of ours can
+// match no version, so the write fails its precondition right away.
+func readIfMatch(c echo.Context) ([]int64, error) {
+	header := strings.TrimSpace(c.Request().Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	versions := make([]int64, 0)
+	for _, tag := range strings.Split(header, ",") {
+		// weak tags never match under the strong comparison If-Match uses
+		tag = strings.TrimSpace(tag)
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		if version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, &appError.AppError{Code: http.StatusPreconditionFailed, Message: fmt.Sprintf("If-Match %s matches no version", header)}
+	}
+
+	return versions, nil
+}
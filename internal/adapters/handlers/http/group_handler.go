@@ -0,0 +1,95 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+)
+
+type GroupHandler struct {
+	groupService services.GroupService
+}
+
+func NewGroupHandler(groupService services.GroupService) *GroupHandler {
+	return &GroupHandler{
+		groupService: groupService,
+	}
+}
+
+func (h *GroupHandler) CreateGroup(c echo.Context) error {
+	var group domain.CreateGroupRequest
+	if err := c.Bind(&group); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&group); err != nil {
+		return err
+	}
+	result, err := h.groupService.CreateGroup(&group)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *GroupHandler) UpdateGroup(c echo.Context) error {
+	var group domain.UpdateGroupRequest
+	if err := c.Bind(&group); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&group); err != nil {
+		return err
+	}
+	result, err := h.groupService.UpdateGroup(&group)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *GroupHandler) DeleteGroup(c echo.Context) error {
+	var group domain.DeleteGroupRequest
+	if err := c.Bind(&group); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&group); err != nil {
+		return err
+	}
+
+	result, err := h.groupService.DeleteGroup(group.Id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *GroupHandler) Groups(c echo.Context) error {
+	result, err := h.groupService.GetGroups()
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *GroupHandler) Group(c echo.Context) error {
+	var group domain.GetGroupRequest
+	if err := c.Bind(&group); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&group); err != nil {
+		return err
+	}
+
+	result, err := h.groupService.GetGroup(group.Id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
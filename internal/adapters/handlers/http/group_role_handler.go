@@ -0,0 +1,75 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+	"user-svc/internal/shared/constants"
+)
+
+type GroupRoleHandler struct {
+	groupRoleService services.GroupRoleService
+}
+
+func NewGroupRoleHandler(groupRoleService services.GroupRoleService) *GroupRoleHandler {
+	return &GroupRoleHandler{
+		groupRoleService: groupRoleService,
+	}
+}
+
+func (h *GroupRoleHandler) AssignRolesToGroup(c echo.Context) error {
+	var groupRole domain.AssignRolesToGroupRequest
+	if err := c.Bind(&groupRole); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&groupRole); err != nil {
+		return err
+	}
+
+	groupRole.ActorId = c.Get(constants.KeyUserID).(string)
+	result, err := h.groupRoleService.AssignRolesToGroup(&groupRole)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *GroupRoleHandler) GetGroupRoles(c echo.Context) error {
+	var groupRole domain.GetGroupRolesRequest
+	if err := c.Bind(&groupRole); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&groupRole); err != nil {
+		return err
+	}
+
+	result, err := h.groupRoleService.GetGroupRoles(&groupRole)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *GroupRoleHandler) RemoveRolesFromGroup(c echo.Context) error {
+	var groupRole domain.RemoveRolesFromGroupRequest
+	if err := c.Bind(&groupRole); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&groupRole); err != nil {
+		return err
+	}
+
+	groupRole.ActorId = c.Get(constants.KeyUserID).(string)
+	result, err := h.groupRoleService.RemoveRolesFromGroup(&groupRole)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+	"user-svc/internal/shared/constants"
+)
+
+type GroupUserHandler struct {
+	groupUserService services.GroupUserService
+}
+
+func NewGroupUserHandler(groupUserService services.GroupUserService) *GroupUserHandler {
+	return &GroupUserHandler{
+		groupUserService: groupUserService,
+	}
+}
+
+func (h *GroupUserHandler) AddUsersToGroup(c echo.Context) error {
+	var groupUser domain.AddUsersToGroupRequest
+	if err := c.Bind(&groupUser); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&groupUser); err != nil {
+		return err
+	}
+
+	groupUser.ActorId = c.Get(constants.KeyUserID).(string)
+	result, err := h.groupUserService.AddUsersToGroup(&groupUser)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *GroupUserHandler) GetGroupUsers(c echo.Context) error {
+	var groupUser domain.GetGroupUsersRequest
+	if err := c.Bind(&groupUser); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&groupUser); err != nil {
+		return err
+	}
+
+	result, err := h.groupUserService.GetGroupUsers(&groupUser)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *GroupUserHandler) RemoveUsersFromGroup(c echo.Context) error {
+	var groupUser domain.RemoveUsersFromGroupRequest
+	if err := c.Bind(&groupUser); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&groupUser); err != nil {
+		return err
+	}
+
+	groupUser.ActorId = c.Get(constants.KeyUserID).(string)
+	result, err := h.groupUserService.RemoveUsersFromGroup(&groupUser)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const mimeMergePatch = "application/merge-patch+json"
+
+// bindMergePatch binds a JSON merge patch (RFC 7396) and the path parameters.
+// The default binder does not know the media type, so the body is decoded
+// here. Members left out stay nil; null asks to remove a member, which none of
+// the patchable members allows, and unknown members are refused.
+func bindMergePatch(c echo.Context, patch interface{}) error {
+	if !strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), mimeMergePatch) {
+		c.Response().Header().Set("Accept-Patch", mimeMergePatch)
+		return echo.ErrUnsupportedMediaType
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return err
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil || members == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "merge patch must be a JSON object")
+	}
+	for name, value := range members {
+		if string(bytes.TrimSpace(value)) == "null" {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("member %s cannot be removed", name))
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(patch); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return (&echo.DefaultBinder{}).BindPathParams(c, patch)
+}
@@ -7,10 +7,20 @@ import (
 	"user-svc/internal/shared/logger"
 )
 
+// omitBodyRoutes are logged without their bodies
+var omitBodyRoutes = []string{
+	// the response carries the issued second factor
+	apiPrefix + breakGlassPath + "/enrollments/:user_id",
+	// the file carries passwords, and may be large
+	apiPrefix + usersPath + "/import",
+	// the response streams every user
+	apiPrefix + usersPath + "/export",
+}
+
 func RegisterAppMiddleware(e *echo.Echo, logger *logger.LoggerWrapper) {
 	e.Use(middleware.Recover())
 	e.Use(middleware.RequestID())
 
-	loggingMiddleware := internalMiddleware.NewLoggingMiddleware(logger)
+	loggingMiddleware := internalMiddleware.NewLoggingMiddleware(logger, omitBodyRoutes...)
 	e.Use(loggingMiddleware.LogRequestAndResponse)
 }
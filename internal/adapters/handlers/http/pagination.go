@@ -0,0 +1,38 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"strconv"
+	"user-svc/internal/core/domain"
+)
+
+// withPageLinks links a paged result to its neighbouring pages, keeping every
+// other query parameter of the request. Keyset pages only link forward.
+func withPageLinks(c echo.Context, result *domain.Response) *domain.Response {
+	switch page := result.Data.(type) {
+	case *domain.Page:
+		links := &domain.PageLinks{}
+		if page.HasNext() {
+			links.Next = pageURL(c, "page", strconv.Itoa(page.Page+1))
+		}
+		if page.Page > 1 {
+			links.Prev = pageURL(c, "page", strconv.Itoa(page.Page-1))
+		}
+		page.Links = links
+	case *domain.CursorPage:
+		links := &domain.PageLinks{}
+		if page.NextCursor != "" {
+			links.Next = pageURL(c, "cursor", page.NextCursor)
+		}
+		page.Links = links
+	}
+	return result
+}
+
+func pageURL(c echo.Context, key string, value string) string {
+	url := *c.Request().URL
+	query := url.Query()
+	query.Set(key, value)
+	url.RawQuery = query.Encode()
+	return url.RequestURI()
+}
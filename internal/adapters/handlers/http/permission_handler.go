@@ -26,11 +26,13 @@ func (h *PermissionHandler) CreatePermission(c echo.Context) error {
 	if err := c.Validate(&permission); err != nil {
 		return err
 	}
+
+	permission.DryRun = isDryRun(c)
 	result, err := h.permissionService.CreatePermission(&permission)
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusCreated, result)
+	return c.JSON(result.Code, result)
 }
 
 func (h *PermissionHandler) UpdatePermission(c echo.Context) error {
@@ -42,6 +44,14 @@ func (h *PermissionHandler) UpdatePermission(c echo.Context) error {
 	if err := c.Validate(&permission); err != nil {
 		return err
 	}
+
+	versions, err := readIfMatch(c)
+	if err != nil {
+		return err
+	}
+	permission.IfMatch = versions
+
+	permission.DryRun = isDryRun(c)
 	result, err := h.permissionService.UpdatePermission(&permission)
 	if err != nil {
 		return err
@@ -49,6 +59,31 @@ func (h *PermissionHandler) UpdatePermission(c echo.Context) error {
 	return c.JSON(http.StatusCreated, result)
 }
 
+func (h *PermissionHandler) PatchPermission(c echo.Context) error {
+	var permission domain.PatchPermissionRequest
+	if err := bindMergePatch(c, &permission); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&permission); err != nil {
+		return err
+	}
+
+	versions, err := readIfMatch(c)
+	if err != nil {
+		return err
+	}
+	permission.IfMatch = versions
+
+	permission.DryRun = isDryRun(c)
+	result, err := h.permissionService.PatchPermission(&permission)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
 func (h *PermissionHandler) DeletePermission(c echo.Context) error {
 	var permission domain.DeletePermissionRequest
 	if err := c.Bind(&permission); err != nil {
@@ -59,7 +94,32 @@ func (h *PermissionHandler) DeletePermission(c echo.Context) error {
 		return err
 	}
 
-	result, err := h.permissionService.DeletePermission(permission.Id)
+	versions, err := readIfMatch(c)
+	if err != nil {
+		return err
+	}
+	permission.IfMatch = versions
+
+	permission.DryRun = isDryRun(c)
+	result, err := h.permissionService.DeletePermission(&permission)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *PermissionHandler) RestorePermission(c echo.Context) error {
+	var permission domain.RestorePermissionRequest
+	if err := c.Bind(&permission); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&permission); err != nil {
+		return err
+	}
+
+	result, err := h.permissionService.RestorePermission(&permission)
 	if err != nil {
 		return err
 	}
@@ -68,12 +128,21 @@ func (h *PermissionHandler) DeletePermission(c echo.Context) error {
 }
 
 func (h *PermissionHandler) Permissions(c echo.Context) error {
-	result, err := h.permissionService.GetPermissions()
+	var permissions domain.GetPermissionsRequest
+	if err := c.Bind(&permissions); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&permissions); err != nil {
+		return err
+	}
+
+	result, err := h.permissionService.GetPermissions(&permissions)
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(http.StatusOK, result)
+	return c.JSON(http.StatusOK, withPageLinks(c, result))
 }
 
 func (h *PermissionHandler) Permission(c echo.Context) error {
@@ -90,6 +159,9 @@ func (h *PermissionHandler) Permission(c echo.Context) error {
 	if err != nil {
 		return err
 	}
+	if entity, ok := result.Data.(*domain.Permission); ok {
+		setETag(c, entity.Version)
+	}
 
 	return c.JSON(http.StatusOK, result)
 }
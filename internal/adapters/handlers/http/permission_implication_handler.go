@@ -0,0 +1,72 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+)
+
+type PermissionImplicationHandler struct {
+	permissionImplicationService services.PermissionImplicationService
+}
+
+func NewPermissionImplicationHandler(permissionImplicationService services.PermissionImplicationService) *PermissionImplicationHandler {
+	return &PermissionImplicationHandler{
+		permissionImplicationService: permissionImplicationService,
+	}
+}
+
+func (h *PermissionImplicationHandler) GetPermissionImplications(c echo.Context) error {
+	var implication domain.GetPermissionImplicationsRequest
+	if err := c.Bind(&implication); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&implication); err != nil {
+		return err
+	}
+
+	result, err := h.permissionImplicationService.GetPermissionImplications(&implication)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *PermissionImplicationHandler) AddPermissionImplications(c echo.Context) error {
+	var implication domain.AddPermissionImplicationsRequest
+	if err := c.Bind(&implication); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&implication); err != nil {
+		return err
+	}
+
+	result, err := h.permissionImplicationService.AddPermissionImplications(&implication)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *PermissionImplicationHandler) RemovePermissionImplications(c echo.Context) error {
+	var implication domain.RemovePermissionImplicationsRequest
+	if err := c.Bind(&implication); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&implication); err != nil {
+		return err
+	}
+
+	result, err := h.permissionImplicationService.RemovePermissionImplications(&implication)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,35 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+)
+
+type PermissionUsageHandler struct {
+	permissionUsageService services.PermissionUsageService
+}
+
+func NewPermissionUsageHandler(permissionUsageService services.PermissionUsageService) *PermissionUsageHandler {
+	return &PermissionUsageHandler{
+		permissionUsageService: permissionUsageService,
+	}
+}
+
+func (h *PermissionUsageHandler) UnusedGrants(c echo.Context) error {
+	var request domain.GetUnusedGrantsRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	result, err := h.permissionUsageService.GetUnusedGrants(&request)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,126 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+)
+
+type RelationHandler struct {
+	relationService services.RelationService
+}
+
+func NewRelationHandler(relationService services.RelationService) *RelationHandler {
+	return &RelationHandler{
+		relationService: relationService,
+	}
+}
+
+func (h *RelationHandler) WriteRelationTuples(c echo.Context) error {
+	var tuples domain.WriteRelationTuplesRequest
+	if err := c.Bind(&tuples); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&tuples); err != nil {
+		return err
+	}
+
+	result, err := h.relationService.WriteRelationTuples(&tuples)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *RelationHandler) DeleteRelationTuples(c echo.Context) error {
+	var tuples domain.WriteRelationTuplesRequest
+	if err := c.Bind(&tuples); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&tuples); err != nil {
+		return err
+	}
+
+	result, err := h.relationService.DeleteRelationTuples(&tuples)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *RelationHandler) RelationTuples(c echo.Context) error {
+	var tuples domain.GetRelationTuplesRequest
+	if err := c.Bind(&tuples); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&tuples); err != nil {
+		return err
+	}
+
+	result, err := h.relationService.GetRelationTuples(&tuples)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *RelationHandler) Check(c echo.Context) error {
+	var check domain.CheckRelationRequest
+	if err := c.Bind(&check); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&check); err != nil {
+		return err
+	}
+
+	result, err := h.relationService.Check(&check)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *RelationHandler) Expand(c echo.Context) error {
+	var expand domain.ExpandRelationRequest
+	if err := c.Bind(&expand); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&expand); err != nil {
+		return err
+	}
+
+	result, err := h.relationService.Expand(&expand)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *RelationHandler) ListObjects(c echo.Context) error {
+	var objects domain.ListObjectsRequest
+	if err := c.Bind(&objects); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&objects); err != nil {
+		return err
+	}
+
+	result, err := h.relationService.ListObjects(&objects)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
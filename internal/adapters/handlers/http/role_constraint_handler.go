@@ -0,0 +1,88 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+)
+
+type RoleConstraintHandler struct {
+	roleConstraintService services.RoleConstraintService
+}
+
+func NewRoleConstraintHandler(roleConstraintService services.RoleConstraintService) *RoleConstraintHandler {
+	return &RoleConstraintHandler{
+		roleConstraintService: roleConstraintService,
+	}
+}
+
+func (h *RoleConstraintHandler) CreateRoleConstraint(c echo.Context) error {
+	var constraint domain.CreateRoleConstraintRequest
+	if err := c.Bind(&constraint); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&constraint); err != nil {
+		return err
+	}
+	result, err := h.roleConstraintService.CreateRoleConstraint(&constraint)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *RoleConstraintHandler) DeleteRoleConstraint(c echo.Context) error {
+	var constraint domain.DeleteRoleConstraintRequest
+	if err := c.Bind(&constraint); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&constraint); err != nil {
+		return err
+	}
+
+	result, err := h.roleConstraintService.DeleteRoleConstraint(constraint.Id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *RoleConstraintHandler) RoleConstraints(c echo.Context) error {
+	result, err := h.roleConstraintService.GetRoleConstraints()
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *RoleConstraintHandler) RoleConstraint(c echo.Context) error {
+	var constraint domain.GetRoleConstraintRequest
+	if err := c.Bind(&constraint); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&constraint); err != nil {
+		return err
+	}
+
+	result, err := h.roleConstraintService.GetRoleConstraint(constraint.Id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *RoleConstraintHandler) Violations(c echo.Context) error {
+	result, err := h.roleConstraintService.GetViolations()
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -26,11 +26,13 @@ func (h *RoleHandler) CreateRole(c echo.Context) error {
 	if err := c.Validate(&role); err != nil {
 		return err
 	}
+
+	role.DryRun = isDryRun(c)
 	result, err := h.roleService.CreateRole(&role)
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusCreated, result)
+	return c.JSON(result.Code, result)
 }
 
 func (h *RoleHandler) UpdateRole(c echo.Context) error {
@@ -42,6 +44,14 @@ func (h *RoleHandler) UpdateRole(c echo.Context) error {
 	if err := c.Validate(&role); err != nil {
 		return err
 	}
+
+	versions, err := readIfMatch(c)
+	if err != nil {
+		return err
+	}
+	role.IfMatch = versions
+
+	role.DryRun = isDryRun(c)
 	result, err := h.roleService.UpdateRole(&role)
 	if err != nil {
 		return err
@@ -49,6 +59,31 @@ func (h *RoleHandler) UpdateRole(c echo.Context) error {
 	return c.JSON(http.StatusCreated, result)
 }
 
+func (h *RoleHandler) PatchRole(c echo.Context) error {
+	var role domain.PatchRoleRequest
+	if err := bindMergePatch(c, &role); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&role); err != nil {
+		return err
+	}
+
+	versions, err := readIfMatch(c)
+	if err != nil {
+		return err
+	}
+	role.IfMatch = versions
+
+	role.DryRun = isDryRun(c)
+	result, err := h.roleService.PatchRole(&role)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
 func (h *RoleHandler) DeleteRole(c echo.Context) error {
 	var role domain.DeleteRoleRequest
 	if err := c.Bind(&role); err != nil {
@@ -59,7 +94,32 @@ func (h *RoleHandler) DeleteRole(c echo.Context) error {
 		return err
 	}
 
-	result, err := h.roleService.DeleteRole(role.Id)
+	versions, err := readIfMatch(c)
+	if err != nil {
+		return err
+	}
+	role.IfMatch = versions
+
+	role.DryRun = isDryRun(c)
+	result, err := h.roleService.DeleteRole(&role)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *RoleHandler) RestoreRole(c echo.Context) error {
+	var role domain.RestoreRoleRequest
+	if err := c.Bind(&role); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&role); err != nil {
+		return err
+	}
+
+	result, err := h.roleService.RestoreRole(&role)
 	if err != nil {
 		return err
 	}
@@ -68,12 +128,21 @@ func (h *RoleHandler) DeleteRole(c echo.Context) error {
 }
 
 func (h *RoleHandler) Roles(c echo.Context) error {
-	result, err := h.roleService.GetRoles()
+	var roles domain.GetRolesRequest
+	if err := c.Bind(&roles); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&roles); err != nil {
+		return err
+	}
+
+	result, err := h.roleService.GetRoles(&roles)
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(http.StatusOK, result)
+	return c.JSON(http.StatusOK, withPageLinks(c, result))
 }
 
 func (h *RoleHandler) Role(c echo.Context) error {
@@ -90,6 +159,9 @@ func (h *RoleHandler) Role(c echo.Context) error {
 	if err != nil {
 		return err
 	}
+	if entity, ok := result.Data.(*domain.Role); ok {
+		setETag(c, entity.Version)
+	}
 
 	return c.JSON(http.StatusOK, result)
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"user-svc/internal/core/domain"
 	"user-svc/internal/core/services"
+	"user-svc/internal/shared/constants"
 )
 
 type RolePermissionHandler struct {
@@ -27,12 +28,14 @@ func (h *RolePermissionHandler) AssignPermissionsToRole(c echo.Context) error {
 		return err
 	}
 
+	rolePermission.ActorId = c.Get(constants.KeyUserID).(string)
+	rolePermission.DryRun = isDryRun(c)
 	result, err := h.rolePermissionService.AssignPermissionsToRole(&rolePermission)
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(http.StatusCreated, result)
+	return c.JSON(result.Code, result)
 }
 
 func (h *RolePermissionHandler) GetRolePermissions(c echo.Context) error {
@@ -63,6 +66,8 @@ func (h *RolePermissionHandler) RemovePermissionsFromRole(c echo.Context) error
 		return err
 	}
 
+	rolePermission.ActorId = c.Get(constants.KeyUserID).(string)
+	rolePermission.DryRun = isDryRun(c)
 	result, err := h.rolePermissionService.RemovePermissionsFromRole(&rolePermission)
 	if err != nil {
 		return err
@@ -70,3 +75,21 @@ func (h *RolePermissionHandler) RemovePermissionsFromRole(c echo.Context) error
 
 	return c.JSON(http.StatusOK, result)
 }
+
+func (h *RolePermissionHandler) GetPermissionUsers(c echo.Context) error {
+	var permissionUser domain.GetPermissionUsersRequest
+	if err := c.Bind(&permissionUser); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&permissionUser); err != nil {
+		return err
+	}
+
+	result, err := h.rolePermissionService.GetPermissionUsers(&permissionUser)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, withPageLinks(c, result))
+}
@@ -2,10 +2,13 @@ package http
 
 import (
 	"github.com/labstack/echo/v4"
+	"net/http"
+	"user-svc/internal/core/domain"
 	"user-svc/internal/core/ports"
 	"user-svc/internal/core/services"
 	"user-svc/internal/middleware"
 	"user-svc/internal/shared/config"
+	"user-svc/internal/shared/logger"
 )
 
 const (
@@ -15,21 +18,57 @@ const (
 	permissionsPath     = "/permissions"
 	userRolesPath       = "/user/:user_id/roles"
 	rolePermissionsPath = "/role/:role_id/permissions"
+	implicationsPath    = "/permission/:permission_id/implications"
+	roleConstraintsPath = "/role-constraints"
+	userAttributesPath  = "/user-attributes"
+	accessRequestsPath  = "/access-requests"
+	accessReviewsPath   = "/access-reviews"
+	permissionUsagePath = "/permission-usage"
+	breakGlassPath      = "/break-glass"
+	groupsPath          = "/groups"
+	groupUsersPath      = "/group/:group_id/users"
+	groupRolesPath      = "/group/:group_id/roles"
+	relationTuplesPath  = "/relation-tuples"
 )
 
 func RegisterHTTPRoutes(
 	e *echo.Echo,
 	cfg *config.Config,
+	log logger.Logger,
 	authRepository ports.AuthRepository,
+	routeIndex *domain.RouteIndex,
 	userService services.UserService,
+	userSearchService services.UserSearchService,
+	userImportService services.UserImportService,
+	userExportService services.UserExportService,
+	userAttributeService services.UserAttributeService,
 	roleService services.RoleService,
 	permissionService services.PermissionService,
 	userRoleService services.UserRoleService,
 	rolePermissionService services.RolePermissionService,
+	permissionImplicationService services.PermissionImplicationService,
+	roleConstraintService services.RoleConstraintService,
+	accessRequestService services.AccessRequestService,
+	accessReviewService services.AccessReviewService,
+	permissionUsageService services.PermissionUsageService,
+	breakGlassService services.BreakGlassService,
+	groupService services.GroupService,
+	groupUserService services.GroupUserService,
+	groupRoleService services.GroupRoleService,
+	adminScopeService services.AdminScopeService,
+	relationService services.RelationService,
 	authService services.AuthService,
 ) {
 	// Create user handler
 	userHandler := NewUserHandler(userService)
+	// Create user search handler
+	userSearchHandler := NewUserSearchHandler(userSearchService)
+	// Create user import handler
+	userImportHandler := NewUserImportHandler(userImportService, cfg.App.UserImport.MaxBytes)
+	// Create user export handler
+	userExportHandler := NewUserExportHandler(userExportService, log)
+	// Create user attribute handler
+	userAttributeHandler := NewUserAttributeHandler(userAttributeService)
 	// Create user role handler
 	userRoleHandler := NewUserRoleHandler(userRoleService)
 	// Create role handler
@@ -38,6 +77,28 @@ func RegisterHTTPRoutes(
 	permissionHandler := NewPermissionHandler(permissionService)
 	// Create role permission handler
 	rolePermissionHandler := NewRolePermissionHandler(rolePermissionService)
+	// Create permission implication handler
+	permissionImplicationHandler := NewPermissionImplicationHandler(permissionImplicationService)
+	// Create role constraint handler
+	roleConstraintHandler := NewRoleConstraintHandler(roleConstraintService)
+	// Create access request handler
+	accessRequestHandler := NewAccessRequestHandler(accessRequestService)
+	// Create access review handler
+	accessReviewHandler := NewAccessReviewHandler(accessReviewService)
+	// Create permission usage handler
+	permissionUsageHandler := NewPermissionUsageHandler(permissionUsageService)
+	// Create break-glass handler
+	breakGlassHandler := NewBreakGlassHandler(breakGlassService)
+	// Create group handler
+	groupHandler := NewGroupHandler(groupService)
+	// Create group user handler
+	groupUserHandler := NewGroupUserHandler(groupUserService)
+	// Create group role handler
+	groupRoleHandler := NewGroupRoleHandler(groupRoleService)
+	// Create admin scope handler
+	adminScopeHandler := NewAdminScopeHandler(adminScopeService)
+	// Create relation handler
+	relationHandler := NewRelationHandler(relationService)
 	// Create auth handler
 	authHandler := NewAuthHandler(authService)
 
@@ -53,12 +114,20 @@ func RegisterHTTPRoutes(
 
 	// create a new instance of the permission middleware
 	checker := &middleware.PermissionCheckerImpl{
-		AuthRepository:        authRepository,
-		RolePermissionService: rolePermissionService,
+		AuthRepository:               authRepository,
+		UserRoleService:              userRoleService,
+		RolePermissionService:        rolePermissionService,
+		PermissionImplicationService: permissionImplicationService,
+		PermissionUsageService:       permissionUsageService,
+		BreakGlassService:            breakGlassService,
 	}
 	permissionMiddleware := &middleware.PermissionMiddleware{
 		Checker: checker,
+		Routes:  routeIndex,
 	}
+	// route adds a guarded route and indexes the permission it requires, which
+	// the dry run impact reports routes from
+	route := permissionMiddleware.Route
 
 	v1 := e.Group(apiPrefix)
 
@@ -70,37 +139,134 @@ func RegisterHTTPRoutes(
 
 	// Register user endpoints
 	userGroup := v1.Group(usersPath, jwtMiddleware.Handle)
-	userGroup.POST("", userHandler.CreateUser, permissionMiddleware.Handle("Create-User"))
-	userGroup.PUT("/:id", userHandler.UpdateUser, permissionMiddleware.Handle("Update-User"))
-	userGroup.DELETE("/:id", userHandler.DeleteUser, permissionMiddleware.Handle("Delete-User"))
-	userGroup.GET("/:id", userHandler.User, permissionMiddleware.Handle("View-User"))
-	userGroup.GET("", userHandler.Users, permissionMiddleware.Handle("List-User"))
+	route(userGroup, http.MethodPost, "", userHandler.CreateUser, domain.PermissionCreateUser)
+	route(userGroup, http.MethodPut, "/:id", userHandler.UpdateUser, domain.PermissionUpdateUser)
+	route(userGroup, http.MethodPatch, "/:id", userHandler.PatchUser, domain.PermissionUpdateUser)
+	route(userGroup, http.MethodDelete, "/:id", userHandler.DeleteUser, domain.PermissionDeleteUser)
+	route(userGroup, http.MethodPost, "/:id/restore", userHandler.RestoreUser, domain.PermissionDeleteUser)
+	route(userGroup, http.MethodGet, "/:id", userHandler.User, domain.PermissionViewUser)
+	route(userGroup, http.MethodGet, "/:id/permissions", userRoleHandler.GetUserPermissions, domain.PermissionViewUser)
+	route(userGroup, http.MethodGet, "", userHandler.Users, domain.PermissionListUser)
+	route(userGroup, http.MethodGet, "/search", userSearchHandler.SearchUsers, domain.PermissionListUser)
+	route(userGroup, http.MethodPost, "/import", userImportHandler.ImportUsers, domain.PermissionImportUser)
+	route(userGroup, http.MethodGet, "/import/:id", userImportHandler.UserImport, domain.PermissionImportUser)
+	route(userGroup, http.MethodGet, "/export", userExportHandler.ExportUsers, domain.PermissionExportUser)
+
+	// Register user attribute endpoints
+	userAttributeGroup := v1.Group(userAttributesPath, jwtMiddleware.Handle)
+	route(userAttributeGroup, http.MethodPost, "", userAttributeHandler.CreateUserAttribute, domain.PermissionCreateUserAttribute)
+	route(userAttributeGroup, http.MethodPut, "/:name", userAttributeHandler.UpdateUserAttribute, domain.PermissionUpdateUserAttribute)
+	route(userAttributeGroup, http.MethodDelete, "/:name", userAttributeHandler.DeleteUserAttribute, domain.PermissionDeleteUserAttribute)
+	route(userAttributeGroup, http.MethodGet, "/:name", userAttributeHandler.UserAttribute, domain.PermissionViewUserAttribute)
+	route(userAttributeGroup, http.MethodGet, "", userAttributeHandler.UserAttributes, domain.PermissionListUserAttribute)
 
 	// Register user role endpoints
 	userRoleGroup := v1.Group(userRolesPath, jwtMiddleware.Handle)
-	userRoleGroup.GET("", userRoleHandler.GetUserRoles, permissionMiddleware.Handle("View-Role"))
-	userRoleGroup.POST("/assign", userRoleHandler.AssignRolesToUser, permissionMiddleware.Handle("Update-Role"))
-	userRoleGroup.DELETE("/revoke", userRoleHandler.RemoveRolesFromUser, permissionMiddleware.Handle("Update-Role"))
+	route(userRoleGroup, http.MethodGet, "", userRoleHandler.GetUserRoles, domain.PermissionViewRole)
+	route(userRoleGroup, http.MethodPost, "/assign", userRoleHandler.AssignRolesToUser, domain.PermissionUpdateRole)
+	route(userRoleGroup, http.MethodDelete, "/revoke", userRoleHandler.RemoveRolesFromUser, domain.PermissionUpdateRole)
 
 	// Register role endpoints
 	roleGroup := v1.Group(rolesPath, jwtMiddleware.Handle)
-	roleGroup.POST("", roleHandler.CreateRole, permissionMiddleware.Handle("Create-Role"))
-	roleGroup.PUT("/:id", roleHandler.UpdateRole, permissionMiddleware.Handle("Update-Role"))
-	roleGroup.DELETE("/:id", roleHandler.DeleteRole, permissionMiddleware.Handle("Delete-Role"))
-	roleGroup.GET("/:id", roleHandler.Role, permissionMiddleware.Handle("View-Role"))
-	roleGroup.GET("", roleHandler.Roles, permissionMiddleware.Handle("List-Role"))
+	route(roleGroup, http.MethodPost, "", roleHandler.CreateRole, domain.PermissionCreateRole)
+	route(roleGroup, http.MethodPut, "/:id", roleHandler.UpdateRole, domain.PermissionUpdateRole)
+	route(roleGroup, http.MethodPatch, "/:id", roleHandler.PatchRole, domain.PermissionUpdateRole)
+	route(roleGroup, http.MethodDelete, "/:id", roleHandler.DeleteRole, domain.PermissionDeleteRole)
+	route(roleGroup, http.MethodPost, "/:id/restore", roleHandler.RestoreRole, domain.PermissionDeleteRole)
+	route(roleGroup, http.MethodGet, "/:id", roleHandler.Role, domain.PermissionViewRole)
+	route(roleGroup, http.MethodGet, "/:id/users", userRoleHandler.GetRoleUsers, domain.PermissionViewRole)
+	route(roleGroup, http.MethodGet, "/:id/admin-scope", adminScopeHandler.AdminScope, domain.PermissionViewRole)
+	route(roleGroup, http.MethodPut, "/:id/admin-scope", adminScopeHandler.UpdateAdminScope, domain.PermissionUpdateAdminScope)
+	route(roleGroup, http.MethodGet, "", roleHandler.Roles, domain.PermissionListRole)
 
 	// Register role permission endpoints
 	rolePermissionGroup := v1.Group(rolePermissionsPath, jwtMiddleware.Handle)
-	rolePermissionGroup.GET("", rolePermissionHandler.GetRolePermissions, permissionMiddleware.Handle("View-Permission"))
-	rolePermissionGroup.POST("/assign", rolePermissionHandler.AssignPermissionsToRole, permissionMiddleware.Handle("Update-Permission"))
-	rolePermissionGroup.DELETE("/revoke", rolePermissionHandler.RemovePermissionsFromRole, permissionMiddleware.Handle("Update-Permission"))
+	route(rolePermissionGroup, http.MethodGet, "", rolePermissionHandler.GetRolePermissions, domain.PermissionViewPermission)
+	route(rolePermissionGroup, http.MethodPost, "/assign", rolePermissionHandler.AssignPermissionsToRole, domain.PermissionUpdatePermission)
+	route(rolePermissionGroup, http.MethodDelete, "/revoke", rolePermissionHandler.RemovePermissionsFromRole, domain.PermissionUpdatePermission)
+
+	// Register permission implication endpoints
+	implicationGroup := v1.Group(implicationsPath, jwtMiddleware.Handle)
+	route(implicationGroup, http.MethodGet, "", permissionImplicationHandler.GetPermissionImplications, domain.PermissionViewPermission)
+	route(implicationGroup, http.MethodPost, "/assign", permissionImplicationHandler.AddPermissionImplications, domain.PermissionUpdatePermission)
+	route(implicationGroup, http.MethodDelete, "/revoke", permissionImplicationHandler.RemovePermissionImplications, domain.PermissionUpdatePermission)
+
+	// Register role constraint endpoints
+	roleConstraintGroup := v1.Group(roleConstraintsPath, jwtMiddleware.Handle)
+	route(roleConstraintGroup, http.MethodPost, "", roleConstraintHandler.CreateRoleConstraint, domain.PermissionCreateRoleConstraint)
+	route(roleConstraintGroup, http.MethodDelete, "/:id", roleConstraintHandler.DeleteRoleConstraint, domain.PermissionDeleteRoleConstraint)
+	route(roleConstraintGroup, http.MethodGet, "/violations", roleConstraintHandler.Violations, domain.PermissionListRoleConstraint)
+	route(roleConstraintGroup, http.MethodGet, "/:id", roleConstraintHandler.RoleConstraint, domain.PermissionViewRoleConstraint)
+	route(roleConstraintGroup, http.MethodGet, "", roleConstraintHandler.RoleConstraints, domain.PermissionListRoleConstraint)
+
+	// Register access request endpoints
+	accessRequestGroup := v1.Group(accessRequestsPath, jwtMiddleware.Handle)
+	route(accessRequestGroup, http.MethodPost, "", accessRequestHandler.CreateAccessRequest, domain.PermissionCreateAccessRequest)
+	route(accessRequestGroup, http.MethodPost, "/:id/approve", accessRequestHandler.ApproveAccessRequest, domain.PermissionApproveAccessRequest)
+	route(accessRequestGroup, http.MethodPost, "/:id/reject", accessRequestHandler.RejectAccessRequest, domain.PermissionApproveAccessRequest)
+	route(accessRequestGroup, http.MethodPost, "/:id/revoke", accessRequestHandler.RevokeAccessRequest, domain.PermissionApproveAccessRequest)
+	route(accessRequestGroup, http.MethodGet, "/:id", accessRequestHandler.AccessRequest, domain.PermissionViewAccessRequest)
+	route(accessRequestGroup, http.MethodGet, "", accessRequestHandler.AccessRequests, domain.PermissionListAccessRequest)
+
+	// Register access review endpoints
+	accessReviewGroup := v1.Group(accessReviewsPath, jwtMiddleware.Handle)
+	route(accessReviewGroup, http.MethodPost, "", accessReviewHandler.CreateAccessReview, domain.PermissionCreateAccessReview)
+	route(accessReviewGroup, http.MethodPut, "/:id/items/:item_id", accessReviewHandler.DecideAccessReviewItem, domain.PermissionDecideAccessReview)
+	route(accessReviewGroup, http.MethodPost, "/:id/close", accessReviewHandler.CloseAccessReview, domain.PermissionCloseAccessReview)
+	route(accessReviewGroup, http.MethodGet, "/:id/export", accessReviewHandler.ExportAccessReview, domain.PermissionViewAccessReview)
+	route(accessReviewGroup, http.MethodGet, "/:id", accessReviewHandler.AccessReview, domain.PermissionViewAccessReview)
+	route(accessReviewGroup, http.MethodGet, "", accessReviewHandler.AccessReviews, domain.PermissionListAccessReview)
+
+	// Register permission usage endpoints
+	permissionUsageGroup := v1.Group(permissionUsagePath, jwtMiddleware.Handle)
+	route(permissionUsageGroup, http.MethodGet, "/unused", permissionUsageHandler.UnusedGrants, domain.PermissionViewPermissionUsage)
+
+	// Register break-glass endpoints
+	breakGlassGroup := v1.Group(breakGlassPath, jwtMiddleware.Handle)
+	route(breakGlassGroup, http.MethodPost, "", breakGlassHandler.ActivateBreakGlass, domain.PermissionActivateBreakGlass)
+	route(breakGlassGroup, http.MethodPost, "/:id/end", breakGlassHandler.EndBreakGlass, domain.PermissionEndBreakGlass)
+	route(breakGlassGroup, http.MethodGet, "/:id", breakGlassHandler.BreakGlassSession, domain.PermissionViewBreakGlass)
+	route(breakGlassGroup, http.MethodGet, "", breakGlassHandler.BreakGlassSessions, domain.PermissionListBreakGlass)
+	route(breakGlassGroup, http.MethodPut, "/enrollments/:user_id", breakGlassHandler.EnrollBreakGlass, domain.PermissionEnrollBreakGlass)
+	route(breakGlassGroup, http.MethodDelete, "/enrollments/:user_id", breakGlassHandler.UnenrollBreakGlass, domain.PermissionEnrollBreakGlass)
+
+	// Register group endpoints
+	groupGroup := v1.Group(groupsPath, jwtMiddleware.Handle)
+	route(groupGroup, http.MethodPost, "", groupHandler.CreateGroup, domain.PermissionCreateGroup)
+	route(groupGroup, http.MethodPut, "/:id", groupHandler.UpdateGroup, domain.PermissionUpdateGroup)
+	route(groupGroup, http.MethodDelete, "/:id", groupHandler.DeleteGroup, domain.PermissionDeleteGroup)
+	route(groupGroup, http.MethodGet, "/:id", groupHandler.Group, domain.PermissionViewGroup)
+	route(groupGroup, http.MethodGet, "", groupHandler.Groups, domain.PermissionListGroup)
+
+	// Register group user endpoints
+	groupUserGroup := v1.Group(groupUsersPath, jwtMiddleware.Handle)
+	route(groupUserGroup, http.MethodGet, "", groupUserHandler.GetGroupUsers, domain.PermissionViewGroup)
+	route(groupUserGroup, http.MethodPost, "/assign", groupUserHandler.AddUsersToGroup, domain.PermissionUpdateGroup)
+	route(groupUserGroup, http.MethodDelete, "/revoke", groupUserHandler.RemoveUsersFromGroup, domain.PermissionUpdateGroup)
+
+	// Register group role endpoints
+	groupRoleGroup := v1.Group(groupRolesPath, jwtMiddleware.Handle)
+	route(groupRoleGroup, http.MethodGet, "", groupRoleHandler.GetGroupRoles, domain.PermissionViewGroup)
+	route(groupRoleGroup, http.MethodPost, "/assign", groupRoleHandler.AssignRolesToGroup, domain.PermissionUpdateGroup)
+	route(groupRoleGroup, http.MethodDelete, "/revoke", groupRoleHandler.RemoveRolesFromGroup, domain.PermissionUpdateGroup)
+
+	// Register relation tuple endpoints
+	relationGroup := v1.Group(relationTuplesPath, jwtMiddleware.Handle)
+	route(relationGroup, http.MethodPost, "", relationHandler.WriteRelationTuples, domain.PermissionWriteRelation)
+	route(relationGroup, http.MethodDelete, "", relationHandler.DeleteRelationTuples, domain.PermissionWriteRelation)
+	route(relationGroup, http.MethodGet, "", relationHandler.RelationTuples, domain.PermissionListRelation)
+	route(relationGroup, http.MethodGet, "/check", relationHandler.Check, domain.PermissionCheckRelation)
+	route(relationGroup, http.MethodGet, "/expand", relationHandler.Expand, domain.PermissionCheckRelation)
+	route(relationGroup, http.MethodGet, "/objects", relationHandler.ListObjects, domain.PermissionCheckRelation)
 
 	// Register permission endpoints
 	permissionGroup := v1.Group(permissionsPath, jwtMiddleware.Handle)
-	permissionGroup.POST("", permissionHandler.CreatePermission, permissionMiddleware.Handle("Create-Permission"))
-	permissionGroup.PUT("/:id", permissionHandler.UpdatePermission, permissionMiddleware.Handle("Update-Permission"))
-	permissionGroup.DELETE("/:id", permissionHandler.DeletePermission, permissionMiddleware.Handle("Delete-Permission"))
-	permissionGroup.GET("/:id", permissionHandler.Permission, permissionMiddleware.Handle("View-Permission"))
-	permissionGroup.GET("", permissionHandler.Permissions, permissionMiddleware.Handle("List-Permission"))
+	route(permissionGroup, http.MethodPost, "", permissionHandler.CreatePermission, domain.PermissionCreatePermission)
+	route(permissionGroup, http.MethodPut, "/:id", permissionHandler.UpdatePermission, domain.PermissionUpdatePermission)
+	route(permissionGroup, http.MethodPatch, "/:id", permissionHandler.PatchPermission, domain.PermissionUpdatePermission)
+	route(permissionGroup, http.MethodDelete, "/:id", permissionHandler.DeletePermission, domain.PermissionDeletePermission)
+	route(permissionGroup, http.MethodPost, "/:id/restore", permissionHandler.RestorePermission, domain.PermissionDeletePermission)
+	route(permissionGroup, http.MethodGet, "/:id", permissionHandler.Permission, domain.PermissionViewPermission)
+	route(permissionGroup, http.MethodGet, "/:id/users", rolePermissionHandler.GetPermissionUsers, domain.PermissionViewPermission)
+	route(permissionGroup, http.MethodGet, "", permissionHandler.Permissions, domain.PermissionListPermission)
 }
@@ -11,11 +11,15 @@ import (
 	"os/signal"
 	"strings"
 	"time"
+	"user-svc/internal/adapters/handlers/scim"
+	"user-svc/internal/adapters/notification"
 	"user-svc/internal/adapters/repository/postgres"
 	"user-svc/internal/adapters/repository/redis"
+	"user-svc/internal/adapters/repository/search"
 	"user-svc/internal/core/domain"
 	"user-svc/internal/core/services"
 	"user-svc/internal/shared/config"
+	"user-svc/internal/shared/cursor"
 	appError "user-svc/internal/shared/error"
 	"user-svc/internal/shared/hash"
 	"user-svc/internal/shared/logger"
@@ -23,7 +27,10 @@ import (
 	validatorHelper "user-svc/internal/shared/validator"
 )
 
-const shutdownTimeout = 10 * time.Second
+const (
+	shutdownTimeout     = 10 * time.Second
+	expiryCheckInterval = time.Minute
+)
 
 func Start() {
 	e := echo.New()
@@ -33,34 +40,85 @@ func Start() {
 	repo := postgres.NewRepository(cfg)
 	cache := redis.NewRepository(cfg)
 	hasher := hash.NewHasher(cfg)
+	cursorCodec := cursor.NewCodec(cfg)
 	openSearch := open_search.NewClient(cfg)
+	searchRepo := search.NewRepository(cfg, openSearch)
 	log := logger.NewLogger(cfg, openSearch)
+	notifier := notification.NewWebhookNotifier(cfg, log)
+	validate := validator.New()
 
-	userService := services.NewUserService(repo, hasher, log)
-	roleService := services.NewRoleService(repo)
-	permissionService := services.NewPermissionService(repo)
-	userRoleService := services.NewUserRoleService(repo, userService, roleService)
-	rolePermissionService := services.NewRolePermissionService(repo, roleService, permissionService)
-	authService := services.NewAuthService(cfg, repo, cache, userRoleService, hasher)
+	routeIndex := domain.NewRouteIndex()
+	safeguardService := services.NewSafeguardService(cfg, repo)
+	accessImpactService := services.NewAccessImpactService(repo, repo, repo, repo, repo, repo, routeIndex)
+	userSearchService := services.NewUserSearchService(cfg, searchRepo, repo, repo, log)
+	userAttributeService := services.NewUserAttributeService(validate, repo)
+	userService := services.NewUserService(repo, hasher, log, safeguardService, cursorCodec, userSearchService, userAttributeService)
+	roleService := services.NewRoleService(repo, safeguardService, accessImpactService, userSearchService, cursorCodec)
+	permissionService := services.NewPermissionService(repo, safeguardService, accessImpactService, cursorCodec)
+	roleConstraintService := services.NewRoleConstraintService(repo, roleService)
+	adminScopeService := services.NewAdminScopeService(cfg, repo, repo, roleService, permissionService)
+	permissionImplicationService := services.NewPermissionImplicationService(repo, permissionService)
+	userRoleService := services.NewUserRoleService(repo, userService, roleService, safeguardService, roleConstraintService, adminScopeService, accessImpactService, userSearchService, permissionImplicationService)
+	rolePermissionService := services.NewRolePermissionService(repo, roleService, permissionService, safeguardService, adminScopeService, permissionImplicationService, accessImpactService)
+	accessRequestService := services.NewAccessRequestService(cfg, repo, repo, roleService, roleConstraintService, safeguardService, adminScopeService, userSearchService)
+	accessReviewService := services.NewAccessReviewService(repo, userService, roleService, userRoleService)
+	permissionUsageService := services.NewPermissionUsageService(cfg, repo, cache)
+	breakGlassService := services.NewBreakGlassService(cfg, repo, cache, repo, repo, repo, userSearchService, notifier)
+	groupService := services.NewGroupService(repo, repo, safeguardService, userSearchService)
+	groupUserService := services.NewGroupUserService(repo, repo, groupService, userService, safeguardService, roleConstraintService, adminScopeService, userSearchService)
+	groupRoleService := services.NewGroupRoleService(repo, repo, groupService, roleService, safeguardService, roleConstraintService, adminScopeService, userSearchService)
+	relationService := services.NewRelationService(cfg, repo)
+	authService := services.NewAuthService(cfg, repo, cache, userRoleService, userAttributeService, hasher)
+	purgeService := services.NewPurgeService(cfg, repo, repo, repo, repo)
+	userImportService := services.NewUserImportService(cfg, validate, repo, repo, repo, roleConstraintService, adminScopeService, userAttributeService, userSearchService, hasher, log)
+	userExportService := services.NewUserExportService(cfg, repo, userAttributeService, repo)
 	// Register http routes
 	RegisterHTTPRoutes(
 		e,
 		cfg,
+		log,
 		cache,
+		routeIndex,
 		*userService,
+		*userSearchService,
+		*userImportService,
+		*userExportService,
+		*userAttributeService,
 		*roleService,
 		*permissionService,
 		*userRoleService,
 		*rolePermissionService,
+		*permissionImplicationService,
+		*roleConstraintService,
+		*accessRequestService,
+		*accessReviewService,
+		*permissionUsageService,
+		*breakGlassService,
+		*groupService,
+		*groupUserService,
+		*groupRoleService,
+		*adminScopeService,
+		*relationService,
 		*authService,
 	)
+	// Register scim routes for the identity provider
+	scim.RegisterRoutes(e, cfg, *userService, *roleService, *userRoleService, *adminScopeService)
 	// Register app middleware
 	RegisterAppMiddleware(e, log)
 	e.Debug = cfg.App.Debug
 	e.Validator = &validatorHelper.CustomValidator{
-		Validator: validator.New(),
+		Validator: validate,
 	}
 	e.HTTPErrorHandler = errorHandler
+	// Reconcile the permissions table with the route registry
+	syncPermissions(permissionService, log)
+	// Expire time-limited role grants and break-glass sessions, and fail the
+	// imports a stopped process left running
+	startExpiryJob(accessRequestService, breakGlassService, userImportService, log)
+	// Move permission usage from redis to postgres
+	startUsageFlushJob(permissionUsageService, time.Duration(cfg.App.PermissionUsage.FlushInterval)*time.Second, log)
+	// Purge deletions past their retention
+	startPurgeJob(purgeService, time.Duration(cfg.App.SoftDelete.PurgeInterval)*time.Minute, log)
 	// Start server
 	startServer(e, cfg.App.Port)
 	quit := make(chan os.Signal, 1)
@@ -82,6 +140,7 @@ func errorHandler(err error, c echo.Context) {
 		c.JSON(appErr.Code, domain.Response{
 			Code:    appErr.Code,
 			Message: appErr.Message,
+			Data:    appErr.Data,
 		})
 		return
 	}
@@ -127,6 +186,84 @@ func errorHandler(err error, c echo.Context) {
 	}
 }
 
+func syncPermissions(permissionService *services.PermissionService, log *logger.LoggerWrapper) {
+	sync, err := permissionService.SyncPermissions(domain.RegisteredPermissions)
+	if err != nil {
+		log.Fatal("failed to sync permissions: ", err)
+	}
+	for _, name := range sync.Created {
+		log.Info("created missing permission: ", name)
+	}
+	for _, name := range sync.Marked {
+		log.Info("marked permission as system: ", name)
+	}
+	for _, permission := range sync.Orphans {
+		log.Warn("permission is not required by any route: ", permission.Name)
+	}
+}
+
+func startExpiryJob(accessRequestService *services.AccessRequestService, breakGlassService *services.BreakGlassService, userImportService *services.UserImportService, log *logger.LoggerWrapper) {
+	if err := userImportService.FailStaleUserImports(); err != nil {
+		log.Error("failed to mark interrupted user imports as failed: ", err)
+	}
+	go func() {
+		ticker := time.NewTicker(expiryCheckInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := breakGlassService.ExpireBreakGlassSessions(); err != nil {
+				log.Error("failed to expire break-glass sessions: ", err)
+			}
+			if err := accessRequestService.ExpireAccessRequests(); err != nil {
+				log.Error("failed to expire access requests: ", err)
+			}
+			if err := userImportService.FailStaleUserImports(); err != nil {
+				log.Error("failed to mark interrupted user imports as failed: ", err)
+			}
+		}
+	}()
+}
+
+func startUsageFlushJob(permissionUsageService *services.PermissionUsageService, interval time.Duration, log *logger.LoggerWrapper) {
+	if interval <= 0 {
+		log.Warn("permission usage flush is disabled, usage stays in redis")
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := permissionUsageService.FlushUsage(); err != nil {
+				log.Error("failed to flush permission usage: ", err)
+			}
+		}
+	}()
+}
+
+func startPurgeJob(purgeService *services.PurgeService, interval time.Duration, log *logger.LoggerWrapper) {
+	if interval <= 0 {
+		log.Warn("purge of deleted records is disabled, they stay in the trash")
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			result, err := purgeService.PurgeDeleted()
+			if err != nil {
+				log.Error("failed to purge deleted records: ", err)
+				continue
+			}
+			if result.Users+result.Roles+result.Permissions > 0 {
+				log.Info(fmt.Sprintf("purged %d users, %d roles and %d permissions deleted before %s",
+					result.Users, result.Roles, result.Permissions, result.Before.Format(time.RFC3339)))
+			}
+			if result.RelationTuples > 0 {
+				log.Info(fmt.Sprintf("pruned %d deleted relation tuples", result.RelationTuples))
+			}
+		}
+	}()
+}
+
 func startServer(e *echo.Echo, port int) {
 	go func() {
 		if err := e.Start(fmt.Sprintf(":%d", port)); err != nil && err != http.ErrServerClosed {
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+)
+
+type UserAttributeHandler struct {
+	userAttributeService services.UserAttributeService
+}
+
+func NewUserAttributeHandler(userAttributeService services.UserAttributeService) *UserAttributeHandler {
+	return &UserAttributeHandler{
+		userAttributeService: userAttributeService,
+	}
+}
+
+func (h *UserAttributeHandler) CreateUserAttribute(c echo.Context) error {
+	var attribute domain.CreateUserAttributeRequest
+	if err := c.Bind(&attribute); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&attribute); err != nil {
+		return err
+	}
+
+	result, err := h.userAttributeService.CreateUserAttribute(&attribute)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *UserAttributeHandler) UpdateUserAttribute(c echo.Context) error {
+	var attribute domain.UpdateUserAttributeRequest
+	if err := c.Bind(&attribute); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&attribute); err != nil {
+		return err
+	}
+
+	result, err := h.userAttributeService.UpdateUserAttribute(&attribute)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *UserAttributeHandler) DeleteUserAttribute(c echo.Context) error {
+	var attribute domain.DeleteUserAttributeRequest
+	if err := c.Bind(&attribute); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&attribute); err != nil {
+		return err
+	}
+
+	result, err := h.userAttributeService.DeleteUserAttribute(attribute.Name)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *UserAttributeHandler) UserAttributes(c echo.Context) error {
+	result, err := h.userAttributeService.GetUserAttributes()
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *UserAttributeHandler) UserAttribute(c echo.Context) error {
+	var attribute domain.GetUserAttributeRequest
+	if err := c.Bind(&attribute); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&attribute); err != nil {
+		return err
+	}
+
+	result, err := h.userAttributeService.GetUserAttribute(attribute.Name)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,204 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+	"user-svc/internal/shared/logger"
+	"user-svc/internal/shared/xlsx"
+)
+
+type UserExportHandler struct {
+	userExportService services.UserExportService
+	logger            logger.Logger
+}
+
+func NewUserExportHandler(userExportService services.UserExportService, logger logger.Logger) *UserExportHandler {
+	return &UserExportHandler{
+		userExportService: userExportService,
+		logger:            logger,
+	}
+}
+
+// ExportUsers streams the users as they are read. The headers only go out with
+// the first user, so a failure before it is still reported as an error. Once
+// they went out the connection is aborted instead, so the client cannot take a
+// truncated file for a complete one.
+func (h *UserExportHandler) ExportUsers(c echo.Context) error {
+	var request domain.ExportUsersRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	encoder := newUserExportEncoder(&request, c.Response())
+	started := false
+	err := h.userExportService.ExportUsers(&request, func(user *domain.UserExport) error {
+		if !started {
+			setUserExportHeaders(c, request.Format)
+			started = true
+		}
+		return encoder.Encode(user)
+	})
+	if err != nil && !started {
+		return err
+	}
+
+	if !started {
+		setUserExportHeaders(c, request.Format)
+	}
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err != nil {
+		h.logger.WithFields(logger.FieldMap{"request_id": c.Response().Header().Get(echo.HeaderXRequestID)}).Error("user export aborted: ", err)
+		panic(http.ErrAbortHandler)
+	}
+	return nil
+}
+
+var userExportContentTypes = map[string]string{
+	domain.UserExportCSV:    "text/csv; charset=utf-8",
+	domain.UserExportNDJSON: "application/x-ndjson",
+	domain.UserExportXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+func setUserExportHeaders(c echo.Context, format string) {
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, userExportContentTypes[format])
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="users.%s"`, format))
+	c.Response().WriteHeader(http.StatusOK)
+}
+
+type userExportEncoder interface {
+	Encode(user *domain.UserExport) error
+	Close() error
+}
+
+func newUserExportEncoder(request *domain.ExportUsersRequest, w io.Writer) userExportEncoder {
+	columns := userExportColumns{roles: request.IncludeRoles, permissions: request.IncludePermissions}
+	switch request.Format {
+	case domain.UserExportNDJSON:
+		return &ndjsonUserExportEncoder{encoder: json.NewEncoder(w)}
+	case domain.UserExportXLSX:
+		return &xlsxUserExportEncoder{columns: columns, writer: xlsx.NewWriter(w, "Users")}
+	default:
+		return &csvUserExportEncoder{columns: columns, writer: csv.NewWriter(w)}
+	}
+}
+
+// userExportColumns lays a user out as a row of cells. Roles and permissions
+// are joined with ; like the roles of an import.
+type userExportColumns struct {
+	roles       bool
+	permissions bool
+}
+
+func (u userExportColumns) header() []string {
+	header := []string{"id", "name", "email", "active", "created_at", "updated_at"}
+	if u.roles {
+		header = append(header, "roles")
+	}
+	if u.permissions {
+		header = append(header, "permissions")
+	}
+	return header
+}
+
+func (u userExportColumns) row(user *domain.UserExport) []string {
+	row := []string{
+		user.Id,
+		user.Name,
+		user.Email,
+		strconv.FormatBool(user.Active),
+		user.CreatedAt.Format(time.RFC3339),
+		user.UpdatedAt.Format(time.RFC3339),
+	}
+	if u.roles {
+		row = append(row, strings.Join(user.Roles, ";"))
+	}
+	if u.permissions {
+		row = append(row, strings.Join(user.Permissions, ";"))
+	}
+	return row
+}
+
+type csvUserExportEncoder struct {
+	columns userExportColumns
+	writer  *csv.Writer
+	started bool
+}
+
+func (e *csvUserExportEncoder) Encode(user *domain.UserExport) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	return e.writer.Write(e.columns.row(user))
+}
+
+func (e *csvUserExportEncoder) Close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvUserExportEncoder) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	return e.writer.Write(e.columns.header())
+}
+
+type ndjsonUserExportEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonUserExportEncoder) Encode(user *domain.UserExport) error {
+	return e.encoder.Encode(user)
+}
+
+func (e *ndjsonUserExportEncoder) Close() error {
+	return nil
+}
+
+type xlsxUserExportEncoder struct {
+	columns userExportColumns
+	writer  *xlsx.Writer
+	started bool
+}
+
+func (e *xlsxUserExportEncoder) Encode(user *domain.UserExport) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	return e.writer.WriteRow(e.columns.row(user))
+}
+
+func (e *xlsxUserExportEncoder) Close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	return e.writer.Close()
+}
+
+func (e *xlsxUserExportEncoder) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	return e.writer.WriteRow(e.columns.header())
+}
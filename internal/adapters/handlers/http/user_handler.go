@@ -1,7 +1,6 @@
 package http
 
 import (
-	"fmt"
 	"github.com/labstack/echo/v4"
 	"net/http"
 	"user-svc/internal/core/domain"
@@ -46,6 +45,12 @@ func (h *UserHandler) UpdateUser(c echo.Context) error {
 		return err
 	}
 
+	versions, err := readIfMatch(c)
+	if err != nil {
+		return err
+	}
+	user.IfMatch = versions
+
 	result, err := h.userService.UpdateUser(&user)
 	if err != nil {
 		return err
@@ -54,6 +59,30 @@ func (h *UserHandler) UpdateUser(c echo.Context) error {
 	return c.JSON(http.StatusOK, result)
 }
 
+func (h *UserHandler) PatchUser(c echo.Context) error {
+	var user domain.PatchUserRequest
+	if err := bindMergePatch(c, &user); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&user); err != nil {
+		return err
+	}
+
+	versions, err := readIfMatch(c)
+	if err != nil {
+		return err
+	}
+	user.IfMatch = versions
+
+	result, err := h.userService.PatchUser(&user)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
 func (h *UserHandler) DeleteUser(c echo.Context) error {
 	var user domain.DeleteUserRequest
 	if err := c.Bind(&user); err != nil {
@@ -64,7 +93,13 @@ func (h *UserHandler) DeleteUser(c echo.Context) error {
 		return err
 	}
 
-	result, err := h.userService.DeleteUser(user.Id)
+	versions, err := readIfMatch(c)
+	if err != nil {
+		return err
+	}
+	user.IfMatch = versions
+
+	result, err := h.userService.DeleteUser(&user)
 	if err != nil {
 		return err
 	}
@@ -72,10 +107,17 @@ func (h *UserHandler) DeleteUser(c echo.Context) error {
 	return c.JSON(http.StatusOK, result)
 }
 
-func (h *UserHandler) Users(c echo.Context) error {
-	result, err := h.userService.GetUsers()
-	key := c.Get("userID")
-	fmt.Println(key)
+func (h *UserHandler) RestoreUser(c echo.Context) error {
+	var user domain.RestoreUserRequest
+	if err := c.Bind(&user); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&user); err != nil {
+		return err
+	}
+
+	result, err := h.userService.RestoreUser(&user)
 	if err != nil {
 		return err
 	}
@@ -83,6 +125,24 @@ func (h *UserHandler) Users(c echo.Context) error {
 	return c.JSON(http.StatusOK, result)
 }
 
+func (h *UserHandler) Users(c echo.Context) error {
+	var users domain.GetUsersRequest
+	if err := c.Bind(&users); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&users); err != nil {
+		return err
+	}
+
+	result, err := h.userService.GetUsers(&users)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, withPageLinks(c, result))
+}
+
 func (h *UserHandler) User(c echo.Context) error {
 	var user domain.GetUserRequest
 	if err := c.Bind(&user); err != nil {
@@ -97,6 +157,9 @@ func (h *UserHandler) User(c echo.Context) error {
 	if err != nil {
 		return err
 	}
+	if entity, ok := result.Data.(*domain.User); ok {
+		setETag(c, entity.Version)
+	}
 
 	return c.JSON(http.StatusOK, result)
 }
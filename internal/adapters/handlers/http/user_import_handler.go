@@ -0,0 +1,91 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+	"strings"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+	"user-svc/internal/shared/constants"
+	appError "user-svc/internal/shared/error"
+)
+
+type UserImportHandler struct {
+	userImportService services.UserImportService
+	maxBytes          int64
+}
+
+func NewUserImportHandler(userImportService services.UserImportService, maxBytes int64) *UserImportHandler {
+	return &UserImportHandler{
+		userImportService: userImportService,
+		maxBytes:          maxBytes,
+	}
+}
+
+// ImportUsers takes the file as the request body. The default binder does not
+// read CSV, so the body is passed on as is, up to the configured size.
+func (h *UserImportHandler) ImportUsers(c echo.Context) error {
+	userImport := domain.ImportUsersRequest{
+		ActorId: c.Get(constants.KeyUserID).(string),
+		Format:  importFormat(c),
+		DryRun:  isDryRun(c),
+	}
+	if err := c.Validate(&userImport); err != nil {
+		return err
+	}
+
+	content, err := io.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body, h.maxBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return &appError.AppError{Code: http.StatusRequestEntityTooLarge, Message: fmt.Sprintf("import is larger than %d bytes", tooLarge.Limit)}
+		}
+		return err
+	}
+	userImport.Content = content
+
+	result, err := h.userImportService.ImportUsers(&userImport)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(result.Code, result)
+}
+
+func (h *UserImportHandler) UserImport(c echo.Context) error {
+	var userImport domain.GetUserImportRequest
+	if err := c.Bind(&userImport); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&userImport); err != nil {
+		return err
+	}
+
+	result, err := h.userImportService.GetUserImport(userImport.Id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(result.Code, result)
+}
+
+// importFormat is the format asked for with ?format=, or else the one the
+// content type names.
+func importFormat(c echo.Context) string {
+	if format := c.QueryParam("format"); format != "" {
+		return format
+	}
+
+	contentType := c.Request().Header.Get(echo.HeaderContentType)
+	switch {
+	case strings.HasPrefix(contentType, "text/csv"):
+		return domain.UserImportCSV
+	case strings.HasPrefix(contentType, "application/x-ndjson"), strings.HasPrefix(contentType, "application/ndjson"):
+		return domain.UserImportNDJSON
+	}
+	return ""
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"user-svc/internal/core/domain"
 	"user-svc/internal/core/services"
+	"user-svc/internal/shared/constants"
 )
 
 type UserRoleHandler struct {
@@ -27,12 +28,14 @@ func (h *UserRoleHandler) AssignRolesToUser(c echo.Context) error {
 		return err
 	}
 
+	userRole.ActorId = c.Get(constants.KeyUserID).(string)
+	userRole.DryRun = isDryRun(c)
 	result, err := h.userRoleService.AssignRolesToUser(&userRole)
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(http.StatusCreated, result)
+	return c.JSON(result.Code, result)
 }
 
 func (h *UserRoleHandler) GetUserRoles(c echo.Context) error {
@@ -63,6 +66,8 @@ func (h *UserRoleHandler) RemoveRolesFromUser(c echo.Context) error {
 		return err
 	}
 
+	userRole.ActorId = c.Get(constants.KeyUserID).(string)
+	userRole.DryRun = isDryRun(c)
 	result, err := h.userRoleService.RemoveRolesFromUser(&userRole)
 	if err != nil {
 		return err
@@ -70,3 +75,39 @@ func (h *UserRoleHandler) RemoveRolesFromUser(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, result)
 }
+
+func (h *UserRoleHandler) GetUserPermissions(c echo.Context) error {
+	var userPermission domain.GetUserPermissionsRequest
+	if err := c.Bind(&userPermission); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&userPermission); err != nil {
+		return err
+	}
+
+	result, err := h.userRoleService.GetUserPermissions(&userPermission)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *UserRoleHandler) GetRoleUsers(c echo.Context) error {
+	var roleUser domain.GetRoleUsersRequest
+	if err := c.Bind(&roleUser); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&roleUser); err != nil {
+		return err
+	}
+
+	result, err := h.userRoleService.GetRoleUsers(&roleUser)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, withPageLinks(c, result))
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+)
+
+type UserSearchHandler struct {
+	userSearchService services.UserSearchService
+}
+
+func NewUserSearchHandler(userSearchService services.UserSearchService) *UserSearchHandler {
+	return &UserSearchHandler{
+		userSearchService: userSearchService,
+	}
+}
+
+func (h *UserSearchHandler) SearchUsers(c echo.Context) error {
+	var request domain.SearchUsersRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	result, err := h.userSearchService.SearchUsers(&request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, withPageLinks(c, result))
+}
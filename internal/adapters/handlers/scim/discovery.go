@@ -0,0 +1,217 @@
+package scim
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"user-svc/internal/core/domain"
+)
+
+type supported struct {
+	Supported bool `json:"supported"`
+}
+
+type filterSupport struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type bulkSupport struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type authenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
+
+type ServiceProviderConfig struct {
+	Schemas               []string                `json:"schemas"`
+	Patch                 supported               `json:"patch"`
+	Bulk                  bulkSupport             `json:"bulk"`
+	Filter                filterSupport           `json:"filter"`
+	ChangePassword        supported               `json:"changePassword"`
+	Sort                  supported               `json:"sort"`
+	Etag                  supported               `json:"etag"`
+	AuthenticationSchemes []*authenticationScheme `json:"authenticationSchemes"`
+	Meta                  *Meta                   `json:"meta"`
+}
+
+type ResourceType struct {
+	Schemas          []string           `json:"schemas"`
+	Id               string             `json:"id"`
+	Name             string             `json:"name"`
+	Endpoint         string             `json:"endpoint"`
+	Schema           string             `json:"schema"`
+	SchemaExtensions []*schemaExtension `json:"schemaExtensions,omitempty"`
+	Meta             *Meta              `json:"meta"`
+}
+
+type schemaExtension struct {
+	Schema   string `json:"schema"`
+	Required bool   `json:"required"`
+}
+
+type Attribute struct {
+	Name          string       `json:"name"`
+	Type          string       `json:"type"`
+	MultiValued   bool         `json:"multiValued"`
+	Required      bool         `json:"required"`
+	CaseExact     bool         `json:"caseExact"`
+	Mutability    string       `json:"mutability"`
+	Returned      string       `json:"returned"`
+	Uniqueness    string       `json:"uniqueness"`
+	SubAttributes []*Attribute `json:"subAttributes,omitempty"`
+}
+
+type Schema struct {
+	Schemas     []string     `json:"schemas"`
+	Id          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Attributes  []*Attribute `json:"attributes"`
+	Meta        *Meta        `json:"meta"`
+}
+
+func attribute(name string, typ string, mutability string, subAttributes ...*Attribute) *Attribute {
+	return &Attribute{Name: name, Type: typ, Mutability: mutability, Returned: "default", Uniqueness: "none", SubAttributes: subAttributes}
+}
+
+func multiValued(a *Attribute) *Attribute {
+	a.MultiValued = true
+	return a
+}
+
+// schemas describes the attributes the service keeps. Others are accepted on
+// writes and dropped.
+var schemas = []*Schema{
+	{
+		Id:          SchemaUser,
+		Name:        "User",
+		Description: "User Account",
+		Attributes: []*Attribute{
+			{Name: "userName", Type: "string", Required: true, Mutability: "readWrite", Returned: "default", Uniqueness: "server"},
+			attribute("name", "complex", "readWrite",
+				attribute("formatted", "string", "readWrite"),
+				attribute("givenName", "string", "writeOnly"),
+				attribute("familyName", "string", "writeOnly"),
+			),
+			attribute("displayName", "string", "readWrite"),
+			multiValued(attribute("emails", "complex", "readOnly",
+				attribute("value", "string", "readOnly"),
+				attribute("type", "string", "readOnly"),
+				attribute("primary", "boolean", "readOnly"),
+			)),
+			attribute("active", "boolean", "readWrite"),
+			{Name: "password", Type: "string", Mutability: "writeOnly", Returned: "never", Uniqueness: "none"},
+			multiValued(attribute("groups", "complex", "readOnly",
+				attribute("value", "string", "readOnly"),
+				attribute("$ref", "reference", "readOnly"),
+				attribute("display", "string", "readOnly"),
+			)),
+		},
+	},
+	{
+		// the attributes are the ones defined on the service, they are listed
+		// and checked there
+		Id:          SchemaUserAttributes,
+		Name:        "UserAttributes",
+		Description: "Attributes defined on the service",
+		Attributes:  []*Attribute{},
+	},
+	{
+		Id:          SchemaGroup,
+		Name:        "Group",
+		Description: "Group, which is a role",
+		Attributes: []*Attribute{
+			{Name: "displayName", Type: "string", Required: true, Mutability: "readWrite", Returned: "default", Uniqueness: "server"},
+			multiValued(attribute("members", "complex", "readWrite",
+				attribute("value", "string", "immutable"),
+				attribute("$ref", "reference", "immutable"),
+				attribute("display", "string", "readOnly"),
+			)),
+		},
+	},
+}
+
+var resourceTypes = []*ResourceType{
+	{Id: "User", Name: "User", Endpoint: "/Users", Schema: SchemaUser, SchemaExtensions: []*schemaExtension{{Schema: SchemaUserAttributes}}},
+	{Id: "Group", Name: "Group", Endpoint: "/Groups", Schema: SchemaGroup},
+}
+
+func ServiceProviderConfigHandler(c echo.Context) error {
+	return respond(c, http.StatusOK, &ServiceProviderConfig{
+		Schemas:        []string{SchemaServiceProviderConfig},
+		Patch:          supported{Supported: true},
+		Filter:         filterSupport{Supported: true, MaxResults: domain.MaxPerPage},
+		ChangePassword: supported{Supported: true},
+		AuthenticationSchemes: []*authenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "OAuth Bearer Token",
+			Description: "Authentication with the bearer token configured for the identity provider",
+			Primary:     true,
+		}},
+		Meta: &Meta{ResourceType: "ServiceProviderConfig", Location: location(c, "ServiceProviderConfig", "")},
+	})
+}
+
+func ResourceTypesHandler(c echo.Context) error {
+	resources := make([]*ResourceType, 0, len(resourceTypes))
+	for _, resourceType := range resourceTypes {
+		resources = append(resources, newResourceType(c, resourceType))
+	}
+	return respond(c, http.StatusOK, listOf(len(resources), resources))
+}
+
+func ResourceTypeHandler(c echo.Context) error {
+	for _, resourceType := range resourceTypes {
+		if resourceType.Id == c.Param("id") {
+			return respond(c, http.StatusOK, newResourceType(c, resourceType))
+		}
+	}
+	return &scimError{code: http.StatusNotFound, detail: "resource type " + c.Param("id") + " not exist"}
+}
+
+func SchemasHandler(c echo.Context) error {
+	resources := make([]*Schema, 0, len(schemas))
+	for _, schema := range schemas {
+		resources = append(resources, newSchema(c, schema))
+	}
+	return respond(c, http.StatusOK, listOf(len(resources), resources))
+}
+
+func SchemaHandler(c echo.Context) error {
+	for _, schema := range schemas {
+		if schema.Id == c.Param("id") {
+			return respond(c, http.StatusOK, newSchema(c, schema))
+		}
+	}
+	return &scimError{code: http.StatusNotFound, detail: "schema " + c.Param("id") + " not exist"}
+}
+
+func newResourceType(c echo.Context, resourceType *ResourceType) *ResourceType {
+	resource := *resourceType
+	resource.Schemas = []string{SchemaResourceType}
+	resource.Meta = &Meta{ResourceType: "ResourceType", Location: location(c, "ResourceTypes", resource.Id)}
+	return &resource
+}
+
+func newSchema(c echo.Context, schema *Schema) *Schema {
+	resource := *schema
+	resource.Schemas = []string{SchemaSchema}
+	resource.Meta = &Meta{ResourceType: "Schema", Location: location(c, "Schemas", resource.Id)}
+	return &resource
+}
+
+func listOf(total int, resources interface{}) *ListResponse {
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: int64(total),
+		StartIndex:   1,
+		ItemsPerPage: total,
+		Resources:    resources,
+	}
+}
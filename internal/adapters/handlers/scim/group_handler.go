@@ -0,0 +1,530 @@
+package scim
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+)
+
+// memberFilterPattern matches the path removing a single member,
+// members[value eq "id"].
+var memberFilterPattern = regexp.MustCompile(`^(?i:members)\[\s*(?i:value)\s+(?i:eq)\s+"([^"]*)"\s*\]$`)
+
+// GroupHandler maps groups to roles. Members are the users granted the role
+// directly, memberships are written as direct role assignments on behalf of
+// the configured actor, so they go through its admin scope and the role
+// constraints like any other assignment. Users holding the role through a
+// group are not members, a membership the client cannot remove is never
+// listed.
+// Renaming and deleting a group are checked against the admin scope of the
+// actor as well, and the roles named unrestricted are left alone, as their
+// names are what makes their holders unrestricted.
+type GroupHandler struct {
+	config            *config.Config
+	roleService       services.RoleService
+	userRoleService   services.UserRoleService
+	adminScopeService services.AdminScopeService
+}
+
+func NewGroupHandler(config *config.Config, roleService services.RoleService, userRoleService services.UserRoleService, adminScopeService services.AdminScopeService) *GroupHandler {
+	return &GroupHandler{
+		config:            config,
+		roleService:       roleService,
+		userRoleService:   userRoleService,
+		adminScopeService: adminScopeService,
+	}
+}
+
+func (h *GroupHandler) Groups(c echo.Context) error {
+	displayName, filtered, err := readFilter(c, "displayName")
+	if err != nil {
+		return err
+	}
+	startIndex, count, err := readPage(c)
+	if err != nil {
+		return err
+	}
+
+	roles := make([]*domain.Role, 0)
+	skip, total, err := fetchWindow(startIndex, count, func(page int, perPage int) (int64, error) {
+		if filtered {
+			// a displayName is unique, only the first page has a group
+			role, err := h.findRole(displayName)
+			if err != nil || role == nil {
+				return 0, err
+			}
+			if page == 1 {
+				roles = append(roles, role)
+			}
+			return 1, nil
+		}
+
+		result, err := h.roleService.GetRoles(&domain.GetRolesRequest{
+			PageRequest: domain.PageRequest{Page: page, PerPage: perPage},
+			Sort:        "created_at",
+		})
+		if err != nil {
+			return 0, err
+		}
+		list := result.Data.(*domain.Page)
+		roles = append(roles, list.Items.([]*domain.Role)...)
+		return list.Total, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	start, end := window(len(roles), skip, count)
+	resources := make([]*Group, 0, end-start)
+	for _, role := range roles[start:end] {
+		resource, err := h.resource(c, role)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, resource)
+	}
+
+	return respond(c, http.StatusOK, &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func (h *GroupHandler) Group(c echo.Context) error {
+	return h.respondGroup(c, http.StatusOK, c.Param("id"))
+}
+
+// CreateGroup creates an active role with no permissions, admins grant it
+// permissions afterwards. Its members are assigned within the admin scope of
+// the actor like any other.
+func (h *GroupHandler) CreateGroup(c echo.Context) error {
+	var resource Group
+	if err := decode(c, &resource); err != nil {
+		return err
+	}
+
+	active := true
+	request := &domain.CreateRoleRequest{Name: resource.DisplayName, Active: &active}
+	if err := c.Validate(request); err != nil {
+		return err
+	}
+	if err := h.checkUnrestricted(request.Name); err != nil {
+		return err
+	}
+
+	if _, err := h.roleService.CreateRole(request); err != nil {
+		return err
+	}
+
+	role, err := h.findRole(request.Name)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return &appError.AppError{Code: http.StatusInternalServerError, Message: "created group could not be read back"}
+	}
+
+	change := newMemberChange()
+	change.add(memberIds(resource.Members))
+	if err := h.applyMembers(role, change); err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, location(c, "Groups", role.Id))
+	return h.respondGroup(c, http.StatusCreated, role.Id)
+}
+
+func (h *GroupHandler) ReplaceGroup(c echo.Context) error {
+	role, err := h.role(c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	var resource Group
+	if err := decode(c, &resource); err != nil {
+		return err
+	}
+
+	if err := h.rename(role, resource.DisplayName); err != nil {
+		return err
+	}
+
+	change := newMemberChange()
+	change.replace(memberIds(resource.Members))
+	if err := h.applyMembers(role, change); err != nil {
+		return err
+	}
+
+	return h.respondGroup(c, http.StatusOK, role.Id)
+}
+
+// PatchGroup renames the group and adds and removes members, identity
+// providers mostly use it for the latter.
+func (h *GroupHandler) PatchGroup(c echo.Context) error {
+	role, err := h.role(c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	operations, err := operations(c)
+	if err != nil {
+		return err
+	}
+
+	var name *string
+	change := newMemberChange()
+	for _, operation := range operations {
+		if err := patchGroup(&name, change, operation); err != nil {
+			return err
+		}
+	}
+
+	if name != nil {
+		if err := h.rename(role, *name); err != nil {
+			return err
+		}
+	}
+	if err := h.applyMembers(role, change); err != nil {
+		return err
+	}
+
+	return h.respondGroup(c, http.StatusOK, role.Id)
+}
+
+func (h *GroupHandler) DeleteGroup(c echo.Context) error {
+	role, err := h.role(c.Param("id"))
+	if err != nil {
+		return err
+	}
+	if err := h.checkRole(role); err != nil {
+		return err
+	}
+
+	if _, err := h.roleService.DeleteRole(&domain.DeleteRoleRequest{Id: role.Id, IfMatch: []int64{role.Version}}); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *GroupHandler) rename(role *domain.Role, name string) error {
+	if name == role.Name {
+		return nil
+	}
+	if err := h.checkRole(role); err != nil {
+		return err
+	}
+	if err := h.checkUnrestricted(name); err != nil {
+		return err
+	}
+
+	_, err := h.roleService.PatchRole(&domain.PatchRoleRequest{Id: role.Id, Name: &name, IfMatch: []int64{role.Version}})
+	return err
+}
+
+// checkRole refuses to rename or delete a role outside the admin scope of the
+// actor, or one named unrestricted.
+func (h *GroupHandler) checkRole(role *domain.Role) error {
+	if err := h.adminScopeService.CheckRoleScope(h.config.App.Scim.ActorId, []string{role.Id}); err != nil {
+		return err
+	}
+	return h.checkUnrestricted(role.Name)
+}
+
+// checkUnrestricted refuses a role name the admin scopes treat as
+// unrestricted, the identity provider cannot take such a name, give it up or
+// delete the role carrying it.
+func (h *GroupHandler) checkUnrestricted(name string) error {
+	if unrestrictedRole(h.config.App.AdminScope.UnrestrictedRoles, name) {
+		return &appError.AppError{Code: http.StatusForbidden, Message: fmt.Sprintf("role %s is unrestricted and cannot be managed through scim", name)}
+	}
+	return nil
+}
+
+func unrestrictedRole(unrestricted []string, name string) bool {
+	for _, role := range unrestricted {
+		if strings.EqualFold(role, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyMembers revokes, then grants the role. A replaced membership is
+// compared to the current members first, so only the difference is written.
+func (h *GroupHandler) applyMembers(role *domain.Role, change *memberChange) error {
+	if change.replaced {
+		members, err := h.members(role.Id)
+		if err != nil {
+			return err
+		}
+		current := make(map[string]bool, len(members))
+		for _, member := range members {
+			current[member.Id] = true
+			if !change.members[member.Id] {
+				change.removed[member.Id] = true
+			}
+		}
+		for id := range change.members {
+			if !current[id] {
+				change.added[id] = true
+			}
+		}
+	}
+
+	for _, id := range sortedKeys(change.removed) {
+		_, err := h.userRoleService.RemoveRolesFromUser(&domain.RemoveRolesFromUserRequest{
+			UserId:  id,
+			RolesId: []string{role.Id},
+			ActorId: h.config.App.Scim.ActorId,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, id := range sortedKeys(change.added) {
+		_, err := h.userRoleService.AssignRolesToUser(&domain.AssignRolesToUserRequest{
+			UserId:  id,
+			RolesId: []string{role.Id},
+			ActorId: h.config.App.Scim.ActorId,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *GroupHandler) respondGroup(c echo.Context, code int, id string) error {
+	role, err := h.role(id)
+	if err != nil {
+		return err
+	}
+
+	resource, err := h.resource(c, role)
+	if err != nil {
+		return err
+	}
+
+	return respond(c, code, resource)
+}
+
+// resource maps the role, with its direct holders as members unless the
+// client excluded them.
+func (h *GroupHandler) resource(c echo.Context, role *domain.Role) (*Group, error) {
+	if excluded(c, "members") {
+		return newGroup(c, role, nil), nil
+	}
+
+	members, err := h.members(role.Id)
+	if err != nil {
+		return nil, err
+	}
+	return newGroup(c, role, members), nil
+}
+
+// members reads every direct holder of the role, a page at a time.
+func (h *GroupHandler) members(roleID string) ([]*domain.User, error) {
+	members := make([]*domain.User, 0)
+	for page := 1; ; page++ {
+		result, err := h.userRoleService.GetDirectRoleUsers(&domain.GetRoleUsersRequest{
+			RoleId:      roleID,
+			PageRequest: domain.PageRequest{Page: page, PerPage: domain.MaxPerPage},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		list := result.Data.(*domain.Page)
+		users := list.Items.([]*domain.User)
+		members = append(members, users...)
+		if len(users) == 0 || int64(len(members)) >= list.Total {
+			return members, nil
+		}
+	}
+}
+
+func (h *GroupHandler) role(id string) (*domain.Role, error) {
+	result, err := h.roleService.GetRole(id)
+	if err != nil {
+		return nil, err
+	}
+	return result.Data.(*domain.Role), nil
+}
+
+// findRole looks a role up by displayName, which is its name. The listing
+// only matches names by prefix, an exact match sorts first among them.
+func (h *GroupHandler) findRole(displayName string) (*domain.Role, error) {
+	result, err := h.roleService.GetRoles(&domain.GetRolesRequest{
+		PageRequest: domain.PageRequest{PerPage: domain.MaxPerPage},
+		Name:        displayName,
+		Sort:        "name",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range result.Data.(*domain.Page).Items.([]*domain.Role) {
+		if strings.EqualFold(role.Name, displayName) {
+			return role, nil
+		}
+	}
+	return nil, nil
+}
+
+// memberChange collects the membership operations of a request in order.
+// Once the members are replaced, later operations edit the new set.
+type memberChange struct {
+	replaced bool
+	members  map[string]bool
+	added    map[string]bool
+	removed  map[string]bool
+}
+
+func newMemberChange() *memberChange {
+	return &memberChange{
+		members: make(map[string]bool),
+		added:   make(map[string]bool),
+		removed: make(map[string]bool),
+	}
+}
+
+func (m *memberChange) add(ids []string) {
+	for _, id := range ids {
+		if m.replaced {
+			m.members[id] = true
+			continue
+		}
+		m.added[id] = true
+		delete(m.removed, id)
+	}
+}
+
+func (m *memberChange) remove(ids []string) {
+	for _, id := range ids {
+		if m.replaced {
+			delete(m.members, id)
+			continue
+		}
+		m.removed[id] = true
+		delete(m.added, id)
+	}
+}
+
+func (m *memberChange) replace(ids []string) {
+	m.replaced = true
+	m.members = make(map[string]bool, len(ids))
+	m.added = make(map[string]bool)
+	m.removed = make(map[string]bool)
+	m.add(ids)
+}
+
+func patchGroup(name **string, change *memberChange, operation *PatchOperation) error {
+	path := strings.ToLower(attributeName(operation.Path))
+	if operation.Op == "remove" {
+		if match := memberFilterPattern.FindStringSubmatch(operation.Path); match != nil {
+			change.remove([]string{match[1]})
+			return nil
+		}
+		switch path {
+		case "members":
+			// members with no value removes them all
+			if operation.Value == nil {
+				change.replace(nil)
+				return nil
+			}
+			ids, err := memberValues(operation.Value)
+			if err != nil {
+				return err
+			}
+			change.remove(ids)
+		case "displayname":
+			return badRequest("mutability", "%s cannot be removed", operation.Path)
+		}
+		return nil
+	}
+
+	if path == "" {
+		attributes, err := objectValue(operation.Value)
+		if err != nil {
+			return err
+		}
+		for attribute, value := range attributes {
+			if err := setGroupAttribute(name, change, operation.Op, attribute, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return setGroupAttribute(name, change, operation.Op, operation.Path, operation.Value)
+}
+
+func setGroupAttribute(name **string, change *memberChange, op string, attribute string, value interface{}) error {
+	switch strings.ToLower(attributeName(attribute)) {
+	case "displayname":
+		displayName, err := stringValue(attribute, value)
+		if err != nil {
+			return err
+		}
+		*name = &displayName
+	case "members":
+		ids, err := memberValues(value)
+		if err != nil {
+			return err
+		}
+		if op == "replace" {
+			change.replace(ids)
+			return nil
+		}
+		change.add(ids)
+	}
+	return nil
+}
+
+// memberValues reads the ids of a list of members, or of a single one.
+func memberValues(value interface{}) ([]string, error) {
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+
+	ids := make([]string, 0, len(values))
+	for _, v := range values {
+		member, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, badRequest("invalidValue", "members must have a value")
+		}
+		id, ok := member["value"].(string)
+		if !ok || id == "" {
+			return nil, badRequest("invalidValue", "members must have a value")
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func memberIds(members []*Reference) []string {
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.Value)
+	}
+	return ids
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
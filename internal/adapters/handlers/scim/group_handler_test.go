@@ -0,0 +1,101 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberFilterPattern(t *testing.T) {
+	tests := []struct {
+		path   string
+		wantID string
+		match  bool
+	}{
+		{path: `members[value eq "user-1"]`, wantID: "user-1", match: true},
+		{path: `Members[Value EQ "user-1"]`, wantID: "user-1", match: true},
+		{path: `members[ value  eq "user-1" ]`, wantID: "user-1", match: true},
+		{path: `members[value eq ""]`, wantID: "", match: true},
+		{path: `members`},
+		{path: `members[display eq "Ann"]`},
+		{path: `members[value ne "user-1"]`},
+		{path: `members[value eq "user-1"].display`},
+		{path: `members[value eq user-1]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			match := memberFilterPattern.FindStringSubmatch(tt.path)
+			if !tt.match {
+				assert.Nil(t, match)
+				return
+			}
+			if assert.NotNil(t, match) {
+				assert.Equal(t, tt.wantID, match[1])
+			}
+		})
+	}
+}
+
+func TestPatchGroup(t *testing.T) {
+	t.Run("members added and removed in order", func(t *testing.T) {
+		var name *string
+		change := newMemberChange()
+		for _, operation := range []*PatchOperation{
+			{Op: "add", Path: "members", Value: []interface{}{map[string]interface{}{"value": "user-1"}, map[string]interface{}{"value": "user-2"}}},
+			{Op: "remove", Path: `members[value eq "user-2"]`},
+			{Op: "remove", Path: "members", Value: []interface{}{map[string]interface{}{"value": "user-3"}}},
+			{Op: "replace", Value: map[string]interface{}{"displayName": "Auditors"}},
+		} {
+			assert.NoError(t, patchGroup(&name, change, operation))
+		}
+
+		assert.Equal(t, "Auditors", *name)
+		assert.False(t, change.replaced)
+		assert.Equal(t, []string{"user-1"}, sortedKeys(change.added))
+		assert.Equal(t, []string{"user-2", "user-3"}, sortedKeys(change.removed))
+	})
+
+	t.Run("members replaced, then edited", func(t *testing.T) {
+		var name *string
+		change := newMemberChange()
+		for _, operation := range []*PatchOperation{
+			{Op: "replace", Path: "members", Value: []interface{}{map[string]interface{}{"value": "user-1"}}},
+			{Op: "add", Path: "members", Value: map[string]interface{}{"value": "user-2"}},
+			{Op: "remove", Path: `members[value eq "user-1"]`},
+		} {
+			assert.NoError(t, patchGroup(&name, change, operation))
+		}
+
+		assert.Nil(t, name)
+		assert.True(t, change.replaced)
+		assert.Equal(t, []string{"user-2"}, sortedKeys(change.members))
+	})
+
+	t.Run("all members removed", func(t *testing.T) {
+		var name *string
+		change := newMemberChange()
+		assert.NoError(t, patchGroup(&name, change, &PatchOperation{Op: "remove", Path: "members"}))
+		assert.True(t, change.replaced)
+		assert.Empty(t, change.members)
+	})
+
+	t.Run("displayName removed", func(t *testing.T) {
+		var name *string
+		err := patchGroup(&name, newMemberChange(), &PatchOperation{Op: "remove", Path: "displayName"})
+		assert.Equal(t, "mutability", scimType(err))
+	})
+
+	t.Run("member without a value", func(t *testing.T) {
+		var name *string
+		err := patchGroup(&name, newMemberChange(), &PatchOperation{Op: "add", Path: "members", Value: []interface{}{map[string]interface{}{"display": "Ann"}}})
+		assert.Equal(t, "invalidValue", scimType(err))
+	})
+}
+
+func TestUnrestrictedRole(t *testing.T) {
+	unrestricted := []string{"Super-Admin"}
+	assert.True(t, unrestrictedRole(unrestricted, "Super-Admin"))
+	assert.True(t, unrestrictedRole(unrestricted, "super-admin"))
+	assert.False(t, unrestrictedRole(unrestricted, "Admin"))
+	assert.False(t, unrestrictedRole(nil, "Super-Admin"))
+}
@@ -0,0 +1,112 @@
+package scim
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"strings"
+	"user-svc/internal/shared/config"
+	"user-svc/internal/shared/constants"
+	appError "user-svc/internal/shared/error"
+)
+
+// scimError is an error with the scimType RFC 7644 gives it, for the errors a
+// client can act upon.
+type scimError struct {
+	code     int
+	scimType string
+	detail   string
+}
+
+func (e *scimError) Error() string {
+	return e.detail
+}
+
+func badRequest(scimType string, format string, args ...interface{}) error {
+	return &scimError{code: http.StatusBadRequest, scimType: scimType, detail: fmt.Sprintf(format, args...)}
+}
+
+// TokenMiddleware authenticates the identity provider by the bearer token
+// configured for it. Provisioning acts as a single client, so there are no
+// sessions or permissions to check.
+type TokenMiddleware struct {
+	config *config.Config
+}
+
+func NewTokenMiddleware(config *config.Config) *TokenMiddleware {
+	return &TokenMiddleware{
+		config: config,
+	}
+}
+
+func (m *TokenMiddleware) Handle(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := m.config.App.Scim.Token
+		if token == "" {
+			return &appError.AppError{Code: http.StatusServiceUnavailable, Message: "scim is not configured"}
+		}
+
+		header := c.Request().Header.Get(constants.KeyAuthorization)
+		bearer := strings.TrimPrefix(header, constants.KeyBearer+" ")
+		if bearer == header || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			c.Response().Header().Set(echo.HeaderWWWAuthenticate, constants.KeyBearer)
+			return &appError.AppError{Code: http.StatusUnauthorized, Message: "invalid authorization token"}
+		}
+
+		return next(c)
+	}
+}
+
+// ErrorMiddleware reports errors in the SCIM error format instead of the one
+// of the REST API, identity providers only understand the former.
+func ErrorMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := next(c)
+		if err == nil || c.Response().Committed {
+			return err
+		}
+
+		code, scimType, detail := http.StatusInternalServerError, "", "Internal server error"
+		var scimErr *scimError
+		var appErr *appError.AppError
+		var httpErr *echo.HTTPError
+		var validationErrs validator.ValidationErrors
+		switch {
+		case errors.As(err, &scimErr):
+			code, scimType, detail = scimErr.code, scimErr.scimType, scimErr.detail
+		case errors.As(err, &appErr):
+			code, detail = appErr.Code, appErr.Message
+			switch code {
+			case http.StatusConflict:
+				scimType = "uniqueness"
+			case http.StatusBadRequest:
+				scimType = "invalidValue"
+			}
+		case errors.As(err, &validationErrs):
+			messages := make([]string, 0, len(validationErrs))
+			for _, v := range validationErrs {
+				messages = append(messages, fmt.Sprintf("%s: invalid value '%v'", v.Field(), v.Value()))
+			}
+			code, scimType, detail = http.StatusBadRequest, "invalidValue", strings.Join(messages, ", ")
+		case errors.As(err, &httpErr):
+			code, detail = httpErr.Code, fmt.Sprint(httpErr.Message)
+		}
+
+		return respond(c, code, &Error{
+			Schemas:  []string{SchemaError},
+			Status:   strconv.Itoa(code),
+			ScimType: scimType,
+			Detail:   detail,
+		})
+	}
+}
+
+// respond writes a SCIM resource, which has its own media type.
+func respond(c echo.Context, code int, resource interface{}) error {
+	c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationSCIM)
+	return c.JSON(code, resource)
+}
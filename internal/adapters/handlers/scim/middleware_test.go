@@ -0,0 +1,62 @@
+package scim
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"user-svc/internal/shared/config"
+	"user-svc/internal/shared/constants"
+	appError "user-svc/internal/shared/error"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenMiddleware_Handle(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		wantCode      int
+	}{
+		{name: "success - correct token", token: "idp-token", authorization: constants.KeyBearer + " idp-token", wantCode: http.StatusOK},
+		{name: "failed - missing token", token: "idp-token", wantCode: http.StatusUnauthorized},
+		{name: "failed - wrong token", token: "idp-token", authorization: constants.KeyBearer + " other-token", wantCode: http.StatusUnauthorized},
+		{name: "failed - token without the bearer scheme", token: "idp-token", authorization: "idp-token", wantCode: http.StatusUnauthorized},
+		{name: "failed - scim not configured", authorization: constants.KeyBearer + " ", wantCode: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.App.Scim.Token = tt.token
+
+			request := httptest.NewRequest(http.MethodGet, basePath+"/Users", nil)
+			if tt.authorization != "" {
+				request.Header.Set(constants.KeyAuthorization, tt.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+
+			called := false
+			err := NewTokenMiddleware(cfg).Handle(func(c echo.Context) error {
+				called = true
+				return c.NoContent(http.StatusOK)
+			})(c)
+
+			if tt.wantCode == http.StatusOK {
+				assert.NoError(t, err)
+				assert.True(t, called)
+				return
+			}
+
+			assert.False(t, called)
+			appErr, ok := err.(*appError.AppError)
+			if assert.True(t, ok) {
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			}
+			if tt.wantCode == http.StatusUnauthorized {
+				assert.Equal(t, constants.KeyBearer, recorder.Header().Get(echo.HeaderWWWAuthenticate))
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package scim
+
+import (
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"regexp"
+	"strconv"
+	"strings"
+	"user-svc/internal/core/domain"
+)
+
+// filterPattern matches the only filter supported, an attribute equal to a
+// string. It is what identity providers send to look up a resource before
+// creating it.
+var filterPattern = regexp.MustCompile(`^\s*([A-Za-z][\w.:]*)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// readFilter returns the value the filter compares the attribute to, and
+// whether there is a filter at all.
+func readFilter(c echo.Context, attribute string) (string, bool, error) {
+	filter := c.QueryParam("filter")
+	if filter == "" {
+		return "", false, nil
+	}
+
+	match := filterPattern.FindStringSubmatch(filter)
+	if match == nil || !strings.EqualFold(attributeName(match[1]), attribute) {
+		return "", false, badRequest("invalidFilter", "only %s eq \"value\" filters are supported", attribute)
+	}
+
+	var value string
+	if err := json.Unmarshal([]byte(match[2]), &value); err != nil {
+		return "", false, badRequest("invalidFilter", "invalid filter value %s", match[2])
+	}
+	return value, true, nil
+}
+
+// attributeName drops the schema an attribute may be qualified with.
+func attributeName(attribute string) string {
+	if i := strings.LastIndex(attribute, ":"); i >= 0 {
+		return attribute[i+1:]
+	}
+	return attribute
+}
+
+// readPage reads startIndex, which counts from 1, and count. A count over the
+// largest page is cut down to it, as RFC 7644 allows.
+func readPage(c echo.Context) (int, int, error) {
+	startIndex, count := 1, domain.MaxPerPage
+	if value := c.QueryParam("startIndex"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, 0, badRequest("invalidValue", "invalid startIndex %s", value)
+		}
+		if n > 1 {
+			startIndex = n
+		}
+	}
+	if value := c.QueryParam("count"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, 0, badRequest("invalidValue", "invalid count %s", value)
+		}
+		switch {
+		case n < 0:
+			count = 0
+		case n < count:
+			count = n
+		}
+	}
+	return startIndex, count, nil
+}
+
+// fetchWindow reads the rows from startIndex on through fetch, which appends
+// a page of the listing and returns the total. A window that does not start
+// on a page boundary spans two pages, the rows of the first one to skip are
+// returned.
+func fetchWindow(startIndex int, count int, fetch func(page int, perPage int) (int64, error)) (int, int64, error) {
+	if count == 0 {
+		// only the total is asked for
+		total, err := fetch(1, 1)
+		return 0, total, err
+	}
+
+	page := (startIndex-1)/count + 1
+	skip := (startIndex - 1) % count
+	total, err := fetch(page, count)
+	if err != nil || skip == 0 || int64(page*count) >= total {
+		return skip, total, err
+	}
+	_, err = fetch(page+1, count)
+	return skip, total, err
+}
+
+// window cuts the rows fetched by fetchWindow down to the ones asked for.
+func window(size int, skip int, count int) (int, int) {
+	if skip > size {
+		skip = size
+	}
+	end := skip + count
+	if end > size {
+		end = size
+	}
+	return skip, end
+}
+
+// excluded tells whether the client left the attribute out of the response,
+// which spares loading the members of every group of a listing.
+func excluded(c echo.Context, attribute string) bool {
+	for _, name := range strings.Split(c.QueryParam("excludedAttributes"), ",") {
+		if strings.EqualFold(attributeName(strings.TrimSpace(name)), attribute) {
+			return true
+		}
+	}
+	return false
+}
+
+func decode(c echo.Context, v interface{}) error {
+	if err := json.NewDecoder(c.Request().Body).Decode(v); err != nil {
+		return badRequest("invalidSyntax", "invalid request body: %s", err.Error())
+	}
+	return nil
+}
+
+// operations reads a PATCH body. Operations are matched case-insensitively,
+// Azure AD capitalizes them.
+func operations(c echo.Context) ([]*PatchOperation, error) {
+	var request PatchRequest
+	if err := decode(c, &request); err != nil {
+		return nil, err
+	}
+
+	for _, operation := range request.Operations {
+		operation.Op = strings.ToLower(operation.Op)
+		if operation.Op != "add" && operation.Op != "replace" && operation.Op != "remove" {
+			return nil, badRequest("invalidSyntax", "unsupported patch operation %s", operation.Op)
+		}
+	}
+	return request.Operations, nil
+}
+
+func stringValue(attribute string, value interface{}) (string, error) {
+	s, ok := value.(string)
+	if !ok {
+		return "", badRequest("invalidValue", "%s must be a string", attribute)
+	}
+	return s, nil
+}
+
+// boolValue also takes "True" and "False", which Azure AD sends for booleans.
+func boolValue(attribute string, value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		if b, err := strconv.ParseBool(strings.ToLower(v)); err == nil {
+			return b, nil
+		}
+	}
+	return false, badRequest("invalidValue", "%s must be a boolean", attribute)
+}
+
+// objectValue is the value of an operation without a path, the attributes to
+// set.
+func objectValue(value interface{}) (map[string]interface{}, error) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, badRequest("invalidValue", "an operation without a path must have an object value")
+	}
+	return object, nil
+}
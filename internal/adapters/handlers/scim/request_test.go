@@ -0,0 +1,188 @@
+package scim
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"user-svc/internal/core/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newContext(method string, query url.Values, body string) echo.Context {
+	target := basePath + "/Users"
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	return echo.New().NewContext(request, httptest.NewRecorder())
+}
+
+// scimType returns the scimType of an error returned by the handlers, empty
+// when it is not a SCIM error.
+func scimType(err error) string {
+	var scimErr *scimError
+	if errors.As(err, &scimErr) {
+		return scimErr.scimType
+	}
+	return ""
+}
+
+func TestReadFilter(t *testing.T) {
+	tests := []struct {
+		name         string
+		filter       string
+		wantValue    string
+		wantFiltered bool
+		wantErr      bool
+	}{
+		{name: "no filter"},
+		{name: "eq", filter: `userName eq "ann@example.com"`, wantValue: "ann@example.com", wantFiltered: true},
+		{name: "operator and attribute in any case", filter: `USERNAME EQ "ann@example.com"`, wantValue: "ann@example.com", wantFiltered: true},
+		{name: "attribute qualified with its schema", filter: `urn:ietf:params:scim:schemas:core:2.0:User:userName eq "ann"`, wantValue: "ann", wantFiltered: true},
+		{name: "escaped quote", filter: `userName eq "a\"b"`, wantValue: `a"b`, wantFiltered: true},
+		{name: "other attribute", filter: `displayName eq "Ann"`, wantErr: true},
+		{name: "other operator", filter: `userName co "ann"`, wantErr: true},
+		{name: "unquoted value", filter: `userName eq ann`, wantErr: true},
+		{name: "compound filter", filter: `userName eq "ann" and active eq true`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{}
+			if tt.filter != "" {
+				query.Set("filter", tt.filter)
+			}
+
+			value, filtered, err := readFilter(newContext(http.MethodGet, query, ""), "userName")
+			if tt.wantErr {
+				assert.Equal(t, "invalidFilter", scimType(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantValue, value)
+			assert.Equal(t, tt.wantFiltered, filtered)
+		})
+	}
+}
+
+func TestReadPage(t *testing.T) {
+	tests := []struct {
+		name           string
+		startIndex     string
+		count          string
+		wantStartIndex int
+		wantCount      int
+		wantErr        bool
+	}{
+		{name: "defaults", wantStartIndex: 1, wantCount: domain.MaxPerPage},
+		{name: "window", startIndex: "11", count: "5", wantStartIndex: 11, wantCount: 5},
+		{name: "start index below one", startIndex: "0", count: "5", wantStartIndex: 1, wantCount: 5},
+		{name: "negative count", count: "-3", wantStartIndex: 1, wantCount: 0},
+		{name: "count over the largest page", count: "100000", wantStartIndex: 1, wantCount: domain.MaxPerPage},
+		{name: "invalid start index", startIndex: "first", wantErr: true},
+		{name: "invalid count", count: "all", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{}
+			if tt.startIndex != "" {
+				query.Set("startIndex", tt.startIndex)
+			}
+			if tt.count != "" {
+				query.Set("count", tt.count)
+			}
+
+			startIndex, count, err := readPage(newContext(http.MethodGet, query, ""))
+			if tt.wantErr {
+				assert.Equal(t, "invalidValue", scimType(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStartIndex, startIndex)
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+}
+
+func TestFetchWindow(t *testing.T) {
+	rows := []string{"a", "b", "c", "d", "e", "f", "g"}
+
+	tests := []struct {
+		name       string
+		startIndex int
+		count      int
+		wantPages  []int
+		wantRows   []string
+	}{
+		{name: "first page", startIndex: 1, count: 3, wantPages: []int{1}, wantRows: []string{"a", "b", "c"}},
+		{name: "on a page boundary", startIndex: 4, count: 3, wantPages: []int{2}, wantRows: []string{"d", "e", "f"}},
+		{name: "across two pages", startIndex: 3, count: 3, wantPages: []int{1, 2}, wantRows: []string{"c", "d", "e"}},
+		{name: "across the last page", startIndex: 6, count: 3, wantPages: []int{2, 3}, wantRows: []string{"f", "g"}},
+		{name: "off a last page", startIndex: 5, count: 4, wantPages: []int{2}, wantRows: []string{"e", "f", "g"}},
+		{name: "past the end", startIndex: 9, count: 3, wantPages: []int{3}, wantRows: []string{}},
+		{name: "count only", startIndex: 1, count: 0, wantPages: []int{1}, wantRows: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := make([]int, 0)
+			fetched := make([]string, 0)
+			skip, total, err := fetchWindow(tt.startIndex, tt.count, func(page int, perPage int) (int64, error) {
+				pages = append(pages, page)
+				start := (page - 1) * perPage
+				if start < len(rows) {
+					end := start + perPage
+					if end > len(rows) {
+						end = len(rows)
+					}
+					fetched = append(fetched, rows[start:end]...)
+				}
+				return int64(len(rows)), nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(rows)), total)
+			assert.Equal(t, tt.wantPages, pages)
+
+			if tt.count == 0 {
+				return
+			}
+			start, end := window(len(fetched), skip, tt.count)
+			assert.Equal(t, tt.wantRows, fetched[start:end])
+		})
+	}
+
+	t.Run("fetch error", func(t *testing.T) {
+		_, _, err := fetchWindow(3, 3, func(page int, perPage int) (int64, error) {
+			return 0, errors.New("connection refused")
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestOperations(t *testing.T) {
+	t.Run("ops matched in any case", func(t *testing.T) {
+		body := `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[` +
+			`{"op":"Replace","path":"active","value":"False"},{"op":"add","value":{"displayName":"Ann"}},{"op":"REMOVE","path":"title"}]}`
+
+		got, err := operations(newContext(http.MethodPatch, nil, body))
+		assert.NoError(t, err)
+		if assert.Len(t, got, 3) {
+			assert.Equal(t, "replace", got[0].Op)
+			assert.Equal(t, "add", got[1].Op)
+			assert.Equal(t, "remove", got[2].Op)
+		}
+	})
+
+	t.Run("unsupported op", func(t *testing.T) {
+		_, err := operations(newContext(http.MethodPatch, nil, `{"Operations":[{"op":"move","path":"active"}]}`))
+		assert.Equal(t, "invalidSyntax", scimType(err))
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		_, err := operations(newContext(http.MethodPatch, nil, `{"Operations":`))
+		assert.Equal(t, "invalidSyntax", scimType(err))
+	})
+}
@@ -0,0 +1,183 @@
+package scim
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	SchemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+	// SchemaUserAttributes extends a user with the attributes defined on the
+	// service, by name
+	SchemaUserAttributes = "urn:user-svc:params:scim:schemas:extension:2.0:User"
+
+	// MIMEApplicationSCIM is the media type of every SCIM response
+	MIMEApplicationSCIM = "application/scim+json"
+
+	basePath = "/scim/v2"
+)
+
+type Meta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// Reference points to another resource, a group of a user or a member of a
+// group.
+type Reference struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+// User is a user of the service. userName is the email, which is how users
+// sign in, displayName is the name. The attributes of the user are under the
+// SchemaUserAttributes extension.
+type User struct {
+	Schemas     []string               `json:"schemas"`
+	Id          string                 `json:"id,omitempty"`
+	ExternalId  string                 `json:"externalId,omitempty"`
+	UserName    string                 `json:"userName"`
+	Name        *Name                  `json:"name,omitempty"`
+	DisplayName string                 `json:"displayName,omitempty"`
+	Emails      []*Email               `json:"emails,omitempty"`
+	Active      *bool                  `json:"active,omitempty"`
+	Password    string                 `json:"password,omitempty"`
+	Groups      []*Reference           `json:"groups,omitempty"`
+	Attributes  map[string]interface{} `json:"urn:user-svc:params:scim:schemas:extension:2.0:User,omitempty"`
+	Meta        *Meta                  `json:"meta,omitempty"`
+}
+
+// Group is a role, its members are the users holding it.
+type Group struct {
+	Schemas     []string     `json:"schemas"`
+	Id          string       `json:"id,omitempty"`
+	ExternalId  string       `json:"externalId,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []*Reference `json:"members,omitempty"`
+	Meta        *Meta        `json:"meta,omitempty"`
+}
+
+type ListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int64       `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// PatchRequest carries the operations of a PATCH, applied in order.
+type PatchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []*PatchOperation `json:"Operations"`
+}
+
+// PatchOperation has the value left raw, its shape depends on the path.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// location is the absolute URL of a resource, as seen by the client. A
+// singleton like ServiceProviderConfig has no id.
+func location(c echo.Context, endpoint string, id string) string {
+	url := fmt.Sprintf("%s://%s%s/%s", c.Scheme(), c.Request().Host, basePath, endpoint)
+	if id != "" {
+		url += "/" + id
+	}
+	return url
+}
+
+func newUser(c echo.Context, user *domain.User, roles []*domain.Role) *User {
+	active := user.Active
+	resource := &User{
+		Schemas:     []string{SchemaUser},
+		Id:          user.Id,
+		UserName:    user.Email,
+		Name:        &Name{Formatted: user.Name},
+		DisplayName: user.Name,
+		Emails:      []*Email{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Meta:        newMeta(c, "User", "Users", user.Id, user.CreatedAt, user.UpdatedAt),
+	}
+	if len(user.Attributes) > 0 {
+		resource.Schemas = append(resource.Schemas, SchemaUserAttributes)
+		resource.Attributes = user.Attributes
+	}
+	for _, role := range roles {
+		resource.Groups = append(resource.Groups, &Reference{Value: role.Id, Ref: location(c, "Groups", role.Id), Display: role.Name})
+	}
+	return resource
+}
+
+func newGroup(c echo.Context, role *domain.Role, members []*domain.User) *Group {
+	resource := &Group{
+		Schemas:     []string{SchemaGroup},
+		Id:          role.Id,
+		DisplayName: role.Name,
+		Meta:        newMeta(c, "Group", "Groups", role.Id, role.CreatedAt, role.UpdatedAt),
+	}
+	for _, user := range members {
+		resource.Members = append(resource.Members, &Reference{Value: user.Id, Ref: location(c, "Users", user.Id), Display: user.Name})
+	}
+	return resource
+}
+
+func newMeta(c echo.Context, resourceType string, endpoint string, id string, created time.Time, lastModified time.Time) *Meta {
+	meta := &Meta{ResourceType: resourceType, Location: location(c, endpoint, id)}
+	if !created.IsZero() {
+		meta.Created = &created
+	}
+	if !lastModified.IsZero() {
+		meta.LastModified = &lastModified
+	}
+	return meta
+}
+
+// displayName is the name a user is given, whichever of the name attributes
+// the identity provider fills.
+func (u *User) displayName() string {
+	switch {
+	case u.DisplayName != "":
+		return u.DisplayName
+	case u.Name != nil && u.Name.Formatted != "":
+		return u.Name.Formatted
+	case u.Name != nil && (u.Name.GivenName != "" || u.Name.FamilyName != ""):
+		if u.Name.GivenName == "" || u.Name.FamilyName == "" {
+			return u.Name.GivenName + u.Name.FamilyName
+		}
+		return u.Name.GivenName + " " + u.Name.FamilyName
+	}
+	return u.UserName
+}
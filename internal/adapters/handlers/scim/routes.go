@@ -0,0 +1,47 @@
+package scim
+
+import (
+	"github.com/labstack/echo/v4"
+	"user-svc/internal/core/services"
+	"user-svc/internal/shared/config"
+)
+
+// RegisterRoutes serves SCIM 2.0 under /scim/v2 for the identity provider.
+// It sits beside the REST API rather than in it, with its own authentication
+// and error format.
+func RegisterRoutes(
+	e *echo.Echo,
+	cfg *config.Config,
+	userService services.UserService,
+	roleService services.RoleService,
+	userRoleService services.UserRoleService,
+	adminScopeService services.AdminScopeService,
+) {
+	userHandler := NewUserHandler(userService, userRoleService)
+	groupHandler := NewGroupHandler(cfg, roleService, userRoleService, adminScopeService)
+
+	group := e.Group(basePath, ErrorMiddleware, NewTokenMiddleware(cfg).Handle)
+
+	// Register discovery endpoints
+	group.GET("/ServiceProviderConfig", ServiceProviderConfigHandler)
+	group.GET("/ResourceTypes", ResourceTypesHandler)
+	group.GET("/ResourceTypes/:id", ResourceTypeHandler)
+	group.GET("/Schemas", SchemasHandler)
+	group.GET("/Schemas/:id", SchemaHandler)
+
+	// Register user endpoints
+	group.GET("/Users", userHandler.Users)
+	group.GET("/Users/:id", userHandler.User)
+	group.POST("/Users", userHandler.CreateUser)
+	group.PUT("/Users/:id", userHandler.ReplaceUser)
+	group.PATCH("/Users/:id", userHandler.PatchUser)
+	group.DELETE("/Users/:id", userHandler.DeleteUser)
+
+	// Register group endpoints
+	group.GET("/Groups", groupHandler.Groups)
+	group.GET("/Groups/:id", groupHandler.Group)
+	group.POST("/Groups", groupHandler.CreateGroup)
+	group.PUT("/Groups/:id", groupHandler.ReplaceGroup)
+	group.PATCH("/Groups/:id", groupHandler.PatchGroup)
+	group.DELETE("/Groups/:id", groupHandler.DeleteGroup)
+}
@@ -0,0 +1,388 @@
+package scim
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strings"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/services"
+	appError "user-svc/internal/shared/error"
+)
+
+type UserHandler struct {
+	userService     services.UserService
+	userRoleService services.UserRoleService
+}
+
+func NewUserHandler(userService services.UserService, userRoleService services.UserRoleService) *UserHandler {
+	return &UserHandler{
+		userService:     userService,
+		userRoleService: userRoleService,
+	}
+}
+
+func (h *UserHandler) Users(c echo.Context) error {
+	userName, filtered, err := readFilter(c, "userName")
+	if err != nil {
+		return err
+	}
+	startIndex, count, err := readPage(c)
+	if err != nil {
+		return err
+	}
+
+	users := make([]*domain.User, 0)
+	skip, total, err := fetchWindow(startIndex, count, func(page int, perPage int) (int64, error) {
+		if filtered {
+			// a userName is unique, only the first page has a user
+			user, err := h.findUser(userName)
+			if err != nil || user == nil {
+				return 0, err
+			}
+			if page == 1 {
+				users = append(users, user)
+			}
+			return 1, nil
+		}
+
+		result, err := h.userService.GetUsers(&domain.GetUsersRequest{
+			PageRequest: domain.PageRequest{Page: page, PerPage: perPage},
+			Sort:        "created_at",
+		})
+		if err != nil {
+			return 0, err
+		}
+		list := result.Data.(*domain.Page)
+		users = append(users, list.Items.([]*domain.User)...)
+		return list.Total, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	start, end := window(len(users), skip, count)
+	resources := make([]*User, 0, end-start)
+	for _, user := range users[start:end] {
+		resource, err := h.resource(c, user)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, resource)
+	}
+
+	return respond(c, http.StatusOK, &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func (h *UserHandler) User(c echo.Context) error {
+	return h.respondUser(c, http.StatusOK, c.Param("id"))
+}
+
+// CreateUser creates the user with a random password when the identity
+// provider sends none, users then sign in through it or reset the password.
+// Groups are read only on a user, memberships are written through groups.
+// Attributes come under the SchemaUserAttributes extension, a user missing a
+// required one is refused as an invalidValue naming it.
+func (h *UserHandler) CreateUser(c echo.Context) error {
+	var resource User
+	if err := decode(c, &resource); err != nil {
+		return err
+	}
+
+	request := &domain.CreateUserRequest{
+		Name:       resource.displayName(),
+		Email:      resource.UserName,
+		Password:   resource.Password,
+		Attributes: resource.Attributes,
+	}
+	if request.Password == "" {
+		password, err := randomPassword()
+		if err != nil {
+			return err
+		}
+		request.Password = password
+	}
+	if err := c.Validate(request); err != nil {
+		return err
+	}
+
+	if _, err := h.userService.CreateUser(request); err != nil {
+		return err
+	}
+
+	user, err := h.findUser(request.Email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return &appError.AppError{Code: http.StatusInternalServerError, Message: "created user could not be read back"}
+	}
+
+	// users are created active, an inactive one is deactivated right away
+	if resource.Active != nil && !*resource.Active {
+		if _, err := h.userService.PatchUser(&domain.PatchUserRequest{Id: user.Id, Active: resource.Active}); err != nil {
+			return err
+		}
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, location(c, "Users", user.Id))
+	return h.respondUser(c, http.StatusCreated, user.Id)
+}
+
+// ReplaceUser keeps the user active as it is when the identity provider
+// leaves active out, the password when it sends none and the attributes when
+// it sends no extension.
+func (h *UserHandler) ReplaceUser(c echo.Context) error {
+	id := c.Param("id")
+	user, err := h.user(id)
+	if err != nil {
+		return err
+	}
+
+	var resource User
+	if err := decode(c, &resource); err != nil {
+		return err
+	}
+
+	active := user.Active
+	if resource.Active != nil {
+		active = *resource.Active
+	}
+	request := &domain.UpdateUserRequest{
+		Id:         id,
+		Name:       resource.displayName(),
+		Email:      resource.UserName,
+		Active:     &active,
+		Password:   resource.Password,
+		Attributes: resource.Attributes,
+	}
+	if err := c.Validate(request); err != nil {
+		return err
+	}
+
+	if _, err := h.userService.UpdateUser(request); err != nil {
+		return err
+	}
+
+	return h.respondUser(c, http.StatusOK, id)
+}
+
+// PatchUser applies the operations as a single patch of the user. Attributes
+// the service does not keep are ignored, so identity providers can send
+// their whole mapping.
+func (h *UserHandler) PatchUser(c echo.Context) error {
+	id := c.Param("id")
+	operations, err := operations(c)
+	if err != nil {
+		return err
+	}
+
+	request := &domain.PatchUserRequest{Id: id}
+	for _, operation := range operations {
+		if err := patchUser(request, operation); err != nil {
+			return err
+		}
+	}
+	if err := c.Validate(request); err != nil {
+		return err
+	}
+
+	if request.Name != nil || request.Email != nil || request.Active != nil || request.Password != nil || request.Attributes != nil {
+		if _, err := h.userService.PatchUser(request); err != nil {
+			return err
+		}
+	}
+
+	return h.respondUser(c, http.StatusOK, id)
+}
+
+func (h *UserHandler) DeleteUser(c echo.Context) error {
+	if _, err := h.userService.DeleteUser(&domain.DeleteUserRequest{Id: c.Param("id")}); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *UserHandler) respondUser(c echo.Context, code int, id string) error {
+	user, err := h.user(id)
+	if err != nil {
+		return err
+	}
+
+	resource, err := h.resource(c, user)
+	if err != nil {
+		return err
+	}
+
+	return respond(c, code, resource)
+}
+
+// resource maps the user, with the roles granted to it directly as its groups
+// unless the client excluded them, like the members of a group.
+func (h *UserHandler) resource(c echo.Context, user *domain.User) (*User, error) {
+	if excluded(c, "groups") {
+		return newUser(c, user, nil), nil
+	}
+
+	result, err := h.userRoleService.GetDirectUserRoles(&domain.GetUserRolesRequest{UserId: user.Id})
+	if err != nil {
+		return nil, err
+	}
+	return newUser(c, user, result.Data.([]*domain.Role)), nil
+}
+
+func (h *UserHandler) user(id string) (*domain.User, error) {
+	result, err := h.userService.GetUser(id)
+	if err != nil {
+		return nil, err
+	}
+	return result.Data.(*domain.User), nil
+}
+
+// findUser looks a user up by userName, which is the email. The listing only
+// matches emails by prefix, an exact match sorts first among them.
+func (h *UserHandler) findUser(userName string) (*domain.User, error) {
+	result, err := h.userService.GetUsers(&domain.GetUsersRequest{
+		PageRequest: domain.PageRequest{PerPage: domain.MaxPerPage},
+		Email:       userName,
+		Sort:        "email",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range result.Data.(*domain.Page).Items.([]*domain.User) {
+		if strings.EqualFold(user.Email, userName) {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func patchUser(request *domain.PatchUserRequest, operation *PatchOperation) error {
+	if operation.Op == "remove" {
+		if name, ok := extensionAttribute(operation.Path); ok {
+			if name == "" {
+				return badRequest("invalidPath", "the attributes of %s are removed one by one", operation.Path)
+			}
+			// a null removes the attribute when the patch is merged
+			return setExtensionAttribute(request, name, nil)
+		}
+		switch strings.ToLower(attributeName(operation.Path)) {
+		case "username", "displayname", "name", "name.formatted", "active", "password":
+			return badRequest("mutability", "%s cannot be removed", operation.Path)
+		}
+		return nil
+	}
+
+	if operation.Path != "" {
+		return setUserAttribute(request, operation.Path, operation.Value)
+	}
+
+	attributes, err := objectValue(operation.Value)
+	if err != nil {
+		return err
+	}
+	for attribute, value := range attributes {
+		if err := setUserAttribute(request, attribute, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setUserAttribute(request *domain.PatchUserRequest, attribute string, value interface{}) error {
+	if name, ok := extensionAttribute(attribute); ok {
+		return setExtensionAttribute(request, name, value)
+	}
+
+	switch strings.ToLower(attributeName(attribute)) {
+	case "username":
+		email, err := stringValue(attribute, value)
+		if err != nil {
+			return err
+		}
+		request.Email = &email
+	case "displayname", "name.formatted":
+		name, err := stringValue(attribute, value)
+		if err != nil {
+			return err
+		}
+		request.Name = &name
+	case "name":
+		var resource User
+		if raw, err := json.Marshal(value); err != nil || json.Unmarshal(raw, &resource.Name) != nil {
+			return badRequest("invalidValue", "%s must be a complex name", attribute)
+		}
+		if name := resource.displayName(); name != "" {
+			request.Name = &name
+		}
+	case "active":
+		active, err := boolValue(attribute, value)
+		if err != nil {
+			return err
+		}
+		request.Active = &active
+	case "password":
+		password, err := stringValue(attribute, value)
+		if err != nil {
+			return err
+		}
+		request.Password = &password
+	}
+	return nil
+}
+
+// extensionAttribute returns the name of the attribute of the
+// SchemaUserAttributes extension a path points to, empty when it points to
+// the extension itself.
+func extensionAttribute(path string) (string, bool) {
+	if len(path) < len(SchemaUserAttributes) || !strings.EqualFold(path[:len(SchemaUserAttributes)], SchemaUserAttributes) {
+		return "", false
+	}
+	name := path[len(SchemaUserAttributes):]
+	if name == "" {
+		return "", true
+	}
+	if name[0] != ':' {
+		return "", false
+	}
+	return name[1:], true
+}
+
+// setExtensionAttribute sets an attribute of the extension, or every
+// attribute of the value when no name is given.
+func setExtensionAttribute(request *domain.PatchUserRequest, name string, value interface{}) error {
+	if request.Attributes == nil {
+		request.Attributes = make(map[string]interface{})
+	}
+	if name != "" {
+		request.Attributes[name] = value
+		return nil
+	}
+
+	attributes, ok := value.(map[string]interface{})
+	if !ok {
+		return badRequest("invalidValue", "%s must be an object", SchemaUserAttributes)
+	}
+	for name, value := range attributes {
+		request.Attributes[name] = value
+	}
+	return nil
+}
+
+func randomPassword() (string, error) {
+	random := make([]byte, 24)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(random), nil
+}
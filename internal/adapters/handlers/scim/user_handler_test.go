@@ -0,0 +1,115 @@
+package scim
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"user-svc/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatchUser(t *testing.T) {
+	t.Run("operations with a path", func(t *testing.T) {
+		request := &domain.PatchUserRequest{Id: "user-1"}
+		for _, operation := range []*PatchOperation{
+			{Op: "replace", Path: "userName", Value: "ann@example.com"},
+			{Op: "replace", Path: "urn:ietf:params:scim:schemas:core:2.0:User:active", Value: "False"},
+			{Op: "add", Path: "name", Value: map[string]interface{}{"givenName": "Ann", "familyName": "Lee"}},
+			{Op: "replace", Path: "title", Value: "Engineer"},
+		} {
+			assert.NoError(t, patchUser(request, operation))
+		}
+
+		assert.Equal(t, "ann@example.com", *request.Email)
+		assert.False(t, *request.Active)
+		assert.Equal(t, "Ann Lee", *request.Name)
+		assert.Nil(t, request.Password)
+	})
+
+	t.Run("operation without a path sets every attribute", func(t *testing.T) {
+		request := &domain.PatchUserRequest{Id: "user-1"}
+		err := patchUser(request, &PatchOperation{Op: "replace", Value: map[string]interface{}{
+			"displayName": "Ann",
+			"active":      true,
+			"password":    "s3cret-passw0rd",
+		}})
+		assert.NoError(t, err)
+
+		assert.Equal(t, "Ann", *request.Name)
+		assert.True(t, *request.Active)
+		assert.Equal(t, "s3cret-passw0rd", *request.Password)
+	})
+
+	t.Run("attributes of the extension", func(t *testing.T) {
+		request := &domain.PatchUserRequest{Id: "user-1"}
+		for _, operation := range []*PatchOperation{
+			{Op: "replace", Path: SchemaUserAttributes + ":department", Value: "Sales"},
+			{Op: "add", Path: SchemaUserAttributes, Value: map[string]interface{}{"level": 3.0}},
+			{Op: "replace", Value: map[string]interface{}{SchemaUserAttributes: map[string]interface{}{"remote": true}}},
+			{Op: "remove", Path: SchemaUserAttributes + ":badge"},
+		} {
+			assert.NoError(t, patchUser(request, operation))
+		}
+
+		assert.Equal(t, map[string]interface{}{"department": "Sales", "level": 3.0, "remote": true, "badge": nil}, request.Attributes)
+		assert.Nil(t, request.Active)
+	})
+
+	t.Run("removing an attribute that is not kept is ignored", func(t *testing.T) {
+		request := &domain.PatchUserRequest{Id: "user-1"}
+		assert.NoError(t, patchUser(request, &PatchOperation{Op: "remove", Path: "phoneNumbers"}))
+		assert.Equal(t, &domain.PatchUserRequest{Id: "user-1"}, request)
+	})
+
+	failures := []struct {
+		name      string
+		operation *PatchOperation
+		wantType  string
+	}{
+		{name: "required attribute removed", operation: &PatchOperation{Op: "remove", Path: "userName"}, wantType: "mutability"},
+		{name: "active removed", operation: &PatchOperation{Op: "remove", Path: "active"}, wantType: "mutability"},
+		{name: "active not a boolean", operation: &PatchOperation{Op: "replace", Path: "active", Value: "yes"}, wantType: "invalidValue"},
+		{name: "userName not a string", operation: &PatchOperation{Op: "replace", Path: "userName", Value: 42.0}, wantType: "invalidValue"},
+		{name: "name not complex", operation: &PatchOperation{Op: "replace", Path: "name", Value: "Ann"}, wantType: "invalidValue"},
+		{name: "no path and no object", operation: &PatchOperation{Op: "replace", Value: "Ann"}, wantType: "invalidValue"},
+		{name: "extension removed as a whole", operation: &PatchOperation{Op: "remove", Path: SchemaUserAttributes}, wantType: "invalidPath"},
+		{name: "extension not an object", operation: &PatchOperation{Op: "replace", Path: SchemaUserAttributes, Value: "Sales"}, wantType: "invalidValue"},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			err := patchUser(&domain.PatchUserRequest{Id: "user-1"}, tt.operation)
+			assert.Equal(t, tt.wantType, scimType(err))
+		})
+	}
+}
+
+func TestExtensionAttribute(t *testing.T) {
+	tests := []struct {
+		path     string
+		wantName string
+		wantOk   bool
+	}{
+		{path: SchemaUserAttributes + ":department", wantName: "department", wantOk: true},
+		{path: strings.ToUpper(SchemaUserAttributes) + ":department", wantName: "department", wantOk: true},
+		{path: SchemaUserAttributes, wantOk: true},
+		{path: SchemaUserAttributes + "s:department"},
+		{path: SchemaUser + ":active"},
+		{path: "active"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			name, ok := extensionAttribute(tt.path)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestUser_Attributes(t *testing.T) {
+	var resource User
+	body := `{"schemas":["` + SchemaUser + `","` + SchemaUserAttributes + `"],"userName":"ann@example.com",` +
+		`"` + SchemaUserAttributes + `":{"department":"Sales","level":3}}`
+	assert.NoError(t, json.Unmarshal([]byte(body), &resource))
+	assert.Equal(t, map[string]interface{}{"department": "Sales", "level": 3.0}, resource.Attributes)
+}
@@ -0,0 +1,95 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/shared/config"
+	"user-svc/internal/shared/logger"
+)
+
+const (
+	webhookTimeout = 10 * time.Second
+	// webhookQueueSize is how many notifications may wait for delivery
+	webhookQueueSize = 100
+)
+
+// WebhookNotifier logs every notification and posts it as JSON to the
+// configured webhook, if any. Posting happens in the background, so a slow
+// webhook never holds up the caller.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+	logger *logger.LoggerWrapper
+	queue  chan *domain.Notification
+}
+
+func NewWebhookNotifier(cfg *config.Config, logger *logger.LoggerWrapper) *WebhookNotifier {
+	n := &WebhookNotifier{
+		url:    cfg.App.Notification.WebhookURL,
+		client: &http.Client{Timeout: webhookTimeout},
+		logger: logger,
+		queue:  make(chan *domain.Notification, webhookQueueSize),
+	}
+	if n.url != "" {
+		go n.deliver()
+	}
+	return n
+}
+
+// Notify logs the notification and queues it for the webhook. It only fails
+// when the queue is full, the notification is still in the log then.
+func (n *WebhookNotifier) Notify(notification *domain.Notification) error {
+	fields := logger.FieldMap{
+		"log_type": "NOTIFICATION",
+		"channel":  notification.Channel,
+	}
+	for key, value := range notification.Fields {
+		fields[key] = value
+	}
+	n.logger.WithFields(fields).Warn(notification.Subject)
+
+	if n.url == "" {
+		return nil
+	}
+
+	select {
+	case n.queue <- notification:
+		return nil
+	default:
+		err := errors.New("notification webhook queue is full")
+		n.logger.Error(err.Error(), ": ", notification.Subject)
+		return err
+	}
+}
+
+func (n *WebhookNotifier) deliver() {
+	for notification := range n.queue {
+		if err := n.post(notification); err != nil {
+			n.logger.Error("failed to post notification: ", err)
+		}
+	}
+}
+
+func (n *WebhookNotifier) post(notification *domain.Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	response, err := n.client.Post(n.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("notification webhook responded with %s", response.Status)
+	}
+
+	return nil
+}
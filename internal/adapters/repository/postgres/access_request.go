@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+const accessRequestColumns = "id, user_id, role_id, justification, duration, status, reviewer_id, review_note, reviewed_at, expires_at, created_at, updated_at"
+
+func (r *Repository) CreateAccessRequest(accessRequest *domain.AccessRequest) error {
+	query := "INSERT INTO access_requests (" + accessRequestColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(accessRequest.Id, accessRequest.UserId, accessRequest.RoleId, accessRequest.Justification, accessRequest.Duration, accessRequest.Status,
+		accessRequest.ReviewerId, accessRequest.ReviewNote, accessRequest.ReviewedAt, accessRequest.ExpiresAt, accessRequest.CreatedAt, accessRequest.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) UpdateAccessRequest(accessRequest *domain.AccessRequest) error {
+	query := "UPDATE access_requests SET status = $1, reviewer_id = $2, review_note = $3, reviewed_at = $4, expires_at = $5, updated_at = $6 WHERE id = $7"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(accessRequest.Status, accessRequest.ReviewerId, accessRequest.ReviewNote, accessRequest.ReviewedAt, accessRequest.ExpiresAt, accessRequest.UpdatedAt, accessRequest.Id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("no rows were affected")
+	}
+
+	return nil
+}
+
+// ApproveAccessRequest grants the requested role and records the approval in
+// one transaction, provided the request is still pending.
+func (r *Repository) ApproveAccessRequest(accessRequest *domain.AccessRequest) error {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := "INSERT INTO user_role (user_id, role_id, expires_at) VALUES ($1, $2, $3)" +
+		" ON CONFLICT (user_id, role_id) DO UPDATE SET expires_at = EXCLUDED.expires_at WHERE user_role.expires_at IS NOT NULL"
+	_, err = tx.Exec(query, accessRequest.UserId, accessRequest.RoleId, accessRequest.ExpiresAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := reviewAccessRequest(tx, accessRequest, domain.AccessRequestPending); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RevokeAccessRequest takes back the grant the request made and records the
+// revocation in one transaction, provided the request is still approved. Only
+// the grant carrying the expiry of the request is deleted, a permanent grant
+// the user holds on its own is kept.
+func (r *Repository) RevokeAccessRequest(accessRequest *domain.AccessRequest) error {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := "DELETE FROM user_role WHERE user_id = $1 AND role_id = $2 AND expires_at IS NOT DISTINCT FROM $3"
+	_, err = tx.Exec(query, accessRequest.UserId, accessRequest.RoleId, accessRequest.ExpiresAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := reviewAccessRequest(tx, accessRequest, domain.AccessRequestApproved); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// reviewAccessRequest records the review, failing when the request is no
+// longer in the from status because a concurrent review got there first.
+func reviewAccessRequest(tx querier, accessRequest *domain.AccessRequest, from string) error {
+	query := "UPDATE access_requests SET status = $1, reviewer_id = $2, review_note = $3, reviewed_at = $4, expires_at = $5, updated_at = $6 WHERE id = $7 AND status = $8"
+	result, err := tx.Exec(query, accessRequest.Status, accessRequest.ReviewerId, accessRequest.ReviewNote, accessRequest.ReviewedAt, accessRequest.ExpiresAt, accessRequest.UpdatedAt, accessRequest.Id, from)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("no rows were affected")
+	}
+
+	return nil
+}
+
+func (r *Repository) GetAccessRequestByID(id string) (*domain.AccessRequest, error) {
+	query := "SELECT " + accessRequestColumns + " FROM access_requests WHERE id = $1"
+	row := r.db.QueryRow(query, id)
+
+	var accessRequest domain.AccessRequest
+	err := row.Scan(&accessRequest.Id, &accessRequest.UserId, &accessRequest.RoleId, &accessRequest.Justification, &accessRequest.Duration, &accessRequest.Status,
+		&accessRequest.ReviewerId, &accessRequest.ReviewNote, &accessRequest.ReviewedAt, &accessRequest.ExpiresAt, &accessRequest.CreatedAt, &accessRequest.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accessRequest, nil
+}
+
+func (r *Repository) GetAccessRequests(filter *domain.AccessRequestFilter) ([]*domain.AccessRequest, error) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.UserId != "" {
+		args = append(args, filter.UserId)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	if filter.RoleId != "" {
+		args = append(args, filter.RoleId)
+		conditions = append(conditions, fmt.Sprintf("role_id = $%d", len(args)))
+	}
+
+	query := "SELECT " + accessRequestColumns + " FROM access_requests"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accessRequests := make([]*domain.AccessRequest, 0)
+	for rows.Next() {
+		var accessRequest domain.AccessRequest
+		err := rows.Scan(&accessRequest.Id, &accessRequest.UserId, &accessRequest.RoleId, &accessRequest.Justification, &accessRequest.Duration, &accessRequest.Status,
+			&accessRequest.ReviewerId, &accessRequest.ReviewNote, &accessRequest.ReviewedAt, &accessRequest.ExpiresAt, &accessRequest.CreatedAt, &accessRequest.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		accessRequests = append(accessRequests, &accessRequest)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return accessRequests, nil
+}
+
+// ExpireAccessRequests moves approved requests whose grant ran out, and pending
+// requests created before pendingBefore, to the expired status.
+func (r *Repository) ExpireAccessRequests(now time.Time, pendingBefore time.Time) (int64, error) {
+	query := `
+		UPDATE access_requests SET status = $1, updated_at = $2
+		WHERE (status = $3 AND expires_at IS NOT NULL AND expires_at <= $2)
+		OR (status = $4 AND created_at <= $5)
+	`
+	result, err := r.db.Exec(query, domain.AccessRequestExpired, now, domain.AccessRequestApproved, domain.AccessRequestPending, pendingBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+func TestRepository_ApproveAccessRequest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	reviewerID := "admin-1"
+	accessRequest := &domain.AccessRequest{Id: "request-1", UserId: "user-1", RoleId: "role-1", Status: domain.AccessRequestApproved,
+		ReviewerId: &reviewerID, ReviewedAt: &now, ExpiresAt: &expiresAt, UpdatedAt: now}
+
+	grant := `INSERT INTO user_role \(user_id, role_id, expires_at\) VALUES \(\$1, \$2, \$3\) ON CONFLICT`
+	review := `UPDATE access_requests SET (.+) WHERE id = \$7 AND status = \$8`
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(grant).WithArgs("user-1", "role-1", &expiresAt).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(review).WithArgs(domain.AccessRequestApproved, &reviewerID, nil, &now, &expiresAt, now, "request-1", domain.AccessRequestPending).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, r.ApproveAccessRequest(accessRequest))
+	})
+
+	t.Run("failed - reviewed concurrently, grant rolled back", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(grant).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(review).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		assert.Error(t, r.ApproveAccessRequest(accessRequest))
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_RevokeAccessRequest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	accessRequest := &domain.AccessRequest{Id: "request-1", UserId: "user-1", RoleId: "role-1", Status: domain.AccessRequestRevoked, ExpiresAt: &expiresAt, UpdatedAt: now}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM user_role WHERE user_id = \$1 AND role_id = \$2 AND expires_at IS NOT DISTINCT FROM \$3`).
+		WithArgs("user-1", "role-1", &expiresAt).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE access_requests SET (.+) WHERE id = \$7 AND status = \$8`).
+		WithArgs(domain.AccessRequestRevoked, nil, nil, nil, &expiresAt, now, "request-1", domain.AccessRequestApproved).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, r.RevokeAccessRequest(accessRequest))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
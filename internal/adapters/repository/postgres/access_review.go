@@ -0,0 +1,272 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"user-svc/internal/core/domain"
+)
+
+const (
+	accessReviewColumns     = "id, name, status, created_by, closed_by, due_at, closed_at, created_at, updated_at"
+	accessReviewItemColumns = "id, review_id, user_id, user_email, role_id, role_name, reviewer_id, decision, comment, decided_at, revoked_at, revoke_error"
+)
+
+func (r *Repository) CreateAccessReview(review *domain.AccessReview) error {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := "INSERT INTO access_reviews (" + accessReviewColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)"
+	_, err = tx.Exec(query, review.Id, review.Name, review.Status, review.CreatedBy, review.ClosedBy, review.DueAt, review.ClosedAt, review.CreatedAt, review.UpdatedAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Items are inserted one by one, a campaign can hold more assignments than
+	// a single statement accepts placeholders
+	stmt, err := tx.Prepare("INSERT INTO access_review_items (" + accessReviewItemColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, item := range review.Items {
+		_, err = stmt.Exec(item.Id, item.ReviewId, item.UserId, item.UserEmail, item.RoleId, item.RoleName, item.ReviewerId,
+			item.Decision, item.Comment, item.DecidedAt, item.RevokedAt, item.RevokeError)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) GetAllAccessReview() ([]*domain.AccessReview, error) {
+	query := "SELECT " + accessReviewColumns + " FROM access_reviews ORDER BY created_at DESC"
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]*domain.AccessReview, 0)
+	for rows.Next() {
+		var review domain.AccessReview
+		err := rows.Scan(&review.Id, &review.Name, &review.Status, &review.CreatedBy, &review.ClosedBy, &review.DueAt, &review.ClosedAt, &review.CreatedAt, &review.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, &review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *Repository) GetAccessReviewByID(id string) (*domain.AccessReview, error) {
+	query := "SELECT " + accessReviewColumns + " FROM access_reviews WHERE id = $1"
+	row := r.db.QueryRow(query, id)
+
+	var review domain.AccessReview
+	err := row.Scan(&review.Id, &review.Name, &review.Status, &review.CreatedBy, &review.ClosedBy, &review.DueAt, &review.ClosedAt, &review.CreatedAt, &review.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	query = "SELECT " + accessReviewItemColumns + " FROM access_review_items WHERE review_id = $1 ORDER BY user_email, role_name, id"
+	rows, err := r.db.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	review.Items = make([]*domain.AccessReviewItem, 0)
+	for rows.Next() {
+		var item domain.AccessReviewItem
+		err := rows.Scan(&item.Id, &item.ReviewId, &item.UserId, &item.UserEmail, &item.RoleId, &item.RoleName, &item.ReviewerId,
+			&item.Decision, &item.Comment, &item.DecidedAt, &item.RevokedAt, &item.RevokeError)
+		if err != nil {
+			return nil, err
+		}
+		review.Items = append(review.Items, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// GetAccessReviewAssignments returns the direct, unexpired role assignments a
+// campaign reviews, limited to the given roles unless none are given.
+func (r *Repository) GetAccessReviewAssignments(roles []string) ([]*domain.AccessReviewItem, error) {
+	query := `
+		SELECT ur.user_id, u.email, ur.role_id, ro.name
+		FROM user_role ur
+		INNER JOIN users u ON u.id = ur.user_id
+		INNER JOIN roles ro ON ro.id = ur.role_id
+		WHERE (ur.expires_at IS NULL OR ur.expires_at > now()) AND u.deleted_at IS NULL AND ro.deleted_at IS NULL
+	`
+	// Build the query string with placeholders for the role IDs
+	valueArgs := make([]interface{}, 0, len(roles))
+	if len(roles) > 0 {
+		valueStrings := make([]string, 0, len(roles))
+		for i, roleID := range roles {
+			valueStrings = append(valueStrings, fmt.Sprintf("$%d", i+1))
+			valueArgs = append(valueArgs, roleID)
+		}
+		query += " AND ur.role_id IN (" + strings.Join(valueStrings, ",") + ")"
+	}
+	query += " ORDER BY u.email, ro.name"
+
+	rows, err := r.db.Query(query, valueArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*domain.AccessReviewItem, 0)
+	for rows.Next() {
+		var item domain.AccessReviewItem
+		err := rows.Scan(&item.UserId, &item.UserEmail, &item.RoleId, &item.RoleName)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// UpdateAccessReviewItem records a decision, only while the campaign is open.
+func (r *Repository) UpdateAccessReviewItem(item *domain.AccessReviewItem) error {
+	query := "UPDATE access_review_items SET decision = $1, comment = $2, decided_at = $3 WHERE id = $4 " +
+		"AND EXISTS (SELECT 1 FROM access_reviews ar WHERE ar.id = access_review_items.review_id AND ar.status = $5)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(item.Decision, item.Comment, item.DecidedAt, item.Id, domain.AccessReviewOpen)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrAccessReviewNotOpen
+	}
+
+	return nil
+}
+
+// StartClosingAccessReview moves an open campaign to closing. Only one caller
+// wins the move, so the revocations are applied once.
+func (r *Repository) StartClosingAccessReview(review *domain.AccessReview) error {
+	query := "UPDATE access_reviews SET status = $1, closed_by = $2, updated_at = $3 WHERE id = $4 AND status = $5"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(domain.AccessReviewClosing, review.ClosedBy, review.UpdatedAt, review.Id, domain.AccessReviewOpen)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrAccessReviewNotOpen
+	}
+
+	return nil
+}
+
+// UpdateAccessReviewItemRevocation stores the outcome of the revocation
+// applied to an item.
+func (r *Repository) UpdateAccessReviewItemRevocation(item *domain.AccessReviewItem) error {
+	query := "UPDATE access_review_items SET revoked_at = $1, revoke_error = $2 WHERE id = $3"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(item.RevokedAt, item.RevokeError, item.Id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("no rows were affected")
+	}
+
+	return nil
+}
+
+// CloseAccessReview closes a campaign once its revocations were applied.
+func (r *Repository) CloseAccessReview(review *domain.AccessReview) error {
+	query := "UPDATE access_reviews SET status = $1, closed_at = $2, updated_at = $3 WHERE id = $4 AND status = $5"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(domain.AccessReviewClosed, review.ClosedAt, review.UpdatedAt, review.Id, domain.AccessReviewClosing)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("no rows were affected")
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+func TestRepository_GetAccessReviewAssignments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	t.Run("all roles", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT ur.user_id, u.email, ur.role_id, ro.name FROM user_role ur (.+) WHERE \(ur.expires_at IS NULL OR ur.expires_at > now\(\)\) AND u.deleted_at IS NULL AND ro.deleted_at IS NULL ORDER BY u.email, ro.name`).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "email", "role_id", "name"}).
+				AddRow("u1", "alice@example.com", "r1", "Admin").
+				AddRow("u2", "bob@example.com", "r1", "Admin"))
+
+		items, err := r.GetAccessReviewAssignments(nil)
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+		assert.Equal(t, "alice@example.com", items[0].UserEmail)
+	})
+
+	t.Run("scoped to roles", func(t *testing.T) {
+		mock.ExpectQuery(`(.+) AND ur.role_id IN \(\$1,\$2\) ORDER BY u.email, ro.name`).
+			WithArgs("r1", "r2").
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "email", "role_id", "name"}))
+
+		items, err := r.GetAccessReviewAssignments([]string{"r1", "r2"})
+		assert.NoError(t, err)
+		assert.Len(t, items, 0)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_StartClosingAccessReview(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+	actor := "admin-1"
+	review := &domain.AccessReview{Id: "review-1", ClosedBy: &actor, UpdatedAt: time.Now()}
+	query := `UPDATE access_reviews SET status = \$1, closed_by = \$2, updated_at = \$3 WHERE id = \$4 AND status = \$5`
+
+	t.Run("open campaign", func(t *testing.T) {
+		mock.ExpectPrepare(query).ExpectExec().
+			WithArgs(domain.AccessReviewClosing, &actor, review.UpdatedAt, "review-1", domain.AccessReviewOpen).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, r.StartClosingAccessReview(review))
+	})
+
+	t.Run("already closing", func(t *testing.T) {
+		mock.ExpectPrepare(query).ExpectExec().
+			WithArgs(domain.AccessReviewClosing, &actor, review.UpdatedAt, "review-1", domain.AccessReviewOpen).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, r.StartClosingAccessReview(review), domain.ErrAccessReviewNotOpen)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_UpdateAccessReviewItem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+	decision := domain.ReviewDecisionRevoke
+	item := &domain.AccessReviewItem{Id: "item-1", Decision: &decision}
+
+	mock.ExpectPrepare(`UPDATE access_review_items SET decision = \$1, comment = \$2, decided_at = \$3 WHERE id = \$4 AND EXISTS \(SELECT 1 FROM access_reviews ar WHERE ar.id = access_review_items.review_id AND ar.status = \$5\)`).
+		ExpectExec().
+		WithArgs(&decision, nil, nil, "item-1", domain.AccessReviewOpen).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.ErrorIs(t, r.UpdateAccessReviewItem(item), domain.ErrAccessReviewNotOpen)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
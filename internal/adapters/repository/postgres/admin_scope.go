@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"user-svc/internal/core/domain"
+)
+
+func (r *Repository) GetAdminScope(roleID string) (*domain.AdminScope, error) {
+	scope := &domain.AdminScope{RoleId: roleID}
+
+	roles, err := r.queryStrings("SELECT role_id FROM admin_scope_role WHERE admin_role_id = $1 ORDER BY role_id", roleID)
+	if err != nil {
+		return nil, err
+	}
+	scope.RolesId = roles
+
+	permissions, err := r.queryStrings("SELECT permission_id FROM admin_scope_permission WHERE admin_role_id = $1 ORDER BY permission_id", roleID)
+	if err != nil {
+		return nil, err
+	}
+	scope.PermissionsId = permissions
+
+	return scope, nil
+}
+
+// UpdateAdminScope replaces the roles and permissions a role may administer.
+func (r *Repository) UpdateAdminScope(scope *domain.AdminScope) error {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for _, table := range []string{"admin_scope_role", "admin_scope_permission"} {
+		_, err = tx.Exec("DELETE FROM "+table+" WHERE admin_role_id = $1", scope.RoleId)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	inserts := []struct {
+		query string
+		ids   []string
+	}{
+		{"INSERT INTO admin_scope_role (admin_role_id, role_id) VALUES ", scope.RolesId},
+		{"INSERT INTO admin_scope_permission (admin_role_id, permission_id) VALUES ", scope.PermissionsId},
+	}
+	for _, insert := range inserts {
+		if len(insert.ids) == 0 {
+			continue
+		}
+
+		// Build the query string with placeholders for the IDs
+		valueStrings := make([]string, 0, len(insert.ids))
+		valueArgs := make([]interface{}, 0, len(insert.ids)*2)
+		for i, id := range insert.ids {
+			valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d)", i*2+1, i*2+2))
+			valueArgs = append(valueArgs, scope.RoleId, id)
+		}
+
+		_, err = tx.Exec(insert.query+strings.Join(valueStrings, ","), valueArgs...)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetScopedRoles returns the roles any of adminRoles may grant or revoke.
+func (r *Repository) GetScopedRoles(adminRoles []string) ([]string, error) {
+	return r.queryScope("SELECT DISTINCT role_id FROM admin_scope_role WHERE admin_role_id IN ", adminRoles)
+}
+
+// GetScopedPermissions returns the permissions any of adminRoles may attach to
+// or detach from roles.
+func (r *Repository) GetScopedPermissions(adminRoles []string) ([]string, error) {
+	return r.queryScope("SELECT DISTINCT permission_id FROM admin_scope_permission WHERE admin_role_id IN ", adminRoles)
+}
+
+func (r *Repository) queryScope(query string, adminRoles []string) ([]string, error) {
+	if len(adminRoles) == 0 {
+		return make([]string, 0), nil
+	}
+
+	// Build the query string with placeholders for the role IDs
+	valueStrings := make([]string, 0, len(adminRoles))
+	valueArgs := make([]interface{}, 0, len(adminRoles))
+	for i, roleID := range adminRoles {
+		valueStrings = append(valueStrings, fmt.Sprintf("$%d", i+1))
+		valueArgs = append(valueArgs, roleID)
+	}
+
+	return r.queryStrings(query+"("+strings.Join(valueStrings, ",")+")", valueArgs...)
+}
+
+func (r *Repository) queryStrings(query string, args ...interface{}) ([]string, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make([]string, 0)
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
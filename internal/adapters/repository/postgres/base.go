@@ -9,7 +9,50 @@ import (
 )
 
 type Repository struct {
-	db *sql.DB
+	db querier
+}
+
+// querier runs the statements of a repository, a *sql.DB or the *sql.Tx of a
+// repository bound to a transaction.
+type querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Prepare(query string) (*sql.Stmt, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// transaction is the transaction a repository method runs in. A repository
+// bound to a transaction hands out that one, and leaves ending it to the
+// owner, so the method becomes a step of the larger transaction.
+type transaction struct {
+	*sql.Tx
+	owned bool
+}
+
+func (t *transaction) Commit() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t *transaction) Rollback() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+func (r *Repository) begin() (*transaction, error) {
+	if tx, ok := r.db.(*sql.Tx); ok {
+		return &transaction{Tx: tx}, nil
+	}
+
+	tx, err := r.db.(*sql.DB).Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &transaction{Tx: tx, owned: true}, nil
 }
 
 func NewRepository(cfg *config.Config) *Repository {
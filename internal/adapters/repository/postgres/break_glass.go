@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+const breakGlassColumns = "id, user_id, role_id, role_name, reason, status, activated_at, expires_at, ended_at, ended_by"
+
+func (r *Repository) CreateBreakGlassSession(session *domain.BreakGlassSession) error {
+	query := "INSERT INTO break_glass_sessions (" + breakGlassColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(session.Id, session.UserId, session.RoleId, session.RoleName, session.Reason, session.Status,
+		session.ActivatedAt, session.ExpiresAt, session.EndedAt, session.EndedBy)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) UpdateBreakGlassSession(session *domain.BreakGlassSession) error {
+	query := "UPDATE break_glass_sessions SET status = $1, ended_at = $2, ended_by = $3 WHERE id = $4"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(session.Status, session.EndedAt, session.EndedBy, session.Id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("no rows were affected")
+	}
+
+	return nil
+}
+
+func (r *Repository) GetBreakGlassSessionByID(id string) (*domain.BreakGlassSession, error) {
+	query := "SELECT " + breakGlassColumns + " FROM break_glass_sessions WHERE id = $1"
+	row := r.db.QueryRow(query, id)
+
+	var session domain.BreakGlassSession
+	err := row.Scan(&session.Id, &session.UserId, &session.RoleId, &session.RoleName, &session.Reason, &session.Status,
+		&session.ActivatedAt, &session.ExpiresAt, &session.EndedAt, &session.EndedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// GetBreakGlassSessions lists sessions newest first, limited to a status when
+// one is given.
+func (r *Repository) GetBreakGlassSessions(status string) ([]*domain.BreakGlassSession, error) {
+	query := "SELECT " + breakGlassColumns + " FROM break_glass_sessions"
+	valueArgs := make([]interface{}, 0, 1)
+	if status != "" {
+		query += " WHERE status = $1"
+		valueArgs = append(valueArgs, status)
+	}
+	query += " ORDER BY activated_at DESC"
+
+	return r.queryBreakGlassSessions(query, valueArgs...)
+}
+
+// GetExpiredBreakGlassSessions lists the sessions still marked active whose
+// time ran out.
+func (r *Repository) GetExpiredBreakGlassSessions(now time.Time) ([]*domain.BreakGlassSession, error) {
+	query := "SELECT " + breakGlassColumns + " FROM break_glass_sessions WHERE status = $1 AND expires_at <= $2 ORDER BY expires_at"
+	return r.queryBreakGlassSessions(query, domain.BreakGlassActive, now)
+}
+
+func (r *Repository) queryBreakGlassSessions(query string, args ...interface{}) ([]*domain.BreakGlassSession, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]*domain.BreakGlassSession, 0)
+	for rows.Next() {
+		var session domain.BreakGlassSession
+		err := rows.Scan(&session.Id, &session.UserId, &session.RoleId, &session.RoleName, &session.Reason, &session.Status,
+			&session.ActivatedAt, &session.ExpiresAt, &session.EndedAt, &session.EndedBy)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// SaveBreakGlassEnrollment stores the secret of a user, replacing the one
+// they were enrolled with before.
+func (r *Repository) SaveBreakGlassEnrollment(enrollment *domain.BreakGlassEnrollment) error {
+	query := "INSERT INTO break_glass_secrets (user_id, secret, enrolled_by, created_at) VALUES ($1, $2, $3, $4) " +
+		"ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, enrolled_by = EXCLUDED.enrolled_by, created_at = EXCLUDED.created_at"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(enrollment.UserId, enrollment.Secret, enrollment.EnrolledBy, enrollment.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetBreakGlassSecret returns an empty secret when the user is not enrolled.
+func (r *Repository) GetBreakGlassSecret(userID string) (string, error) {
+	var secret string
+	err := r.db.QueryRow("SELECT secret FROM break_glass_secrets WHERE user_id = $1", userID).Scan(&secret)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+func (r *Repository) DeleteBreakGlassEnrollment(userID string) error {
+	query := "DELETE FROM break_glass_secrets WHERE user_id = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("no rows were affected")
+	}
+
+	return nil
+}
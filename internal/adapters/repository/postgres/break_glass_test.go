@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+func TestRepository_GetExpiredBreakGlassSessions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+	now := time.Now()
+	columns := []string{"id", "user_id", "role_id", "role_name", "reason", "status", "activated_at", "expires_at", "ended_at", "ended_by"}
+
+	mock.ExpectQuery(`SELECT (.+) FROM break_glass_sessions WHERE status = \$1 AND expires_at <= \$2 ORDER BY expires_at`).
+		WithArgs(domain.BreakGlassActive, now).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("s1", "u1", "r1", "Admin", "database outage", domain.BreakGlassActive, now.Add(-time.Hour), now.Add(-time.Minute), nil, nil))
+
+	sessions, err := r.GetExpiredBreakGlassSessions(now)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 1)
+	assert.Equal(t, "Admin", sessions[0].RoleName)
+	assert.Nil(t, sessions[0].EndedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_GetBreakGlassSecret(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	t.Run("enrolled user", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT secret FROM break_glass_secrets WHERE user_id = \$1`).
+			WithArgs("u1").
+			WillReturnRows(sqlmock.NewRows([]string{"secret"}).AddRow("GEZDGNBVGY3TQOJQ"))
+
+		secret, err := r.GetBreakGlassSecret("u1")
+		assert.NoError(t, err)
+		assert.Equal(t, "GEZDGNBVGY3TQOJQ", secret)
+	})
+
+	t.Run("user not enrolled", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT secret FROM break_glass_secrets WHERE user_id = \$1`).
+			WithArgs("u2").
+			WillReturnRows(sqlmock.NewRows([]string{"secret"}))
+
+		secret, err := r.GetBreakGlassSecret("u2")
+		assert.NoError(t, err)
+		assert.Equal(t, "", secret)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"errors"
+	"user-svc/internal/core/domain"
+)
+
+func (r *Repository) CreateGroup(group *domain.Group) error {
+	query := "INSERT INTO groups (id, name, created_at, updated_at) VALUES ($1, $2, $3, $4)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(group.Id, group.Name, group.CreatedAt, group.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) UpdateGroup(group *domain.Group) error {
+	query := "UPDATE groups SET name = $1, updated_at = $2 WHERE id = $3"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(group.Name, group.UpdatedAt, group.Id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("no rows were affected")
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteGroup(id string) error {
+	query := "DELETE FROM groups WHERE id = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("no rows were affected")
+	}
+
+	return nil
+}
+
+func (r *Repository) GetAllGroup() ([]*domain.Group, error) {
+	query := "SELECT id, name, created_at, updated_at FROM groups"
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := make([]*domain.Group, 0)
+	for rows.Next() {
+		var group domain.Group
+		err := rows.Scan(&group.Id, &group.Name, &group.CreatedAt, &group.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, &group)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (r *Repository) GetGroupByID(id string) (*domain.Group, error) {
+	query := "SELECT id, name, created_at, updated_at FROM groups WHERE id = $1"
+	row := r.db.QueryRow(query, id)
+
+	var group domain.Group
+	err := row.Scan(&group.Id, &group.Name, &group.CreatedAt, &group.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+func (r *Repository) GroupIsExist(name string) (bool, error) {
+	query := "SELECT COUNT(*) FROM groups WHERE name = $1"
+	var count int
+	row := r.db.QueryRow(query, name)
+	err := row.Scan(&count)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0 == true, nil
+}
+
+func (r *Repository) GetGroupByName(name string) (*domain.Group, error) {
+	query := "SELECT id, name, created_at, updated_at FROM groups WHERE name = $1"
+	row := r.db.QueryRow(query, name)
+
+	var group domain.Group
+	err := row.Scan(&group.Id, &group.Name, &group.CreatedAt, &group.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
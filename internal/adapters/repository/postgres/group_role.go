@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"user-svc/internal/core/domain"
+)
+
+func (r *Repository) GetGroupRoles(groupID string) ([]*domain.Role, error) {
+	query := `
+		SELECT r.id, r.name, r.active
+		FROM group_role gr
+		INNER JOIN roles r ON gr.role_id = r.id
+		WHERE gr.group_id = $1 AND r.deleted_at IS NULL
+	`
+	rows, err := r.db.Query(query, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := make([]*domain.Role, 0)
+	for rows.Next() {
+		var role domain.Role
+		err := rows.Scan(&role.Id, &role.Name, &role.Active)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, &role)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *Repository) AddGroupRoles(groupID string, roles []string) error {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Build the query string with placeholders for the role IDs
+	valueStrings := make([]string, 0, len(roles))
+	valueArgs := make([]interface{}, 0, len(roles)*2)
+	for i, roleID := range roles {
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d)", i*2+1, i*2+2))
+		valueArgs = append(valueArgs, groupID, roleID)
+	}
+	query := "INSERT INTO group_role (group_id, role_id) VALUES " + strings.Join(valueStrings, ",") + " ON CONFLICT DO NOTHING"
+
+	// Execute the query with the role IDs as arguments
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(valueArgs...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) RemoveGroupRoles(groupID string, roles []string) error {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Build the query string with placeholders for the role IDs
+	valueStrings := make([]string, 0, len(roles))
+	valueArgs := make([]interface{}, 0, len(roles))
+	for i, roleID := range roles {
+		valueStrings = append(valueStrings, fmt.Sprintf("$%d", i+2))
+		valueArgs = append(valueArgs, roleID)
+	}
+	query := "DELETE FROM group_role WHERE group_id = $1 AND role_id IN (" + strings.Join(valueStrings, ",") + ")"
+
+	// Execute the query with the role IDs as arguments
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(append([]interface{}{groupID}, valueArgs...)...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
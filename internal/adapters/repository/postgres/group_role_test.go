@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRepository_GetGroupRoles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "active"}).
+		AddRow("r1", "Operator", true).
+		AddRow("r2", "Auditor", false)
+	mock.ExpectQuery(`SELECT r.id, r.name, r.active FROM group_role gr INNER JOIN roles r ON gr.role_id = r.id WHERE gr.group_id = \$1 AND r.deleted_at IS NULL`).
+		WithArgs("g1").WillReturnRows(rows)
+
+	roles, err := r.GetGroupRoles("g1")
+	assert.NoError(t, err)
+	assert.Len(t, roles, 2)
+	assert.False(t, roles[1].Active)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_AddGroupRoles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+	query := `INSERT INTO group_role \(group_id, role_id\) VALUES \(\$1, \$2\) ON CONFLICT DO NOTHING`
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectPrepare(query).ExpectExec().WithArgs("g1", "r1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, r.AddGroupRoles("g1", []string{"r1"}))
+	})
+
+	t.Run("exec error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectPrepare(query).ExpectExec().WillReturnError(errors.New("exec error"))
+		mock.ExpectRollback()
+
+		assert.Error(t, r.AddGroupRoles("g1", []string{"r1"}))
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_RemoveGroupRoles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(`DELETE FROM group_role WHERE group_id = \$1 AND role_id IN \(\$2\)`).
+		ExpectExec().WithArgs("g1", "r1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, r.RemoveGroupRoles("g1", []string{"r1"}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
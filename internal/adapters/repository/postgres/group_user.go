@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"user-svc/internal/core/domain"
+)
+
+func (r *Repository) GetGroupUsers(groupID string) ([]*domain.User, error) {
+	query := `
+		SELECT u.id, u.name, u.email, u.active
+		FROM group_user gu
+		INNER JOIN users u ON gu.user_id = u.id
+		WHERE gu.group_id = $1 AND u.deleted_at IS NULL
+	`
+	rows, err := r.db.Query(query, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]*domain.User, 0)
+	for rows.Next() {
+		var user domain.User
+		err := rows.Scan(&user.Id, &user.Name, &user.Email, &user.Active)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *Repository) AddGroupUsers(groupID string, users []string) error {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Build the query string with placeholders for the user IDs
+	valueStrings := make([]string, 0, len(users))
+	valueArgs := make([]interface{}, 0, len(users)*2)
+	for i, userID := range users {
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d)", i*2+1, i*2+2))
+		valueArgs = append(valueArgs, groupID, userID)
+	}
+	query := "INSERT INTO group_user (group_id, user_id) VALUES " + strings.Join(valueStrings, ",") + " ON CONFLICT DO NOTHING"
+
+	// Execute the query with the user IDs as arguments
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(valueArgs...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) RemoveGroupUsers(groupID string, users []string) error {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Build the query string with placeholders for the user IDs
+	valueStrings := make([]string, 0, len(users))
+	valueArgs := make([]interface{}, 0, len(users))
+	for i, userID := range users {
+		valueStrings = append(valueStrings, fmt.Sprintf("$%d", i+2))
+		valueArgs = append(valueArgs, userID)
+	}
+	query := "DELETE FROM group_user WHERE group_id = $1 AND user_id IN (" + strings.Join(valueStrings, ",") + ")"
+
+	// Execute the query with the user IDs as arguments
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(append([]interface{}{groupID}, valueArgs...)...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRepository_GetGroupUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "email", "active"}).
+		AddRow("u1", "Jane", "jane@example.com", true)
+	mock.ExpectQuery(`SELECT u.id, u.name, u.email, u.active FROM group_user gu INNER JOIN users u ON gu.user_id = u.id WHERE gu.group_id = \$1 AND u.deleted_at IS NULL`).
+		WithArgs("g1").WillReturnRows(rows)
+
+	users, err := r.GetGroupUsers("g1")
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, "jane@example.com", users[0].Email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_AddGroupUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+	query := `INSERT INTO group_user \(group_id, user_id\) VALUES \(\$1, \$2\),\(\$3, \$4\) ON CONFLICT DO NOTHING`
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectPrepare(query).ExpectExec().WithArgs("g1", "u1", "g1", "u2").WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		assert.NoError(t, r.AddGroupUsers("g1", []string{"u1", "u2"}))
+	})
+
+	t.Run("exec error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectPrepare(query).ExpectExec().WillReturnError(errors.New("exec error"))
+		mock.ExpectRollback()
+
+		assert.Error(t, r.AddGroupUsers("g1", []string{"u1", "u2"}))
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_RemoveGroupUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(`DELETE FROM group_user WHERE group_id = \$1 AND user_id IN \(\$2,\$3\)`).
+		ExpectExec().WithArgs("g1", "u1", "u2").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	assert.NoError(t, r.RemoveGroupUsers("g1", []string{"u1", "u2"}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
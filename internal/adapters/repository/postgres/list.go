@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// conditions collects the WHERE clauses of a list query along with their
+// arguments. Each clause refers to its argument as ?, which is numbered as it
+// is added.
+type conditions struct {
+	clauses []string
+	args    []interface{}
+}
+
+func (c *conditions) add(clause string, args ...interface{}) {
+	for _, arg := range args {
+		c.args = append(c.args, arg)
+		clause = strings.Replace(clause, "?", fmt.Sprintf("$%d", len(c.args)), 1)
+	}
+	c.clauses = append(c.clauses, clause)
+}
+
+func (c *conditions) prefix(column string, value string) {
+	if value != "" {
+		c.add(column+" ILIKE ?", likeEscaper.Replace(value)+"%")
+	}
+}
+
+// contains matches the value anywhere in any of the columns.
+func (c *conditions) contains(columns []string, value string) {
+	if value == "" {
+		return
+	}
+
+	pattern := "%" + likeEscaper.Replace(value) + "%"
+	clauses := make([]string, 0, len(columns))
+	args := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		clauses = append(clauses, column+" ILIKE ?")
+		args = append(args, pattern)
+	}
+	c.add("("+strings.Join(clauses, " OR ")+")", args...)
+}
+
+func (c *conditions) createdBetween(column string, from *time.Time, to *time.Time) {
+	if from != nil {
+		c.add(column+" >= ?", *from)
+	}
+	if to != nil {
+		c.add(column+" < ?", *to)
+	}
+}
+
+func (c *conditions) updatedSince(column string, since *time.Time) {
+	if since != nil {
+		c.add(column+" >= ?", *since)
+	}
+}
+
+// after keeps the rows past a keyset position, in (created_at, id) order.
+func (c *conditions) after(cursor *domain.Cursor) {
+	if cursor != nil {
+		c.add("(created_at, id) > (?, ?)", cursor.CreatedAt, cursor.Id)
+	}
+}
+
+func (c *conditions) where() string {
+	if len(c.clauses) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(c.clauses, " AND ")
+}
+
+// page appends the LIMIT and OFFSET placeholders after the condition
+// arguments.
+func (c *conditions) page(limit int, offset int) (string, []interface{}) {
+	args := append(append([]interface{}{}, c.args...), limit, offset)
+	return fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(c.args)+1, len(c.args)+2), args
+}
+
+// limit appends the LIMIT placeholder after the condition arguments.
+func (c *conditions) limit(limit int) (string, []interface{}) {
+	args := append(append([]interface{}{}, c.args...), limit)
+	return fmt.Sprintf(" LIMIT $%d", len(c.args)+1), args
+}
+
+const keysetOrder = " ORDER BY created_at ASC, id ASC"
+
+// orderBy only sorts by whitelisted columns, falling back to the first one.
+// The id breaks ties so pages stay stable.
+func orderBy(columns map[string]string, fallback string, sort string, order string) string {
+	column, ok := columns[sort]
+	if !ok {
+		column = columns[fallback]
+	}
+	direction := "ASC"
+	if order == domain.SortDesc {
+		direction = "DESC"
+	}
+	return fmt.Sprintf(" ORDER BY %s %s, id %s", column, direction, direction)
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+// ApplyManifestPlan runs every change of the plan through the repository
+// methods, bound to a single transaction which is rolled back instead of
+// committed on a dry run.
+func (r *Repository) ApplyManifestPlan(plan *domain.ManifestPlan, dryRun bool) error {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := applyManifestPlan(&Repository{db: tx.Tx}, plan); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if dryRun {
+		return tx.Rollback()
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func applyManifestPlan(repo *Repository, plan *domain.ManifestPlan) error {
+	for _, permission := range plan.CreatePermissions {
+		if err := repo.CreatePermission(permission); err != nil {
+			return err
+		}
+	}
+	for _, role := range plan.CreateRoles {
+		if err := repo.CreateRole(role); err != nil {
+			return err
+		}
+	}
+	for _, role := range plan.UpdateRoles {
+		if err := repo.UpdateRole(role); err != nil {
+			return err
+		}
+	}
+	for _, binding := range plan.AddRolePermissions {
+		if err := repo.AddRolePermissions(binding.RoleId, []string{binding.PermissionId}); err != nil {
+			return err
+		}
+	}
+	for _, binding := range plan.AddUserRoles {
+		if err := repo.AddUserRoles(binding.UserId, []string{binding.RoleId}, nil); err != nil {
+			return err
+		}
+	}
+	for _, binding := range plan.RemoveUserRoles {
+		if err := repo.RemoveUserRoles(binding.UserId, []string{binding.RoleId}); err != nil {
+			return err
+		}
+	}
+	for _, binding := range plan.RemoveRolePermissions {
+		if err := repo.RemoveRolePermissions(binding.RoleId, []string{binding.PermissionId}); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	for _, role := range plan.DeleteRoles {
+		if err := repo.DeleteRole(role.Id, role.Version, now); err != nil {
+			return err
+		}
+	}
+	for _, permission := range plan.DeletePermissions {
+		if err := repo.DeletePermission(permission.Id, permission.Version, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+func TestRepository_ApplyManifestPlan(t *testing.T) {
+	now := time.Now()
+	plan := &domain.ManifestPlan{
+		CreatePermissions:  []*domain.Permission{{Id: "p1", Name: "Export-User", CreatedAt: now, UpdatedAt: now}},
+		UpdateRoles:        []*domain.Role{{Id: "r1", Name: "Auditor", Active: false, Version: 3, UpdatedAt: now}},
+		AddRolePermissions: []*domain.RolePermissionBinding{{RoleId: "r1", PermissionId: "p1"}},
+		AddUserRoles:       []*domain.UserRoleBinding{{UserId: "u1", RoleId: "r1"}},
+	}
+
+	// every step goes through the repository methods, inside the one transaction
+	expectPlan := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectPrepare(`INSERT INTO permissions`).ExpectExec().
+			WithArgs("p1", "Export-User", false, now, now).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectPrepare(`UPDATE roles SET name = \$1, active = \$2, updated_at = \$3, version = version \+ 1 WHERE id = \$4 AND version = \$5`).ExpectExec().
+			WithArgs("Auditor", false, now, "r1", int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectPrepare(`INSERT INTO role_permission`).ExpectExec().
+			WithArgs("r1", "p1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectPrepare(`INSERT INTO user_role`).ExpectExec().
+			WithArgs("u1", "r1", nil).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectPlan(mock)
+		mock.ExpectCommit()
+
+		assert.NoError(t, (&Repository{db}).ApplyManifestPlan(plan, false))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success - dry run rolled back", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		expectPlan(mock)
+		mock.ExpectRollback()
+
+		assert.NoError(t, (&Repository{db}).ApplyManifestPlan(plan, true))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failed - role changed since the plan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(`INSERT INTO permissions`).ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectPrepare(`UPDATE roles`).ExpectExec().WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, (&Repository{db}).ApplyManifestPlan(plan, false), domain.ErrVersionMismatch)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failed - pruned role changed since the plan", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		prune := &domain.ManifestPlan{DeleteRoles: []*domain.Role{{Id: "r2", Name: "Legacy", Version: 5}}}
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare(`UPDATE roles SET deleted_at = \$1, version = version \+ 1 WHERE id = \$2 AND version = \$3`).ExpectExec().
+			WithArgs(sqlmock.AnyArg(), "r2", int64(5)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, (&Repository{db}).ApplyManifestPlan(prune, false), domain.ErrVersionMismatch)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
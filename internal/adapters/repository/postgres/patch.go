@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"user-svc/internal/core/domain"
+)
+
+// assignments collects the columns an UPDATE writes along with their values,
+// placeholders are numbered as the columns are set.
+type assignments struct {
+	columns []string
+	args    []interface{}
+}
+
+func (a *assignments) set(column string, value interface{}) {
+	a.args = append(a.args, value)
+	a.columns = append(a.columns, fmt.Sprintf("%s = $%d", column, len(a.args)))
+}
+
+// patch writes the assigned columns of a live record, only when it is still
+// at the version it was read at, and bumps the version.
+func (r *Repository) patch(table string, set *assignments, id string, version int64) error {
+	query := fmt.Sprintf("UPDATE %s SET %s, version = version + 1 WHERE id = $%d AND version = $%d AND deleted_at IS NULL",
+		table, strings.Join(set.columns, ", "), len(set.args)+1, len(set.args)+2)
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(append(set.args, id, version)...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrVersionMismatch
+	}
+
+	return nil
+}
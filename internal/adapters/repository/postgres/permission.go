@@ -2,18 +2,21 @@ package postgres
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 	"user-svc/internal/core/domain"
 )
 
 func (r *Repository) CreatePermission(permission *domain.Permission) error {
-	query := "INSERT INTO permissions (id, name, created_at, updated_at) VALUES ($1, $2, $3, $4)"
+	query := "INSERT INTO permissions (id, name, system, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(permission.Id, permission.Name, permission.CreatedAt, permission.UpdatedAt)
+	_, err = stmt.Exec(permission.Id, permission.Name, permission.System, permission.CreatedAt, permission.UpdatedAt)
 	if err != nil {
 		return err
 	}
@@ -21,15 +24,17 @@ func (r *Repository) CreatePermission(permission *domain.Permission) error {
 	return nil
 }
 
+// UpdatePermission only writes the permission when it is still at the version
+// it was read at, and bumps the version.
 func (r *Repository) UpdatePermission(permission *domain.Permission) error {
-	query := "UPDATE permissions SET name = $1, updated_at = $2 WHERE id = $3"
+	query := "UPDATE permissions SET name = $1, updated_at = $2, version = version + 1 WHERE id = $3 AND version = $4 AND deleted_at IS NULL"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	result, err := stmt.Exec(permission.Name, permission.UpdatedAt, permission.Id)
+	result, err := stmt.Exec(permission.Name, permission.UpdatedAt, permission.Id, permission.Version)
 	if err != nil {
 		return err
 	}
@@ -40,21 +45,59 @@ func (r *Repository) UpdatePermission(permission *domain.Permission) error {
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("no rows were affected")
+		return domain.ErrVersionMismatch
+	}
+
+	return nil
+}
+
+// PatchPermission only writes the columns the patch sets.
+func (r *Repository) PatchPermission(id string, version int64, patch *domain.PermissionPatch) error {
+	set := &assignments{}
+	if patch.Name != nil {
+		set.set("name", *patch.Name)
+	}
+	set.set("updated_at", patch.UpdatedAt)
+
+	return r.patch("permissions", set, id, version)
+}
+
+// DeletePermission soft deletes the permission when it is still at the version
+// it was read at, its assignments are kept so a restore brings them back.
+func (r *Repository) DeletePermission(id string, version int64, deletedAt time.Time) error {
+	query := "UPDATE permissions SET deleted_at = $1, version = version + 1 WHERE id = $2 AND version = $3 AND deleted_at IS NULL"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(deletedAt, id, version)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrVersionMismatch
 	}
 
 	return nil
 }
 
-func (r *Repository) DeletePermission(id string) error {
-	query := "DELETE FROM permissions WHERE id = $1"
+func (r *Repository) RestorePermission(id string, restoredAt time.Time) error {
+	query := "UPDATE permissions SET deleted_at = NULL, updated_at = $1, version = version + 1 WHERE id = $2 AND deleted_at IS NOT NULL"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	result, err := stmt.Exec(id)
+	result, err := stmt.Exec(restoredAt, id)
 	if err != nil {
 		return err
 	}
@@ -71,8 +114,48 @@ func (r *Repository) DeletePermission(id string) error {
 	return nil
 }
 
+// MarkSystemPermissions flags the permissions as system ones, which changes
+// them, so their version is bumped.
+func (r *Repository) MarkSystemPermissions(ids []string, updatedAt time.Time) error {
+	// Build the query string with placeholders for the permission IDs
+	valueStrings := make([]string, 0, len(ids))
+	valueArgs := make([]interface{}, 0, len(ids)+1)
+	valueArgs = append(valueArgs, updatedAt)
+	for i, id := range ids {
+		valueStrings = append(valueStrings, fmt.Sprintf("$%d", i+2))
+		valueArgs = append(valueArgs, id)
+	}
+	query := "UPDATE permissions SET system = TRUE, updated_at = $1, version = version + 1 WHERE id IN (" + strings.Join(valueStrings, ",") + ") AND system = FALSE"
+
+	_, err := r.db.Exec(query, valueArgs...)
+	return err
+}
+
+// PurgePermissions removes the permissions deleted before the given time for good,
+// their grants to roles and implications go with them.
+func (r *Repository) PurgePermissions(before time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM permissions WHERE deleted_at IS NOT NULL AND deleted_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *Repository) GetDeletedPermissionByID(id string) (*domain.Permission, error) {
+	query := "SELECT id, name, system, created_at, updated_at, deleted_at FROM permissions WHERE id = $1 AND deleted_at IS NOT NULL"
+	row := r.db.QueryRow(query, id)
+
+	var permission domain.Permission
+	err := row.Scan(&permission.Id, &permission.Name, &permission.System, &permission.CreatedAt, &permission.UpdatedAt, &permission.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &permission, nil
+}
+
 func (r *Repository) GetAllPermission() ([]*domain.Permission, error) {
-	query := "SELECT id, name, created_at, updated_at FROM permissions"
+	query := "SELECT id, name, system, version, created_at, updated_at FROM permissions WHERE deleted_at IS NULL"
 	rows, err := r.db.Query(query)
 	if err != nil {
 		return nil, err
@@ -82,7 +165,79 @@ func (r *Repository) GetAllPermission() ([]*domain.Permission, error) {
 	permissions := make([]*domain.Permission, 0)
 	for rows.Next() {
 		var permission domain.Permission
-		err := rows.Scan(&permission.Id, &permission.Name, &permission.CreatedAt, &permission.UpdatedAt)
+		err := rows.Scan(&permission.Id, &permission.Name, &permission.System, &permission.Version, &permission.CreatedAt, &permission.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, &permission)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+var permissionSortColumns = map[string]string{
+	"name":       "name",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// GetPermissions returns a page of the permissions matching the filter along
+// with the total number of matches.
+func (r *Repository) GetPermissions(filter *domain.PermissionFilter) ([]*domain.Permission, int64, error) {
+	where := permissionConditions(filter)
+
+	var total int64
+	query := "SELECT COUNT(*) FROM permissions" + where.where()
+	if err := r.db.QueryRow(query, where.args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	page, args := where.page(filter.Limit, filter.Offset)
+	query = "SELECT id, name, system, created_at, updated_at FROM permissions" + where.where() +
+		orderBy(permissionSortColumns, "name", filter.Sort, filter.Order) + page
+	permissions, err := r.queryPermissions(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return permissions, total, nil
+}
+
+// GetPermissionsAfter returns the permissions matching the filter that come
+// after its keyset position in creation order.
+func (r *Repository) GetPermissionsAfter(filter *domain.PermissionFilter) ([]*domain.Permission, error) {
+	where := permissionConditions(filter)
+	where.after(filter.After)
+
+	limit, args := where.limit(filter.Limit)
+	query := "SELECT id, name, system, created_at, updated_at FROM permissions" + where.where() + keysetOrder + limit
+	return r.queryPermissions(query, args...)
+}
+
+func permissionConditions(filter *domain.PermissionFilter) *conditions {
+	where := &conditions{}
+	where.add("deleted_at IS NULL")
+	where.prefix("name", filter.NamePrefix)
+	where.createdBetween("created_at", filter.CreatedFrom, filter.CreatedTo)
+	where.updatedSince("updated_at", filter.UpdatedSince)
+	return where
+}
+
+func (r *Repository) queryPermissions(query string, args ...interface{}) ([]*domain.Permission, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := make([]*domain.Permission, 0)
+	for rows.Next() {
+		var permission domain.Permission
+		err := rows.Scan(&permission.Id, &permission.Name, &permission.System, &permission.CreatedAt, &permission.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -97,11 +252,11 @@ func (r *Repository) GetAllPermission() ([]*domain.Permission, error) {
 }
 
 func (r *Repository) GetPermissionByID(id string) (*domain.Permission, error) {
-	query := "SELECT id, name, created_at, updated_at FROM permissions WHERE id = $1"
+	query := "SELECT id, name, system, created_at, updated_at, version FROM permissions WHERE id = $1 AND deleted_at IS NULL"
 	row := r.db.QueryRow(query, id)
 
 	var permission domain.Permission
-	err := row.Scan(&permission.Id, &permission.Name, &permission.CreatedAt, &permission.UpdatedAt)
+	err := row.Scan(&permission.Id, &permission.Name, &permission.System, &permission.CreatedAt, &permission.UpdatedAt, &permission.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +265,7 @@ func (r *Repository) GetPermissionByID(id string) (*domain.Permission, error) {
 }
 
 func (r *Repository) PermissionIsExist(name string) (bool, error) {
-	query := "SELECT COUNT(*) FROM permissions WHERE name = $1"
+	query := "SELECT COUNT(*) FROM permissions WHERE name = $1 AND deleted_at IS NULL"
 	var count int
 	row := r.db.QueryRow(query, name)
 	err := row.Scan(&count)
@@ -122,11 +277,11 @@ func (r *Repository) PermissionIsExist(name string) (bool, error) {
 }
 
 func (r *Repository) GetPermissionByName(name string) (*domain.Permission, error) {
-	query := "SELECT id, name, created_at, updated_at FROM roles WHERE name = $1"
+	query := "SELECT id, name, system, created_at, updated_at FROM permissions WHERE name = $1 AND deleted_at IS NULL"
 	row := r.db.QueryRow(query, name)
 
 	var permission domain.Permission
-	err := row.Scan(&permission.Id, &permission.Name, &permission.CreatedAt, &permission.UpdatedAt)
+	err := row.Scan(&permission.Id, &permission.Name, &permission.System, &permission.CreatedAt, &permission.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"user-svc/internal/core/domain"
+)
+
+func (r *Repository) GetAllPermissionImplications() ([]*domain.PermissionImplication, error) {
+	query := `
+		SELECT p.id, p.name, ip.id, ip.name
+		FROM permission_implications pi
+		INNER JOIN permissions p ON p.id = pi.permission_id
+		INNER JOIN permissions ip ON ip.id = pi.implied_permission_id
+		WHERE p.deleted_at IS NULL AND ip.deleted_at IS NULL
+	`
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	implications := make([]*domain.PermissionImplication, 0)
+	for rows.Next() {
+		var implication domain.PermissionImplication
+		err := rows.Scan(&implication.PermissionId, &implication.PermissionName, &implication.ImpliedPermissionId, &implication.ImpliedPermissionName)
+		if err != nil {
+			return nil, err
+		}
+		implications = append(implications, &implication)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return implications, nil
+}
+
+func (r *Repository) GetPermissionImplications(permissionID string) ([]*domain.Permission, error) {
+	query := `
+		SELECT p.id, p.name
+		FROM permission_implications pi
+		INNER JOIN permissions p ON p.id = pi.implied_permission_id
+		WHERE pi.permission_id = $1 AND p.deleted_at IS NULL
+	`
+	rows, err := r.db.Query(query, permissionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := make([]*domain.Permission, 0)
+	for rows.Next() {
+		var permission domain.Permission
+		err := rows.Scan(&permission.Id, &permission.Name)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, &permission)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (r *Repository) AddPermissionImplications(permissionID string, implied []string) error {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Build the query string with placeholders for the permission IDs
+	valueStrings := make([]string, 0, len(implied))
+	valueArgs := make([]interface{}, 0, len(implied)*2)
+	for i, impliedID := range implied {
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d)", i*2+1, i*2+2))
+		valueArgs = append(valueArgs, permissionID, impliedID)
+	}
+	query := "INSERT INTO permission_implications (permission_id, implied_permission_id) VALUES " + strings.Join(valueStrings, ",") + " ON CONFLICT DO NOTHING"
+
+	_, err = tx.Exec(query, valueArgs...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) RemovePermissionImplications(permissionID string, implied []string) error {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Build the query string with placeholders for the permission IDs
+	valueStrings := make([]string, 0, len(implied))
+	valueArgs := make([]interface{}, 0, len(implied))
+	for i, impliedID := range implied {
+		valueStrings = append(valueStrings, fmt.Sprintf("$%d", i+2))
+		valueArgs = append(valueArgs, impliedID)
+	}
+	query := "DELETE FROM permission_implications WHERE permission_id = $1 AND implied_permission_id IN (" + strings.Join(valueStrings, ",") + ")"
+
+	_, err = tx.Exec(query, append([]interface{}{permissionID}, valueArgs...)...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
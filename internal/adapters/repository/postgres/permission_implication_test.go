@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRepository_GetAllPermissionImplications(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	query := `SELECT p.id, p.name, ip.id, ip.name FROM permission_implications pi (.+)`
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "implied_id", "implied_name"}).
+			AddRow("p1", "Update-User", "p2", "View-User")
+		mock.ExpectQuery(query).WillReturnRows(rows)
+
+		implications, err := r.GetAllPermissionImplications()
+		assert.NoError(t, err)
+		assert.Len(t, implications, 1)
+		assert.Equal(t, "View-User", implications[0].ImpliedPermissionName)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mock.ExpectQuery(query).WillReturnError(errors.New("query error"))
+
+		implications, err := r.GetAllPermissionImplications()
+		assert.Error(t, err)
+		assert.Nil(t, implications)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_AddPermissionImplications(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO permission_implications \(permission_id, implied_permission_id\) VALUES \(\$1, \$2\),\(\$3, \$4\) ON CONFLICT DO NOTHING`).
+		WithArgs("p1", "p2", "p1", "p3").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err = r.AddPermissionImplications("p1", []string{"p2", "p3"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
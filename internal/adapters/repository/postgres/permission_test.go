@@ -1 +1,23 @@
 package postgres
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRepository_MarkSystemPermissions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+	now := time.Now()
+
+	mock.ExpectExec(`UPDATE permissions SET system = TRUE, updated_at = \$1, version = version \+ 1 WHERE id IN \(\$2,\$3\) AND system = FALSE`).
+		WithArgs(now, "p1", "p2").WillReturnResult(sqlmock.NewResult(0, 2))
+
+	assert.NoError(t, r.MarkSystemPermissions([]string{"p1", "p2"}, now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"user-svc/internal/core/domain"
+)
+
+// SavePermissionUsage upserts the last use of each permission, resolving the
+// permission by name. Usage of a permission that no longer exists is dropped,
+// and an older use never overwrites a newer one.
+func (r *Repository) SavePermissionUsage(usages []*domain.PermissionUsage) error {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO permission_usage (user_id, permission_id, last_used_at)
+		SELECT u.id, p.id, $3
+		FROM users u
+		INNER JOIN permissions p ON p.name = $2
+		WHERE u.id = $1 AND p.deleted_at IS NULL
+		ON CONFLICT (user_id, permission_id)
+		DO UPDATE SET last_used_at = GREATEST(permission_usage.last_used_at, EXCLUDED.last_used_at)
+	`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, usage := range usages {
+		_, err = stmt.Exec(usage.UserId, usage.Permission, usage.UsedAt)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetGrantUsage lists the permissions granted to active roles, limited to one
+// role when given, with how many users hold the role and the last time any of
+// them used the permission. The usage table does not say which role a use
+// went through, so a use counts for every role of the user granting it.
+func (r *Repository) GetGrantUsage(roleID string) ([]*domain.GrantUsage, error) {
+	query := `
+		SELECT ro.id, ro.name, p.id, p.name, COUNT(DISTINCT er.user_id), MAX(pu.last_used_at)
+		FROM roles ro
+		INNER JOIN role_permission rp ON rp.role_id = ro.id
+		INNER JOIN permissions p ON p.id = rp.permission_id
+		LEFT JOIN (` + effectiveUserRoles + `) er ON er.role_id = ro.id
+		LEFT JOIN permission_usage pu ON pu.user_id = er.user_id AND pu.permission_id = p.id
+		WHERE ro.active = true AND ro.deleted_at IS NULL AND p.deleted_at IS NULL
+	`
+	valueArgs := make([]interface{}, 0, 1)
+	if roleID != "" {
+		query += " AND ro.id = $1"
+		valueArgs = append(valueArgs, roleID)
+	}
+	query += " GROUP BY ro.id, ro.name, p.id, p.name ORDER BY ro.name, p.name"
+
+	rows, err := r.db.Query(query, valueArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	grants := make([]*domain.GrantUsage, 0)
+	for rows.Next() {
+		var grant domain.GrantUsage
+		err := rows.Scan(&grant.RoleId, &grant.RoleName, &grant.PermissionId, &grant.PermissionName, &grant.Holders, &grant.LastUsedAt)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, &grant)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return grants, nil
+}
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+func TestRepository_SavePermissionUsage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+	usedAt := time.Now()
+
+	mock.ExpectBegin()
+	prepared := mock.ExpectPrepare(`INSERT INTO permission_usage (.+) ON CONFLICT \(user_id, permission_id\) DO UPDATE SET last_used_at = GREATEST(.+)`)
+	prepared.ExpectExec().WithArgs("u1", "View-User", usedAt).WillReturnResult(sqlmock.NewResult(0, 1))
+	prepared.ExpectExec().WithArgs("u2", "List-User", usedAt).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = r.SavePermissionUsage([]*domain.PermissionUsage{
+		{UserId: "u1", Permission: "View-User", UsedAt: usedAt},
+		{UserId: "u2", Permission: "List-User", UsedAt: usedAt},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_GetGrantUsage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+	usedAt := time.Now()
+
+	mock.ExpectQuery(`SELECT ro.id, ro.name, p.id, p.name, COUNT\(DISTINCT er.user_id\), MAX\(pu.last_used_at\) (.+) AND ro.id = \$1 GROUP BY`).
+		WithArgs("r1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "id", "name", "count", "max"}).
+			AddRow("r1", "Manager", "p1", "List-User", 2, usedAt).
+			AddRow("r1", "Manager", "p2", "Delete-User", 2, nil))
+
+	grants, err := r.GetGrantUsage("r1")
+	assert.NoError(t, err)
+	assert.Len(t, grants, 2)
+	assert.Equal(t, 2, grants[0].Holders)
+	assert.NotNil(t, grants[0].LastUsedAt)
+	assert.Nil(t, grants[1].LastUsedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+// WriteRelationTuples deletes and inserts tuples under a single new revision and
+// returns it. Deleted tuples are kept with the revision they were deleted at,
+// so reads at an older revision still see them.
+func (r *Repository) WriteRelationTuples(writes []*domain.RelationTuple, deletes []*domain.RelationTuple) (int64, error) {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// writers are serialized so revisions become visible in order
+	_, err = tx.Exec("LOCK TABLE relation_tuples IN SHARE ROW EXCLUSIVE MODE")
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	var revision int64
+	err = tx.QueryRow("SELECT nextval('relation_tuple_revision')").Scan(&revision)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	// readers only see the revision once this write commits
+	_, err = tx.Exec("UPDATE relation_tuple_store SET revision = $1", revision)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	for _, tuple := range deletes {
+		query := `
+			UPDATE relation_tuples SET deleted_revision = $1
+			WHERE namespace = $2 AND object_id = $3 AND relation = $4
+			AND subject_namespace = $5 AND subject_id = $6 AND subject_relation = $7
+			AND deleted_revision IS NULL
+		`
+		_, err = tx.Exec(query, revision, tuple.Namespace, tuple.ObjectId, tuple.Relation, tuple.SubjectNamespace, tuple.SubjectId, tuple.SubjectRelation)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	if len(writes) > 0 {
+		// Build the query string with placeholders for the tuples
+		now := time.Now()
+		valueStrings := make([]string, 0, len(writes))
+		valueArgs := make([]interface{}, 0, len(writes)*8)
+		for i, tuple := range writes {
+			valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)", i*8+1, i*8+2, i*8+3, i*8+4, i*8+5, i*8+6, i*8+7, i*8+8))
+			valueArgs = append(valueArgs, tuple.Namespace, tuple.ObjectId, tuple.Relation, tuple.SubjectNamespace, tuple.SubjectId, tuple.SubjectRelation, revision, now)
+		}
+		query := "INSERT INTO relation_tuples (namespace, object_id, relation, subject_namespace, subject_id, subject_relation, created_revision, created_at) VALUES " +
+			strings.Join(valueStrings, ",") + " ON CONFLICT DO NOTHING"
+
+		_, err = tx.Exec(query, valueArgs...)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return revision, nil
+}
+
+// GetRelationTuples returns the tuples matching filter as they were at revision.
+func (r *Repository) GetRelationTuples(filter *domain.RelationTupleFilter, revision int64) ([]*domain.RelationTuple, error) {
+	args := []interface{}{revision}
+	conditions := []string{"created_revision <= $1", "(deleted_revision IS NULL OR deleted_revision > $1)"}
+	fields := []struct {
+		column string
+		value  string
+	}{
+		{"namespace", filter.Namespace},
+		{"object_id", filter.ObjectId},
+		{"relation", filter.Relation},
+		{"subject_namespace", filter.SubjectNamespace},
+		{"subject_id", filter.SubjectId},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		args = append(args, field.value)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", field.column, len(args)))
+	}
+	if filter.SubjectRelation != nil {
+		args = append(args, *filter.SubjectRelation)
+		conditions = append(conditions, fmt.Sprintf("subject_relation = $%d", len(args)))
+	}
+
+	query := "SELECT namespace, object_id, relation, subject_namespace, subject_id, subject_relation, created_at FROM relation_tuples WHERE " +
+		strings.Join(conditions, " AND ") + " ORDER BY namespace, object_id, relation, subject_namespace, subject_id, subject_relation"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tuples := make([]*domain.RelationTuple, 0)
+	for rows.Next() {
+		var tuple domain.RelationTuple
+		err := rows.Scan(&tuple.Namespace, &tuple.ObjectId, &tuple.Relation, &tuple.SubjectNamespace, &tuple.SubjectId, &tuple.SubjectRelation, &tuple.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		tuples = append(tuples, &tuple)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tuples, nil
+}
+
+// PruneRelationTuples removes the tuples deleted at or before the last
+// committed revision. Reads are evaluated at that revision, so none of them
+// sees these tuples anymore.
+func (r *Repository) PruneRelationTuples() (int64, error) {
+	result, err := r.db.Exec("DELETE FROM relation_tuples WHERE deleted_revision <= (SELECT revision FROM relation_tuple_store)")
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// GetRelationRevision returns the revision of the last committed write, every
+// tuple up to it is visible.
+func (r *Repository) GetRelationRevision() (int64, error) {
+	var revision int64
+	err := r.db.QueryRow("SELECT revision FROM relation_tuple_store").Scan(&revision)
+	if err != nil {
+		return 0, err
+	}
+
+	return revision, nil
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"user-svc/internal/core/domain"
+)
+
+func TestRepository_WriteRelationTuples(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+	deletes := []*domain.RelationTuple{{Namespace: "document", ObjectId: "readme", Relation: "owner", SubjectNamespace: "user", SubjectId: "alice"}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`LOCK TABLE relation_tuples`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT nextval\('relation_tuple_revision'\)`).WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(4))
+	mock.ExpectExec(`UPDATE relation_tuple_store SET revision = \$1`).WithArgs(int64(4)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE relation_tuples SET deleted_revision = \$1`).
+		WithArgs(int64(4), "document", "readme", "owner", "user", "alice", "").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	revision, err := r.WriteRelationTuples(nil, deletes)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), revision)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_GetRelationRevision(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	mock.ExpectQuery(`SELECT revision FROM relation_tuple_store`).WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(3))
+
+	revision, err := r.GetRelationRevision()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), revision)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_PruneRelationTuples(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	mock.ExpectExec(`DELETE FROM relation_tuples WHERE deleted_revision <= \(SELECT revision FROM relation_tuple_store\)`).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	pruned, err := r.PruneRelationTuples()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), pruned)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,18 +2,19 @@ package postgres
 
 import (
 	"errors"
+	"time"
 	"user-svc/internal/core/domain"
 )
 
 func (r *Repository) CreateRole(role *domain.Role) error {
-	query := "INSERT INTO roles (id, name, active, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)"
+	query := "INSERT INTO roles (id, name, active, system, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(role.Id, role.Name, role.Active, role.CreatedAt, role.UpdatedAt)
+	_, err = stmt.Exec(role.Id, role.Name, role.Active, role.System, role.CreatedAt, role.UpdatedAt)
 	if err != nil {
 		return err
 	}
@@ -21,15 +22,17 @@ func (r *Repository) CreateRole(role *domain.Role) error {
 	return nil
 }
 
+// UpdateRole only writes the role when it is still at the version it was
+// read at, and bumps the version.
 func (r *Repository) UpdateRole(role *domain.Role) error {
-	query := "UPDATE roles SET name = $1, active = $2, updated_at = $3 WHERE id = $4"
+	query := "UPDATE roles SET name = $1, active = $2, updated_at = $3, version = version + 1 WHERE id = $4 AND version = $5 AND deleted_at IS NULL"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	result, err := stmt.Exec(role.Name, role.Active, role.UpdatedAt, role.Id)
+	result, err := stmt.Exec(role.Name, role.Active, role.UpdatedAt, role.Id, role.Version)
 	if err != nil {
 		return err
 	}
@@ -40,21 +43,62 @@ func (r *Repository) UpdateRole(role *domain.Role) error {
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("no rows were affected")
+		return domain.ErrVersionMismatch
 	}
 
 	return nil
 }
 
-func (r *Repository) DeleteRole(id string) error {
-	query := "DELETE FROM roles WHERE id = $1"
+// PatchRole only writes the columns the patch sets.
+func (r *Repository) PatchRole(id string, version int64, patch *domain.RolePatch) error {
+	set := &assignments{}
+	if patch.Name != nil {
+		set.set("name", *patch.Name)
+	}
+	if patch.Active != nil {
+		set.set("active", *patch.Active)
+	}
+	set.set("updated_at", patch.UpdatedAt)
+
+	return r.patch("roles", set, id, version)
+}
+
+// DeleteRole soft deletes the role when it is still at the version it was
+// read at, its assignments are kept so a restore brings them back.
+func (r *Repository) DeleteRole(id string, version int64, deletedAt time.Time) error {
+	query := "UPDATE roles SET deleted_at = $1, version = version + 1 WHERE id = $2 AND version = $3 AND deleted_at IS NULL"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	result, err := stmt.Exec(id)
+	result, err := stmt.Exec(deletedAt, id, version)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrVersionMismatch
+	}
+
+	return nil
+}
+
+func (r *Repository) RestoreRole(id string, restoredAt time.Time) error {
+	query := "UPDATE roles SET deleted_at = NULL, updated_at = $1, version = version + 1 WHERE id = $2 AND deleted_at IS NOT NULL"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(restoredAt, id)
 	if err != nil {
 		return err
 	}
@@ -71,8 +115,31 @@ func (r *Repository) DeleteRole(id string) error {
 	return nil
 }
 
+// PurgeRoles removes the roles deleted before the given time for good,
+// their grants, permissions and scopes go with them.
+func (r *Repository) PurgeRoles(before time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM roles WHERE deleted_at IS NOT NULL AND deleted_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *Repository) GetDeletedRoleByID(id string) (*domain.Role, error) {
+	query := "SELECT id, name, active, system, created_at, updated_at, deleted_at FROM roles WHERE id = $1 AND deleted_at IS NOT NULL"
+	row := r.db.QueryRow(query, id)
+
+	var role domain.Role
+	err := row.Scan(&role.Id, &role.Name, &role.Active, &role.System, &role.CreatedAt, &role.UpdatedAt, &role.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
 func (r *Repository) GetAllRole() ([]*domain.Role, error) {
-	query := "SELECT id, name, active, created_at, updated_at FROM roles"
+	query := "SELECT id, name, active, system, version, created_at, updated_at FROM roles WHERE deleted_at IS NULL"
 	rows, err := r.db.Query(query)
 	if err != nil {
 		return nil, err
@@ -82,7 +149,82 @@ func (r *Repository) GetAllRole() ([]*domain.Role, error) {
 	roles := make([]*domain.Role, 0)
 	for rows.Next() {
 		var role domain.Role
-		err := rows.Scan(&role.Id, &role.Name, &role.Active, &role.CreatedAt, &role.UpdatedAt)
+		err := rows.Scan(&role.Id, &role.Name, &role.Active, &role.System, &role.Version, &role.CreatedAt, &role.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, &role)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+var roleSortColumns = map[string]string{
+	"name":       "name",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// GetRoles returns a page of the roles matching the filter along with the
+// total number of matches.
+func (r *Repository) GetRoles(filter *domain.RoleFilter) ([]*domain.Role, int64, error) {
+	where := roleConditions(filter)
+
+	var total int64
+	query := "SELECT COUNT(*) FROM roles" + where.where()
+	if err := r.db.QueryRow(query, where.args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	page, args := where.page(filter.Limit, filter.Offset)
+	query = "SELECT id, name, active, system, created_at, updated_at FROM roles" + where.where() +
+		orderBy(roleSortColumns, "name", filter.Sort, filter.Order) + page
+	roles, err := r.queryRoles(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return roles, total, nil
+}
+
+// GetRolesAfter returns the roles matching the filter that come after its
+// keyset position in creation order.
+func (r *Repository) GetRolesAfter(filter *domain.RoleFilter) ([]*domain.Role, error) {
+	where := roleConditions(filter)
+	where.after(filter.After)
+
+	limit, args := where.limit(filter.Limit)
+	query := "SELECT id, name, active, system, created_at, updated_at FROM roles" + where.where() + keysetOrder + limit
+	return r.queryRoles(query, args...)
+}
+
+func roleConditions(filter *domain.RoleFilter) *conditions {
+	where := &conditions{}
+	where.add("deleted_at IS NULL")
+	if filter.Active != nil {
+		where.add("active = ?", *filter.Active)
+	}
+	where.prefix("name", filter.NamePrefix)
+	where.createdBetween("created_at", filter.CreatedFrom, filter.CreatedTo)
+	where.updatedSince("updated_at", filter.UpdatedSince)
+	return where
+}
+
+func (r *Repository) queryRoles(query string, args ...interface{}) ([]*domain.Role, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := make([]*domain.Role, 0)
+	for rows.Next() {
+		var role domain.Role
+		err := rows.Scan(&role.Id, &role.Name, &role.Active, &role.System, &role.CreatedAt, &role.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -97,11 +239,11 @@ func (r *Repository) GetAllRole() ([]*domain.Role, error) {
 }
 
 func (r *Repository) GetRoleByID(id string) (*domain.Role, error) {
-	query := "SELECT id, name, active, created_at, updated_at FROM roles WHERE id = $1"
+	query := "SELECT id, name, active, system, created_at, updated_at, version FROM roles WHERE id = $1 AND deleted_at IS NULL"
 	row := r.db.QueryRow(query, id)
 
 	var role domain.Role
-	err := row.Scan(&role.Id, &role.Name, &role.Active, &role.CreatedAt, &role.UpdatedAt)
+	err := row.Scan(&role.Id, &role.Name, &role.Active, &role.System, &role.CreatedAt, &role.UpdatedAt, &role.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +252,7 @@ func (r *Repository) GetRoleByID(id string) (*domain.Role, error) {
 }
 
 func (r *Repository) RoleIsExist(name string) (bool, error) {
-	query := "SELECT COUNT(*) FROM roles WHERE name = $1"
+	query := "SELECT COUNT(*) FROM roles WHERE name = $1 AND deleted_at IS NULL"
 	var count int
 	row := r.db.QueryRow(query, name)
 	err := row.Scan(&count)
@@ -122,11 +264,11 @@ func (r *Repository) RoleIsExist(name string) (bool, error) {
 }
 
 func (r *Repository) GetRoleByName(name string) (*domain.Role, error) {
-	query := "SELECT id, name, active, created_at, updated_at FROM roles WHERE name = $1"
+	query := "SELECT id, name, active, system, created_at, updated_at FROM roles WHERE name = $1 AND deleted_at IS NULL"
 	row := r.db.QueryRow(query, name)
 
 	var role domain.Role
-	err := row.Scan(&role.Id, &role.Name, &role.Active, &role.CreatedAt, &role.UpdatedAt)
+	err := row.Scan(&role.Id, &role.Name, &role.Active, &role.System, &role.CreatedAt, &role.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
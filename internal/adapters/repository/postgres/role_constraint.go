@@ -0,0 +1,204 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"user-svc/internal/core/domain"
+)
+
+const roleConstraintSelect = `
+		SELECT c.id, c.name, c.type, c.max_count, c.created_at, c.updated_at, cr.role_id
+		FROM role_constraints c
+		INNER JOIN role_constraint_role cr ON cr.constraint_id = c.id
+	`
+
+func (r *Repository) CreateRoleConstraint(constraint *domain.RoleConstraint) error {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := "INSERT INTO role_constraints (id, name, type, max_count, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)"
+	_, err = tx.Exec(query, constraint.Id, constraint.Name, constraint.Type, constraint.MaxCount, constraint.CreatedAt, constraint.UpdatedAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Build the query string with placeholders for the role IDs
+	valueStrings := make([]string, 0, len(constraint.RolesId))
+	valueArgs := make([]interface{}, 0, len(constraint.RolesId)*2)
+	for i, roleID := range constraint.RolesId {
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d)", i*2+1, i*2+2))
+		valueArgs = append(valueArgs, constraint.Id, roleID)
+	}
+	query = "INSERT INTO role_constraint_role (constraint_id, role_id) VALUES " + strings.Join(valueStrings, ",")
+
+	_, err = tx.Exec(query, valueArgs...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteRoleConstraint(id string) error {
+	query := "DELETE FROM role_constraints WHERE id = $1"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("no rows were affected")
+	}
+
+	return nil
+}
+
+func (r *Repository) GetAllRoleConstraint() ([]*domain.RoleConstraint, error) {
+	query := roleConstraintSelect + " ORDER BY c.created_at, c.id"
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanRoleConstraints(rows)
+}
+
+func (r *Repository) GetRoleConstraintByID(id string) (*domain.RoleConstraint, error) {
+	query := roleConstraintSelect + " WHERE c.id = $1"
+	rows, err := r.db.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	constraints, err := scanRoleConstraints(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(constraints) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return constraints[0], nil
+}
+
+func (r *Repository) GetRoleConstraintsByRoles(roles []string) ([]*domain.RoleConstraint, error) {
+	if len(roles) == 0 {
+		return make([]*domain.RoleConstraint, 0), nil
+	}
+
+	// Build the query string with placeholders for the role IDs
+	valueStrings := make([]string, 0, len(roles))
+	valueArgs := make([]interface{}, 0, len(roles))
+	for i, roleID := range roles {
+		valueStrings = append(valueStrings, fmt.Sprintf("$%d", i+1))
+		valueArgs = append(valueArgs, roleID)
+	}
+	query := roleConstraintSelect + " WHERE c.id IN (SELECT constraint_id FROM role_constraint_role WHERE role_id IN (" + strings.Join(valueStrings, ",") + ")) ORDER BY c.created_at, c.id"
+
+	rows, err := r.db.Query(query, valueArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanRoleConstraints(rows)
+}
+
+func (r *Repository) GetRoleGrants(roles []string) ([]*domain.UserRoleGrant, error) {
+	if len(roles) == 0 {
+		return make([]*domain.UserRoleGrant, 0), nil
+	}
+
+	// Build the query string with placeholders for the role IDs
+	valueStrings := make([]string, 0, len(roles))
+	valueArgs := make([]interface{}, 0, len(roles))
+	for i, roleID := range roles {
+		valueStrings = append(valueStrings, fmt.Sprintf("$%d", i+1))
+		valueArgs = append(valueArgs, roleID)
+	}
+	query := "SELECT DISTINCT er.user_id, er.role_id FROM (" + effectiveUserRoles + ") er WHERE er.role_id IN (" + strings.Join(valueStrings, ",") + ")"
+
+	rows, err := r.db.Query(query, valueArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	grants := make([]*domain.UserRoleGrant, 0)
+	for rows.Next() {
+		var grant domain.UserRoleGrant
+		err := rows.Scan(&grant.UserId, &grant.RoleId)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, &grant)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return grants, nil
+}
+
+// scanRoleConstraints folds one row per constraint role back into constraints,
+// keeping the order in which the constraints were returned.
+func scanRoleConstraints(rows *sql.Rows) ([]*domain.RoleConstraint, error) {
+	constraints := make([]*domain.RoleConstraint, 0)
+	index := make(map[string]*domain.RoleConstraint)
+	for rows.Next() {
+		var constraint domain.RoleConstraint
+		var roleID string
+		err := rows.Scan(&constraint.Id, &constraint.Name, &constraint.Type, &constraint.MaxCount, &constraint.CreatedAt, &constraint.UpdatedAt, &roleID)
+		if err != nil {
+			return nil, err
+		}
+
+		existing, ok := index[constraint.Id]
+		if !ok {
+			existing = &constraint
+			index[constraint.Id] = existing
+			constraints = append(constraints, existing)
+		}
+		existing.RolesId = append(existing.RolesId, roleID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return constraints, nil
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"fmt"
+	"github.com/lib/pq"
 	"strings"
 	"user-svc/internal/core/domain"
 )
@@ -11,7 +12,7 @@ func (r *Repository) GetRolePermissions(roleId string) ([]*domain.Permission, er
 		SELECT p.id, p.name
 		FROM role_permission rp
 		INNER JOIN permissions p ON p.id = rp.permission_id
-		WHERE rp.role_id = $1
+		WHERE rp.role_id = $1 AND p.deleted_at IS NULL
 	`
 	rows, err := r.db.Query(query, roleId)
 	if err != nil {
@@ -38,7 +39,7 @@ func (r *Repository) GetRolePermissions(roleId string) ([]*domain.Permission, er
 
 func (r *Repository) AddRolePermissions(roleId string, permissions []string) error {
 	// Start transaction
-	tx, err := r.db.Begin()
+	tx, err := r.begin()
 	if err != nil {
 		return err
 	}
@@ -82,7 +83,7 @@ func (r *Repository) AddRolePermissions(roleId string, permissions []string) err
 
 func (r *Repository) RemoveRolePermissions(roleId string, permissions []string) error {
 	// Start transaction
-	tx, err := r.db.Begin()
+	tx, err := r.begin()
 	if err != nil {
 		return err
 	}
@@ -123,3 +124,54 @@ func (r *Repository) RemoveRolePermissions(roleId string, permissions []string)
 
 	return nil
 }
+
+// GetPermissionUsers returns a page of the users granted any of the
+// permissions through an active role, along with the total number of such
+// users. Each user comes with the names of the permissions it holds among them.
+func (r *Repository) GetPermissionUsers(permissionIDs []string, limit int, offset int) ([]*domain.PermissionUser, int64, error) {
+	// Build the query string with placeholders for the permission IDs
+	valueStrings := make([]string, 0, len(permissionIDs))
+	args := make([]interface{}, 0, len(permissionIDs)+2)
+	for i, permissionID := range permissionIDs {
+		valueStrings = append(valueStrings, fmt.Sprintf("$%d", i+1))
+		args = append(args, permissionID)
+	}
+	grants := `
+		FROM users u
+		INNER JOIN (` + effectiveUserRoles + `) er ON er.user_id = u.id
+		INNER JOIN roles r ON r.id = er.role_id
+		INNER JOIN role_permission rp ON rp.role_id = r.id
+		INNER JOIN permissions p ON p.id = rp.permission_id
+		WHERE u.deleted_at IS NULL AND r.active = true AND p.deleted_at IS NULL
+		AND rp.permission_id IN (` + strings.Join(valueStrings, ",") + `)
+	`
+
+	var total int64
+	if err := r.db.QueryRow("SELECT COUNT(DISTINCT u.id)"+grants, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := "SELECT u.id, u.name, u.email, u.active, u.created_at, u.updated_at, array_agg(DISTINCT p.name ORDER BY p.name)" + grants +
+		fmt.Sprintf(" GROUP BY u.id ORDER BY u.name, u.id LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	rows, err := r.db.Query(query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := make([]*domain.PermissionUser, 0)
+	for rows.Next() {
+		user := &domain.PermissionUser{User: &domain.User{}}
+		err := rows.Scan(&user.Id, &user.Name, &user.Email, &user.Active, &user.CreatedAt, &user.UpdatedAt, pq.Array(&user.Permissions))
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
@@ -1 +1,32 @@
 package postgres
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRepository_GetPermissionUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	mock.ExpectQuery(`SELECT COUNT\(DISTINCT u.id\) FROM users u INNER JOIN \((.+)\) er (.+) AND rp.permission_id IN \(\$1,\$2\)`).
+		WithArgs("p-list", "p-view").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(`SELECT u.id, u.name, u.email, u.active, u.created_at, u.updated_at, array_agg\(DISTINCT p.name ORDER BY p.name\) (.+) AND rp.permission_id IN \(\$1,\$2\) GROUP BY u.id ORDER BY u.name, u.id LIMIT \$3 OFFSET \$4`).
+		WithArgs("p-list", "p-view", 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "active", "created_at", "updated_at", "permissions"}).
+			AddRow("u3", "Carol", "carol@example.com", true, time.Now(), time.Now(), "{List-User,View-User}"))
+
+	users, total, err := r.GetPermissionUsers([]string{"p-list", "p-view"}, 2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, users, 1)
+	assert.Equal(t, []string{"List-User", "View-User"}, users[0].Permissions)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,18 +2,26 @@ package postgres
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 	"user-svc/internal/core/domain"
 )
 
 func (r *Repository) CreateUser(user *domain.User) error {
-	query := "INSERT INTO users (id, name, email, salt, password, active, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)"
+	attributesJSON, err := marshalAttributes(user.Attributes)
+	if err != nil {
+		return err
+	}
+
+	query := "INSERT INTO users (id, name, email, salt, password, active, attributes, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(user.Id, user.Name, user.Email, user.Salt, user.Password, user.Active, user.CreatedAt, user.UpdatedAt)
+	_, err = stmt.Exec(user.Id, user.Name, user.Email, user.Salt, user.Password, user.Active, attributesJSON, user.CreatedAt, user.UpdatedAt)
 	if err != nil {
 		return err
 	}
@@ -21,15 +29,68 @@ func (r *Repository) CreateUser(user *domain.User) error {
 	return nil
 }
 
+// UpdateUser only writes the user when it is still at the version it was
+// read at, and bumps the version. Users are read without their credentials,
+// so the salt and password are only written when a new password is set.
 func (r *Repository) UpdateUser(user *domain.User) error {
-	query := "UPDATE users SET name = $1, email = $2, salt = $3, password = $4, active = $5, updated_at = $6 WHERE id = $7"
+	attributesJSON, err := marshalAttributes(user.Attributes)
+	if err != nil {
+		return err
+	}
+
+	set := &assignments{}
+	set.set("name", user.Name)
+	set.set("email", user.Email)
+	if user.Password != "" {
+		set.set("salt", user.Salt)
+		set.set("password", user.Password)
+	}
+	set.set("active", user.Active)
+	set.set("attributes", attributesJSON)
+	set.set("updated_at", user.UpdatedAt)
+
+	return r.patch("users", set, user.Id, user.Version)
+}
+
+// PatchUser only writes the columns the patch sets.
+func (r *Repository) PatchUser(id string, version int64, patch *domain.UserPatch) error {
+	set := &assignments{}
+	if patch.Name != nil {
+		set.set("name", *patch.Name)
+	}
+	if patch.Email != nil {
+		set.set("email", *patch.Email)
+	}
+	if patch.Active != nil {
+		set.set("active", *patch.Active)
+	}
+	if patch.Password != nil {
+		set.set("salt", *patch.Salt)
+		set.set("password", *patch.Password)
+	}
+	if patch.Attributes != nil {
+		attributesJSON, err := marshalAttributes(patch.Attributes)
+		if err != nil {
+			return err
+		}
+		set.set("attributes", attributesJSON)
+	}
+	set.set("updated_at", patch.UpdatedAt)
+
+	return r.patch("users", set, id, version)
+}
+
+// DeleteUser soft deletes the user when it is still at the version it was
+// read at, its assignments are kept so a restore brings them back.
+func (r *Repository) DeleteUser(id string, version int64, deletedAt time.Time) error {
+	query := "UPDATE users SET deleted_at = $1, version = version + 1 WHERE id = $2 AND version = $3 AND deleted_at IS NULL"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	result, err := stmt.Exec(user.Name, user.Email, user.Salt, user.Password, user.Active, user.UpdatedAt, user.Id)
+	result, err := stmt.Exec(deletedAt, id, version)
 	if err != nil {
 		return err
 	}
@@ -40,21 +101,21 @@ func (r *Repository) UpdateUser(user *domain.User) error {
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("no rows were affected")
+		return domain.ErrVersionMismatch
 	}
 
 	return nil
 }
 
-func (r *Repository) DeleteUser(id string) error {
-	query := "DELETE FROM users WHERE id = $1"
+func (r *Repository) RestoreUser(id string, restoredAt time.Time) error {
+	query := "UPDATE users SET deleted_at = NULL, updated_at = $1, version = version + 1 WHERE id = $2 AND deleted_at IS NOT NULL"
 	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	result, err := stmt.Exec(id)
+	result, err := stmt.Exec(restoredAt, id)
 	if err != nil {
 		return err
 	}
@@ -71,9 +132,93 @@ func (r *Repository) DeleteUser(id string) error {
 	return nil
 }
 
-func (r *Repository) GetAllUsers() ([]*domain.User, error) {
-	query := "SELECT id, name, email, salt, password, active, created_at, updated_at FROM users"
-	rows, err := r.db.Query(query)
+// PurgeUsers removes the users deleted before the given time for good,
+// their role grants, group memberships and usage go with them.
+func (r *Repository) PurgeUsers(before time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *Repository) GetDeletedUserByID(id string) (*domain.User, error) {
+	query := "SELECT id, name, email, active, created_at, updated_at, deleted_at FROM users WHERE id = $1 AND deleted_at IS NOT NULL"
+	row := r.db.QueryRow(query, id)
+
+	var user domain.User
+	err := row.Scan(&user.Id, &user.Name, &user.Email, &user.Active, &user.CreatedAt, &user.UpdatedAt, &user.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+var userSortColumns = map[string]string{
+	"name":       "name",
+	"email":      "email",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// GetUsers returns a page of the users matching the filter along with the
+// total number of matches. Credentials are never selected.
+func (r *Repository) GetUsers(filter *domain.UserFilter) ([]*domain.User, int64, error) {
+	where := userConditions(filter)
+
+	var total int64
+	query := "SELECT COUNT(*) FROM users" + where.where()
+	if err := r.db.QueryRow(query, where.args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	page, args := where.page(filter.Limit, filter.Offset)
+	query = "SELECT " + userColumns + " FROM users" + where.where() +
+		orderBy(userSortColumns, "name", filter.Sort, filter.Order) + page
+	users, err := r.queryUsers(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// GetUsersAfter returns the users matching the filter that come after its
+// keyset position in creation order. Rows inserted during an iteration are
+// neither skipped nor repeated, and no total is counted.
+func (r *Repository) GetUsersAfter(filter *domain.UserFilter) ([]*domain.User, error) {
+	where := userConditions(filter)
+	where.after(filter.After)
+
+	limit, args := where.limit(filter.Limit)
+	query := "SELECT " + userColumns + " FROM users" + where.where() + keysetOrder + limit
+	return r.queryUsers(query, args...)
+}
+
+func userConditions(filter *domain.UserFilter) *conditions {
+	where := &conditions{}
+	where.add("deleted_at IS NULL")
+	if filter.Active != nil {
+		where.add("active = ?", *filter.Active)
+	}
+	where.prefix("email", filter.EmailPrefix)
+	where.createdBetween("created_at", filter.CreatedFrom, filter.CreatedTo)
+	where.updatedSince("updated_at", filter.UpdatedSince)
+	if filter.HasRole != "" {
+		where.add("id IN (SELECT er.user_id FROM ("+effectiveUserRoles+") er WHERE er.role_id = ?)", filter.HasRole)
+	}
+	for _, attribute := range filter.Attributes {
+		where.add("attributes->>? = ?", attribute.Name, attribute.Value)
+	}
+	return where
+}
+
+// userColumns are the columns queryUsers scans, credentials are left out.
+const userColumns = "id, name, email, active, attributes, created_at, updated_at"
+
+func (r *Repository) queryUsers(query string, args ...interface{}) ([]*domain.User, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +227,7 @@ func (r *Repository) GetAllUsers() ([]*domain.User, error) {
 	users := make([]*domain.User, 0)
 	for rows.Next() {
 		var user domain.User
-		err := rows.Scan(&user.Id, &user.Name, &user.Email, &user.Salt, &user.Password, &user.Active, &user.CreatedAt, &user.UpdatedAt)
+		err := rows.Scan(&user.Id, &user.Name, &user.Email, &user.Active, attributes{&user.Attributes}, &user.CreatedAt, &user.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -97,11 +242,11 @@ func (r *Repository) GetAllUsers() ([]*domain.User, error) {
 }
 
 func (r *Repository) GetUserByID(id string) (*domain.User, error) {
-	query := "SELECT id, name, email, active, created_at, updated_at FROM users WHERE id = $1"
+	query := "SELECT id, name, email, active, attributes, created_at, updated_at, version FROM users WHERE id = $1 AND deleted_at IS NULL"
 	row := r.db.QueryRow(query, id)
 
 	var user domain.User
-	err := row.Scan(&user.Id, &user.Name, &user.Email, &user.Active, &user.CreatedAt, &user.UpdatedAt)
+	err := row.Scan(&user.Id, &user.Name, &user.Email, &user.Active, attributes{&user.Attributes}, &user.CreatedAt, &user.UpdatedAt, &user.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -109,12 +254,29 @@ func (r *Repository) GetUserByID(id string) (*domain.User, error) {
 	return &user, nil
 }
 
+// GetUsersByIDs returns the live users among the given ids.
+func (r *Repository) GetUsersByIDs(ids []string) ([]*domain.User, error) {
+	if len(ids) == 0 {
+		return make([]*domain.User, 0), nil
+	}
+
+	// Build the query string with placeholders for the ids
+	valueStrings := make([]string, 0, len(ids))
+	valueArgs := make([]interface{}, 0, len(ids))
+	for i, id := range ids {
+		valueStrings = append(valueStrings, fmt.Sprintf("$%d", i+1))
+		valueArgs = append(valueArgs, id)
+	}
+	query := "SELECT " + userColumns + " FROM users WHERE deleted_at IS NULL AND id IN (" + strings.Join(valueStrings, ",") + ")"
+	return r.queryUsers(query, valueArgs...)
+}
+
 func (r *Repository) GetUserByEmail(email string) (*domain.User, error) {
-	query := "SELECT id, name, email, active, salt, password, created_at, updated_at FROM users WHERE email = $1"
+	query := "SELECT id, name, email, active, attributes, salt, password, created_at, updated_at FROM users WHERE email = $1 AND deleted_at IS NULL"
 	row := r.db.QueryRow(query, email)
 
 	var user domain.User
-	err := row.Scan(&user.Id, &user.Name, &user.Email, &user.Active, &user.Salt, &user.Password, &user.CreatedAt, &user.UpdatedAt)
+	err := row.Scan(&user.Id, &user.Name, &user.Email, &user.Active, attributes{&user.Attributes}, &user.Salt, &user.Password, &user.CreatedAt, &user.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +285,7 @@ func (r *Repository) GetUserByEmail(email string) (*domain.User, error) {
 }
 
 func (r *Repository) UserIsExist(email string) (bool, error) {
-	query := "SELECT COUNT(*) FROM users WHERE email = $1"
+	query := "SELECT COUNT(*) FROM users WHERE email = $1 AND deleted_at IS NULL"
 	var count int
 	row := r.db.QueryRow(query, email)
 	err := row.Scan(&count)
@@ -133,3 +295,39 @@ func (r *Repository) UserIsExist(email string) (bool, error) {
 
 	return count > 0 == true, nil
 }
+
+func (r *Repository) GetExistingEmails(emails []string) ([]string, error) {
+	existing := make([]string, 0)
+	if len(emails) == 0 {
+		return existing, nil
+	}
+
+	// Build the query string with placeholders for the emails
+	valueStrings := make([]string, 0, len(emails))
+	valueArgs := make([]interface{}, 0, len(emails))
+	for i, email := range emails {
+		valueStrings = append(valueStrings, fmt.Sprintf("$%d", i+1))
+		valueArgs = append(valueArgs, email)
+	}
+	query := "SELECT email FROM users WHERE deleted_at IS NULL AND email IN (" + strings.Join(valueStrings, ",") + ")"
+
+	rows, err := r.db.Query(query, valueArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, err
+		}
+		existing = append(existing, email)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
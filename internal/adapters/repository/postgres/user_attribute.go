@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"user-svc/internal/core/domain"
+)
+
+const userAttributeColumns = "name, type, required, rules, claim, description, created_at, updated_at"
+
+// attributes scans the attributes of a user, a user holding none is left
+// with a nil map.
+type attributes struct {
+	values *map[string]interface{}
+}
+
+func (a attributes) Scan(src interface{}) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into attributes", src)
+	}
+
+	values := make(map[string]interface{})
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return err
+	}
+	if len(values) > 0 {
+		*a.values = values
+	}
+	return nil
+}
+
+// marshalAttributes writes the attributes of a user, an empty object when it
+// holds none.
+func marshalAttributes(values map[string]interface{}) ([]byte, error) {
+	if len(values) == 0 {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(values)
+}
+
+func (r *Repository) CreateUserAttribute(attribute *domain.UserAttribute) error {
+	query := "INSERT INTO user_attribute_definitions (" + userAttributeColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(attribute.Name, attribute.Type, attribute.Required, attribute.Rules, attribute.Claim, attribute.Description, attribute.CreatedAt, attribute.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) UpdateUserAttribute(attribute *domain.UserAttribute) error {
+	query := "UPDATE user_attribute_definitions SET required = $1, rules = $2, claim = $3, description = $4, updated_at = $5 WHERE name = $6"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(attribute.Required, attribute.Rules, attribute.Claim, attribute.Description, attribute.UpdatedAt, attribute.Name)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("no rows were affected")
+	}
+
+	return nil
+}
+
+// DeleteUserAttribute also strips the values from deleted users, so a restore
+// does not bring back an attribute that no longer exists.
+func (r *Repository) DeleteUserAttribute(name string) error {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	result, err := tx.Exec("DELETE FROM user_attribute_definitions WHERE name = $1", name)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return errors.New("no rows were affected")
+	}
+
+	_, err = tx.Exec("UPDATE users SET attributes = attributes - $1, version = version + 1 WHERE jsonb_exists(attributes, $1)", name)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) GetUserAttributes() ([]*domain.UserAttribute, error) {
+	query := "SELECT " + userAttributeColumns + " FROM user_attribute_definitions ORDER BY name"
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	definitions := make([]*domain.UserAttribute, 0)
+	for rows.Next() {
+		var attribute domain.UserAttribute
+		err := rows.Scan(&attribute.Name, &attribute.Type, &attribute.Required, &attribute.Rules, &attribute.Claim, &attribute.Description, &attribute.CreatedAt, &attribute.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, &attribute)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return definitions, nil
+}
+
+func (r *Repository) GetUserAttributeByName(name string) (*domain.UserAttribute, error) {
+	query := "SELECT " + userAttributeColumns + " FROM user_attribute_definitions WHERE name = $1"
+	row := r.db.QueryRow(query, name)
+
+	var attribute domain.UserAttribute
+	err := row.Scan(&attribute.Name, &attribute.Type, &attribute.Required, &attribute.Rules, &attribute.Claim, &attribute.Description, &attribute.CreatedAt, &attribute.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attribute, nil
+}
+
+func (r *Repository) CountUsersWithoutAttribute(name string) (int64, error) {
+	query := "SELECT COUNT(*) FROM users WHERE deleted_at IS NULL AND NOT jsonb_exists(attributes, $1)"
+	var count int64
+	if err := r.db.QueryRow(query, name).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+func TestRepository_CreateUserAttribute(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{db}
+	attribute := &domain.UserAttribute{Name: "department", Type: domain.UserAttributeString, Required: true, Rules: "min=2", Claim: true, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+
+	mock.ExpectPrepare(`^INSERT INTO user_attribute_definitions (.+) VALUES (.+)`).
+		ExpectExec().
+		WithArgs("department", "string", true, "min=2", true, "", attribute.CreatedAt, attribute.UpdatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.CreateUserAttribute(attribute))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_DeleteUserAttribute(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{db}
+
+	t.Run("success - values stripped from users", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`^DELETE FROM user_attribute_definitions WHERE name = \$1$`).
+			WithArgs("department").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`^UPDATE users SET attributes = attributes - \$1, version = version \+ 1 WHERE jsonb_exists\(attributes, \$1\)$`).
+			WithArgs("department").
+			WillReturnResult(sqlmock.NewResult(0, 12))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.DeleteUserAttribute("department"))
+	})
+
+	t.Run("not exist", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`^DELETE FROM user_attribute_definitions`).
+			WithArgs("team").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		assert.EqualError(t, repo.DeleteUserAttribute("team"), "no rows were affected")
+	})
+
+	t.Run("failed to strip values", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`^DELETE FROM user_attribute_definitions`).
+			WithArgs("department").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`^UPDATE users`).
+			WithArgs("department").
+			WillReturnError(errors.New("some error"))
+		mock.ExpectRollback()
+
+		assert.Error(t, repo.DeleteUserAttribute("department"))
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_GetUserAttributes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{db}
+	now := time.Now()
+	columns := []string{"name", "type", "required", "rules", "claim", "description", "created_at", "updated_at"}
+
+	mock.ExpectQuery(`^SELECT (.+) FROM user_attribute_definitions ORDER BY name$`).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("department", "string", true, "min=2", true, "", now, now).
+			AddRow("hired_on", "date", false, "", false, "First day", now, now))
+
+	attributes, err := repo.GetUserAttributes()
+	require.NoError(t, err)
+	require.Len(t, attributes, 2)
+	assert.Equal(t, &domain.UserAttribute{Name: "hired_on", Type: "date", Description: "First day", CreatedAt: now, UpdatedAt: now}, attributes[1])
+
+	mock.ExpectQuery(`^SELECT (.+) FROM user_attribute_definitions WHERE name = \$1$`).
+		WithArgs("team").
+		WillReturnError(sql.ErrNoRows)
+
+	attribute, err := repo.GetUserAttributeByName("team")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, attribute)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_CountUsersWithoutAttribute(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{db}
+	mock.ExpectQuery(`^SELECT COUNT\(\*\) FROM users WHERE deleted_at IS NULL AND NOT jsonb_exists\(attributes, \$1\)$`).
+		WithArgs("department").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := repo.CountUsersWithoutAttribute("department")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"fmt"
+	"github.com/lib/pq"
+	"user-svc/internal/core/domain"
+)
+
+const (
+	userExportRoles = `
+		ARRAY(
+			SELECT DISTINCT r.name
+			FROM (` + effectiveUserRoles + `) er
+			INNER JOIN roles r ON r.id = er.role_id
+			WHERE er.user_id = users.id AND r.active = true
+			ORDER BY r.name
+		)`
+	userExportPermissions = `
+		ARRAY(
+			SELECT DISTINCT p.name
+			FROM (` + effectiveUserRoles + `) er
+			INNER JOIN roles r ON r.id = er.role_id
+			INNER JOIN role_permission rp ON rp.role_id = r.id
+			INNER JOIN permissions p ON p.id = rp.permission_id
+			WHERE er.user_id = users.id AND r.active = true AND p.deleted_at IS NULL
+			ORDER BY p.name
+		)`
+)
+
+// ExportUsers declares a cursor over the users matching the filter and
+// fetches it a batch at a time, in creation order. The cursor lives in a read
+// only transaction, so the export is a consistent snapshot.
+func (r *Repository) ExportUsers(query *domain.UserExportQuery, write func(user *domain.UserExport) error) error {
+	where := userConditions(query.Filter)
+	columns := "id, name, email, active, created_at, updated_at"
+	if query.IncludeRoles {
+		columns += ", " + userExportRoles
+	}
+	if query.IncludePermissions {
+		columns += ", " + userExportPermissions
+	}
+
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = tx.Exec("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec("DECLARE user_export NO SCROLL CURSOR FOR SELECT "+columns+" FROM users"+where.where()+keysetOrder, where.args...)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM user_export", query.FetchSize)
+	for {
+		fetched, err := fetchUserExport(tx, fetch, query, write)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if fetched < query.FetchSize {
+			break
+		}
+	}
+
+	// Commit the transaction, which closes the cursor
+	return tx.Commit()
+}
+
+// fetchUserExport writes a batch of the cursor and returns its size.
+func fetchUserExport(tx querier, fetch string, query *domain.UserExportQuery, write func(user *domain.UserExport) error) (int, error) {
+	rows, err := tx.Query(fetch)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	fetched := 0
+	for rows.Next() {
+		var user domain.UserExport
+		dest := []interface{}{&user.Id, &user.Name, &user.Email, &user.Active, &user.CreatedAt, &user.UpdatedAt}
+		if query.IncludeRoles {
+			dest = append(dest, pq.Array(&user.Roles))
+		}
+		if query.IncludePermissions {
+			dest = append(dest, pq.Array(&user.Permissions))
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return 0, err
+		}
+
+		if err := write(&user); err != nil {
+			return 0, err
+		}
+		fetched++
+	}
+
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	return fetched, nil
+}
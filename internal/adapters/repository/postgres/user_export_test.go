@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+func TestRepository_ExportUsers(t *testing.T) {
+	now := time.Now()
+	active := true
+	columns := []string{"id", "name", "email", "active", "created_at", "updated_at", "roles"}
+
+	t.Run("fetches until the cursor runs dry", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		r := &Repository{db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`DECLARE user_export NO SCROLL CURSOR FOR SELECT id, name, email, active, created_at, updated_at, (.+) FROM users WHERE deleted_at IS NULL AND active = \$1 AND email ILIKE \$2 ORDER BY created_at ASC, id ASC`).
+			WithArgs(true, "ann%").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`FETCH FORWARD 2 FROM user_export`).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("1", "Ann", "ann@example.com", true, now, now, "{Admin,Editor}").
+				AddRow("2", "Anna", "anna@example.com", true, now, now, "{}"))
+		mock.ExpectQuery(`FETCH FORWARD 2 FROM user_export`).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("3", "Annie", "annie@example.com", true, now, now, "{Viewer}"))
+		mock.ExpectCommit()
+
+		var users []*domain.UserExport
+		err = r.ExportUsers(&domain.UserExportQuery{
+			Filter:       &domain.UserFilter{Active: &active, EmailPrefix: "ann"},
+			IncludeRoles: true,
+			FetchSize:    2,
+		}, func(user *domain.UserExport) error {
+			users = append(users, user)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Len(t, users, 3)
+		assert.Equal(t, []string{"Admin", "Editor"}, users[0].Roles)
+		assert.Empty(t, users[1].Roles)
+		assert.Nil(t, users[0].Permissions)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("write error rolls back", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		defer db.Close()
+
+		r := &Repository{db}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`SET TRANSACTION`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`DECLARE user_export NO SCROLL CURSOR FOR SELECT id, name, email, active, created_at, updated_at FROM users WHERE deleted_at IS NULL ORDER BY`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`FETCH FORWARD 10 FROM user_export`).
+			WillReturnRows(sqlmock.NewRows(columns[:6]).AddRow("1", "Ann", "ann@example.com", true, now, now))
+		mock.ExpectRollback()
+
+		err = r.ExportUsers(&domain.UserExportQuery{Filter: &domain.UserFilter{}, FetchSize: 10}, func(user *domain.UserExport) error {
+			return errors.New("broken pipe")
+		})
+		assert.EqualError(t, err, "broken pipe")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
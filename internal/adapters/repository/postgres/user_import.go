@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+const userImportColumns = "id, status, format, dry_run, created_by, total, processed, created, invalid, failed, rows, created_at, updated_at, finished_at"
+
+func (r *Repository) CreateUserImport(userImport *domain.UserImport) error {
+	rows, err := json.Marshal(userImport.Rows)
+	if err != nil {
+		return err
+	}
+
+	query := "INSERT INTO user_imports (" + userImportColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(userImport.Id, userImport.Status, userImport.Format, userImport.DryRun, userImport.CreatedBy, userImport.Total,
+		userImport.Processed, userImport.Created, userImport.Invalid, userImport.Failed, rows, userImport.CreatedAt, userImport.UpdatedAt, userImport.FinishedAt)
+	return err
+}
+
+// UpdateUserImport records the progress of an import along with its report.
+func (r *Repository) UpdateUserImport(userImport *domain.UserImport) error {
+	rows, err := json.Marshal(userImport.Rows)
+	if err != nil {
+		return err
+	}
+
+	query := "UPDATE user_imports SET status = $1, processed = $2, created = $3, invalid = $4, failed = $5, rows = $6, updated_at = $7, finished_at = $8 WHERE id = $9"
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(userImport.Status, userImport.Processed, userImport.Created, userImport.Invalid, userImport.Failed, rows,
+		userImport.UpdatedAt, userImport.FinishedAt, userImport.Id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("no rows were affected")
+	}
+
+	return nil
+}
+
+// FailStaleUserImports marks the running imports last updated before the
+// given time as failed.
+func (r *Repository) FailStaleUserImports(updatedBefore time.Time, finishedAt time.Time) (int64, error) {
+	query := "UPDATE user_imports SET status = $1, updated_at = $2, finished_at = $2 WHERE status = $3 AND updated_at < $4"
+	result, err := r.db.Exec(query, domain.UserImportFailed, finishedAt, domain.UserImportRunning, updatedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *Repository) GetUserImportByID(id string) (*domain.UserImport, error) {
+	query := "SELECT " + userImportColumns + " FROM user_imports WHERE id = $1"
+	row := r.db.QueryRow(query, id)
+
+	var userImport domain.UserImport
+	var rows []byte
+	err := row.Scan(&userImport.Id, &userImport.Status, &userImport.Format, &userImport.DryRun, &userImport.CreatedBy, &userImport.Total,
+		&userImport.Processed, &userImport.Created, &userImport.Invalid, &userImport.Failed, &rows, &userImport.CreatedAt, &userImport.UpdatedAt, &userImport.FinishedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(rows, &userImport.Rows); err != nil {
+		return nil, err
+	}
+
+	return &userImport, nil
+}
+
+func (r *Repository) ImportUsers(users []*domain.User, grants []*domain.UserRoleGrant) error {
+	// Start transaction
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			tx.Rollback()
+		}
+	}()
+
+	userStmt, err := tx.Prepare("INSERT INTO users (id, name, email, salt, password, active, attributes, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer userStmt.Close()
+
+	for _, user := range users {
+		attributesJSON, err := marshalAttributes(user.Attributes)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		_, err = userStmt.Exec(user.Id, user.Name, user.Email, user.Salt, user.Password, user.Active, attributesJSON, user.CreatedAt, user.UpdatedAt)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if len(grants) > 0 {
+		// Build the query string with placeholders for the grants
+		valueStrings := make([]string, 0, len(grants))
+		valueArgs := make([]interface{}, 0, len(grants)*2)
+		for i, grant := range grants {
+			valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d)", i*2+1, i*2+2))
+			valueArgs = append(valueArgs, grant.UserId, grant.RoleId)
+		}
+		_, err = tx.Exec("INSERT INTO user_role (user_id, role_id) VALUES "+strings.Join(valueStrings, ","), valueArgs...)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
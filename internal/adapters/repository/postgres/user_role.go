@@ -3,15 +3,110 @@ package postgres
 import (
 	"fmt"
 	"strings"
+	"time"
 	"user-svc/internal/core/domain"
 )
 
+// effectiveUserRoles lists every role a user holds, directly or through a group,
+// with the group it was inherited from (empty for direct grants). Grants of
+// deleted users and roles are kept for a restore but confer nothing.
+const effectiveUserRoles = `
+		SELECT ur.user_id, ur.role_id, '' AS group_id
+		FROM user_role ur
+		INNER JOIN users lu ON lu.id = ur.user_id AND lu.deleted_at IS NULL
+		INNER JOIN roles lr ON lr.id = ur.role_id AND lr.deleted_at IS NULL
+		WHERE ur.expires_at IS NULL OR ur.expires_at > now()
+		UNION ALL
+		SELECT gu.user_id, gr.role_id, gu.group_id::text AS group_id
+		FROM group_user gu
+		INNER JOIN group_role gr ON gr.group_id = gu.group_id
+		INNER JOIN users lu ON lu.id = gu.user_id AND lu.deleted_at IS NULL
+		INNER JOIN roles lr ON lr.id = gr.role_id AND lr.deleted_at IS NULL
+	`
+
+// GetUserRoles returns the active roles of a user, including roles inherited
+// through groups.
 func (r *Repository) GetUserRoles(userID string) ([]*domain.Role, error) {
 	query := `
-		SELECT r.id, r.name
+		SELECT DISTINCT r.id, r.name, r.active
+		FROM (` + effectiveUserRoles + `) er
+		INNER JOIN roles r ON er.role_id = r.id
+		WHERE er.user_id = $1 AND r.active = true
+	`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := make([]*domain.Role, 0)
+	for rows.Next() {
+		var role domain.Role
+		err := rows.Scan(&role.Id, &role.Name, &role.Active)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, &role)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// GetUsersRoleNames returns the names of the active roles of each user,
+// including roles inherited through groups. Users without roles are left out.
+func (r *Repository) GetUsersRoleNames(userIDs []string) (map[string][]string, error) {
+	names := make(map[string][]string)
+	if len(userIDs) == 0 {
+		return names, nil
+	}
+
+	// Build the query string with placeholders for the user ids
+	valueStrings := make([]string, 0, len(userIDs))
+	valueArgs := make([]interface{}, 0, len(userIDs))
+	for i, userID := range userIDs {
+		valueStrings = append(valueStrings, fmt.Sprintf("$%d", i+1))
+		valueArgs = append(valueArgs, userID)
+	}
+	query := `
+		SELECT DISTINCT er.user_id, r.name
+		FROM (` + effectiveUserRoles + `) er
+		INNER JOIN roles r ON er.role_id = r.id
+		WHERE r.active = true AND er.user_id IN (` + strings.Join(valueStrings, ",") + `)
+		ORDER BY er.user_id, r.name`
+
+	rows, err := r.db.Query(query, valueArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID, name string
+		if err := rows.Scan(&userID, &name); err != nil {
+			return nil, err
+		}
+		names[userID] = append(names[userID], name)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// GetDirectUserRoles returns the roles granted to the user itself, active or
+// not, leaving out roles inherited through groups.
+func (r *Repository) GetDirectUserRoles(userID string) ([]*domain.Role, error) {
+	query := `
+		SELECT r.id, r.name, r.active
 		FROM user_role ur
 		INNER JOIN roles r ON ur.role_id = r.id
-		WHERE ur.user_id = $1
+		WHERE ur.user_id = $1 AND r.deleted_at IS NULL
 	`
 	rows, err := r.db.Query(query, userID)
 	if err != nil {
@@ -22,7 +117,7 @@ func (r *Repository) GetUserRoles(userID string) ([]*domain.Role, error) {
 	roles := make([]*domain.Role, 0)
 	for rows.Next() {
 		var role domain.Role
-		err := rows.Scan(&role.Id, &role.Name)
+		err := rows.Scan(&role.Id, &role.Name, &role.Active)
 		if err != nil {
 			return nil, err
 		}
@@ -36,9 +131,12 @@ func (r *Repository) GetUserRoles(userID string) ([]*domain.Role, error) {
 	return roles, nil
 }
 
-func (r *Repository) AddUserRoles(userID string, roles []string) error {
+// AddUserRoles grants roles to a user, optionally until expiresAt. Re-granting a
+// role the user already holds only touches a time-limited grant: it is
+// extended, or made permanent when expiresAt is nil.
+func (r *Repository) AddUserRoles(userID string, roles []string, expiresAt *time.Time) error {
 	// Start transaction
-	tx, err := r.db.Begin()
+	tx, err := r.begin()
 	if err != nil {
 		return err
 	}
@@ -51,12 +149,13 @@ func (r *Repository) AddUserRoles(userID string, roles []string) error {
 
 	// Build the query string with placeholders for the role IDs
 	valueStrings := make([]string, 0, len(roles))
-	valueArgs := make([]interface{}, 0, len(roles)*2)
+	valueArgs := make([]interface{}, 0, len(roles)*3)
 	for i, roleID := range roles {
-		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d)", i*2+1, i*2+2))
-		valueArgs = append(valueArgs, userID, roleID)
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d)", i*3+1, i*3+2, i*3+3))
+		valueArgs = append(valueArgs, userID, roleID, expiresAt)
 	}
-	query := "INSERT INTO user_role (user_id, role_id) VALUES " + strings.Join(valueStrings, ",")
+	query := "INSERT INTO user_role (user_id, role_id, expires_at) VALUES " + strings.Join(valueStrings, ",") +
+		" ON CONFLICT (user_id, role_id) DO UPDATE SET expires_at = EXCLUDED.expires_at WHERE user_role.expires_at IS NOT NULL"
 
 	// Execute the query with the role IDs as arguments
 	stmt, err := tx.Prepare(query)
@@ -82,7 +181,7 @@ func (r *Repository) AddUserRoles(userID string, roles []string) error {
 
 func (r *Repository) RemoveUserRoles(userID string, roles []string) error {
 	// Start transaction
-	tx, err := r.db.Begin()
+	tx, err := r.begin()
 	if err != nil {
 		return err
 	}
@@ -123,3 +222,199 @@ func (r *Repository) RemoveUserRoles(userID string, roles []string) error {
 
 	return nil
 }
+
+func (r *Repository) GetPermissionHolders(permissions []string) ([]*domain.PermissionHolder, error) {
+	if len(permissions) == 0 {
+		return make([]*domain.PermissionHolder, 0), nil
+	}
+
+	// Build the query string with placeholders for the permission names
+	valueStrings := make([]string, 0, len(permissions))
+	valueArgs := make([]interface{}, 0, len(permissions))
+	for i, permission := range permissions {
+		valueStrings = append(valueStrings, fmt.Sprintf("$%d", i+1))
+		valueArgs = append(valueArgs, permission)
+	}
+	query := `
+		SELECT er.user_id, er.role_id, er.group_id, p.id, p.name
+		FROM (` + effectiveUserRoles + `) er
+		INNER JOIN users u ON u.id = er.user_id
+		INNER JOIN roles r ON r.id = er.role_id
+		INNER JOIN role_permission rp ON rp.role_id = r.id
+		INNER JOIN permissions p ON p.id = rp.permission_id
+		WHERE u.active = true AND r.active = true AND p.deleted_at IS NULL AND p.name IN (` + strings.Join(valueStrings, ",") + `)`
+
+	rows, err := r.db.Query(query, valueArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	holders := make([]*domain.PermissionHolder, 0)
+	for rows.Next() {
+		var holder domain.PermissionHolder
+		err := rows.Scan(&holder.UserId, &holder.RoleId, &holder.GroupId, &holder.PermissionId, &holder.PermissionName)
+		if err != nil {
+			return nil, err
+		}
+		holders = append(holders, &holder)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return holders, nil
+}
+
+// RemoveExpiredUserRoles drops the grants that ran out and returns the users
+// that held them.
+func (r *Repository) RemoveExpiredUserRoles(now time.Time) ([]string, error) {
+	query := "DELETE FROM user_role WHERE expires_at IS NOT NULL AND expires_at <= $1 RETURNING user_id"
+	rows, err := r.db.Query(query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seen := make(map[string]bool)
+	userIDs := make([]string, 0)
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		if !seen[userID] {
+			seen[userID] = true
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+// GetRoleHolderIDs returns the users assigned the role directly or through a
+// group, whatever the state of the role, so they can be found after the role
+// was renamed, deactivated or deleted.
+func (r *Repository) GetRoleHolderIDs(roleID string) ([]string, error) {
+	query := `
+		SELECT ur.user_id FROM user_role ur WHERE ur.role_id = $1
+		UNION
+		SELECT gu.user_id FROM group_user gu INNER JOIN group_role gr ON gr.group_id = gu.group_id WHERE gr.role_id = $1`
+	rows, err := r.db.Query(query, roleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := make([]string, 0)
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+// GetUserPermissions resolves the permissions a user holds through active
+// roles, with one grant per role (and group) that carries the permission.
+func (r *Repository) GetUserPermissions(userID string) ([]*domain.EffectivePermission, error) {
+	query := `
+		SELECT DISTINCT p.id, p.name, r.id, r.name, er.group_id
+		FROM (` + effectiveUserRoles + `) er
+		INNER JOIN roles r ON r.id = er.role_id
+		INNER JOIN role_permission rp ON rp.role_id = r.id
+		INNER JOIN permissions p ON p.id = rp.permission_id
+		WHERE er.user_id = $1 AND r.active = true AND p.deleted_at IS NULL
+		ORDER BY p.name, r.name, er.group_id
+	`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := make([]*domain.EffectivePermission, 0)
+	index := make(map[string]*domain.EffectivePermission)
+	for rows.Next() {
+		var permission domain.EffectivePermission
+		var grant domain.PermissionGrant
+		err := rows.Scan(&permission.Id, &permission.Name, &grant.RoleId, &grant.RoleName, &grant.GroupId)
+		if err != nil {
+			return nil, err
+		}
+
+		existing, ok := index[permission.Id]
+		if !ok {
+			existing = &permission
+			index[permission.Id] = existing
+			permissions = append(permissions, existing)
+		}
+		existing.GrantedBy = append(existing.GrantedBy, &grant)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+// GetRoleUsers returns a page of the users holding a role, directly or through
+// a group, along with the total number of such users.
+func (r *Repository) GetRoleUsers(roleID string, limit int, offset int) ([]*domain.User, int64, error) {
+	holders := "SELECT er.user_id FROM (" + effectiveUserRoles + ") er WHERE er.role_id = $1"
+	return r.getUsersPage(holders, roleID, limit, offset)
+}
+
+// GetDirectRoleUsers returns a page of the users granted a role themselves,
+// leaving out the ones holding it through a group, along with the total
+// number of such users.
+func (r *Repository) GetDirectRoleUsers(roleID string, limit int, offset int) ([]*domain.User, int64, error) {
+	holders := "SELECT ur.user_id FROM user_role ur WHERE ur.role_id = $1"
+	return r.getUsersPage(holders, roleID, limit, offset)
+}
+
+// getUsersPage pages through the users whose id is returned by holders, a
+// query taking its single argument as $1.
+func (r *Repository) getUsersPage(holders string, arg interface{}, limit int, offset int) ([]*domain.User, int64, error) {
+	var total int64
+	query := "SELECT COUNT(*) FROM users WHERE deleted_at IS NULL AND id IN (" + holders + ")"
+	if err := r.db.QueryRow(query, arg).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query = "SELECT id, name, email, active, created_at, updated_at FROM users WHERE deleted_at IS NULL AND id IN (" + holders + ") ORDER BY name, id LIMIT $2 OFFSET $3"
+	rows, err := r.db.Query(query, arg, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := make([]*domain.User, 0)
+	for rows.Next() {
+		var user domain.User
+		err := rows.Scan(&user.Id, &user.Name, &user.Email, &user.Active, &user.CreatedAt, &user.UpdatedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, &user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
@@ -1 +1,192 @@
 package postgres
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRepository_GetUserRoles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	userID := "8c1d6b5e-6a6f-4a4e-9c3c-2f3c9c1d2e3f"
+	query := `SELECT DISTINCT r.id, r.name, r.active FROM \((.+)group_user(.+)\) er INNER JOIN roles r ON er.role_id = r.id WHERE er.user_id = \$1 AND r.active = true`
+
+	t.Run("success - only active roles", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "active"}).
+			AddRow("1", "Admin", true)
+		mock.ExpectQuery(query).WithArgs(userID).WillReturnRows(rows)
+
+		roles, err := r.GetUserRoles(userID)
+		assert.NoError(t, err)
+		assert.Len(t, roles, 1)
+		assert.Equal(t, "Admin", roles[0].Name)
+		assert.True(t, roles[0].Active)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs(userID).WillReturnError(errors.New("query error"))
+
+		roles, err := r.GetUserRoles(userID)
+		assert.Error(t, err)
+		assert.Nil(t, roles)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_GetUserPermissions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	userID := "8c1d6b5e-6a6f-4a4e-9c3c-2f3c9c1d2e3f"
+	query := `SELECT DISTINCT p.id, p.name, r.id, r.name, er.group_id FROM \((.+)\) er (.+) WHERE er.user_id = \$1 AND r.active = true`
+
+	t.Run("success - grants folded per permission", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "role_id", "role_name", "group_id"}).
+			AddRow("p1", "Delete-User", "r1", "Admin", "").
+			AddRow("p1", "Delete-User", "r2", "Manager", "g1").
+			AddRow("p2", "View-User", "r2", "Manager", "g1")
+		mock.ExpectQuery(query).WithArgs(userID).WillReturnRows(rows)
+
+		permissions, err := r.GetUserPermissions(userID)
+		assert.NoError(t, err)
+		assert.Len(t, permissions, 2)
+		assert.Len(t, permissions[0].GrantedBy, 2)
+		assert.Equal(t, "g1", permissions[0].GrantedBy[1].GroupId)
+		assert.Len(t, permissions[1].GrantedBy, 1)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs(userID).WillReturnError(errors.New("query error"))
+
+		permissions, err := r.GetUserPermissions(userID)
+		assert.Error(t, err)
+		assert.Nil(t, permissions)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_GetRoleUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	roleID := "1c1d6b5e-6a6f-4a4e-9c3c-2f3c9c1d2e3f"
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM users WHERE deleted_at IS NULL AND id IN \((.+)er.role_id = \$1\)`).
+		WithArgs(roleID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(`SELECT id, name, email, active, created_at, updated_at FROM users WHERE deleted_at IS NULL AND id IN \((.+)\) ORDER BY name, id LIMIT \$2 OFFSET \$3`).
+		WithArgs(roleID, 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "active", "created_at", "updated_at"}).
+			AddRow("u3", "Carol", "carol@example.com", true, time.Now(), time.Now()))
+
+	users, total, err := r.GetRoleUsers(roleID, 2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, users, 1)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_GetDirectRoleUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	roleID := "1c1d6b5e-6a6f-4a4e-9c3c-2f3c9c1d2e3f"
+
+	// only grants made to the user itself, none inherited through a group
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM users WHERE deleted_at IS NULL AND id IN \(SELECT ur.user_id FROM user_role ur WHERE ur.role_id = \$1\)`).
+		WithArgs(roleID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT id, name, email, active, created_at, updated_at FROM users WHERE deleted_at IS NULL AND id IN \(SELECT ur.user_id FROM user_role ur WHERE ur.role_id = \$1\) ORDER BY name, id LIMIT \$2 OFFSET \$3`).
+		WithArgs(roleID, 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "active", "created_at", "updated_at"}).
+			AddRow("u1", "Alice", "alice@example.com", true, time.Now(), time.Now()))
+
+	users, total, err := r.GetDirectRoleUsers(roleID, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, users, 1)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_GetPermissionHolders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	// direct grants that have not expired, and grants inherited through groups,
+	// both leaving out deleted users and roles
+	query := `SELECT er.user_id, er.role_id, er.group_id, p.id, p.name FROM \(` +
+		`\s*SELECT ur.user_id, ur.role_id, '' AS group_id FROM user_role ur (.+) lu.deleted_at IS NULL (.+) lr.deleted_at IS NULL WHERE ur.expires_at IS NULL OR ur.expires_at > now\(\)` +
+		`\s*UNION ALL\s*SELECT gu.user_id, gr.role_id, gu.group_id::text AS group_id FROM group_user gu INNER JOIN group_role gr ON gr.group_id = gu.group_id (.+) lu.deleted_at IS NULL (.+) lr.deleted_at IS NULL\s*\) er` +
+		` (.+) WHERE u.active = true AND r.active = true AND p.deleted_at IS NULL AND p.name IN \(\$1\)`
+
+	rows := sqlmock.NewRows([]string{"user_id", "role_id", "group_id", "id", "name"}).
+		AddRow("u1", "r1", "", "p1", "Update-Role").
+		AddRow("u2", "r1", "g1", "p1", "Update-Role")
+	mock.ExpectQuery(query).WithArgs("Update-Role").WillReturnRows(rows)
+
+	holders, err := r.GetPermissionHolders([]string{"Update-Role"})
+	assert.NoError(t, err)
+	assert.Len(t, holders, 2)
+	assert.Equal(t, "", holders[0].GroupId)
+	assert.Equal(t, "g1", holders[1].GroupId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_RemoveExpiredUserRoles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	now := time.Now()
+	mock.ExpectQuery(`DELETE FROM user_role WHERE expires_at IS NOT NULL AND expires_at <= \$1 RETURNING user_id`).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("u1").AddRow("u2").AddRow("u1"))
+
+	userIDs, err := r.RemoveExpiredUserRoles(now)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, userIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_GetRoleHolderIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+
+	roleID := "1c1d6b5e-6a6f-4a4e-9c3c-2f3c9c1d2e3f"
+	mock.ExpectQuery(`SELECT ur.user_id FROM user_role ur WHERE ur.role_id = \$1\s*UNION\s*SELECT gu.user_id FROM group_user gu INNER JOIN group_role gr ON gr.group_id = gu.group_id WHERE gr.role_id = \$1`).
+		WithArgs(roleID).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("u1").AddRow("u2"))
+
+	userIDs, err := r.GetRoleHolderIDs(roleID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, userIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"fmt"
+	"user-svc/internal/core/domain"
+)
+
+// SearchUsers is the fallback when the search index is disabled, it matches
+// the query anywhere in name or email. The roles of the returned documents
+// are left to the caller.
+func (r *Repository) SearchUsers(query *domain.UserSearchQuery) (*domain.UserSearchResult, error) {
+	var where conditions
+	where.add("deleted_at IS NULL")
+	where.contains([]string{"name", "email"}, query.Query)
+	if query.Active != nil {
+		where.add("active = ?", *query.Active)
+	}
+
+	// facets ignore the role filter, so the other roles stay selectable
+	roles, err := r.getRoleFacets(&where)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Role != "" {
+		where.add("id IN (SELECT er.user_id FROM ("+effectiveUserRoles+") er INNER JOIN roles r ON r.id = er.role_id WHERE r.name = ?)", query.Role)
+	}
+
+	result := &domain.UserSearchResult{Users: make([]*domain.UserDocument, 0), Roles: roles}
+	countQuery := "SELECT COUNT(*) FROM users" + where.where()
+	if err := r.db.QueryRow(countQuery, where.args...).Scan(&result.Total); err != nil {
+		return nil, err
+	}
+
+	page, args := where.page(query.Limit, query.Offset)
+	users, err := r.queryUsers("SELECT "+userColumns+" FROM users"+where.where()+" ORDER BY name ASC, id ASC"+page, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		result.Users = append(result.Users, &domain.UserDocument{
+			Id:        user.Id,
+			Name:      user.Name,
+			Email:     user.Email,
+			Active:    user.Active,
+			Roles:     make([]string, 0),
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+		})
+	}
+
+	return result, nil
+}
+
+func (r *Repository) getRoleFacets(where *conditions) ([]*domain.FacetCount, error) {
+	query := `
+		SELECT r.name, COUNT(DISTINCT er.user_id)
+		FROM (` + effectiveUserRoles + `) er
+		INNER JOIN roles r ON r.id = er.role_id
+		WHERE r.active = true AND er.user_id IN (SELECT id FROM users` + where.where() + `)
+		GROUP BY r.name
+		ORDER BY 2 DESC, r.name ASC` + fmt.Sprintf(" LIMIT %d", domain.RoleFacetSize)
+	rows, err := r.db.Query(query, where.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	facets := make([]*domain.FacetCount, 0)
+	for rows.Next() {
+		var facet domain.FacetCount
+		if err := rows.Scan(&facet.Value, &facet.Count); err != nil {
+			return nil, err
+		}
+		facets = append(facets, &facet)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
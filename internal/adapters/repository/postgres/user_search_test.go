@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+func TestRepository_SearchUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock DB connection: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db}
+	active := true
+	now := time.Now()
+
+	// Test case: query matched anywhere in name or email, facets ignore the role filter
+	mock.ExpectQuery(`SELECT r.name, COUNT\(DISTINCT er.user_id\)(.+)SELECT id FROM users WHERE deleted_at IS NULL AND \(name ILIKE \$1 OR email ILIKE \$2\) AND active = \$3\)(.+)LIMIT 20$`).
+		WithArgs(`%a\_b%`, `%a\_b%`, true).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "count"}).AddRow("Admin", 1).AddRow("Viewer", 3))
+	mock.ExpectQuery(`^SELECT COUNT\(\*\) FROM users WHERE deleted_at IS NULL AND \(name ILIKE \$1 OR email ILIKE \$2\) AND active = \$3 AND id IN \((.+) WHERE r.name = \$4\)$`).
+		WithArgs(`%a\_b%`, `%a\_b%`, true, "Admin").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`^SELECT id, name, email, active, attributes, created_at, updated_at FROM users WHERE (.+) ORDER BY name ASC, id ASC LIMIT \$5 OFFSET \$6$`).
+		WithArgs(`%a\_b%`, `%a\_b%`, true, "Admin", 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "active", "attributes", "created_at", "updated_at"}).
+			AddRow("1", "a_b", "a_b@mail.com", true, []byte(`{}`), now, now))
+
+	result, err := repo.SearchUsers(&domain.UserSearchQuery{Query: "a_b", Active: &active, Role: "Admin", Limit: 20})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Total)
+	require.Len(t, result.Users, 1)
+	assert.Equal(t, "a_b@mail.com", result.Users[0].Email)
+	assert.Equal(t, []*domain.FacetCount{{Value: "Admin", Count: 1}, {Value: "Viewer", Count: 3}}, result.Roles)
+
+	// Test case: failed query
+	mock.ExpectQuery("SELECT r.name").WillReturnError(fmt.Errorf("some error"))
+
+	result, err = repo.SearchUsers(&domain.UserSearchQuery{Query: "a", Limit: 20})
+	require.Error(t, err)
+	require.Nil(t, result)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_GetUsersRoleNames(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock DB connection: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db}
+
+	mock.ExpectQuery(`SELECT DISTINCT er.user_id, r.name(.+)er.user_id IN \(\$1,\$2\)`).
+		WithArgs("1", "2").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "name"}).AddRow("1", "Admin").AddRow("1", "Viewer"))
+
+	names, err := repo.GetUsersRoleNames([]string{"1", "2"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"1": {"Admin", "Viewer"}}, names)
+
+	// Test case: no users, no query
+	names, err = repo.GetUsersRoleNames(nil)
+	require.NoError(t, err)
+	assert.Empty(t, names)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -29,6 +29,9 @@ func TestRepository_Create(t *testing.T) {
 		Active:    true,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+		Attributes: map[string]interface{}{
+			"department": "sales",
+		},
 	}
 
 	query := `^INSERT INTO (.+) VALUES (.+)`
@@ -44,7 +47,7 @@ func TestRepository_Create(t *testing.T) {
 	t.Run("execution of statement fails", func(t *testing.T) {
 		mock.ExpectPrepare(query).
 			ExpectExec().
-			WithArgs(user.Id, user.Name, user.Email, user.Salt, user.Password, user.Active, user.CreatedAt, user.UpdatedAt).
+			WithArgs(user.Id, user.Name, user.Email, user.Salt, user.Password, user.Active, []byte(`{"department":"sales"}`), user.CreatedAt, user.UpdatedAt).
 			WillReturnError(fmt.Errorf("failed to execute statement"))
 
 		err = repo.CreateUser(user)
@@ -54,7 +57,7 @@ func TestRepository_Create(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mock.ExpectPrepare(query).
 			ExpectExec().
-			WithArgs(user.Id, user.Name, user.Email, user.Salt, user.Password, user.Active, user.CreatedAt, user.UpdatedAt).
+			WithArgs(user.Id, user.Name, user.Email, user.Salt, user.Password, user.Active, []byte(`{"department":"sales"}`), user.CreatedAt, user.UpdatedAt).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err = repo.CreateUser(user)
@@ -82,6 +85,7 @@ func TestRepository_Update(t *testing.T) {
 		Active:    true,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+		Version:   3,
 	}
 
 	query := "UPDATE (.+) SET (.+)"
@@ -89,23 +93,33 @@ func TestRepository_Update(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mock.ExpectPrepare(query)
 		mock.ExpectExec(query).
-			WithArgs(user.Name, user.Email, user.Salt, user.Password, user.Active, user.UpdatedAt, user.Id).
+			WithArgs(user.Name, user.Email, user.Salt, user.Password, user.Active, []byte("{}"), user.UpdatedAt, user.Id, user.Version).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		err = r.UpdateUser(user)
 		assert.NoError(t, err)
 	})
 
-	t.Run("no rows affected", func(t *testing.T) {
+	t.Run("version moved on", func(t *testing.T) {
 		mock.ExpectPrepare(query)
 		mock.ExpectExec(query).
-			WithArgs(user.Name, user.Email, user.Salt, user.Password, user.Active, user.UpdatedAt, user.Id).
+			WithArgs(user.Name, user.Email, user.Salt, user.Password, user.Active, []byte("{}"), user.UpdatedAt, user.Id, user.Version).
 			WillReturnResult(sqlmock.NewResult(0, 0))
 
 		err = r.UpdateUser(user)
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrVersionMismatch)
+	})
 
-		assert.True(t, err.Error() == errors.New("no rows were affected").Error())
+	t.Run("success - credentials kept without a new password", func(t *testing.T) {
+		unchanged := *user
+		unchanged.Salt, unchanged.Password = "", ""
+		mock.ExpectPrepare(`UPDATE users SET name = \$1, email = \$2, active = \$3, attributes = \$4, updated_at = \$5, version = version \+ 1 WHERE id = \$6 AND version = \$7`)
+		mock.ExpectExec("UPDATE users").
+			WithArgs(unchanged.Name, unchanged.Email, unchanged.Active, []byte("{}"), unchanged.UpdatedAt, unchanged.Id, unchanged.Version).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = r.UpdateUser(&unchanged)
+		assert.NoError(t, err)
 	})
 
 	t.Run("error preparing statement", func(t *testing.T) {
@@ -131,7 +145,7 @@ func TestRepository_Update(t *testing.T) {
 		expectedErr := errors.New("failed to get rows affected")
 		mock.ExpectPrepare(query)
 		mock.ExpectExec(query).
-			WithArgs(user.Name, user.Email, user.Salt, user.Password, user.Active, user.UpdatedAt, user.Id).
+			WithArgs(user.Name, user.Email, user.Salt, user.Password, user.Active, []byte("{}"), user.UpdatedAt, user.Id, user.Version).
 			WillReturnError(expectedErr)
 
 		err = r.UpdateUser(user)
@@ -148,40 +162,40 @@ func TestRepository_Delete(t *testing.T) {
 	defer db.Close()
 
 	repo := &Repository{db}
-	query := "DELETE FROM (.+) WHERE (.+)"
+	query := "UPDATE users SET deleted_at = (.+) WHERE (.+)"
+	deletedAt := time.Now()
 	// Test case: prepare statement fails
 	mock.ExpectPrepare(query).
 		WillReturnError(fmt.Errorf("failed to prepare statement"))
 
-	err = repo.DeleteUser("1")
+	err = repo.DeleteUser("1", 2, deletedAt)
 	assert.Error(t, err)
 
 	// Test case: execution of statement fails
 	mock.ExpectPrepare(query).
 		ExpectExec().
-		WithArgs("1").
+		WithArgs(deletedAt, "1", int64(2)).
 		WillReturnError(fmt.Errorf("failed to execute statement"))
 
-	err = repo.DeleteUser("1")
+	err = repo.DeleteUser("1", 2, deletedAt)
 	assert.Error(t, err)
 
-	// Test case: no rows affected
+	// Test case: no rows affected, the user was changed or deleted since it was read
 	mock.ExpectPrepare(query).
 		ExpectExec().
-		WithArgs("1").
+		WithArgs(deletedAt, "1", int64(2)).
 		WillReturnResult(sqlmock.NewResult(0, 0))
 
-	err = repo.DeleteUser("1")
-	assert.Error(t, err)
-	assert.True(t, err.Error() == errors.New("no rows were affected").Error())
+	err = repo.DeleteUser("1", 2, deletedAt)
+	assert.ErrorIs(t, err, domain.ErrVersionMismatch)
 
 	// Test case: success
 	mock.ExpectPrepare(query).
 		ExpectExec().
-		WithArgs("1").
+		WithArgs(deletedAt, "1", int64(2)).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err = repo.DeleteUser("1")
+	err = repo.DeleteUser("1", 2, deletedAt)
 	assert.NoError(t, err)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
@@ -203,70 +217,148 @@ func TestRepository_Users(t *testing.T) {
 			Id:        "1",
 			Name:      "test1",
 			Email:     "test1@mail.com",
-			Salt:      "xxx",
-			Password:  "xxx",
 			Active:    true,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
+			Attributes: map[string]interface{}{
+				"department": "sales",
+			},
 		},
 		{
 			Id:        "2",
 			Name:      "test2",
 			Email:     "test2@mail.com",
-			Salt:      "yyy",
-			Password:  "yyy",
 			Active:    false,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "email", "salt", "password", "active", "created_at", "updated_at"}).
-		AddRow(expectedUsers[0].Id, expectedUsers[0].Name, expectedUsers[0].Email, expectedUsers[0].Salt, expectedUsers[0].Password, expectedUsers[0].Active, expectedUsers[0].CreatedAt, expectedUsers[0].UpdatedAt).
-		AddRow(expectedUsers[1].Id, expectedUsers[1].Name, expectedUsers[1].Email, expectedUsers[1].Salt, expectedUsers[1].Password, expectedUsers[1].Active, expectedUsers[1].CreatedAt, expectedUsers[1].UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "name", "email", "active", "attributes", "created_at", "updated_at"}).
+		AddRow(expectedUsers[0].Id, expectedUsers[0].Name, expectedUsers[0].Email, expectedUsers[0].Active, []byte(`{"department":"sales"}`), expectedUsers[0].CreatedAt, expectedUsers[0].UpdatedAt).
+		AddRow(expectedUsers[1].Id, expectedUsers[1].Name, expectedUsers[1].Email, expectedUsers[1].Active, []byte(`{}`), expectedUsers[1].CreatedAt, expectedUsers[1].UpdatedAt)
 
-	// Test case: successfully retrieve users
-	mock.ExpectQuery("^SELECT").WillReturnRows(rows)
+	// Test case: successfully retrieve a page of users, without credentials
+	mock.ExpectQuery(`^SELECT COUNT\(\*\) FROM users WHERE deleted_at IS NULL$`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+	mock.ExpectQuery(`^SELECT id, name, email, active, attributes, created_at, updated_at FROM users WHERE deleted_at IS NULL ORDER BY name ASC, id ASC LIMIT \$1 OFFSET \$2$`).
+		WithArgs(20, 0).
+		WillReturnRows(rows)
 
-	users, err := repo.GetAllUsers()
+	users, total, err := repo.GetUsers(&domain.UserFilter{Limit: 20, Offset: 0})
 	require.NoError(t, err)
+	require.Equal(t, int64(42), total)
 	require.Equal(t, len(expectedUsers), len(users))
 
 	for i, expectedUser := range expectedUsers {
 		assert.Equal(t, expectedUser.Id, users[i].Id)
 		assert.Equal(t, expectedUser.Name, users[i].Name)
 		assert.Equal(t, expectedUser.Email, users[i].Email)
-		assert.Equal(t, expectedUser.Salt, users[i].Salt)
-		assert.Equal(t, expectedUser.Password, users[i].Password)
+		assert.Empty(t, users[i].Salt)
+		assert.Empty(t, users[i].Password)
 		assert.Equal(t, expectedUser.Active, users[i].Active)
+		assert.Equal(t, expectedUser.Attributes, users[i].Attributes)
 		assert.Equal(t, expectedUser.CreatedAt.Unix(), users[i].CreatedAt.Unix())
 		assert.Equal(t, expectedUser.UpdatedAt.Unix(), users[i].UpdatedAt.Unix())
 	}
 
+	// Test case: filters and sort are parameterized
+	active := true
+	createdFrom := time.Now().AddDate(0, -1, 0)
+	mock.ExpectQuery(`^SELECT COUNT\(\*\) FROM users WHERE deleted_at IS NULL AND active = \$1 AND email ILIKE \$2 AND created_at >= \$3 AND id IN \((.+) WHERE er.role_id = \$4\)$`).
+		WithArgs(true, `a\_b%`, createdFrom, "role-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`ORDER BY created_at DESC, id DESC LIMIT \$5 OFFSET \$6$`).
+		WithArgs(true, `a\_b%`, createdFrom, "role-1", 10, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "active", "attributes", "created_at", "updated_at"}))
+
+	users, total, err = repo.GetUsers(&domain.UserFilter{Active: &active, EmailPrefix: "a_b", CreatedFrom: &createdFrom, HasRole: "role-1",
+		Sort: "created_at", Order: domain.SortDesc, Limit: 10, Offset: 20})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), total)
+	require.Len(t, users, 0)
+
+	// Test case: attributes compared as text
+	mock.ExpectQuery(`^SELECT COUNT\(\*\) FROM users WHERE deleted_at IS NULL AND attributes->>\$1 = \$2 AND attributes->>\$3 = \$4$`).
+		WithArgs("department", "sales", "remote", "true").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`LIMIT \$5 OFFSET \$6$`).
+		WithArgs("department", "sales", "remote", "true", 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "active", "attributes", "created_at", "updated_at"}))
+
+	_, _, err = repo.GetUsers(&domain.UserFilter{Attributes: []*domain.AttributeFilter{{Name: "department", Value: "sales"}, {Name: "remote", Value: "true"}}, Limit: 20})
+	require.NoError(t, err)
+
+	// Test case: unknown sort field falls back to name
+	mock.ExpectQuery(`^SELECT COUNT`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`ORDER BY name ASC, id ASC`).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "active", "attributes", "created_at", "updated_at"}))
+
+	_, _, err = repo.GetUsers(&domain.UserFilter{Sort: "password; DROP TABLE users", Limit: 20})
+	require.NoError(t, err)
+
 	// Test case: failed query
 	expectedErr := fmt.Errorf("some error")
-	mock.ExpectQuery("^SELECT").WillReturnError(expectedErr)
+	mock.ExpectQuery("^SELECT COUNT").WillReturnError(expectedErr)
 
-	users, err = repo.GetAllUsers()
+	users, _, err = repo.GetUsers(&domain.UserFilter{Limit: 20})
 	require.Error(t, err)
 	require.Nil(t, users)
 	assert.Equal(t, expectedErr, err)
 
 	// Test case: failed row scan
-	rows = sqlmock.NewRows([]string{"id", "name"}).AddRow("1", "test")
-
-	mock.ExpectQuery("^SELECT").WillReturnRows(rows)
+	mock.ExpectQuery("^SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("^SELECT id").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("1", "test"))
 
-	users, err = repo.GetAllUsers()
+	users, _, err = repo.GetUsers(&domain.UserFilter{Limit: 20})
 	require.Error(t, err)
 	require.Nil(t, users)
-	assert.Contains(t, err.Error(), "sql: expected 2 destination arguments in Scan, not 8")
+	assert.Contains(t, err.Error(), "sql: expected 2 destination arguments in Scan, not 7")
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
 
+func TestRepository_UsersAfter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock DB connection: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db}
+	createdAt := time.Now()
+
+	// Test case: first keyset page, ordered by creation without a count
+	mock.ExpectQuery(`^SELECT id, name, email, active, attributes, created_at, updated_at FROM users WHERE deleted_at IS NULL ORDER BY created_at ASC, id ASC LIMIT \$1$`).
+		WithArgs(21).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "active", "attributes", "created_at", "updated_at"}).
+			AddRow("1", "test1", "test1@mail.com", true, []byte(`{}`), createdAt, createdAt))
+
+	users, err := repo.GetUsersAfter(&domain.UserFilter{Limit: 21})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "1", users[0].Id)
+
+	// Test case: next keyset page of the users updated since a time
+	updatedSince := createdAt.AddDate(0, 0, -1)
+	mock.ExpectQuery(`^SELECT (.+) FROM users WHERE deleted_at IS NULL AND updated_at >= \$1 AND \(created_at, id\) > \(\$2, \$3\) ORDER BY created_at ASC, id ASC LIMIT \$4$`).
+		WithArgs(updatedSince, createdAt, "1", 21).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "active", "attributes", "created_at", "updated_at"}))
+
+	users, err = repo.GetUsersAfter(&domain.UserFilter{UpdatedSince: &updatedSince, After: &domain.Cursor{CreatedAt: createdAt, Id: "1"}, Limit: 21})
+	require.NoError(t, err)
+	require.Len(t, users, 0)
+
+	// Test case: failed query
+	mock.ExpectQuery("^SELECT").WillReturnError(fmt.Errorf("some error"))
+
+	users, err = repo.GetUsersAfter(&domain.UserFilter{Limit: 21})
+	require.Error(t, err)
+	require.Nil(t, users)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestRepository_UserByID(t *testing.T) {
 	// Initialize test data and repository
 	db, mock, err := sqlmock.New()
@@ -285,12 +377,16 @@ func TestRepository_UserByID(t *testing.T) {
 		Active:    true,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+		Version:   2,
+		Attributes: map[string]interface{}{
+			"employee_id": float64(42),
+		},
 	}
 
 	// Set up mock database response
 	query := "SELECT (.+) FROM users WHERE (.+)"
-	rows := sqlmock.NewRows([]string{"id", "name", "email", "active", "created_at", "updated_at"}).
-		AddRow(expectedUser.Id, expectedUser.Name, expectedUser.Email, expectedUser.Active, expectedUser.CreatedAt, expectedUser.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "name", "email", "active", "attributes", "created_at", "updated_at", "version"}).
+		AddRow(expectedUser.Id, expectedUser.Name, expectedUser.Email, expectedUser.Active, []byte(`{"employee_id":42}`), expectedUser.CreatedAt, expectedUser.UpdatedAt, expectedUser.Version)
 	mock.ExpectQuery(query).
 		WithArgs(id).
 		WillReturnRows(rows)
@@ -328,8 +424,8 @@ func TestUserByEmail(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	row := sqlmock.NewRows([]string{"id", "name", "email", "active", "salt", "password", "created_at", "updated_at"}).
-		AddRow(expectedUser.Id, expectedUser.Name, expectedUser.Email, expectedUser.Active, expectedUser.Salt, expectedUser.Password, expectedUser.CreatedAt, expectedUser.UpdatedAt)
+	row := sqlmock.NewRows([]string{"id", "name", "email", "active", "attributes", "salt", "password", "created_at", "updated_at"}).
+		AddRow(expectedUser.Id, expectedUser.Name, expectedUser.Email, expectedUser.Active, []byte(`{}`), expectedUser.Salt, expectedUser.Password, expectedUser.CreatedAt, expectedUser.UpdatedAt)
 
 	// Set up the mock DB to return the expected row data
 	mock.ExpectQuery("^SELECT (.+) FROM users WHERE email = (.+)$").
@@ -372,3 +468,100 @@ func TestExist(t *testing.T) {
 	err = mock.ExpectationsWereMet()
 	assert.NoError(t, err)
 }
+
+func TestRepository_RestoreUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock DB connection: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db}
+	restoredAt := time.Now()
+	query := `UPDATE users SET deleted_at = NULL, updated_at = \$1, version = version \+ 1 WHERE id = \$2 AND deleted_at IS NOT NULL`
+
+	// Test case: user not deleted
+	mock.ExpectPrepare(query).
+		ExpectExec().
+		WithArgs(restoredAt, "1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.RestoreUser("1", restoredAt)
+	assert.Error(t, err)
+
+	// Test case: success
+	mock.ExpectPrepare(query).
+		ExpectExec().
+		WithArgs(restoredAt, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.RestoreUser("1", restoredAt)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRepository_PurgeUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock DB connection: %v", err)
+	}
+	defer db.Close()
+
+	repo := &Repository{db}
+	before := time.Now().AddDate(0, 0, -30)
+	mock.ExpectExec(`DELETE FROM users WHERE deleted_at IS NOT NULL AND deleted_at < \$1`).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	purged, err := repo.PurgeUsers(before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRepository_PatchUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := &Repository{db}
+	active := false
+	patch := &domain.UserPatch{Active: &active, UpdatedAt: time.Now()}
+	query := `^UPDATE users SET active = \$1, updated_at = \$2, version = version \+ 1 WHERE id = \$3 AND version = \$4 AND deleted_at IS NULL$`
+
+	t.Run("success - only patched columns written", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs(false, patch.UpdatedAt, "123", int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, r.PatchUser("123", 2, patch))
+	})
+
+	t.Run("version moved on", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs(false, patch.UpdatedAt, "123", int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, r.PatchUser("123", 2, patch), domain.ErrVersionMismatch)
+	})
+
+	t.Run("attributes written whole", func(t *testing.T) {
+		attributesPatch := &domain.UserPatch{Attributes: map[string]interface{}{}, UpdatedAt: patch.UpdatedAt}
+		mock.ExpectPrepare(`^UPDATE users SET attributes = \$1, updated_at = \$2, (.+)`).
+			ExpectExec().
+			WithArgs([]byte("{}"), patch.UpdatedAt, "123", int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, r.PatchUser("123", 2, attributesPatch))
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
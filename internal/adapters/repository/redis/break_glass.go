@@ -0,0 +1,72 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	breakGlassKeyPrefix        = "break_glass:"
+	breakGlassCodeKeyPrefix    = "break_glass_code:"
+	breakGlassFailureKeyPrefix = "break_glass_failure:"
+)
+
+func (r *Repository) SaveBreakGlassSession(userID string, sessionID string, expiration time.Duration) error {
+	return r.Set(breakGlassKeyPrefix+userID, sessionID, expiration)
+}
+
+// GetBreakGlassSession returns an empty session id when the user has no
+// active session.
+func (r *Repository) GetBreakGlassSession(userID string) (string, error) {
+	sessionID, err := r.Get(breakGlassKeyPrefix + userID)
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return sessionID, nil
+}
+
+func (r *Repository) DeleteBreakGlassSession(userID string) error {
+	return r.Delete(breakGlassKeyPrefix + userID)
+}
+
+// UseBreakGlassCode marks the code of the counter used, only the first caller
+// gets true. The mark outlives the code.
+func (r *Repository) UseBreakGlassCode(userID string, counter int64, expiration time.Duration) (bool, error) {
+	return r.client.SetNX(r.ctx, fmt.Sprintf("%s%s:%d", breakGlassCodeKeyPrefix, userID, counter), 1, expiration).Result()
+}
+
+// CountBreakGlassFailure increments the failures of the user. The count
+// expires with the window opened by the first failure, later failures do not
+// extend it.
+func (r *Repository) CountBreakGlassFailure(userID string, expiration time.Duration) (int64, error) {
+	key := breakGlassFailureKeyPrefix + userID
+	failures, err := r.client.Incr(r.ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if failures == 1 {
+		if err := r.client.Expire(r.ctx, key, expiration).Err(); err != nil {
+			return 0, err
+		}
+	}
+	return failures, nil
+}
+
+// GetBreakGlassFailures returns 0 when the user has no failure in the window.
+func (r *Repository) GetBreakGlassFailures(userID string) (int64, error) {
+	failures, err := r.Get(breakGlassFailureKeyPrefix + userID)
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(failures, 10, 64)
+}
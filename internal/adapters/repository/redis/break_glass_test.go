@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"context"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRepository_GetBreakGlassSession(t *testing.T) {
+	t.Run("success - active session", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		r := &Repository{client: db, ctx: context.TODO()}
+		mock.ExpectGet(breakGlassKeyPrefix + "u1").SetVal("session-1")
+
+		sessionID, err := r.GetBreakGlassSession("u1")
+		assert.NoError(t, err)
+		assert.Equal(t, "session-1", sessionID)
+	})
+
+	t.Run("success - no session", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		r := &Repository{client: db, ctx: context.TODO()}
+		mock.ExpectGet(breakGlassKeyPrefix + "u1").RedisNil()
+
+		sessionID, err := r.GetBreakGlassSession("u1")
+		assert.NoError(t, err)
+		assert.Equal(t, "", sessionID)
+	})
+}
+
+func TestRepository_UseBreakGlassCode(t *testing.T) {
+	t.Run("success - first use", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		r := &Repository{client: db, ctx: context.TODO()}
+		mock.ExpectSetNX(breakGlassCodeKeyPrefix+"u1:42", 1, 90*time.Second).SetVal(true)
+
+		fresh, err := r.UseBreakGlassCode("u1", 42, 90*time.Second)
+		assert.NoError(t, err)
+		assert.True(t, fresh)
+	})
+
+	t.Run("success - code already used", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		r := &Repository{client: db, ctx: context.TODO()}
+		mock.ExpectSetNX(breakGlassCodeKeyPrefix+"u1:42", 1, 90*time.Second).SetVal(false)
+
+		fresh, err := r.UseBreakGlassCode("u1", 42, 90*time.Second)
+		assert.NoError(t, err)
+		assert.False(t, fresh)
+	})
+}
+
+func TestRepository_CountBreakGlassFailure(t *testing.T) {
+	t.Run("success - first failure opens the window", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		r := &Repository{client: db, ctx: context.TODO()}
+		mock.ExpectIncr(breakGlassFailureKeyPrefix + "u1").SetVal(1)
+		mock.ExpectExpire(breakGlassFailureKeyPrefix+"u1", 90*time.Second).SetVal(true)
+
+		failures, err := r.CountBreakGlassFailure("u1", 90*time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), failures)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success - later failure keeps the window", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		r := &Repository{client: db, ctx: context.TODO()}
+		mock.ExpectIncr(breakGlassFailureKeyPrefix + "u1").SetVal(3)
+
+		failures, err := r.CountBreakGlassFailure("u1", 90*time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), failures)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_GetBreakGlassFailures(t *testing.T) {
+	t.Run("success - failures counted", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		r := &Repository{client: db, ctx: context.TODO()}
+		mock.ExpectGet(breakGlassFailureKeyPrefix + "u1").SetVal("2")
+
+		failures, err := r.GetBreakGlassFailures("u1")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), failures)
+	})
+
+	t.Run("success - no failure", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		r := &Repository{client: db, ctx: context.TODO()}
+		mock.ExpectGet(breakGlassFailureKeyPrefix + "u1").RedisNil()
+
+		failures, err := r.GetBreakGlassFailures("u1")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), failures)
+	})
+}
@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+const (
+	permissionUsageKey         = "permission_usage"
+	permissionUsageFlushingKey = "permission_usage:flushing"
+)
+
+// RecordPermissionUsage keeps the latest use per user and permission in a
+// single hash, so repeated requests only overwrite one field.
+func (r *Repository) RecordPermissionUsage(userID string, permission string, usedAt time.Time) error {
+	field := userID + "|" + permission
+	if err := r.client.HSet(r.ctx, permissionUsageKey, field, usedAt.Unix()).Err(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetPendingPermissionUsage moves the recorded usage aside before reading it,
+// so usage recorded during a flush lands in a fresh hash. Usage left aside by
+// a flush that failed is returned again instead.
+func (r *Repository) GetPendingPermissionUsage() ([]*domain.PermissionUsage, error) {
+	pending, err := r.Exists(permissionUsageFlushingKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !pending {
+		err := r.client.Rename(r.ctx, permissionUsageKey, permissionUsageFlushingKey).Err()
+		if err != nil && strings.Contains(err.Error(), "no such key") {
+			return make([]*domain.PermissionUsage, 0), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fields, err := r.client.HGetAll(r.ctx, permissionUsageFlushingKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]*domain.PermissionUsage, 0, len(fields))
+	for field, value := range fields {
+		userID, permission, found := strings.Cut(field, "|")
+		if !found {
+			continue
+		}
+		usedAt, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		usages = append(usages, &domain.PermissionUsage{
+			UserId:     userID,
+			Permission: permission,
+			UsedAt:     time.Unix(usedAt, 0).UTC(),
+		})
+	}
+
+	return usages, nil
+}
+
+func (r *Repository) ClearPendingPermissionUsage() error {
+	return r.Delete(permissionUsageFlushingKey)
+}
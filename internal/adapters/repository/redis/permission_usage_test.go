@@ -0,0 +1,65 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRepository_RecordPermissionUsage(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	r := &Repository{client: db, ctx: context.TODO()}
+	usedAt := time.Unix(1700000000, 0)
+
+	mock.ExpectHSet(permissionUsageKey, "u1|View-User", usedAt.Unix()).SetVal(1)
+
+	assert.NoError(t, r.RecordPermissionUsage("u1", "View-User", usedAt))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_GetPendingPermissionUsage(t *testing.T) {
+	t.Run("success - recorded usage moved aside and read", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		r := &Repository{client: db, ctx: context.TODO()}
+
+		mock.ExpectExists(permissionUsageFlushingKey).SetVal(0)
+		mock.ExpectRename(permissionUsageKey, permissionUsageFlushingKey).SetVal("OK")
+		mock.ExpectHGetAll(permissionUsageFlushingKey).SetVal(map[string]string{"u1|View-User": "1700000000"})
+
+		usages, err := r.GetPendingPermissionUsage()
+		assert.NoError(t, err)
+		assert.Len(t, usages, 1)
+		assert.Equal(t, "u1", usages[0].UserId)
+		assert.Equal(t, "View-User", usages[0].Permission)
+		assert.Equal(t, int64(1700000000), usages[0].UsedAt.Unix())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success - nothing recorded", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		r := &Repository{client: db, ctx: context.TODO()}
+
+		mock.ExpectExists(permissionUsageFlushingKey).SetVal(0)
+		mock.ExpectRename(permissionUsageKey, permissionUsageFlushingKey).SetErr(errors.New("ERR no such key"))
+
+		usages, err := r.GetPendingPermissionUsage()
+		assert.NoError(t, err)
+		assert.Len(t, usages, 0)
+	})
+
+	t.Run("success - usage left by a failed flush read again", func(t *testing.T) {
+		db, mock := redismock.NewClientMock()
+		r := &Repository{client: db, ctx: context.TODO()}
+
+		mock.ExpectExists(permissionUsageFlushingKey).SetVal(1)
+		mock.ExpectHGetAll(permissionUsageFlushingKey).SetVal(map[string]string{"u1|View-User": "1700000000"})
+
+		usages, err := r.GetPendingPermissionUsage()
+		assert.NoError(t, err)
+		assert.Len(t, usages, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,48 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/opensearch-project/opensearch-go"
+	"github.com/opensearch-project/opensearch-go/opensearchapi"
+	"user-svc/internal/shared/config"
+	"user-svc/internal/shared/open_search"
+)
+
+// Repository keeps the search indexes in OpenSearch.
+type Repository struct {
+	client    *opensearch.Client
+	userIndex string
+}
+
+func NewRepository(config *config.Config, client *open_search.OpenSearchClient) *Repository {
+	return &Repository{
+		client:    client.Client,
+		userIndex: config.App.UserSearch.Index,
+	}
+}
+
+// do sends the request and decodes the response into out, when given. Error
+// responses with one of the ignored status codes are not reported.
+func (r *Repository) do(request opensearchapi.Request, out interface{}, ignore ...int) error {
+	res, err := request.Do(context.Background(), r.client)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		for _, code := range ignore {
+			if res.StatusCode == code {
+				return nil
+			}
+		}
+		return fmt.Errorf("opensearch: %s", res.String())
+	}
+
+	if out != nil {
+		return json.NewDecoder(res.Body).Decode(out)
+	}
+	return nil
+}
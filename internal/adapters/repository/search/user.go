@@ -0,0 +1,153 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/opensearch-project/opensearch-go/opensearchapi"
+	"net/http"
+	"strings"
+	"user-svc/internal/core/domain"
+)
+
+// userMapping keeps a keyword copy of name and email for exact matches, roles
+// are keywords so they can be aggregated into facets.
+const userMapping = `{
+	"mappings": {
+		"properties": {
+			"id": {"type": "keyword"},
+			"name": {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+			"email": {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+			"active": {"type": "boolean"},
+			"roles": {"type": "keyword"},
+			"created_at": {"type": "date"},
+			"updated_at": {"type": "date"}
+		}
+	}
+}`
+
+type userSearchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			Source *domain.UserDocument `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+	Aggregations struct {
+		Roles struct {
+			Buckets []struct {
+				Key      string `json:"key"`
+				DocCount int64  `json:"doc_count"`
+			} `json:"buckets"`
+		} `json:"roles"`
+	} `json:"aggregations"`
+}
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+}
+
+// ResetUserIndex drops the user index and creates it empty with the current
+// mapping.
+func (r *Repository) ResetUserIndex() error {
+	if err := r.do(opensearchapi.IndicesDeleteRequest{Index: []string{r.userIndex}}, nil, http.StatusNotFound); err != nil {
+		return err
+	}
+	return r.do(opensearchapi.IndicesCreateRequest{Index: r.userIndex, Body: strings.NewReader(userMapping)}, nil)
+}
+
+// IndexUsers adds or replaces the documents in one bulk request.
+func (r *Repository) IndexUsers(users []*domain.UserDocument) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, user := range users {
+		action := map[string]interface{}{"index": map[string]string{"_id": user.Id}}
+		if err := encoder.Encode(action); err != nil {
+			return err
+		}
+		if err := encoder.Encode(user); err != nil {
+			return err
+		}
+	}
+
+	var response bulkResponse
+	if err := r.do(opensearchapi.BulkRequest{Index: r.userIndex, Body: &body}, &response); err != nil {
+		return err
+	}
+	if response.Errors {
+		return fmt.Errorf("opensearch: failed to index some of %d users", len(users))
+	}
+	return nil
+}
+
+// DeleteUser removes the document of a user, a user that is not indexed is
+// not an error.
+func (r *Repository) DeleteUser(id string) error {
+	return r.do(opensearchapi.DeleteRequest{Index: r.userIndex, DocumentID: id}, nil, http.StatusNotFound)
+}
+
+// SearchUsers matches the query fuzzily against name and email, name weighing
+// double. The role filter is applied after the role facets are counted.
+func (r *Repository) SearchUsers(query *domain.UserSearchQuery) (*domain.UserSearchResult, error) {
+	filters := make([]interface{}, 0)
+	if query.Active != nil {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"active": *query.Active}})
+	}
+
+	search := map[string]interface{}{
+		"from":             query.Offset,
+		"size":             query.Limit,
+		"track_total_hits": true,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": map[string]interface{}{
+					"multi_match": map[string]interface{}{
+						"query":     query.Query,
+						"fields":    []string{"name^2", "email"},
+						"fuzziness": "AUTO",
+					},
+				},
+				"filter": filters,
+			},
+		},
+		"aggs": map[string]interface{}{
+			"roles": map[string]interface{}{
+				"terms": map[string]interface{}{"field": "roles", "size": domain.RoleFacetSize},
+			},
+		},
+		"sort": []interface{}{"_score", map[string]string{"id": "asc"}},
+	}
+	if query.Role != "" {
+		search["post_filter"] = map[string]interface{}{"term": map[string]string{"roles": query.Role}}
+	}
+
+	body, err := json.Marshal(search)
+	if err != nil {
+		return nil, err
+	}
+
+	var response userSearchResponse
+	if err := r.do(opensearchapi.SearchRequest{Index: []string{r.userIndex}, Body: bytes.NewReader(body)}, &response); err != nil {
+		return nil, err
+	}
+
+	result := &domain.UserSearchResult{
+		Users: make([]*domain.UserDocument, 0, len(response.Hits.Hits)),
+		Total: response.Hits.Total.Value,
+		Roles: make([]*domain.FacetCount, 0, len(response.Aggregations.Roles.Buckets)),
+	}
+	for _, hit := range response.Hits.Hits {
+		result.Users = append(result.Users, hit.Source)
+	}
+	for _, bucket := range response.Aggregations.Roles.Buckets {
+		result.Roles = append(result.Roles, &domain.FacetCount{Value: bucket.Key, Count: bucket.DocCount})
+	}
+
+	return result, nil
+}
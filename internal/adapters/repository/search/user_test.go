@@ -0,0 +1,106 @@
+package search
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"user-svc/internal/core/domain"
+
+	"github.com/opensearch-project/opensearch-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRepository(t *testing.T, handler http.HandlerFunc) *Repository {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the client checks the server version before its first request
+		if r.URL.Path == "/" {
+			io.WriteString(w, `{"version": {"number": "1.3.0", "distribution": "opensearch"}}`)
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := opensearch.NewClient(opensearch.Config{Addresses: []string{server.URL}})
+	require.NoError(t, err)
+	return &Repository{client: client, userIndex: "users"}
+}
+
+func TestRepository_SearchUsers(t *testing.T) {
+	var search map[string]interface{}
+	repo := newTestRepository(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/users/_search", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&search))
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{
+			"hits": {"total": {"value": 3}, "hits": [{"_source": {"id": "1", "name": "Alice", "email": "alice@mail.com", "active": true, "roles": ["Admin"]}}]},
+			"aggregations": {"roles": {"buckets": [{"key": "Admin", "doc_count": 1}, {"key": "Viewer", "doc_count": 2}]}}
+		}`)
+	})
+
+	active := true
+	result, err := repo.SearchUsers(&domain.UserSearchQuery{Query: "alcie", Active: &active, Role: "Admin", Limit: 20, Offset: 20})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.Total)
+	require.Len(t, result.Users, 1)
+	assert.Equal(t, []string{"Admin"}, result.Users[0].Roles)
+	assert.Equal(t, []*domain.FacetCount{{Value: "Admin", Count: 1}, {Value: "Viewer", Count: 2}}, result.Roles)
+
+	// the role filter is a post filter so facets count every role
+	assert.Equal(t, float64(20), search["from"])
+	assert.Equal(t, map[string]interface{}{"term": map[string]interface{}{"roles": "Admin"}}, search["post_filter"])
+	match := search["query"].(map[string]interface{})["bool"].(map[string]interface{})["must"].(map[string]interface{})["multi_match"].(map[string]interface{})
+	assert.Equal(t, "AUTO", match["fuzziness"])
+}
+
+func TestRepository_IndexUsers(t *testing.T) {
+	t.Run("success - documents sent in bulk", func(t *testing.T) {
+		var body string
+		repo := newTestRepository(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/users/_bulk", r.URL.Path)
+			data, _ := io.ReadAll(r.Body)
+			body = string(data)
+			io.WriteString(w, `{"errors": false, "items": []}`)
+		})
+
+		err := repo.IndexUsers([]*domain.UserDocument{{Id: "1", Roles: []string{}}, {Id: "2", Roles: []string{}}})
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(body), "\n")
+		require.Len(t, lines, 4)
+		assert.JSONEq(t, `{"index": {"_id": "1"}}`, lines[0])
+	})
+
+	t.Run("failed - item errors reported", func(t *testing.T) {
+		repo := newTestRepository(t, func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, `{"errors": true, "items": []}`)
+		})
+
+		assert.Error(t, repo.IndexUsers([]*domain.UserDocument{{Id: "1"}}))
+	})
+}
+
+func TestRepository_DeleteUser(t *testing.T) {
+	t.Run("success - missing document ignored", func(t *testing.T) {
+		repo := newTestRepository(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method)
+			assert.Equal(t, "/users/_doc/1", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"result": "not_found"}`)
+		})
+
+		assert.NoError(t, repo.DeleteUser("1"))
+	})
+
+	t.Run("failed - server error", func(t *testing.T) {
+		repo := newTestRepository(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			io.WriteString(w, `{"error": "boom"}`)
+		})
+
+		assert.Error(t, repo.DeleteUser("1"))
+	})
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "sort"
+
+// AccessImpact is returned by a dry run instead of applying the change: the
+// effective-permission delta of every user whose access would change, and the
+// lock-out that would refuse the change when applied.
+type AccessImpact struct {
+	DryRun  bool               `json:"dry_run"`
+	Users   []*UserAccessDelta `json:"users"`
+	Lockout string             `json:"lockout,omitempty"`
+}
+
+type UserAccessDelta struct {
+	UserId            string   `json:"user_id"`
+	LostPermissions   []string `json:"lost_permissions"`
+	GainedPermissions []string `json:"gained_permissions"`
+	LostRoutes        []string `json:"lost_routes"`
+	GainedRoutes      []string `json:"gained_routes"`
+}
+
+// RouteIndex maps each permission to the routes that require it. It is filled
+// while the routes are registered and only read afterwards.
+type RouteIndex struct {
+	routes map[string][]string
+}
+
+func NewRouteIndex() *RouteIndex {
+	return &RouteIndex{routes: make(map[string][]string)}
+}
+
+func (i *RouteIndex) Add(permission PermissionName, route string) {
+	i.routes[string(permission)] = append(i.routes[string(permission)], route)
+}
+
+// Routes returns the sorted routes that require any of the permissions.
+func (i *RouteIndex) Routes(permissions []string) []string {
+	routes := make([]string, 0)
+	for _, permission := range permissions {
+		routes = append(routes, i.routes[permission]...)
+	}
+	sort.Strings(routes)
+	return routes
+}
@@ -0,0 +1,56 @@
+package domain
+
+import "time"
+
+const (
+	AccessRequestPending  = "pending"
+	AccessRequestApproved = "approved"
+	AccessRequestRejected = "rejected"
+	AccessRequestExpired  = "expired"
+	AccessRequestRevoked  = "revoked"
+)
+
+type AccessRequest struct {
+	Id            string     `json:"id"`
+	UserId        string     `json:"user_id"`
+	RoleId        string     `json:"role_id"`
+	Justification string     `json:"justification"`
+	Duration      *int64     `json:"duration,omitempty"`
+	Status        string     `json:"status"`
+	ReviewerId    *string    `json:"reviewer_id,omitempty"`
+	ReviewNote    *string    `json:"review_note,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at,omitempty"`
+	UpdatedAt     time.Time  `json:"updated_at,omitempty"`
+}
+
+type AccessRequestFilter struct {
+	Status string
+	UserId string
+	RoleId string
+}
+
+type CreateAccessRequestRequest struct {
+	UserId        string `json:"-"`
+	RoleId        string `json:"role_id" validate:"required,uuid"`
+	Justification string `json:"justification" validate:"required"`
+	// Duration of the grant in minutes, capped by the configured maximum
+	Duration *int64 `json:"duration" validate:"omitempty,min=1"`
+}
+
+type ReviewAccessRequestRequest struct {
+	ReviewerId string `json:"-"`
+	Id         string `param:"id" validate:"required,uuid"`
+	Note       string `json:"note"`
+}
+
+type GetAccessRequestRequest struct {
+	Id string `param:"id" validate:"required,uuid"`
+}
+
+type GetAccessRequestsRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=pending approved rejected expired revoked"`
+	UserId string `query:"user_id" validate:"omitempty,uuid"`
+	RoleId string `query:"role_id" validate:"omitempty,uuid"`
+}
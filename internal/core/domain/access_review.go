@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	AccessReviewOpen = "open"
+	// AccessReviewClosing marks a campaign whose revocations are being
+	// applied, it takes no decisions and cannot be closed again
+	AccessReviewClosing = "closing"
+	AccessReviewClosed  = "closed"
+
+	ReviewDecisionKeep   = "keep"
+	ReviewDecisionRevoke = "revoke"
+
+	AccessReviewExportCSV  = "csv"
+	AccessReviewExportJSON = "json"
+)
+
+// ErrAccessReviewNotOpen is returned by a write that needs the campaign open
+// when it was closed since it was read.
+var ErrAccessReviewNotOpen = errors.New("access review is not open")
+
+// AccessReview is a certification campaign over a snapshot of direct role
+// assignments. Each item is certified by the reviewer it was assigned to.
+type AccessReview struct {
+	Id        string              `json:"id"`
+	Name      string              `json:"name"`
+	Status    string              `json:"status"`
+	CreatedBy string              `json:"created_by"`
+	ClosedBy  *string             `json:"closed_by,omitempty"`
+	DueAt     *time.Time          `json:"due_at,omitempty"`
+	ClosedAt  *time.Time          `json:"closed_at,omitempty"`
+	CreatedAt time.Time           `json:"created_at,omitempty"`
+	UpdatedAt time.Time           `json:"updated_at,omitempty"`
+	Items     []*AccessReviewItem `json:"items,omitempty"`
+}
+
+type AccessReviewItem struct {
+	Id          string     `json:"id"`
+	ReviewId    string     `json:"review_id"`
+	UserId      string     `json:"user_id"`
+	UserEmail   string     `json:"user_email"`
+	RoleId      string     `json:"role_id"`
+	RoleName    string     `json:"role_name"`
+	ReviewerId  string     `json:"reviewer_id"`
+	Decision    *string    `json:"decision,omitempty"`
+	Comment     *string    `json:"comment,omitempty"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	RevokeError *string    `json:"revoke_error,omitempty"`
+}
+
+// AccessReviewExport is the evidence file of a campaign.
+type AccessReviewExport struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+type CreateAccessReviewRequest struct {
+	CreatedBy string `json:"-"`
+	Name      string `json:"name" validate:"required"`
+	// RolesId limits the campaign to assignments of these roles, all roles
+	// are reviewed when empty
+	RolesId     []string   `json:"roles_id" validate:"dive,uuid"`
+	ReviewersId []string   `json:"reviewers_id" validate:"required,min=1,dive,uuid"`
+	DueAt       *time.Time `json:"due_at"`
+}
+
+type GetAccessReviewRequest struct {
+	Id string `param:"id" validate:"required,uuid"`
+}
+
+type DecideAccessReviewItemRequest struct {
+	ReviewerId string `json:"-"`
+	Id         string `param:"id" validate:"required,uuid"`
+	ItemId     string `param:"item_id" validate:"required,uuid"`
+	Decision   string `json:"decision" validate:"required,oneof=keep revoke"`
+	Comment    string `json:"comment"`
+}
+
+type CloseAccessReviewRequest struct {
+	ActorId string `json:"-"`
+	Id      string `param:"id" validate:"required,uuid"`
+}
+
+type ExportAccessReviewRequest struct {
+	Id     string `param:"id" validate:"required,uuid"`
+	Format string `query:"format" validate:"omitempty,oneof=csv json"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+// AdminScope limits what the holders of a role may administer: the roles they
+// may grant or revoke, and the permissions they may attach to or detach from
+// roles.
+type AdminScope struct {
+	RoleId        string   `json:"role_id"`
+	RolesId       []string `json:"roles_id"`
+	PermissionsId []string `json:"permissions_id"`
+}
+
+type GetAdminScopeRequest struct {
+	RoleId string `param:"id" validate:"required,uuid"`
+}
+
+type UpdateAdminScopeRequest struct {
+	RoleId        string   `param:"id" validate:"required,uuid"`
+	RolesId       []string `json:"roles_id" validate:"dive,uuid"`
+	PermissionsId []string `json:"permissions_id" validate:"dive,uuid"`
+}
@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+const (
+	BreakGlassActive  = "active"
+	BreakGlassEnded   = "ended"
+	BreakGlassExpired = "expired"
+)
+
+// BreakGlassSession is an emergency grant of the configured break-glass role,
+// limited in time and announced to security.
+type BreakGlassSession struct {
+	Id          string     `json:"id"`
+	UserId      string     `json:"user_id"`
+	RoleId      string     `json:"role_id"`
+	RoleName    string     `json:"role_name"`
+	Reason      string     `json:"reason"`
+	Status      string     `json:"status"`
+	ActivatedAt time.Time  `json:"activated_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	EndedAt     *time.Time `json:"ended_at,omitempty"`
+	EndedBy     *string    `json:"ended_by,omitempty"`
+}
+
+// BreakGlassEnrollment is the second factor a user activates break-glass
+// with. The secret is only shown when it is issued.
+type BreakGlassEnrollment struct {
+	UserId     string    `json:"user_id"`
+	Secret     string    `json:"secret,omitempty"`
+	URI        string    `json:"uri,omitempty"`
+	EnrolledBy string    `json:"enrolled_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ActivateBreakGlassRequest struct {
+	UserId string `json:"-"`
+	Reason string `json:"reason" validate:"required,min=10"`
+	// Code is the current one-time code of the secret the user was enrolled with
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type EnrollBreakGlassRequest struct {
+	ActorId string `json:"-"`
+	UserId  string `param:"user_id" validate:"required,uuid"`
+}
+
+type UnenrollBreakGlassRequest struct {
+	ActorId string `json:"-"`
+	UserId  string `param:"user_id" validate:"required,uuid"`
+}
+
+type EndBreakGlassRequest struct {
+	ActorId string `json:"-"`
+	Id      string `param:"id" validate:"required,uuid"`
+}
+
+type GetBreakGlassSessionRequest struct {
+	Id string `param:"id" validate:"required,uuid"`
+}
+
+type GetBreakGlassSessionsRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=active ended expired"`
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+type Group struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+type CreateGroupRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type UpdateGroupRequest struct {
+	Id   string `param:"id" validate:"required,uuid"`
+	Name string `json:"name" validate:"required"`
+}
+
+type DeleteGroupRequest struct {
+	Id string `param:"id" validate:"required,uuid"`
+}
+
+type GetGroupRequest struct {
+	Id string `param:"id" validate:"required,uuid"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+type GetGroupRolesRequest struct {
+	GroupId string `param:"group_id" validate:"required,uuid"`
+}
+
+type AssignRolesToGroupRequest struct {
+	GroupId string   `param:"group_id" validate:"required,uuid"`
+	RolesId []string `json:"roles_id" validate:"required,min=1,dive,uuid"`
+	ActorId string   `json:"-"`
+}
+
+type RemoveRolesFromGroupRequest struct {
+	GroupId string   `param:"group_id" validate:"required,uuid"`
+	RolesId []string `json:"roles_id" validate:"required,min=1,dive,uuid"`
+	ActorId string   `json:"-"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+type GetGroupUsersRequest struct {
+	GroupId string `param:"group_id" validate:"required,uuid"`
+}
+
+type AddUsersToGroupRequest struct {
+	GroupId string   `param:"group_id" validate:"required,uuid"`
+	UsersId []string `json:"users_id" validate:"required,min=1,dive,uuid"`
+	ActorId string   `json:"-"`
+}
+
+type RemoveUsersFromGroupRequest struct {
+	GroupId string   `param:"group_id" validate:"required,uuid"`
+	UsersId []string `json:"users_id" validate:"required,min=1,dive,uuid"`
+	ActorId string   `json:"-"`
+}
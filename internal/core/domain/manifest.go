@@ -0,0 +1,68 @@
+package domain
+
+// Manifest declares the roles and permissions an environment should have, the
+// permissions bound to each role and, optionally, the roles bound to users.
+type Manifest struct {
+	Permissions []*ManifestPermission `json:"permissions" yaml:"permissions"`
+	Roles       []*ManifestRole       `json:"roles" yaml:"roles"`
+	Users       []*ManifestUser       `json:"users" yaml:"users"`
+}
+
+type ManifestPermission struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+type ManifestRole struct {
+	Name string `json:"name" yaml:"name"`
+	// Active defaults to true when omitted
+	Active      *bool    `json:"active" yaml:"active"`
+	Permissions []string `json:"permissions" yaml:"permissions"`
+}
+
+// ManifestUser binds roles to an existing user, looked up by email.
+type ManifestUser struct {
+	Email string   `json:"email" yaml:"email"`
+	Roles []string `json:"roles" yaml:"roles"`
+}
+
+// ManifestPlan is the diff between a manifest and the live database. Removals
+// are only planned when pruning.
+type ManifestPlan struct {
+	CreatePermissions     []*Permission            `json:"create_permissions"`
+	DeletePermissions     []*Permission            `json:"delete_permissions"`
+	CreateRoles           []*Role                  `json:"create_roles"`
+	UpdateRoles           []*Role                  `json:"update_roles"`
+	DeleteRoles           []*Role                  `json:"delete_roles"`
+	AddRolePermissions    []*RolePermissionBinding `json:"add_role_permissions"`
+	RemoveRolePermissions []*RolePermissionBinding `json:"remove_role_permissions"`
+	AddUserRoles          []*UserRoleBinding       `json:"add_user_roles"`
+	RemoveUserRoles       []*UserRoleBinding       `json:"remove_user_roles"`
+}
+
+func (p *ManifestPlan) Empty() bool {
+	return len(p.CreatePermissions) == 0 && len(p.DeletePermissions) == 0 &&
+		len(p.CreateRoles) == 0 && len(p.UpdateRoles) == 0 && len(p.DeleteRoles) == 0 &&
+		len(p.AddRolePermissions) == 0 && len(p.RemoveRolePermissions) == 0 &&
+		len(p.AddUserRoles) == 0 && len(p.RemoveUserRoles) == 0
+}
+
+type RolePermissionBinding struct {
+	RoleId         string `json:"role_id"`
+	RoleName       string `json:"role_name"`
+	PermissionId   string `json:"permission_id"`
+	PermissionName string `json:"permission_name"`
+}
+
+type UserRoleBinding struct {
+	UserId    string `json:"user_id"`
+	UserEmail string `json:"user_email"`
+	RoleId    string `json:"role_id"`
+	RoleName  string `json:"role_name"`
+}
+
+type ManifestOptions struct {
+	// Prune removes roles, permissions and bindings the manifest does not declare
+	Prune bool
+	// DryRun applies the plan in a transaction that is rolled back
+	DryRun bool
+}
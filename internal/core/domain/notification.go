@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+const NotificationChannelSecurity = "security"
+
+// Notification is a message for the people watching a channel, with the
+// details as flat fields so any sink can render them.
+type Notification struct {
+	Channel   string            `json:"channel"`
+	Subject   string            `json:"subject"`
+	Fields    map[string]string `json:"fields"`
+	CreatedAt time.Time         `json:"created_at"`
+}
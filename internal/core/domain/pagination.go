@@ -0,0 +1,96 @@
+package domain
+
+import "time"
+
+const (
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// PageRequest is embedded in list requests that support offset pagination.
+type PageRequest struct {
+	Page    int `query:"page" validate:"omitempty,min=1"`
+	PerPage int `query:"per_page" validate:"omitempty,min=1,max=100"`
+}
+
+func (p PageRequest) CurrentPage() int {
+	if p.Page < 1 {
+		return 1
+	}
+	return p.Page
+}
+
+func (p PageRequest) Limit() int {
+	if p.PerPage < 1 {
+		return DefaultPerPage
+	}
+	if p.PerPage > MaxPerPage {
+		return MaxPerPage
+	}
+	return p.PerPage
+}
+
+func (p PageRequest) Offset() int {
+	return (p.CurrentPage() - 1) * p.Limit()
+}
+
+type Page struct {
+	Items   interface{} `json:"items"`
+	Total   int64       `json:"total"`
+	Page    int         `json:"page"`
+	PerPage int         `json:"per_page"`
+	Links   *PageLinks  `json:"links,omitempty"`
+	// Facets count the matches per value of a field, for searches only
+	Facets map[string][]*FacetCount `json:"facets,omitempty"`
+}
+
+// PageLinks point to the neighbouring pages, each omitted at its end of the
+// list.
+type PageLinks struct {
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
+
+// HasNext reports whether items remain after this page.
+func (p *Page) HasNext() bool {
+	return int64(p.Page*p.PerPage) < p.Total
+}
+
+// CursorRequest is embedded in list requests that also support keyset
+// pagination, which is used instead of pages once a cursor or a limit is
+// given. Keyset pages are always ordered by creation.
+type CursorRequest struct {
+	Cursor string `query:"cursor"`
+	Size   int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+func (c CursorRequest) UseCursor() bool {
+	return c.Cursor != "" || c.Size > 0
+}
+
+func (c CursorRequest) CursorLimit() int {
+	if c.Size < 1 {
+		return DefaultPerPage
+	}
+	if c.Size > MaxPerPage {
+		return MaxPerPage
+	}
+	return c.Size
+}
+
+// Cursor is the keyset position, the (created_at, id) of the last item
+// returned, a page starts after.
+type Cursor struct {
+	CreatedAt time.Time
+	Id        string
+}
+
+type CursorPage struct {
+	Items      interface{} `json:"items"`
+	Limit      int         `json:"limit"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	Links      *PageLinks  `json:"links,omitempty"`
+}
@@ -5,23 +5,76 @@ import "time"
 type Permission struct {
 	Id        string    `json:"id"`
 	Name      string    `json:"name"`
+	System    bool      `json:"system"`
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Version is bumped on every write, it is the entity tag of the record
+	Version int64 `json:"version,omitempty"`
+	// DeletedAt is only set on permissions in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 type CreatePermissionRequest struct {
-	Name string `json:"name" validate:"required"`
+	Name   string `json:"name" validate:"required"`
+	DryRun bool   `json:"-"`
 }
 
 type UpdatePermissionRequest struct {
-	Id   string `param:"id" validate:"required,uuid"`
-	Name string `json:"name" validate:"required"`
+	Id      string  `param:"id" validate:"required,uuid"`
+	Name    string  `json:"name" validate:"required"`
+	DryRun  bool    `json:"-"`
+	IfMatch []int64 `json:"-"`
+}
+
+// PatchPermissionRequest is a JSON merge patch of a permission, the members
+// left out keep their value.
+type PatchPermissionRequest struct {
+	Id      string  `param:"id" json:"-" validate:"required,uuid"`
+	Name    *string `json:"name" validate:"omitempty,min=1"`
+	DryRun  bool    `json:"-"`
+	IfMatch []int64 `json:"-"`
+}
+
+// PermissionPatch holds the columns a patch writes, nil ones are left alone.
+type PermissionPatch struct {
+	Name      *string
+	UpdatedAt time.Time
 }
 
 type DeletePermissionRequest struct {
+	Id      string  `param:"id" validate:"required,uuid"`
+	DryRun  bool    `json:"-"`
+	IfMatch []int64 `json:"-"`
+}
+
+type RestorePermissionRequest struct {
 	Id string `param:"id" validate:"required,uuid"`
 }
 
 type GetPermissionRequest struct {
 	Id string `param:"id" validate:"required,uuid"`
 }
+
+type PermissionFilter struct {
+	NamePrefix   string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	UpdatedSince *time.Time
+	// After is the keyset position a keyset page starts after, the first page when nil
+	After  *Cursor
+	Sort   string
+	Order  string
+	Limit  int
+	Offset int
+}
+
+type GetPermissionsRequest struct {
+	PageRequest
+	CursorRequest
+	Name         string     `query:"name"`
+	CreatedFrom  *time.Time `query:"created_from"`
+	CreatedTo    *time.Time `query:"created_to"`
+	UpdatedSince *time.Time `query:"updated_since"`
+	Sort         string     `query:"sort" validate:"omitempty,oneof=name created_at updated_at"`
+	Order        string     `query:"order" validate:"omitempty,oneof=asc desc"`
+}
@@ -0,0 +1,39 @@
+package domain
+
+// PermissionImplication states that holding a permission also grants the
+// implied one, e.g. Update-User implies View-User.
+type PermissionImplication struct {
+	PermissionId          string `json:"permission_id"`
+	PermissionName        string `json:"permission_name"`
+	ImpliedPermissionId   string `json:"implied_permission_id"`
+	ImpliedPermissionName string `json:"implied_permission_name"`
+}
+
+type GetPermissionImplicationsRequest struct {
+	PermissionId string `param:"permission_id" validate:"required,uuid"`
+}
+
+type AddPermissionImplicationsRequest struct {
+	PermissionId         string   `param:"permission_id" validate:"required,uuid"`
+	ImpliedPermissionsId []string `json:"implied_permissions_id" validate:"required,min=1,dive,uuid"`
+}
+
+type RemovePermissionImplicationsRequest struct {
+	PermissionId         string   `param:"permission_id" validate:"required,uuid"`
+	ImpliedPermissionsId []string `json:"implied_permissions_id" validate:"required,min=1,dive,uuid"`
+}
+
+// ImpliedPermission is a permission implied by held ones, ImpliedBy names the
+// held permissions implying it, directly or transitively.
+type ImpliedPermission struct {
+	Id        string
+	Name      string
+	ImpliedBy []string
+}
+
+// ImpliedPermissions reports the prerequisites of newly assigned permissions
+// that the role did not hold yet, either added with them or left missing.
+type ImpliedPermissions struct {
+	Added   []*Permission `json:"added,omitempty"`
+	Missing []*Permission `json:"missing,omitempty"`
+}
@@ -0,0 +1,105 @@
+package domain
+
+// PermissionName is a permission a route can require. Routes only reference
+// the constants below, so every required permission is known at startup.
+type PermissionName string
+
+const (
+	PermissionListUser   PermissionName = "List-User"
+	PermissionViewUser   PermissionName = "View-User"
+	PermissionCreateUser PermissionName = "Create-User"
+	PermissionUpdateUser PermissionName = "Update-User"
+	PermissionDeleteUser PermissionName = "Delete-User"
+	PermissionImportUser PermissionName = "Import-User"
+	PermissionExportUser PermissionName = "Export-User"
+
+	PermissionListUserAttribute   PermissionName = "List-User-Attribute"
+	PermissionViewUserAttribute   PermissionName = "View-User-Attribute"
+	PermissionCreateUserAttribute PermissionName = "Create-User-Attribute"
+	PermissionUpdateUserAttribute PermissionName = "Update-User-Attribute"
+	PermissionDeleteUserAttribute PermissionName = "Delete-User-Attribute"
+
+	PermissionListRole   PermissionName = "List-Role"
+	PermissionViewRole   PermissionName = "View-Role"
+	PermissionCreateRole PermissionName = "Create-Role"
+	PermissionUpdateRole PermissionName = "Update-Role"
+	PermissionDeleteRole PermissionName = "Delete-Role"
+
+	PermissionUpdateAdminScope PermissionName = "Update-Admin-Scope"
+
+	PermissionListPermission   PermissionName = "List-Permission"
+	PermissionViewPermission   PermissionName = "View-Permission"
+	PermissionCreatePermission PermissionName = "Create-Permission"
+	PermissionUpdatePermission PermissionName = "Update-Permission"
+	PermissionDeletePermission PermissionName = "Delete-Permission"
+
+	PermissionViewPermissionUsage PermissionName = "View-Permission-Usage"
+
+	PermissionListRoleConstraint   PermissionName = "List-Role-Constraint"
+	PermissionViewRoleConstraint   PermissionName = "View-Role-Constraint"
+	PermissionCreateRoleConstraint PermissionName = "Create-Role-Constraint"
+	PermissionDeleteRoleConstraint PermissionName = "Delete-Role-Constraint"
+
+	PermissionListAccessRequest    PermissionName = "List-Access-Request"
+	PermissionViewAccessRequest    PermissionName = "View-Access-Request"
+	PermissionCreateAccessRequest  PermissionName = "Create-Access-Request"
+	PermissionApproveAccessRequest PermissionName = "Approve-Access-Request"
+
+	PermissionListAccessReview   PermissionName = "List-Access-Review"
+	PermissionViewAccessReview   PermissionName = "View-Access-Review"
+	PermissionCreateAccessReview PermissionName = "Create-Access-Review"
+	PermissionDecideAccessReview PermissionName = "Decide-Access-Review"
+	PermissionCloseAccessReview  PermissionName = "Close-Access-Review"
+
+	PermissionListBreakGlass     PermissionName = "List-Break-Glass"
+	PermissionViewBreakGlass     PermissionName = "View-Break-Glass"
+	PermissionActivateBreakGlass PermissionName = "Activate-Break-Glass"
+	PermissionEndBreakGlass      PermissionName = "End-Break-Glass"
+	PermissionEnrollBreakGlass   PermissionName = "Enroll-Break-Glass"
+
+	PermissionListGroup   PermissionName = "List-Group"
+	PermissionViewGroup   PermissionName = "View-Group"
+	PermissionCreateGroup PermissionName = "Create-Group"
+	PermissionUpdateGroup PermissionName = "Update-Group"
+	PermissionDeleteGroup PermissionName = "Delete-Group"
+
+	PermissionListRelation  PermissionName = "List-Relation"
+	PermissionCheckRelation PermissionName = "Check-Relation"
+	PermissionWriteRelation PermissionName = "Write-Relation"
+)
+
+// RegisteredPermissions is the registry the permissions table is reconciled
+// against on startup.
+var RegisteredPermissions = []PermissionName{
+	PermissionListUser, PermissionViewUser, PermissionCreateUser, PermissionUpdateUser, PermissionDeleteUser, PermissionImportUser, PermissionExportUser,
+	PermissionListUserAttribute, PermissionViewUserAttribute, PermissionCreateUserAttribute, PermissionUpdateUserAttribute, PermissionDeleteUserAttribute,
+	PermissionListRole, PermissionViewRole, PermissionCreateRole, PermissionUpdateRole, PermissionDeleteRole,
+	PermissionUpdateAdminScope,
+	PermissionListPermission, PermissionViewPermission, PermissionCreatePermission, PermissionUpdatePermission, PermissionDeletePermission,
+	PermissionViewPermissionUsage,
+	PermissionListRoleConstraint, PermissionViewRoleConstraint, PermissionCreateRoleConstraint, PermissionDeleteRoleConstraint,
+	PermissionListAccessRequest, PermissionViewAccessRequest, PermissionCreateAccessRequest, PermissionApproveAccessRequest,
+	PermissionListAccessReview, PermissionViewAccessReview, PermissionCreateAccessReview, PermissionDecideAccessReview, PermissionCloseAccessReview,
+	PermissionListBreakGlass, PermissionViewBreakGlass, PermissionActivateBreakGlass, PermissionEndBreakGlass, PermissionEnrollBreakGlass,
+	PermissionListGroup, PermissionViewGroup, PermissionCreateGroup, PermissionUpdateGroup, PermissionDeleteGroup,
+	PermissionListRelation, PermissionCheckRelation, PermissionWriteRelation,
+}
+
+func IsRegisteredPermission(name PermissionName) bool {
+	for _, registered := range RegisteredPermissions {
+		if registered == name {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionSync reports how the permissions table was reconciled with the
+// registry. Marked are stored registered permissions that were not flagged as
+// system yet. Orphans are stored permissions no route requires; they are only
+// reported, since they may still be granted through roles.
+type PermissionSync struct {
+	Created []string      `json:"created"`
+	Marked  []string      `json:"marked"`
+	Orphans []*Permission `json:"orphans"`
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// PermissionUsage is the last time a user exercised a permission.
+type PermissionUsage struct {
+	UserId     string    `json:"user_id"`
+	Permission string    `json:"permission"`
+	UsedAt     time.Time `json:"used_at"`
+}
+
+// GrantUsage is a permission granted to a role together with the last time
+// any holder of the role used it. Usage is recorded per user and permission,
+// not per role, so a use is credited to every role of the user granting it.
+type GrantUsage struct {
+	RoleId         string     `json:"role_id"`
+	RoleName       string     `json:"role_name"`
+	PermissionId   string     `json:"permission_id"`
+	PermissionName string     `json:"permission_name"`
+	Holders        int        `json:"holders"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+}
+
+// RoleRightSizing suggests which permissions of a role to keep and which to
+// remove, given the grants its holders did not use.
+type RoleRightSizing struct {
+	RoleId            string        `json:"role_id"`
+	RoleName          string        `json:"role_name"`
+	Holders           int           `json:"holders"`
+	UnusedGrants      []*GrantUsage `json:"unused_grants"`
+	KeepPermissions   []string      `json:"keep_permissions"`
+	RemovePermissions []string      `json:"remove_permissions"`
+	Suggestion        string        `json:"suggestion"`
+}
+
+// UnusedGrantsAttributionNote tells the readers of the report how uses are
+// credited to roles.
+const UnusedGrantsAttributionNote = "usage is recorded per user and permission, a use is credited to every role of the user granting the permission, " +
+	"so a grant shared with another role of the same holders may be reported as used while only the other role is needed"
+
+type UnusedGrantsReport struct {
+	Days  int                `json:"days"`
+	Since time.Time          `json:"since"`
+	Note  string             `json:"note"`
+	Roles []*RoleRightSizing `json:"roles"`
+}
+
+type GetUnusedGrantsRequest struct {
+	Days   int    `query:"days" validate:"omitempty,min=1"`
+	RoleId string `query:"role_id" validate:"omitempty,uuid"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// PurgeResult counts what a purge removed for good, everything deleted
+// before Before.
+type PurgeResult struct {
+	Before      time.Time `json:"before"`
+	Users       int64     `json:"users"`
+	Roles       int64     `json:"roles"`
+	Permissions int64     `json:"permissions"`
+	// RelationTuples are deleted tuples no read can see anymore, whenever
+	// they were deleted
+	RelationTuples int64 `json:"relation_tuples"`
+}
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RelationTuple states that a subject has a relation to an object, written as
+// object#relation@subject. The subject is either an object, such as user:alice,
+// or the set of subjects holding a relation to an object, such as
+// group:eng#member.
+type RelationTuple struct {
+	Namespace        string    `json:"namespace"`
+	ObjectId         string    `json:"object_id"`
+	Relation         string    `json:"relation"`
+	SubjectNamespace string    `json:"subject_namespace"`
+	SubjectId        string    `json:"subject_id"`
+	SubjectRelation  string    `json:"subject_relation,omitempty"`
+	CreatedAt        time.Time `json:"created_at,omitempty"`
+}
+
+func (t *RelationTuple) Object() string {
+	return t.Namespace + ":" + t.ObjectId
+}
+
+func (t *RelationTuple) Subject() string {
+	subject := t.SubjectNamespace + ":" + t.SubjectId
+	if t.SubjectRelation != "" {
+		subject += "#" + t.SubjectRelation
+	}
+	return subject
+}
+
+func (t *RelationTuple) String() string {
+	return t.Object() + "#" + t.Relation + "@" + t.Subject()
+}
+
+// ParseObject splits an object reference such as document:readme into its
+// namespace and id.
+func ParseObject(object string) (string, string, error) {
+	namespace, id, ok := strings.Cut(object, ":")
+	if !ok || namespace == "" || id == "" || strings.Contains(id, "#") {
+		return "", "", fmt.Errorf("invalid object %q, expected namespace:id", object)
+	}
+	return namespace, id, nil
+}
+
+// ParseSubject splits a subject such as user:alice or group:eng#member into its
+// namespace, id and optional relation.
+func ParseSubject(subject string) (string, string, string, error) {
+	object, relation, _ := strings.Cut(subject, "#")
+	namespace, id, err := ParseObject(object)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid subject %q, expected namespace:id or namespace:id#relation", subject)
+	}
+	return namespace, id, relation, nil
+}
+
+// RelationTupleFilter selects tuples, empty fields match anything.
+type RelationTupleFilter struct {
+	Namespace        string
+	ObjectId         string
+	Relation         string
+	SubjectNamespace string
+	SubjectId        string
+	SubjectRelation  *string
+}
+
+// RelationTree is the expansion of a relation on an object: the subjects it is
+// granted to directly, and the subtrees it is rewritten or delegated to.
+type RelationTree struct {
+	Object   string          `json:"object"`
+	Relation string          `json:"relation"`
+	Subjects []string        `json:"subjects"`
+	Children []*RelationTree `json:"children,omitempty"`
+}
+
+// EncodeConsistencyToken turns a tuple store revision into the opaque token
+// handed to clients.
+func EncodeConsistencyToken(revision int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(revision, 10)))
+}
+
+func DecodeConsistencyToken(token string) (int64, error) {
+	value, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, errors.New("invalid consistency token")
+	}
+	revision, err := strconv.ParseInt(string(value), 10, 64)
+	if err != nil || revision < 0 {
+		return 0, errors.New("invalid consistency token")
+	}
+	return revision, nil
+}
+
+type RelationTupleRequest struct {
+	Object   string `json:"object" validate:"required"`
+	Relation string `json:"relation" validate:"required"`
+	Subject  string `json:"subject" validate:"required"`
+}
+
+type WriteRelationTuplesRequest struct {
+	Tuples []*RelationTupleRequest `json:"tuples" validate:"required,min=1,dive,required"`
+}
+
+type GetRelationTuplesRequest struct {
+	Object           string `query:"object"`
+	Namespace        string `query:"namespace"`
+	Relation         string `query:"relation"`
+	Subject          string `query:"subject"`
+	ConsistencyToken string `query:"consistency_token"`
+}
+
+type CheckRelationRequest struct {
+	Object           string `query:"object" validate:"required"`
+	Relation         string `query:"relation" validate:"required"`
+	Subject          string `query:"subject" validate:"required"`
+	ConsistencyToken string `query:"consistency_token"`
+}
+
+type ExpandRelationRequest struct {
+	Object           string `query:"object" validate:"required"`
+	Relation         string `query:"relation" validate:"required"`
+	ConsistencyToken string `query:"consistency_token"`
+}
+
+type ListObjectsRequest struct {
+	Namespace        string `query:"namespace" validate:"required"`
+	Relation         string `query:"relation" validate:"required"`
+	Subject          string `query:"subject" validate:"required"`
+	ConsistencyToken string `query:"consistency_token"`
+}
+
+type RelationTuples struct {
+	Tuples           []*RelationTuple `json:"tuples"`
+	ConsistencyToken string           `json:"consistency_token"`
+}
+
+type RelationCheck struct {
+	Allowed          bool   `json:"allowed"`
+	ConsistencyToken string `json:"consistency_token"`
+}
+
+type RelationExpansion struct {
+	Tree             *RelationTree `json:"tree"`
+	ConsistencyToken string        `json:"consistency_token"`
+}
+
+type RelationObjects struct {
+	Objects          []string `json:"objects"`
+	ConsistencyToken string   `json:"consistency_token"`
+}
@@ -6,25 +6,82 @@ type Role struct {
 	Id        string    `json:"id"`
 	Name      string    `json:"name"`
 	Active    bool      `json:"active,omitempty"`
+	System    bool      `json:"system"`
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Version is bumped on every write, it is the entity tag of the record
+	Version int64 `json:"version,omitempty"`
+	// DeletedAt is only set on roles in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 type CreateRoleRequest struct {
 	Name   string `json:"name" validate:"required"`
 	Active *bool  `json:"active" validate:"required"`
+	DryRun bool   `json:"-"`
 }
 
 type UpdateRoleRequest struct {
-	Id     string `param:"id" validate:"required,uuid"`
-	Name   string `json:"name" validate:"required"`
-	Active *bool  `json:"active" validate:"required"`
+	Id      string  `param:"id" validate:"required,uuid"`
+	Name    string  `json:"name" validate:"required"`
+	Active  *bool   `json:"active" validate:"required"`
+	DryRun  bool    `json:"-"`
+	IfMatch []int64 `json:"-"`
+}
+
+// PatchRoleRequest is a JSON merge patch of a role, the members left out keep
+// their value.
+type PatchRoleRequest struct {
+	Id      string  `param:"id" json:"-" validate:"required,uuid"`
+	Name    *string `json:"name" validate:"omitempty,min=1"`
+	Active  *bool   `json:"active"`
+	DryRun  bool    `json:"-"`
+	IfMatch []int64 `json:"-"`
+}
+
+// RolePatch holds the columns a patch writes, nil ones are left alone.
+type RolePatch struct {
+	Name      *string
+	Active    *bool
+	UpdatedAt time.Time
 }
 
 type DeleteRoleRequest struct {
+	Id      string  `param:"id" validate:"required,uuid"`
+	DryRun  bool    `json:"-"`
+	IfMatch []int64 `json:"-"`
+}
+
+type RestoreRoleRequest struct {
 	Id string `param:"id" validate:"required,uuid"`
 }
 
 type GetRoleRequest struct {
 	Id string `param:"id" validate:"required,uuid"`
 }
+
+type RoleFilter struct {
+	Active       *bool
+	NamePrefix   string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	UpdatedSince *time.Time
+	// After is the keyset position a keyset page starts after, the first page when nil
+	After  *Cursor
+	Sort   string
+	Order  string
+	Limit  int
+	Offset int
+}
+
+type GetRolesRequest struct {
+	PageRequest
+	CursorRequest
+	Active       *bool      `query:"active"`
+	Name         string     `query:"name"`
+	CreatedFrom  *time.Time `query:"created_from"`
+	CreatedTo    *time.Time `query:"created_to"`
+	UpdatedSince *time.Time `query:"updated_since"`
+	Sort         string     `query:"sort" validate:"omitempty,oneof=name created_at updated_at"`
+	Order        string     `query:"order" validate:"omitempty,oneof=asc desc"`
+}
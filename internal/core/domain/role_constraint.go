@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+const (
+	// ConstraintMutuallyExclusive limits how many roles of the set a single user may hold.
+	ConstraintMutuallyExclusive = "mutually_exclusive"
+	// ConstraintCardinality limits how many users may hold each role of the set.
+	ConstraintCardinality = "cardinality"
+)
+
+type RoleConstraint struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	RolesId   []string  `json:"roles_id"`
+	MaxCount  int       `json:"max_count"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+type ConstraintViolation struct {
+	ConstraintId   string   `json:"constraint_id"`
+	ConstraintName string   `json:"constraint_name"`
+	Type           string   `json:"type"`
+	MaxCount       int      `json:"max_count"`
+	UserId         string   `json:"user_id,omitempty"`
+	RoleId         string   `json:"role_id,omitempty"`
+	RolesId        []string `json:"roles_id,omitempty"`
+	UsersId        []string `json:"users_id,omitempty"`
+}
+
+type CreateRoleConstraintRequest struct {
+	Name     string   `json:"name" validate:"required"`
+	Type     string   `json:"type" validate:"required,oneof=mutually_exclusive cardinality"`
+	RolesId  []string `json:"roles_id" validate:"required,min=1,dive,uuid"`
+	MaxCount int      `json:"max_count" validate:"required,min=1"`
+}
+
+type DeleteRoleConstraintRequest struct {
+	Id string `param:"id" validate:"required,uuid"`
+}
+
+type GetRoleConstraintRequest struct {
+	Id string `param:"id" validate:"required,uuid"`
+}
@@ -7,9 +7,29 @@ type GetRolePermissionRequest struct {
 type AssignPermissionToRoleRequest struct {
 	RoleId        string   `param:"role_id" validate:"required,uuid"`
 	PermissionsId []string `json:"permissions_id" validate:"required,min=1,dive,uuid"`
+	AddImplied    bool     `json:"add_implied"`
+	ActorId       string   `json:"-"`
+	DryRun        bool     `json:"-"`
 }
 
 type RemovePermissionFromRoleRequest struct {
 	RoleId        string   `param:"role_id" validate:"required,uuid"`
 	PermissionsId []string `json:"permissions_id" validate:"required,min=1,dive,uuid"`
+	ActorId       string   `json:"-"`
+	DryRun        bool     `json:"-"`
+}
+
+type GetPermissionUsersRequest struct {
+	PermissionId string `param:"id" validate:"required,uuid"`
+	PageRequest
+}
+
+// PermissionUser is a user holding a permission. Direct tells a role grants
+// the permission itself, ImpliedBy names the held permissions implying it.
+type PermissionUser struct {
+	*User
+	Direct    bool     `json:"direct"`
+	ImpliedBy []string `json:"implied_by,omitempty"`
+	// Permissions are the names of the looked up permissions the user holds
+	Permissions []string `json:"-"`
 }
@@ -0,0 +1,39 @@
+package domain
+
+// AccessChange describes a pending mutation in terms of the grants it takes away,
+// so it can be checked against the lock-out safeguard before it is applied.
+// Dry runs also describe what the mutation grants or renames.
+type AccessChange struct {
+	UserIds                []string
+	RoleIds                []string
+	PermissionIds          []string
+	GroupIds               []string
+	RevokedUserRoles       []*UserRoleGrant
+	RevokedRolePermissions []*RolePermissionGrant
+	RevokedGroupUsers      []*GroupUserGrant
+	RevokedGroupRoles      []*GroupRoleGrant
+	ActivatedRoleIds       []string
+	GrantedUserRoles       []*UserRoleGrant
+	GrantedRolePermissions []*RolePermissionGrant
+	RenamedPermissions     []*Permission
+}
+
+type UserRoleGrant struct {
+	UserId string `json:"user_id"`
+	RoleId string `json:"role_id"`
+}
+
+type RolePermissionGrant struct {
+	RoleId       string `json:"role_id"`
+	PermissionId string `json:"permission_id"`
+}
+
+type GroupUserGrant struct {
+	GroupId string `json:"group_id"`
+	UserId  string `json:"user_id"`
+}
+
+type GroupRoleGrant struct {
+	GroupId string `json:"group_id"`
+	RoleId  string `json:"role_id"`
+}
@@ -5,34 +5,107 @@ import (
 )
 
 type User struct {
-	Id        string    `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	Active    bool      `json:"active"`
-	Salt      string    `json:"-"`
-	Password  string    `json:"-"`
-	CreatedAt time.Time `json:"created_at,omitempty"`
-	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	Id         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	Email      string                 `json:"email"`
+	Active     bool                   `json:"active"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Salt       string                 `json:"-"`
+	Password   string                 `json:"-"`
+	CreatedAt  time.Time              `json:"created_at,omitempty"`
+	UpdatedAt  time.Time              `json:"updated_at,omitempty"`
+	// Version is bumped on every write, it is the entity tag of the record
+	Version int64 `json:"version,omitempty"`
+	// DeletedAt is only set on users in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 type CreateUserRequest struct {
-	Name     string `json:"name" validate:"required"`
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required"`
+	Name       string                 `json:"name" validate:"required"`
+	Email      string                 `json:"email" validate:"required,email"`
+	Password   string                 `json:"password" validate:"required"`
+	Attributes map[string]interface{} `json:"attributes"`
 }
 
+// UpdateUserRequest replaces a user, its attributes are kept when left out.
 type UpdateUserRequest struct {
-	Id       string `param:"id" validate:"required,uuid"`
-	Name     string `json:"name" validate:"required"`
-	Email    string `json:"email" validate:"required,email"`
-	Active   *bool  `json:"active" validate:"required"`
-	Password string `json:"password"`
+	Id         string                 `param:"id" validate:"required,uuid"`
+	Name       string                 `json:"name" validate:"required"`
+	Email      string                 `json:"email" validate:"required,email"`
+	Active     *bool                  `json:"active" validate:"required"`
+	Password   string                 `json:"password"`
+	Attributes map[string]interface{} `json:"attributes"`
+	IfMatch    []int64                `json:"-"`
+}
+
+// PatchUserRequest is a JSON merge patch of a user, the members left out keep
+// their value. Attributes are merged the same way, a null removes one.
+type PatchUserRequest struct {
+	Id         string                 `param:"id" json:"-" validate:"required,uuid"`
+	Name       *string                `json:"name" validate:"omitempty,min=1"`
+	Email      *string                `json:"email" validate:"omitempty,email"`
+	Active     *bool                  `json:"active"`
+	Password   *string                `json:"password" validate:"omitempty,min=1"`
+	Attributes map[string]interface{} `json:"attributes"`
+	IfMatch    []int64                `json:"-"`
+}
+
+// UserPatch holds the columns a patch writes, nil ones are left alone.
+// Attributes are all the attributes of the user once patched.
+type UserPatch struct {
+	Name       *string
+	Email      *string
+	Active     *bool
+	Salt       *string
+	Password   *string
+	Attributes map[string]interface{}
+	UpdatedAt  time.Time
 }
 
 type DeleteUserRequest struct {
+	Id      string  `param:"id" validate:"required,uuid"`
+	IfMatch []int64 `json:"-"`
+}
+
+type RestoreUserRequest struct {
 	Id string `param:"id" validate:"required,uuid"`
 }
 
 type GetUserRequest struct {
 	Id string `param:"id" validate:"required,uuid"`
 }
+
+// UserFilter narrows and orders the users listed. Sort is one of the fields
+// whitelisted by GetUsersRequest, the repository maps it to a column.
+type UserFilter struct {
+	Active      *bool
+	EmailPrefix string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// HasRole keeps users holding the role, directly or through a group
+	HasRole      string
+	UpdatedSince *time.Time
+	Attributes   []*AttributeFilter
+	// After is the keyset position a keyset page starts after, the first page when nil
+	After  *Cursor
+	Sort   string
+	Order  string
+	Limit  int
+	Offset int
+}
+
+// GetUsersRequest filters on attributes with name:value pairs, a user must
+// match every one of them.
+type GetUsersRequest struct {
+	PageRequest
+	CursorRequest
+	Active       *bool      `query:"active"`
+	Email        string     `query:"email"`
+	CreatedFrom  *time.Time `query:"created_from"`
+	CreatedTo    *time.Time `query:"created_to"`
+	UpdatedSince *time.Time `query:"updated_since"`
+	HasRole      string     `query:"has_role" validate:"omitempty,uuid"`
+	Attributes   []string   `query:"attribute" validate:"dive,contains=:"`
+	Sort         string     `query:"sort" validate:"omitempty,oneof=name email created_at updated_at"`
+	Order        string     `query:"order" validate:"omitempty,oneof=asc desc"`
+}
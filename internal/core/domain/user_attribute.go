@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+const (
+	UserAttributeString  = "string"
+	UserAttributeNumber  = "number"
+	UserAttributeInteger = "integer"
+	UserAttributeBoolean = "boolean"
+	// UserAttributeDate values are strings in the 2006-01-02 layout
+	UserAttributeDate = "date"
+
+	UserAttributeDateLayout = "2006-01-02"
+)
+
+// UserAttribute defines an attribute users may hold, like a department or an
+// employee id. The values are kept along with the user, only defined
+// attributes are accepted and they are checked against the definition on
+// every write of a user.
+type UserAttribute struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+	// Rules are validator tags the values must pass, like min=2,max=64
+	Rules string `json:"rules,omitempty"`
+	// Claim puts the value in the access tokens of the user
+	Claim       bool      `json:"claim"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+// AttributeFilter keeps the users whose attribute has the value, compared as
+// text.
+type AttributeFilter struct {
+	Name  string
+	Value string
+}
+
+type CreateUserAttributeRequest struct {
+	Name        string `json:"name" validate:"required,max=63"`
+	Type        string `json:"type" validate:"required,oneof=string number integer boolean date"`
+	Required    bool   `json:"required"`
+	Rules       string `json:"rules"`
+	Claim       bool   `json:"claim"`
+	Description string `json:"description"`
+}
+
+// UpdateUserAttributeRequest replaces a definition, the type of an attribute
+// cannot change once users hold values of it.
+type UpdateUserAttributeRequest struct {
+	Name        string `param:"name" validate:"required"`
+	Required    *bool  `json:"required" validate:"required"`
+	Rules       string `json:"rules"`
+	Claim       *bool  `json:"claim" validate:"required"`
+	Description string `json:"description"`
+}
+
+type DeleteUserAttributeRequest struct {
+	Name string `param:"name" validate:"required"`
+}
+
+type GetUserAttributeRequest struct {
+	Name string `param:"name" validate:"required"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+const (
+	UserExportCSV    = "csv"
+	UserExportNDJSON = "ndjson"
+	UserExportXLSX   = "xlsx"
+)
+
+// UserExport is a row of a user export. Roles and permissions are names and
+// only filled when asked for.
+type UserExport struct {
+	Id          string    `json:"id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Roles       []string  `json:"roles,omitempty"`
+	Permissions []string  `json:"permissions,omitempty"`
+}
+
+// UserExportQuery selects the users exported and what comes along with them.
+// FetchSize is the number of rows read from the cursor at a time.
+type UserExportQuery struct {
+	Filter             *UserFilter
+	IncludeRoles       bool
+	IncludePermissions bool
+	FetchSize          int
+}
+
+// ExportUsersRequest takes the filters of GetUsersRequest. The export is in
+// creation order, so it neither pages nor sorts.
+type ExportUsersRequest struct {
+	Format             string     `query:"format" validate:"required,oneof=csv ndjson xlsx"`
+	Active             *bool      `query:"active"`
+	Email              string     `query:"email"`
+	CreatedFrom        *time.Time `query:"created_from"`
+	CreatedTo          *time.Time `query:"created_to"`
+	UpdatedSince       *time.Time `query:"updated_since"`
+	HasRole            string     `query:"has_role" validate:"omitempty,uuid"`
+	Attributes         []string   `query:"attribute" validate:"dive,contains=:"`
+	IncludeRoles       bool       `query:"include_roles"`
+	IncludePermissions bool       `query:"include_permissions"`
+}
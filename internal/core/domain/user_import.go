@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+const (
+	UserImportCSV    = "csv"
+	UserImportNDJSON = "ndjson"
+
+	UserImportRunning   = "running"
+	UserImportCompleted = "completed"
+	// UserImportRejected imports had invalid rows, none of the rows is written
+	UserImportRejected = "rejected"
+	// UserImportFailed imports stopped before every row was written, the rows
+	// past the processed ones are left as validated
+	UserImportFailed = "failed"
+
+	UserImportRowValid   = "valid"
+	UserImportRowInvalid = "invalid"
+	UserImportRowCreated = "created"
+	UserImportRowFailed  = "failed"
+)
+
+// UserImport creates users along with their roles from a file. Every row is
+// validated before any is written, the rows are then written in batches by a
+// background job whose progress is polled.
+type UserImport struct {
+	Id         string           `json:"id"`
+	Status     string           `json:"status"`
+	Format     string           `json:"format"`
+	DryRun     bool             `json:"dry_run"`
+	CreatedBy  string           `json:"created_by"`
+	Total      int              `json:"total"`
+	Processed  int              `json:"processed"`
+	Created    int              `json:"created"`
+	Invalid    int              `json:"invalid"`
+	Failed     int              `json:"failed"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+	FinishedAt *time.Time       `json:"finished_at,omitempty"`
+	Rows       []*UserImportRow `json:"rows,omitempty"`
+}
+
+// UserImportRow is a row of the file along with its result. Roles are names,
+// the password is only kept in memory while the import runs.
+type UserImportRow struct {
+	Line       int                    `json:"line"`
+	Name       string                 `json:"name" validate:"required"`
+	Email      string                 `json:"email" validate:"required,email"`
+	Password   string                 `json:"-"`
+	Roles      []string               `json:"roles,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Status     string                 `json:"status"`
+	UserId     string                 `json:"user_id,omitempty"`
+	Errors     []string               `json:"errors,omitempty"`
+}
+
+type ImportUsersRequest struct {
+	ActorId string `json:"-"`
+	Format  string `json:"-" validate:"required,oneof=csv ndjson"`
+	DryRun  bool   `json:"-"`
+	Content []byte `json:"-"`
+}
+
+type GetUserImportRequest struct {
+	Id string `param:"id" validate:"required,uuid"`
+}
@@ -7,9 +7,48 @@ type GetUserRolesRequest struct {
 type AssignRolesToUserRequest struct {
 	UserId  string   `param:"user_id" validate:"required,uuid"`
 	RolesId []string `json:"roles_id" validate:"required,min=1,dive,uuid"`
+	ActorId string   `json:"-"`
+	DryRun  bool     `json:"-"`
 }
 
 type RemoveRolesFromUserRequest struct {
 	UserId  string   `param:"user_id" validate:"required,uuid"`
 	RolesId []string `json:"roles_id" validate:"dive,required,min=1,uuid"`
+	ActorId string   `json:"-"`
+	DryRun  bool     `json:"-"`
+}
+
+type PermissionHolder struct {
+	UserId         string `json:"user_id"`
+	RoleId         string `json:"role_id"`
+	GroupId        string `json:"group_id,omitempty"`
+	PermissionId   string `json:"permission_id"`
+	PermissionName string `json:"permission_name"`
+}
+
+type GetUserPermissionsRequest struct {
+	UserId string `param:"id" validate:"required,uuid"`
+}
+
+type GetRoleUsersRequest struct {
+	RoleId string `param:"id" validate:"required,uuid"`
+	PageRequest
+}
+
+// EffectivePermission is a permission a user holds, with every role that
+// grants it and every held permission that implies it. A permission the user
+// only holds through an implication is granted by no role.
+type EffectivePermission struct {
+	Id        string             `json:"id"`
+	Name      string             `json:"name"`
+	GrantedBy []*PermissionGrant `json:"granted_by"`
+	ImpliedBy []string           `json:"implied_by,omitempty"`
+}
+
+// PermissionGrant is a role that grants a permission to a user, along with the
+// group the role is inherited from, if any.
+type PermissionGrant struct {
+	RoleId   string `json:"role_id"`
+	RoleName string `json:"role_name"`
+	GroupId  string `json:"group_id,omitempty"`
 }
@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// RoleFacetSize caps how many role facets a user search returns.
+const RoleFacetSize = 20
+
+// UserDocument is a user as kept in the search index, together with the
+// names of the roles the user holds.
+type UserDocument struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Active    bool      `json:"active"`
+	Roles     []string  `json:"roles"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type UserSearchQuery struct {
+	// Query is matched fuzzily against name and email
+	Query  string
+	Active *bool
+	// Role keeps users holding the role, by name
+	Role   string
+	Limit  int
+	Offset int
+}
+
+// FacetCount is how many matches share a value.
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type UserSearchResult struct {
+	Users []*UserDocument
+	Total int64
+	// Roles counts the matching users per role, ignoring the role filter
+	Roles []*FacetCount
+}
+
+type SearchUsersRequest struct {
+	PageRequest
+	Q      string `query:"q" validate:"required,max=200"`
+	Active *bool  `query:"active"`
+	Role   string `query:"role"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "errors"
+
+// ErrVersionMismatch is returned by a conditional write when the record was
+// changed since it was read.
+var ErrVersionMismatch = errors.New("version mismatch")
+
+// VersionMatches tells whether a write made with If-Match may go through on a
+// record at the given version. No If-Match, or If-Match: *, matches any.
+func VersionMatches(ifMatch []int64, version int64) bool {
+	if len(ifMatch) == 0 {
+		return true
+	}
+	for _, v := range ifMatch {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
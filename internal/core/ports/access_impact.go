@@ -0,0 +1,7 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type AccessImpactService interface {
+	Simulate(change *domain.AccessChange) (*domain.AccessImpact, error)
+}
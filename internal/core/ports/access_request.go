@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+type AccessRequestService interface {
+	CreateAccessRequest(request *domain.CreateAccessRequestRequest) (*domain.Response, error)
+	ApproveAccessRequest(request *domain.ReviewAccessRequestRequest) (*domain.Response, error)
+	RejectAccessRequest(request *domain.ReviewAccessRequestRequest) (*domain.Response, error)
+	RevokeAccessRequest(request *domain.ReviewAccessRequestRequest) (*domain.Response, error)
+	GetAccessRequests(request *domain.GetAccessRequestsRequest) (*domain.Response, error)
+	GetAccessRequest(id string) (*domain.Response, error)
+	ExpireAccessRequests() error
+}
+
+type AccessRequestRepository interface {
+	CreateAccessRequest(accessRequest *domain.AccessRequest) error
+	UpdateAccessRequest(accessRequest *domain.AccessRequest) error
+	ApproveAccessRequest(accessRequest *domain.AccessRequest) error
+	RevokeAccessRequest(accessRequest *domain.AccessRequest) error
+	GetAccessRequestByID(id string) (*domain.AccessRequest, error)
+	GetAccessRequests(filter *domain.AccessRequestFilter) ([]*domain.AccessRequest, error)
+	ExpireAccessRequests(now time.Time, pendingBefore time.Time) (int64, error)
+}
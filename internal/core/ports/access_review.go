@@ -0,0 +1,23 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type AccessReviewService interface {
+	CreateAccessReview(request *domain.CreateAccessReviewRequest) (*domain.Response, error)
+	GetAccessReviews() (*domain.Response, error)
+	GetAccessReview(id string) (*domain.Response, error)
+	DecideAccessReviewItem(request *domain.DecideAccessReviewItemRequest) (*domain.Response, error)
+	CloseAccessReview(request *domain.CloseAccessReviewRequest) (*domain.Response, error)
+	ExportAccessReview(request *domain.ExportAccessReviewRequest) (*domain.AccessReviewExport, error)
+}
+
+type AccessReviewRepository interface {
+	CreateAccessReview(review *domain.AccessReview) error
+	GetAllAccessReview() ([]*domain.AccessReview, error)
+	GetAccessReviewByID(id string) (*domain.AccessReview, error)
+	GetAccessReviewAssignments(roles []string) ([]*domain.AccessReviewItem, error)
+	UpdateAccessReviewItem(item *domain.AccessReviewItem) error
+	StartClosingAccessReview(review *domain.AccessReview) error
+	UpdateAccessReviewItemRevocation(item *domain.AccessReviewItem) error
+	CloseAccessReview(review *domain.AccessReview) error
+}
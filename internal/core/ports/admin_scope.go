@@ -0,0 +1,17 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type AdminScopeService interface {
+	GetAdminScope(roleID string) (*domain.Response, error)
+	UpdateAdminScope(request *domain.UpdateAdminScopeRequest) (*domain.Response, error)
+	CheckRoleScope(actorID string, roles []string) error
+	CheckPermissionScope(actorID string, permissions []string) error
+}
+
+type AdminScopeRepository interface {
+	GetAdminScope(roleID string) (*domain.AdminScope, error)
+	UpdateAdminScope(scope *domain.AdminScope) error
+	GetScopedRoles(adminRoles []string) ([]string, error)
+	GetScopedPermissions(adminRoles []string) ([]string, error)
+}
@@ -0,0 +1,45 @@
+package ports
+
+import (
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+type BreakGlassService interface {
+	EnrollBreakGlass(request *domain.EnrollBreakGlassRequest) (*domain.Response, error)
+	UnenrollBreakGlass(request *domain.UnenrollBreakGlassRequest) (*domain.Response, error)
+	ActivateBreakGlass(request *domain.ActivateBreakGlassRequest) (*domain.Response, error)
+	EndBreakGlass(request *domain.EndBreakGlassRequest) (*domain.Response, error)
+	GetBreakGlassSessions(request *domain.GetBreakGlassSessionsRequest) (*domain.Response, error)
+	GetBreakGlassSession(id string) (*domain.Response, error)
+	GetActiveBreakGlassSession(userID string) (string, error)
+	ExpireBreakGlassSessions() error
+}
+
+type BreakGlassRepository interface {
+	CreateBreakGlassSession(session *domain.BreakGlassSession) error
+	UpdateBreakGlassSession(session *domain.BreakGlassSession) error
+	GetBreakGlassSessionByID(id string) (*domain.BreakGlassSession, error)
+	GetBreakGlassSessions(status string) ([]*domain.BreakGlassSession, error)
+	GetExpiredBreakGlassSessions(now time.Time) ([]*domain.BreakGlassSession, error)
+	SaveBreakGlassEnrollment(enrollment *domain.BreakGlassEnrollment) error
+	GetBreakGlassSecret(userID string) (string, error)
+	DeleteBreakGlassEnrollment(userID string) error
+}
+
+// BreakGlassCacheRepository remembers the active session of each user until
+// it expires, so every request can be tagged without a database lookup, the
+// one-time codes used while they are still valid and the failed second
+// factors of each user.
+type BreakGlassCacheRepository interface {
+	SaveBreakGlassSession(userID string, sessionID string, expiration time.Duration) error
+	GetBreakGlassSession(userID string) (string, error)
+	DeleteBreakGlassSession(userID string) error
+	// UseBreakGlassCode reports false when the code of the given counter was
+	// already used by the user
+	UseBreakGlassCode(userID string, counter int64, expiration time.Duration) (bool, error)
+	// CountBreakGlassFailure counts a failed second factor of the user and
+	// returns the failures since the first one that has not expired
+	CountBreakGlassFailure(userID string, expiration time.Duration) (int64, error)
+	GetBreakGlassFailures(userID string) (int64, error)
+}
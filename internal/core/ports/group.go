@@ -0,0 +1,21 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type GroupService interface {
+	CreateGroup(request *domain.CreateGroupRequest) (*domain.Response, error)
+	UpdateGroup(request *domain.UpdateGroupRequest) (*domain.Response, error)
+	DeleteGroup(id string) (*domain.Response, error)
+	GetGroups() (*domain.Response, error)
+	GetGroup(id string) (*domain.Response, error)
+}
+
+type GroupRepository interface {
+	CreateGroup(group *domain.Group) error
+	UpdateGroup(group *domain.Group) error
+	DeleteGroup(id string) error
+	GetAllGroup() ([]*domain.Group, error)
+	GetGroupByID(id string) (*domain.Group, error)
+	GetGroupByName(name string) (*domain.Group, error)
+	GroupIsExist(name string) (bool, error)
+}
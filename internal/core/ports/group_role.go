@@ -0,0 +1,15 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type GroupRoleService interface {
+	GetGroupRoles(request *domain.GetGroupRolesRequest) (*domain.Response, error)
+	AssignRolesToGroup(request *domain.AssignRolesToGroupRequest) (*domain.Response, error)
+	RemoveRolesFromGroup(request *domain.RemoveRolesFromGroupRequest) (*domain.Response, error)
+}
+
+type GroupRoleRepository interface {
+	GetGroupRoles(groupID string) ([]*domain.Role, error)
+	AddGroupRoles(groupID string, roles []string) error
+	RemoveGroupRoles(groupID string, roles []string) error
+}
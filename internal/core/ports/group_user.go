@@ -0,0 +1,15 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type GroupUserService interface {
+	GetGroupUsers(request *domain.GetGroupUsersRequest) (*domain.Response, error)
+	AddUsersToGroup(request *domain.AddUsersToGroupRequest) (*domain.Response, error)
+	RemoveUsersFromGroup(request *domain.RemoveUsersFromGroupRequest) (*domain.Response, error)
+}
+
+type GroupUserRepository interface {
+	GetGroupUsers(groupID string) ([]*domain.User, error)
+	AddGroupUsers(groupID string, users []string) error
+	RemoveGroupUsers(groupID string, users []string) error
+}
@@ -0,0 +1,12 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type ManifestService interface {
+	Plan(manifest *domain.Manifest, options *domain.ManifestOptions) (*domain.ManifestPlan, error)
+	Apply(manifest *domain.Manifest, options *domain.ManifestOptions) (*domain.ManifestPlan, error)
+}
+
+type ManifestRepository interface {
+	ApplyManifestPlan(plan *domain.ManifestPlan, dryRun bool) error
+}
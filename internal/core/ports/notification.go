@@ -0,0 +1,7 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type Notifier interface {
+	Notify(notification *domain.Notification) error
+}
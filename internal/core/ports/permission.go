@@ -1,20 +1,34 @@
 package ports
 
-import "user-svc/internal/core/domain"
+import (
+	"time"
+	"user-svc/internal/core/domain"
+)
 
 type PermissionService interface {
 	CreatePermission(request *domain.CreatePermissionRequest) (*domain.Response, error)
 	UpdatePermission(request *domain.UpdatePermissionRequest) (*domain.Response, error)
-	DeletePermission(id string) (*domain.Response, error)
-	GetPermissions() (*domain.Response, error)
+	PatchPermission(request *domain.PatchPermissionRequest) (*domain.Response, error)
+	DeletePermission(request *domain.DeletePermissionRequest) (*domain.Response, error)
+	RestorePermission(request *domain.RestorePermissionRequest) (*domain.Response, error)
+	GetPermissions(request *domain.GetPermissionsRequest) (*domain.Response, error)
 	GetPermission(id string) (*domain.Response, error)
+	SyncPermissions(registry []domain.PermissionName) (*domain.PermissionSync, error)
 }
 
 type PermissionRepository interface {
 	CreatePermission(role *domain.Permission) error
 	UpdatePermission(role *domain.Permission) error
-	DeletePermission(id string) error
+	PatchPermission(id string, version int64, patch *domain.PermissionPatch) error
+	DeletePermission(id string, version int64, deletedAt time.Time) error
+	RestorePermission(id string, restoredAt time.Time) error
+	// MarkSystemPermissions flags the permissions as system ones
+	MarkSystemPermissions(ids []string, updatedAt time.Time) error
+	PurgePermissions(before time.Time) (int64, error)
+	GetDeletedPermissionByID(id string) (*domain.Permission, error)
 	GetAllPermission() ([]*domain.Permission, error)
+	GetPermissions(filter *domain.PermissionFilter) ([]*domain.Permission, int64, error)
+	GetPermissionsAfter(filter *domain.PermissionFilter) ([]*domain.Permission, error)
 	GetPermissionByID(id string) (*domain.Permission, error)
 	GetPermissionByName(name string) (*domain.Permission, error)
 	PermissionIsExist(name string) (bool, error)
@@ -0,0 +1,24 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type PermissionImplicationService interface {
+	GetPermissionImplications(request *domain.GetPermissionImplicationsRequest) (*domain.Response, error)
+	AddPermissionImplications(request *domain.AddPermissionImplicationsRequest) (*domain.Response, error)
+	RemovePermissionImplications(request *domain.RemovePermissionImplicationsRequest) (*domain.Response, error)
+	ExpandPermissions(permissions []string) ([]string, error)
+	GetImpliedPermissions(permissionsID []string) ([]*domain.Permission, error)
+	// TraceImpliedPermissions returns the permissions implied by the given ones
+	// that are not part of the list, each with the given ones implying it
+	TraceImpliedPermissions(permissionsID []string) ([]*domain.ImpliedPermission, error)
+	// GetImplyingPermissions returns the permissions implying the given one,
+	// directly or transitively
+	GetImplyingPermissions(permissionID string) ([]*domain.Permission, error)
+}
+
+type PermissionImplicationRepository interface {
+	GetAllPermissionImplications() ([]*domain.PermissionImplication, error)
+	GetPermissionImplications(permissionID string) ([]*domain.Permission, error)
+	AddPermissionImplications(permissionID string, implied []string) error
+	RemovePermissionImplications(permissionID string, implied []string) error
+}
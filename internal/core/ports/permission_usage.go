@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+type PermissionUsageService interface {
+	RecordUsage(userID string, permission string) error
+	FlushUsage() error
+	GetUnusedGrants(request *domain.GetUnusedGrantsRequest) (*domain.Response, error)
+}
+
+type PermissionUsageRepository interface {
+	SavePermissionUsage(usages []*domain.PermissionUsage) error
+	GetGrantUsage(roleID string) ([]*domain.GrantUsage, error)
+}
+
+// PermissionUsageCacheRepository aggregates usage between flushes, keeping
+// only the latest use of each permission by each user.
+type PermissionUsageCacheRepository interface {
+	RecordPermissionUsage(userID string, permission string, usedAt time.Time) error
+	GetPendingPermissionUsage() ([]*domain.PermissionUsage, error)
+	ClearPendingPermissionUsage() error
+}
@@ -0,0 +1,7 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type PurgeService interface {
+	PurgeDeleted() (*domain.PurgeResult, error)
+}
@@ -0,0 +1,21 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type RelationService interface {
+	WriteRelationTuples(request *domain.WriteRelationTuplesRequest) (*domain.Response, error)
+	DeleteRelationTuples(request *domain.WriteRelationTuplesRequest) (*domain.Response, error)
+	GetRelationTuples(request *domain.GetRelationTuplesRequest) (*domain.Response, error)
+	Check(request *domain.CheckRelationRequest) (*domain.Response, error)
+	Expand(request *domain.ExpandRelationRequest) (*domain.Response, error)
+	ListObjects(request *domain.ListObjectsRequest) (*domain.Response, error)
+}
+
+type RelationRepository interface {
+	WriteRelationTuples(writes []*domain.RelationTuple, deletes []*domain.RelationTuple) (int64, error)
+	GetRelationTuples(filter *domain.RelationTupleFilter, revision int64) ([]*domain.RelationTuple, error)
+	GetRelationRevision() (int64, error)
+	// PruneRelationTuples removes the tuples deleted at or before the last
+	// committed revision
+	PruneRelationTuples() (int64, error)
+}
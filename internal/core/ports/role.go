@@ -1,20 +1,31 @@
 package ports
 
-import "user-svc/internal/core/domain"
+import (
+	"time"
+	"user-svc/internal/core/domain"
+)
 
 type RoleService interface {
 	CreateRole(request *domain.CreateRoleRequest) (*domain.Response, error)
 	UpdateRole(request *domain.UpdateRoleRequest) (*domain.Response, error)
-	DeleteRole(id string) (*domain.Response, error)
-	GetRoles() (*domain.Response, error)
+	PatchRole(request *domain.PatchRoleRequest) (*domain.Response, error)
+	DeleteRole(request *domain.DeleteRoleRequest) (*domain.Response, error)
+	RestoreRole(request *domain.RestoreRoleRequest) (*domain.Response, error)
+	GetRoles(request *domain.GetRolesRequest) (*domain.Response, error)
 	GetRole(id string) (*domain.Response, error)
 }
 
 type RoleRepository interface {
 	CreateRole(role *domain.Role) error
 	UpdateRole(role *domain.Role) error
-	DeleteRole(id string) error
+	PatchRole(id string, version int64, patch *domain.RolePatch) error
+	DeleteRole(id string, version int64, deletedAt time.Time) error
+	RestoreRole(id string, restoredAt time.Time) error
+	PurgeRoles(before time.Time) (int64, error)
+	GetDeletedRoleByID(id string) (*domain.Role, error)
 	GetAllRole() ([]*domain.Role, error)
+	GetRoles(filter *domain.RoleFilter) ([]*domain.Role, int64, error)
+	GetRolesAfter(filter *domain.RoleFilter) ([]*domain.Role, error)
 	GetRoleByID(id string) (*domain.Role, error)
 	GetRoleByName(name string) (*domain.Role, error)
 	RoleIsExist(name string) (bool, error)
@@ -0,0 +1,21 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type RoleConstraintService interface {
+	CreateRoleConstraint(request *domain.CreateRoleConstraintRequest) (*domain.Response, error)
+	DeleteRoleConstraint(id string) (*domain.Response, error)
+	GetRoleConstraints() (*domain.Response, error)
+	GetRoleConstraint(id string) (*domain.Response, error)
+	GetViolations() (*domain.Response, error)
+	CheckAssignment(userID string, roles []string) error
+}
+
+type RoleConstraintRepository interface {
+	CreateRoleConstraint(constraint *domain.RoleConstraint) error
+	DeleteRoleConstraint(id string) error
+	GetAllRoleConstraint() ([]*domain.RoleConstraint, error)
+	GetRoleConstraintByID(id string) (*domain.RoleConstraint, error)
+	GetRoleConstraintsByRoles(roles []string) ([]*domain.RoleConstraint, error)
+	GetRoleGrants(roles []string) ([]*domain.UserRoleGrant, error)
+}
@@ -6,10 +6,13 @@ type RolePermissionService interface {
 	GetRolePermissions(request *domain.GetRolePermissionRequest) (*domain.Response, error)
 	AssignPermissionsToRole(request *domain.AssignPermissionToRoleRequest) (*domain.Response, error)
 	RemovePermissionsFromRole(request *domain.RemovePermissionFromRoleRequest) (*domain.Response, error)
+	GetPermissionUsers(request *domain.GetPermissionUsersRequest) (*domain.Response, error)
 }
 
 type RolePermissionRepository interface {
 	GetRolePermissions(roleId string) ([]*domain.Permission, error)
 	AddRolePermissions(roleId string, permissions []string) error
 	RemoveRolePermissions(roleId string, permissions []string) error
+	// GetPermissionUsers pages the users holding any of the permissions
+	GetPermissionUsers(permissionIDs []string, limit int, offset int) ([]*domain.PermissionUser, int64, error)
 }
@@ -0,0 +1,7 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type SafeguardService interface {
+	CheckLockout(change *domain.AccessChange) error
+}
@@ -1,21 +1,35 @@
 package ports
 
-import "user-svc/internal/core/domain"
+import (
+	"time"
+	"user-svc/internal/core/domain"
+)
 
 type UserService interface {
 	CreateUser(request *domain.CreateUserRequest) (*domain.Response, error)
 	UpdateUser(request *domain.UpdateUserRequest) (*domain.Response, error)
-	DeleteUser(id string) (*domain.Response, error)
-	GetUsers() (*domain.Response, error)
+	PatchUser(request *domain.PatchUserRequest) (*domain.Response, error)
+	DeleteUser(request *domain.DeleteUserRequest) (*domain.Response, error)
+	RestoreUser(request *domain.RestoreUserRequest) (*domain.Response, error)
+	GetUsers(request *domain.GetUsersRequest) (*domain.Response, error)
 	GetUser(id string) (*domain.Response, error)
 }
 
 type UserRepository interface {
 	CreateUser(user *domain.User) error
 	UpdateUser(user *domain.User) error
-	DeleteUser(id string) error
-	GetAllUsers() ([]*domain.User, error)
+	PatchUser(id string, version int64, patch *domain.UserPatch) error
+	DeleteUser(id string, version int64, deletedAt time.Time) error
+	RestoreUser(id string, restoredAt time.Time) error
+	PurgeUsers(before time.Time) (int64, error)
+	GetDeletedUserByID(id string) (*domain.User, error)
+	GetUsers(filter *domain.UserFilter) ([]*domain.User, int64, error)
+	GetUsersAfter(filter *domain.UserFilter) ([]*domain.User, error)
+	SearchUsers(query *domain.UserSearchQuery) (*domain.UserSearchResult, error)
 	GetUserByID(id string) (*domain.User, error)
+	GetUsersByIDs(ids []string) ([]*domain.User, error)
 	GetUserByEmail(email string) (*domain.User, error)
 	UserIsExist(email string) (bool, error)
+	// GetExistingEmails returns the emails among the given ones a live user has
+	GetExistingEmails(emails []string) ([]string, error)
 }
@@ -0,0 +1,28 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type UserAttributeService interface {
+	CreateUserAttribute(request *domain.CreateUserAttributeRequest) (*domain.Response, error)
+	UpdateUserAttribute(request *domain.UpdateUserAttributeRequest) (*domain.Response, error)
+	DeleteUserAttribute(name string) (*domain.Response, error)
+	GetUserAttributes() (*domain.Response, error)
+	GetUserAttribute(name string) (*domain.Response, error)
+	// ValidateAttributes checks the attributes of a user against their definitions
+	ValidateAttributes(attributes map[string]interface{}) error
+	// ParseAttributeFilters reads name:value filters on defined attributes
+	ParseAttributeFilters(filters []string) ([]*domain.AttributeFilter, error)
+	// ClaimAttributes returns the attributes to put in the access tokens of a user
+	ClaimAttributes(attributes map[string]interface{}) (map[string]interface{}, error)
+}
+
+type UserAttributeRepository interface {
+	CreateUserAttribute(attribute *domain.UserAttribute) error
+	UpdateUserAttribute(attribute *domain.UserAttribute) error
+	// DeleteUserAttribute removes the definition along with the values users hold
+	DeleteUserAttribute(name string) error
+	GetUserAttributes() ([]*domain.UserAttribute, error)
+	GetUserAttributeByName(name string) (*domain.UserAttribute, error)
+	// CountUsersWithoutAttribute counts the live users holding no value of the attribute
+	CountUsersWithoutAttribute(name string) (int64, error)
+}
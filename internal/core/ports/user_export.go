@@ -0,0 +1,15 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type UserExportService interface {
+	// ExportUsers passes the users to write one at a time, an error returned
+	// by write stops the export
+	ExportUsers(request *domain.ExportUsersRequest, write func(user *domain.UserExport) error) error
+}
+
+type UserExportRepository interface {
+	// ExportUsers reads the users through a cursor and passes them to write as
+	// they are fetched, so memory stays constant whatever the number of users
+	ExportUsers(query *domain.UserExportQuery, write func(user *domain.UserExport) error) error
+}
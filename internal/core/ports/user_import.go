@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"time"
+	"user-svc/internal/core/domain"
+)
+
+type UserImportService interface {
+	ImportUsers(request *domain.ImportUsersRequest) (*domain.Response, error)
+	GetUserImport(id string) (*domain.Response, error)
+	FailStaleUserImports() error
+}
+
+type UserImportRepository interface {
+	CreateUserImport(userImport *domain.UserImport) error
+	UpdateUserImport(userImport *domain.UserImport) error
+	GetUserImportByID(id string) (*domain.UserImport, error)
+	// FailStaleUserImports marks the running imports last updated before the
+	// given time as failed
+	FailStaleUserImports(updatedBefore time.Time, finishedAt time.Time) (int64, error)
+	// ImportUsers writes a batch of users and their role grants in a single
+	// transaction
+	ImportUsers(users []*domain.User, grants []*domain.UserRoleGrant) error
+}
@@ -1,15 +1,30 @@
 package ports
 
-import "user-svc/internal/core/domain"
+import (
+	"time"
+	"user-svc/internal/core/domain"
+)
 
 type UserRoleService interface {
 	GetUserRoles(request *domain.GetUserRolesRequest) (*domain.Response, error)
 	AssignRolesToUser(request *domain.AssignRolesToUserRequest) (*domain.Response, error)
 	RemoveRolesFromUser(request *domain.RemoveRolesFromUserRequest) (*domain.Response, error)
+	GetUserPermissions(request *domain.GetUserPermissionsRequest) (*domain.Response, error)
+	GetRoleUsers(request *domain.GetRoleUsersRequest) (*domain.Response, error)
+	GetDirectUserRoles(request *domain.GetUserRolesRequest) (*domain.Response, error)
+	GetDirectRoleUsers(request *domain.GetRoleUsersRequest) (*domain.Response, error)
 }
 
 type UserRoleRepository interface {
 	GetUserRoles(userID string) ([]*domain.Role, error)
-	AddUserRoles(userID string, roles []string) error
+	GetUsersRoleNames(userIDs []string) (map[string][]string, error)
+	GetDirectUserRoles(userID string) ([]*domain.Role, error)
+	AddUserRoles(userID string, roles []string, expiresAt *time.Time) error
 	RemoveUserRoles(userID string, roles []string) error
+	RemoveExpiredUserRoles(now time.Time) ([]string, error)
+	GetRoleHolderIDs(roleID string) ([]string, error)
+	GetPermissionHolders(permissions []string) ([]*domain.PermissionHolder, error)
+	GetUserPermissions(userID string) ([]*domain.EffectivePermission, error)
+	GetRoleUsers(roleID string, limit int, offset int) ([]*domain.User, int64, error)
+	GetDirectRoleUsers(roleID string, limit int, offset int) ([]*domain.User, int64, error)
 }
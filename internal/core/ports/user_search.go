@@ -0,0 +1,23 @@
+package ports
+
+import "user-svc/internal/core/domain"
+
+type UserSearchService interface {
+	SearchUsers(request *domain.SearchUsersRequest) (*domain.Response, error)
+	IndexUser(userID string) error
+	// IndexUsers and IndexRoleHolders refresh the roles of the documents
+	// after an assignment changed. They are best effort, a failure is logged
+	// and fixed by the next reindex
+	IndexUsers(userIDs []string)
+	IndexRoleHolders(roleID string)
+	RemoveUser(userID string) error
+	Reindex() (int, error)
+}
+
+// UserSearchRepository is the full-text index users are searched in.
+type UserSearchRepository interface {
+	ResetUserIndex() error
+	IndexUsers(users []*domain.UserDocument) error
+	DeleteUser(id string) error
+	SearchUsers(query *domain.UserSearchQuery) (*domain.UserSearchResult, error)
+}
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	appError "user-svc/internal/shared/error"
+)
+
+type AccessImpactService struct {
+	userRoleRepository              ports.UserRoleRepository
+	roleRepository                  ports.RoleRepository
+	permissionRepository            ports.PermissionRepository
+	rolePermissionRepository        ports.RolePermissionRepository
+	roleConstraintRepository        ports.RoleConstraintRepository
+	permissionImplicationRepository ports.PermissionImplicationRepository
+	routes                          *domain.RouteIndex
+}
+
+func NewAccessImpactService(userRoleRepository ports.UserRoleRepository, roleRepository ports.RoleRepository, permissionRepository ports.PermissionRepository, rolePermissionRepository ports.RolePermissionRepository, roleConstraintRepository ports.RoleConstraintRepository, permissionImplicationRepository ports.PermissionImplicationRepository, routes *domain.RouteIndex) *AccessImpactService {
+	return &AccessImpactService{
+		userRoleRepository:              userRoleRepository,
+		roleRepository:                  roleRepository,
+		permissionRepository:            permissionRepository,
+		rolePermissionRepository:        rolePermissionRepository,
+		roleConstraintRepository:        roleConstraintRepository,
+		permissionImplicationRepository: permissionImplicationRepository,
+		routes:                          routes,
+	}
+}
+
+// accessSimulation holds what a change needs to be replayed against the
+// effective permissions of a single user.
+type accessSimulation struct {
+	change          *domain.AccessChange
+	holds           map[string]bool
+	active          map[string]bool
+	names           map[string]string
+	renamed         map[string]string
+	rolePermissions map[string][]*domain.Permission
+}
+
+// Simulate works out the effective permissions of every user the change
+// reaches, before and after it, without applying anything. Implied permissions
+// are expanded on both sides. Group changes are not simulated.
+func (s *AccessImpactService) Simulate(change *domain.AccessChange) (*domain.AccessImpact, error) {
+	implications, err := s.permissionImplicationRepository.GetAllPermissionImplications()
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	sim := &accessSimulation{
+		change:          change,
+		holds:           make(map[string]bool),
+		active:          make(map[string]bool),
+		names:           make(map[string]string),
+		renamed:         make(map[string]string),
+		rolePermissions: make(map[string][]*domain.Permission),
+	}
+
+	users, err := s.affectedUsers(sim)
+	if err != nil {
+		return nil, err
+	}
+
+	impact := &domain.AccessImpact{DryRun: true, Users: make([]*domain.UserAccessDelta, 0)}
+	for _, userID := range users {
+		delta, err := s.userDelta(sim, userID, implications)
+		if err != nil {
+			return nil, err
+		}
+		if delta != nil {
+			impact.Users = append(impact.Users, delta)
+		}
+	}
+
+	return impact, nil
+}
+
+// affectedUsers loads what the simulation needs about the changed roles and
+// permissions and returns the sorted users they reach.
+func (s *AccessImpactService) affectedUsers(sim *accessSimulation) ([]string, error) {
+	change := sim.change
+	users := append([]string{}, change.UserIds...)
+	for _, grant := range change.RevokedUserRoles {
+		users = append(users, grant.UserId)
+	}
+	for _, grant := range change.GrantedUserRoles {
+		users = append(users, grant.UserId)
+	}
+
+	roles := append([]string{}, change.RoleIds...)
+	roles = append(roles, change.ActivatedRoleIds...)
+	granting := append([]string{}, change.ActivatedRoleIds...)
+	for _, grant := range change.RevokedRolePermissions {
+		roles = append(roles, grant.RoleId)
+	}
+	for _, grant := range change.GrantedRolePermissions {
+		roles = append(roles, grant.RoleId)
+	}
+	for _, grant := range change.GrantedUserRoles {
+		granting = append(granting, grant.RoleId)
+	}
+
+	grants, err := s.roleConstraintRepository.GetRoleGrants(uniqueStrings(roles))
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	for _, grant := range grants {
+		sim.holds[grant.UserId+"/"+grant.RoleId] = true
+		users = append(users, grant.UserId)
+	}
+
+	for _, roleID := range uniqueStrings(append(roles, granting...)) {
+		role, err := s.roleRepository.GetRoleByID(roleID)
+		if err != nil && role == nil {
+			return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("role with id %s not exist", roleID)}
+		}
+		sim.active[role.Id] = role.Active
+	}
+
+	for _, roleID := range uniqueStrings(granting) {
+		permissions, err := s.rolePermissionRepository.GetRolePermissions(roleID)
+		if err != nil {
+			return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		}
+		sim.rolePermissions[roleID] = permissions
+	}
+
+	permissions := append([]string{}, change.PermissionIds...)
+	for _, permission := range change.RenamedPermissions {
+		sim.renamed[permission.Id] = permission.Name
+		permissions = append(permissions, permission.Id)
+	}
+	for _, grant := range change.GrantedRolePermissions {
+		permissions = append(permissions, grant.PermissionId)
+	}
+	for _, permissionID := range uniqueStrings(permissions) {
+		permission, err := s.permissionRepository.GetPermissionByID(permissionID)
+		if err != nil && permission == nil {
+			return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("permission with id %s not exist", permissionID)}
+		}
+		sim.names[permission.Id] = permission.Name
+	}
+
+	// holders of removed or renamed permissions are found by name
+	held := make([]string, 0)
+	for _, permissionID := range change.PermissionIds {
+		held = append(held, sim.names[permissionID])
+	}
+	for _, permission := range change.RenamedPermissions {
+		held = append(held, sim.names[permission.Id])
+	}
+	if len(held) > 0 {
+		holders, err := s.userRoleRepository.GetPermissionHolders(held)
+		if err != nil {
+			return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		}
+		for _, holder := range holders {
+			users = append(users, holder.UserId)
+		}
+	}
+
+	users = uniqueStrings(users)
+	sort.Strings(users)
+	return users, nil
+}
+
+func (s *AccessImpactService) userDelta(sim *accessSimulation, userID string, implications []*domain.PermissionImplication) (*domain.UserAccessDelta, error) {
+	effective, err := s.userRoleRepository.GetUserPermissions(userID)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	before := make([]string, 0, len(effective))
+	after := make([]string, 0, len(effective))
+	for _, permission := range effective {
+		before = append(before, permission.Name)
+		for _, grant := range permission.GrantedBy {
+			holder := &domain.PermissionHolder{UserId: userID, RoleId: grant.RoleId, GroupId: grant.GroupId, PermissionId: permission.Id, PermissionName: permission.Name}
+			if !isRevoked(sim.change, holder) {
+				after = append(after, sim.name(permission.Id, permission.Name))
+				break
+			}
+		}
+	}
+
+	for _, roleID := range sim.grantedRoles(userID) {
+		for _, permission := range sim.rolePermissions[roleID] {
+			holder := &domain.PermissionHolder{UserId: userID, RoleId: roleID, PermissionId: permission.Id, PermissionName: permission.Name}
+			if !isRevoked(sim.change, holder) {
+				after = append(after, sim.name(permission.Id, permission.Name))
+			}
+		}
+	}
+	for _, grant := range sim.change.GrantedRolePermissions {
+		if sim.holdsAfter(userID, grant.RoleId) {
+			after = append(after, sim.name(grant.PermissionId, sim.names[grant.PermissionId]))
+		}
+	}
+
+	before = expandPermissionNames(implications, before)
+	after = expandPermissionNames(implications, after)
+	lost := subtractStrings(before, after)
+	gained := subtractStrings(after, before)
+	if len(lost) == 0 && len(gained) == 0 {
+		return nil, nil
+	}
+
+	return &domain.UserAccessDelta{
+		UserId:            userID,
+		LostPermissions:   lost,
+		GainedPermissions: gained,
+		LostRoutes:        s.routes.Routes(lost),
+		GainedRoutes:      s.routes.Routes(gained),
+	}, nil
+}
+
+func (sim *accessSimulation) name(permissionID, name string) string {
+	if renamed, ok := sim.renamed[permissionID]; ok {
+		return renamed
+	}
+	return name
+}
+
+// grantedRoles returns the roles that start granting their permissions to the
+// user with the change.
+func (sim *accessSimulation) grantedRoles(userID string) []string {
+	roles := make([]string, 0)
+	for _, roleID := range sim.change.ActivatedRoleIds {
+		if sim.holds[userID+"/"+roleID] {
+			roles = append(roles, roleID)
+		}
+	}
+	for _, grant := range sim.change.GrantedUserRoles {
+		if grant.UserId == userID && sim.active[grant.RoleId] {
+			roles = append(roles, grant.RoleId)
+		}
+	}
+	return roles
+}
+
+func (sim *accessSimulation) holdsAfter(userID, roleID string) bool {
+	if contains(sim.change.RoleIds, roleID) {
+		return false
+	}
+	if sim.holds[userID+"/"+roleID] && (sim.active[roleID] || contains(sim.change.ActivatedRoleIds, roleID)) {
+		return true
+	}
+	return contains(sim.grantedRoles(userID), roleID)
+}
+
+// subtractStrings returns the sorted values of a that are not in b.
+func subtractStrings(a, b []string) []string {
+	result := make([]string, 0)
+	for _, value := range uniqueStrings(a) {
+		if !contains(b, value) {
+			result = append(result, value)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// dryRun simulates the change instead of applying it. A lock-out the change
+// would cause is reported along with the impact rather than refused, nil
+// safeguardService skips the check for changes that revoke nothing.
+func dryRun(accessImpactService ports.AccessImpactService, safeguardService ports.SafeguardService, change *domain.AccessChange) (*domain.Response, error) {
+	impact, err := accessImpactService.Simulate(change)
+	if err != nil {
+		return nil, err
+	}
+
+	if safeguardService != nil {
+		if err := safeguardService.CheckLockout(change); err != nil {
+			appErr, ok := err.(*appError.AppError)
+			if !ok || appErr.Code != http.StatusConflict {
+				return nil, err
+			}
+			impact.Lockout = appErr.Message
+		}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    impact,
+	}, nil
+}
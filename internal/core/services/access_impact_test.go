@@ -0,0 +1,127 @@
+package services
+
+import (
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAccessImpactService_Simulate(t *testing.T) {
+	routes := domain.NewRouteIndex()
+	routes.Add(domain.PermissionUpdateUser, "PUT /api/v1/users/:id")
+	routes.Add(domain.PermissionViewUser, "GET /api/v1/users/:id")
+	routes.Add(domain.PermissionDeleteUser, "DELETE /api/v1/users/:id")
+
+	implications := []*domain.PermissionImplication{
+		{PermissionId: "p-update", PermissionName: "Update-User", ImpliedPermissionId: "p-view", ImpliedPermissionName: "View-User"},
+	}
+	permissions := map[string]*domain.Permission{
+		"p-update": {Id: "p-update", Name: "Update-User"},
+		"p-view":   {Id: "p-view", Name: "View-User"},
+		"p-delete": {Id: "p-delete", Name: "Delete-User"},
+	}
+	effective := map[string][]*domain.EffectivePermission{
+		"user-1": {{Id: "p-update", Name: "Update-User", GrantedBy: []*domain.PermissionGrant{{RoleId: "role-editor"}}}},
+		"user-2": {{Id: "p-update", Name: "Update-User", GrantedBy: []*domain.PermissionGrant{{RoleId: "role-editor"}, {RoleId: "role-admin"}}}},
+		"user-3": {},
+	}
+
+	tests := []struct {
+		name       string
+		change     *domain.AccessChange
+		grants     []*domain.UserRoleGrant
+		wantUsers  []string
+		wantLost   []string
+		wantGained []string
+		wantRoutes []string
+	}{
+		{
+			name:       "revoked permission only affects users without another grant",
+			change:     &domain.AccessChange{RevokedRolePermissions: []*domain.RolePermissionGrant{{RoleId: "role-editor", PermissionId: "p-update"}}},
+			grants:     []*domain.UserRoleGrant{{UserId: "user-1", RoleId: "role-editor"}, {UserId: "user-2", RoleId: "role-editor"}},
+			wantUsers:  []string{"user-1"},
+			wantLost:   []string{"Update-User", "View-User"},
+			wantGained: []string{},
+			wantRoutes: []string{"GET /api/v1/users/:id", "PUT /api/v1/users/:id"},
+		},
+		{
+			name:       "granted role adds its permissions",
+			change:     &domain.AccessChange{GrantedUserRoles: []*domain.UserRoleGrant{{UserId: "user-3", RoleId: "role-cleaner"}}},
+			wantUsers:  []string{"user-3"},
+			wantLost:   []string{},
+			wantGained: []string{"Delete-User"},
+			wantRoutes: []string{},
+		},
+		{
+			name:       "granted role permission reaches role holders",
+			change:     &domain.AccessChange{GrantedRolePermissions: []*domain.RolePermissionGrant{{RoleId: "role-editor", PermissionId: "p-delete"}}},
+			grants:     []*domain.UserRoleGrant{{UserId: "user-1", RoleId: "role-editor"}},
+			wantUsers:  []string{"user-1"},
+			wantLost:   []string{},
+			wantGained: []string{"Delete-User"},
+			wantRoutes: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRoleRepository := mockCore.UserRoleRepository{}
+			for userID, result := range effective {
+				mockUserRoleRepository.On("GetUserPermissions", userID).Return(result, nil)
+			}
+
+			mockRoleRepository := mockCore.RoleRepository{}
+			mockRoleRepository.On("GetRoleByID", mock.Anything).Return(func(id string) *domain.Role {
+				return &domain.Role{Id: id, Active: true}
+			}, nil)
+
+			mockPermissionRepository := mockCore.PermissionRepository{}
+			mockPermissionRepository.On("GetPermissionByID", mock.Anything).Return(func(id string) *domain.Permission {
+				return permissions[id]
+			}, nil)
+
+			mockRolePermissionRepository := mockCore.RolePermissionRepository{}
+			mockRolePermissionRepository.On("GetRolePermissions", "role-cleaner").Return([]*domain.Permission{permissions["p-delete"]}, nil)
+
+			mockRoleConstraintRepository := mockCore.RoleConstraintRepository{}
+			mockRoleConstraintRepository.On("GetRoleGrants", mock.Anything).Return(tt.grants, nil)
+
+			mockImplicationRepository := mockCore.PermissionImplicationRepository{}
+			mockImplicationRepository.On("GetAllPermissionImplications").Return(implications, nil)
+
+			s := NewAccessImpactService(&mockUserRoleRepository, &mockRoleRepository, &mockPermissionRepository, &mockRolePermissionRepository, &mockRoleConstraintRepository, &mockImplicationRepository, routes)
+			got, err := s.Simulate(tt.change)
+			if err != nil {
+				t.Fatalf("Simulate() error = %v", err)
+			}
+
+			if len(got.Users) != len(tt.wantUsers) {
+				t.Fatalf("Simulate() users = %d, want %v", len(got.Users), tt.wantUsers)
+			}
+			for i, userID := range tt.wantUsers {
+				delta := got.Users[i]
+				if delta.UserId != userID {
+					t.Errorf("Simulate() user = %s, want %s", delta.UserId, userID)
+				}
+				assertStrings(t, "lost permissions", delta.LostPermissions, tt.wantLost)
+				assertStrings(t, "gained permissions", delta.GainedPermissions, tt.wantGained)
+				assertStrings(t, "lost routes", delta.LostRoutes, tt.wantRoutes)
+			}
+		})
+	}
+}
+
+func assertStrings(t *testing.T, name string, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+		return
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s = %v, want %v", name, got, want)
+			return
+		}
+	}
+}
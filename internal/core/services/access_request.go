@@ -0,0 +1,248 @@
+package services
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+)
+
+type AccessRequestService struct {
+	config                  *config.Config
+	accessRequestRepository ports.AccessRequestRepository
+	userRoleRepository      ports.UserRoleRepository
+	roleService             ports.RoleService
+	roleConstraintService   ports.RoleConstraintService
+	safeguardService        ports.SafeguardService
+	adminScopeService       ports.AdminScopeService
+	userSearchService       ports.UserSearchService
+}
+
+func NewAccessRequestService(config *config.Config, accessRequestRepository ports.AccessRequestRepository, userRoleRepository ports.UserRoleRepository, roleService ports.RoleService, roleConstraintService ports.RoleConstraintService, safeguardService ports.SafeguardService, adminScopeService ports.AdminScopeService, userSearchService ports.UserSearchService) *AccessRequestService {
+	return &AccessRequestService{
+		config:                  config,
+		accessRequestRepository: accessRequestRepository,
+		userRoleRepository:      userRoleRepository,
+		roleService:             roleService,
+		roleConstraintService:   roleConstraintService,
+		safeguardService:        safeguardService,
+		adminScopeService:       adminScopeService,
+		userSearchService:       userSearchService,
+	}
+}
+
+func (s *AccessRequestService) CreateAccessRequest(request *domain.CreateAccessRequestRequest) (*domain.Response, error) {
+	result, err := s.roleService.GetRole(request.RoleId)
+	if err != nil && result == nil {
+		return nil, err
+	}
+
+	role := result.Data.(*domain.Role)
+	if !role.Active {
+		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("role %s is not active", role.Name)}
+	}
+
+	duration := request.Duration
+	maxDuration := s.config.App.AccessRequest.MaxDuration
+	if duration == nil && maxDuration > 0 {
+		duration = &maxDuration
+	}
+	if duration != nil && maxDuration > 0 && *duration > maxDuration {
+		return nil, &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("duration must not exceed %d minutes", maxDuration)}
+	}
+
+	pending, err := s.accessRequestRepository.GetAccessRequests(&domain.AccessRequestFilter{
+		Status: domain.AccessRequestPending,
+		UserId: request.UserId,
+		RoleId: request.RoleId,
+	})
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	if len(pending) > 0 {
+		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("a pending request for role %s already exist", role.Name)}
+	}
+
+	accessRequest := &domain.AccessRequest{
+		Id:            uuid.New().String(),
+		UserId:        request.UserId,
+		RoleId:        request.RoleId,
+		Justification: request.Justification,
+		Duration:      duration,
+		Status:        domain.AccessRequestPending,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+
+	if err := s.accessRequestRepository.CreateAccessRequest(accessRequest); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusCreated,
+		Message: http.StatusText(http.StatusCreated),
+		Data:    accessRequest,
+	}, nil
+}
+
+func (s *AccessRequestService) ApproveAccessRequest(request *domain.ReviewAccessRequestRequest) (*domain.Response, error) {
+	accessRequest, err := s.reviewable(request, domain.AccessRequestPending)
+	if err != nil {
+		return nil, err
+	}
+
+	// approving grants the role, so the reviewer must be allowed to grant it
+	if err := s.adminScopeService.CheckRoleScope(request.ReviewerId, []string{accessRequest.RoleId}); err != nil {
+		return nil, err
+	}
+
+	if err := s.roleConstraintService.CheckAssignment(accessRequest.UserId, []string{accessRequest.RoleId}); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if accessRequest.Duration != nil {
+		expiresAt := now.Add(time.Duration(*accessRequest.Duration) * time.Minute)
+		accessRequest.ExpiresAt = &expiresAt
+	}
+
+	return s.review(accessRequest, request, domain.AccessRequestApproved, now, s.accessRequestRepository.ApproveAccessRequest)
+}
+
+func (s *AccessRequestService) RejectAccessRequest(request *domain.ReviewAccessRequestRequest) (*domain.Response, error) {
+	accessRequest, err := s.reviewable(request, domain.AccessRequestPending)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.review(accessRequest, request, domain.AccessRequestRejected, time.Now(), s.accessRequestRepository.UpdateAccessRequest)
+}
+
+func (s *AccessRequestService) RevokeAccessRequest(request *domain.ReviewAccessRequestRequest) (*domain.Response, error) {
+	accessRequest, err := s.reviewable(request, domain.AccessRequestApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.adminScopeService.CheckRoleScope(request.ReviewerId, []string{accessRequest.RoleId}); err != nil {
+		return nil, err
+	}
+
+	change := &domain.AccessChange{
+		RevokedUserRoles: []*domain.UserRoleGrant{{UserId: accessRequest.UserId, RoleId: accessRequest.RoleId}},
+	}
+	if err := s.safeguardService.CheckLockout(change); err != nil {
+		return nil, err
+	}
+
+	return s.review(accessRequest, request, domain.AccessRequestRevoked, time.Now(), s.accessRequestRepository.RevokeAccessRequest)
+}
+
+func (s *AccessRequestService) GetAccessRequests(request *domain.GetAccessRequestsRequest) (*domain.Response, error) {
+	result, err := s.accessRequestRepository.GetAccessRequests(&domain.AccessRequestFilter{
+		Status: request.Status,
+		UserId: request.UserId,
+		RoleId: request.RoleId,
+	})
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+func (s *AccessRequestService) GetAccessRequest(id string) (*domain.Response, error) {
+	result, err := s.accessRequestRepository.GetAccessRequestByID(id)
+	if err != nil && result == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("access request with id %s not exist", id)}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+// ExpireAccessRequests drops time-limited grants that ran out and closes the
+// requests behind them, along with pending requests nobody reviewed in time.
+func (s *AccessRequestService) ExpireAccessRequests() error {
+	now := time.Now()
+	expired, err := s.userRoleRepository.RemoveExpiredUserRoles(now)
+	if err != nil {
+		return err
+	}
+	s.userSearchService.IndexUsers(expired)
+
+	var pendingBefore time.Time
+	if lifetime := s.config.App.AccessRequest.PendingLifetime; lifetime > 0 {
+		pendingBefore = now.Add(-time.Duration(lifetime) * time.Minute)
+	}
+
+	_, err = s.accessRequestRepository.ExpireAccessRequests(now, pendingBefore)
+	return err
+}
+
+func (s *AccessRequestService) reviewable(request *domain.ReviewAccessRequestRequest, status string) (*domain.AccessRequest, error) {
+	accessRequest, err := s.accessRequestRepository.GetAccessRequestByID(request.Id)
+	if err != nil && accessRequest == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("access request with id %s not exist", request.Id)}
+	}
+
+	if accessRequest.Status != status {
+		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("access request is %s", accessRequest.Status)}
+	}
+
+	if accessRequest.UserId == request.ReviewerId {
+		return nil, &appError.AppError{Code: http.StatusForbidden, Message: "access request cannot be reviewed by its requester"}
+	}
+
+	approverRoles := s.config.App.AccessRequest.ApproverRoles
+	if len(approverRoles) == 0 {
+		return accessRequest, nil
+	}
+
+	roles, err := s.userRoleRepository.GetUserRoles(request.ReviewerId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	for _, role := range roles {
+		if contains(approverRoles, role.Name) {
+			return accessRequest, nil
+		}
+	}
+
+	return nil, &appError.AppError{Code: http.StatusForbidden, Message: "user is not an approver of access requests"}
+}
+
+// review records the decision through save, which also applies it to the
+// grants of the user in the same transaction.
+func (s *AccessRequestService) review(accessRequest *domain.AccessRequest, request *domain.ReviewAccessRequestRequest, status string, now time.Time, save func(accessRequest *domain.AccessRequest) error) (*domain.Response, error) {
+	accessRequest.Status = status
+	accessRequest.ReviewerId = &request.ReviewerId
+	accessRequest.ReviewedAt = &now
+	accessRequest.UpdatedAt = now
+	if request.Note != "" {
+		accessRequest.ReviewNote = &request.Note
+	}
+
+	if err := save(accessRequest); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	if status != domain.AccessRequestRejected {
+		s.userSearchService.IndexUsers([]string{accessRequest.UserId})
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    accessRequest,
+	}, nil
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+)
+
+func TestAccessRequestService_ApproveAccessRequest(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.AccessRequest.ApproverRoles = []string{"Admin"}
+
+	duration := int64(60)
+	pendingRequest := func() *domain.AccessRequest {
+		return &domain.AccessRequest{
+			Id:       "request-1",
+			UserId:   "user-1",
+			RoleId:   "role-1",
+			Duration: &duration,
+			Status:   domain.AccessRequestPending,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		request       *domain.ReviewAccessRequestRequest
+		accessRequest *domain.AccessRequest
+		reviewerRoles []*domain.Role
+		scope         error
+		wantCode      int
+	}{
+		{
+			name:          "success - approved with time-limited grant",
+			request:       &domain.ReviewAccessRequestRequest{Id: "request-1", ReviewerId: "admin-1"},
+			accessRequest: pendingRequest(),
+			reviewerRoles: []*domain.Role{{Id: "admin", Name: "Admin", Active: true}},
+		},
+		{
+			name:          "failed - request already reviewed",
+			request:       &domain.ReviewAccessRequestRequest{Id: "request-1", ReviewerId: "admin-1"},
+			accessRequest: &domain.AccessRequest{Id: "request-1", UserId: "user-1", RoleId: "role-1", Status: domain.AccessRequestRejected},
+			reviewerRoles: []*domain.Role{{Id: "admin", Name: "Admin", Active: true}},
+			wantCode:      http.StatusConflict,
+		},
+		{
+			name:          "failed - requester approves own request",
+			request:       &domain.ReviewAccessRequestRequest{Id: "request-1", ReviewerId: "user-1"},
+			accessRequest: pendingRequest(),
+			reviewerRoles: []*domain.Role{{Id: "admin", Name: "Admin", Active: true}},
+			wantCode:      http.StatusForbidden,
+		},
+		{
+			name:          "failed - role outside the reviewer admin scope",
+			request:       &domain.ReviewAccessRequestRequest{Id: "request-1", ReviewerId: "admin-1"},
+			accessRequest: pendingRequest(),
+			reviewerRoles: []*domain.Role{{Id: "admin", Name: "Admin", Active: true}},
+			scope:         &appError.AppError{Code: http.StatusForbidden, Message: "role Super-Admin is outside the admin scope"},
+			wantCode:      http.StatusForbidden,
+		},
+		{
+			name:          "failed - reviewer is not an approver",
+			request:       &domain.ReviewAccessRequestRequest{Id: "request-1", ReviewerId: "manager-1"},
+			accessRequest: pendingRequest(),
+			reviewerRoles: []*domain.Role{{Id: "manager", Name: "Manager", Active: true}},
+			wantCode:      http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAccessRequestRepository := mockCore.AccessRequestRepository{}
+			mockAccessRequestRepository.On("GetAccessRequestByID", tt.request.Id).Return(tt.accessRequest, nil)
+			mockAccessRequestRepository.On("ApproveAccessRequest", mock.Anything).Return(nil)
+
+			mockUserRoleRepository := mockCore.UserRoleRepository{}
+			mockUserRoleRepository.On("GetUserRoles", tt.request.ReviewerId).Return(tt.reviewerRoles, nil)
+
+			mockRoleConstraintService := mockCore.RoleConstraintService{}
+			mockRoleConstraintService.On("CheckAssignment", mock.Anything, mock.Anything).Return(nil)
+
+			mockAdminScopeService := mockCore.AdminScopeService{}
+			mockAdminScopeService.On("CheckRoleScope", tt.request.ReviewerId, []string{"role-1"}).Return(tt.scope)
+
+			s := NewAccessRequestService(cfg, &mockAccessRequestRepository, &mockUserRoleRepository, &mockCore.RoleService{}, &mockRoleConstraintService, &mockCore.SafeguardService{}, &mockAdminScopeService, indexingSearchService())
+			got, err := s.ApproveAccessRequest(tt.request)
+			if tt.wantCode != 0 {
+				appErr, ok := err.(*appError.AppError)
+				if !ok || appErr.Code != tt.wantCode {
+					t.Errorf("ApproveAccessRequest() error = %v, want code %d", err, tt.wantCode)
+				}
+				mockAccessRequestRepository.AssertNotCalled(t, "ApproveAccessRequest", mock.Anything)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ApproveAccessRequest() error = %v", err)
+			}
+			approved := got.Data.(*domain.AccessRequest)
+			if approved.Status != domain.AccessRequestApproved || approved.ExpiresAt == nil {
+				t.Errorf("ApproveAccessRequest() got = %+v, want approved with expiry", approved)
+			}
+			mockAccessRequestRepository.AssertCalled(t, "ApproveAccessRequest", approved)
+		})
+	}
+}
+
+func TestAccessRequestService_RevokeAccessRequest(t *testing.T) {
+	cfg := &config.Config{}
+
+	tests := []struct {
+		name     string
+		scope    error
+		lockout  error
+		wantCode int
+	}{
+		{
+			name: "success - grant revoked",
+		},
+		{
+			name:     "failed - role outside the reviewer admin scope",
+			scope:    &appError.AppError{Code: http.StatusForbidden, Message: "role Super-Admin is outside the admin scope"},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "failed - revocation locks out",
+			lockout:  &appError.AppError{Code: http.StatusConflict, Message: "change would leave no active user holding permission Update-Role"},
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &domain.ReviewAccessRequestRequest{Id: "request-1", ReviewerId: "admin-1"}
+
+			mockAccessRequestRepository := mockCore.AccessRequestRepository{}
+			mockAccessRequestRepository.On("GetAccessRequestByID", request.Id).Return(&domain.AccessRequest{Id: "request-1", UserId: "user-1", RoleId: "role-1", Status: domain.AccessRequestApproved}, nil)
+			mockAccessRequestRepository.On("RevokeAccessRequest", mock.Anything).Return(nil)
+
+			mockSafeguardService := mockCore.SafeguardService{}
+			mockSafeguardService.On("CheckLockout", &domain.AccessChange{
+				RevokedUserRoles: []*domain.UserRoleGrant{{UserId: "user-1", RoleId: "role-1"}},
+			}).Return(tt.lockout)
+
+			mockAdminScopeService := mockCore.AdminScopeService{}
+			mockAdminScopeService.On("CheckRoleScope", "admin-1", []string{"role-1"}).Return(tt.scope)
+
+			s := NewAccessRequestService(cfg, &mockAccessRequestRepository, &mockCore.UserRoleRepository{}, &mockCore.RoleService{}, &mockCore.RoleConstraintService{}, &mockSafeguardService, &mockAdminScopeService, indexingSearchService())
+			got, err := s.RevokeAccessRequest(request)
+			if tt.wantCode != 0 {
+				appErr, ok := err.(*appError.AppError)
+				if !ok || appErr.Code != tt.wantCode {
+					t.Errorf("RevokeAccessRequest() error = %v, want code %d", err, tt.wantCode)
+				}
+				mockAccessRequestRepository.AssertNotCalled(t, "RevokeAccessRequest", mock.Anything)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("RevokeAccessRequest() error = %v", err)
+			}
+			if revoked := got.Data.(*domain.AccessRequest); revoked.Status != domain.AccessRequestRevoked {
+				t.Errorf("RevokeAccessRequest() got = %+v, want revoked", revoked)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	appError "user-svc/internal/shared/error"
+)
+
+type AccessReviewService struct {
+	accessReviewRepository ports.AccessReviewRepository
+	userService            ports.UserService
+	roleService            ports.RoleService
+	userRoleService        ports.UserRoleService
+}
+
+func NewAccessReviewService(accessReviewRepository ports.AccessReviewRepository, userService ports.UserService, roleService ports.RoleService, userRoleService ports.UserRoleService) *AccessReviewService {
+	return &AccessReviewService{
+		accessReviewRepository: accessReviewRepository,
+		userService:            userService,
+		roleService:            roleService,
+		userRoleService:        userRoleService,
+	}
+}
+
+// CreateAccessReview snapshots the direct role assignments in scope and hands
+// them out to the reviewers in turn. Nobody is assigned their own assignment.
+func (s *AccessReviewService) CreateAccessReview(request *domain.CreateAccessReviewRequest) (*domain.Response, error) {
+	roles := uniqueStrings(request.RolesId)
+	for _, roleID := range roles {
+		role, err := s.roleService.GetRole(roleID)
+		if err != nil && role == nil {
+			return nil, err
+		}
+	}
+
+	reviewers := uniqueStrings(request.ReviewersId)
+	for _, reviewerID := range reviewers {
+		reviewer, err := s.userService.GetUser(reviewerID)
+		if err != nil && reviewer == nil {
+			return nil, err
+		}
+	}
+
+	items, err := s.accessReviewRepository.GetAccessReviewAssignments(roles)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	if len(items) == 0 {
+		return nil, &appError.AppError{Code: http.StatusBadRequest, Message: "no role assignments to review in scope"}
+	}
+
+	review := &domain.AccessReview{
+		Id:        uuid.New().String(),
+		Name:      request.Name,
+		Status:    domain.AccessReviewOpen,
+		CreatedBy: request.CreatedBy,
+		DueAt:     request.DueAt,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Items:     items,
+	}
+
+	for i, item := range items {
+		reviewerID := ""
+		for j := range reviewers {
+			if candidate := reviewers[(i+j)%len(reviewers)]; candidate != item.UserId {
+				reviewerID = candidate
+				break
+			}
+		}
+		if reviewerID == "" {
+			return nil, &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("user %s cannot review their own role %s, add another reviewer", item.UserEmail, item.RoleName)}
+		}
+
+		item.Id = uuid.New().String()
+		item.ReviewId = review.Id
+		item.ReviewerId = reviewerID
+	}
+
+	if err := s.accessReviewRepository.CreateAccessReview(review); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusCreated,
+		Message: http.StatusText(http.StatusCreated),
+		Data:    review,
+	}, nil
+}
+
+func (s *AccessReviewService) GetAccessReviews() (*domain.Response, error) {
+	result, err := s.accessReviewRepository.GetAllAccessReview()
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+func (s *AccessReviewService) GetAccessReview(id string) (*domain.Response, error) {
+	result, err := s.accessReviewRepository.GetAccessReviewByID(id)
+	if err != nil && result == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("access review with id %s not exist", id)}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+// DecideAccessReviewItem records a keep or revoke decision. Only the reviewer
+// the item was assigned to can decide it, and decisions can change until the
+// campaign is closed.
+func (s *AccessReviewService) DecideAccessReviewItem(request *domain.DecideAccessReviewItemRequest) (*domain.Response, error) {
+	review, err := s.accessReviewRepository.GetAccessReviewByID(request.Id)
+	if err != nil && review == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("access review with id %s not exist", request.Id)}
+	}
+
+	if review.Status != domain.AccessReviewOpen {
+		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("access review is %s", review.Status)}
+	}
+
+	var item *domain.AccessReviewItem
+	for _, candidate := range review.Items {
+		if candidate.Id == request.ItemId {
+			item = candidate
+			break
+		}
+	}
+	if item == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("access review item with id %s not exist", request.ItemId)}
+	}
+
+	if item.ReviewerId != request.ReviewerId {
+		return nil, &appError.AppError{Code: http.StatusForbidden, Message: "access review item is assigned to another reviewer"}
+	}
+
+	now := time.Now()
+	item.Decision = &request.Decision
+	item.Comment = &request.Comment
+	item.DecidedAt = &now
+
+	if err := s.accessReviewRepository.UpdateAccessReviewItem(item); errors.Is(err, domain.ErrAccessReviewNotOpen) {
+		return nil, &appError.AppError{Code: http.StatusConflict, Message: err.Error()}
+	} else if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    item,
+	}, nil
+}
+
+// CloseAccessReview applies the revoke decisions through the user role
+// service, so admin scopes and the lock-out safeguard still hold, and closes
+// the campaign. The campaign is moved to closing first, so concurrent closes
+// and late decisions are refused, and each outcome is stored as soon as it is
+// applied. A revocation that fails is recorded on its item instead of keeping
+// the campaign open. Undecided items are kept. A campaign left in closing by
+// an interrupted close is resumed, skipping the items already processed.
+func (s *AccessReviewService) CloseAccessReview(request *domain.CloseAccessReviewRequest) (*domain.Response, error) {
+	review, err := s.accessReviewRepository.GetAccessReviewByID(request.Id)
+	if err != nil && review == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("access review with id %s not exist", request.Id)}
+	}
+
+	switch review.Status {
+	case domain.AccessReviewOpen:
+		review.Status = domain.AccessReviewClosing
+		review.ClosedBy = &request.ActorId
+		review.UpdatedAt = time.Now()
+		if err := s.accessReviewRepository.StartClosingAccessReview(review); errors.Is(err, domain.ErrAccessReviewNotOpen) {
+			return nil, &appError.AppError{Code: http.StatusConflict, Message: err.Error()}
+		} else if err != nil {
+			return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		}
+
+		// decisions may have changed between the first read and the claim
+		review, err = s.accessReviewRepository.GetAccessReviewByID(request.Id)
+		if err != nil {
+			return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		}
+	case domain.AccessReviewClosing:
+	default:
+		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("access review is %s", review.Status)}
+	}
+
+	for _, item := range review.Items {
+		if item.Decision == nil || *item.Decision != domain.ReviewDecisionRevoke {
+			continue
+		}
+		if item.RevokedAt != nil || item.RevokeError != nil {
+			continue
+		}
+
+		_, err := s.userRoleService.RemoveRolesFromUser(&domain.RemoveRolesFromUserRequest{
+			UserId:  item.UserId,
+			RolesId: []string{item.RoleId},
+			ActorId: request.ActorId,
+		})
+		if err != nil {
+			message := err.Error()
+			item.RevokeError = &message
+		} else {
+			revokedAt := time.Now()
+			item.RevokedAt = &revokedAt
+		}
+
+		if err := s.accessReviewRepository.UpdateAccessReviewItemRevocation(item); err != nil {
+			return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		}
+	}
+
+	now := time.Now()
+	review.Status = domain.AccessReviewClosed
+	review.ClosedAt = &now
+	review.UpdatedAt = now
+
+	if err := s.accessReviewRepository.CloseAccessReview(review); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    review,
+	}, nil
+}
+
+// ExportAccessReview renders the campaign and every decision as an evidence
+// file, CSV unless JSON is asked for.
+func (s *AccessReviewService) ExportAccessReview(request *domain.ExportAccessReviewRequest) (*domain.AccessReviewExport, error) {
+	review, err := s.accessReviewRepository.GetAccessReviewByID(request.Id)
+	if err != nil && review == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("access review with id %s not exist", request.Id)}
+	}
+
+	if request.Format == domain.AccessReviewExportJSON {
+		content, err := json.MarshalIndent(review, "", "  ")
+		if err != nil {
+			return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		}
+		return &domain.AccessReviewExport{
+			FileName:    fmt.Sprintf("access-review-%s.json", review.Id),
+			ContentType: "application/json",
+			Content:     content,
+		}, nil
+	}
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Write([]string{"review_id", "review_name", "review_status", "item_id", "user_id", "user_email", "role_id", "role_name",
+		"reviewer_id", "decision", "comment", "decided_at", "revoked_at", "revoke_error"})
+	for _, item := range review.Items {
+		writer.Write([]string{review.Id, review.Name, review.Status, item.Id, item.UserId, item.UserEmail, item.RoleId, item.RoleName,
+			item.ReviewerId, stringValue(item.Decision), stringValue(item.Comment), timeValue(item.DecidedAt), timeValue(item.RevokedAt), stringValue(item.RevokeError)})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.AccessReviewExport{
+		FileName:    fmt.Sprintf("access-review-%s.csv", review.Id),
+		ContentType: "text/csv",
+		Content:     buffer.Bytes(),
+	}, nil
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func timeValue(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,281 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	appError "user-svc/internal/shared/error"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAccessReviewService_CreateAccessReview(t *testing.T) {
+	tests := []struct {
+		name          string
+		reviewers     []string
+		assignments   []*domain.AccessReviewItem
+		wantReviewers []string
+		wantCode      int
+	}{
+		{
+			name:      "success - items handed out in turn, never to their holder",
+			reviewers: []string{"manager-1", "manager-2"},
+			assignments: []*domain.AccessReviewItem{
+				{UserId: "user-1", RoleId: "role-1"},
+				{UserId: "manager-2", RoleId: "role-1"},
+				{UserId: "user-2", RoleId: "role-1"},
+			},
+			wantReviewers: []string{"manager-1", "manager-1", "manager-1"},
+		},
+		{
+			name:        "failed - only reviewer holds the assignment",
+			reviewers:   []string{"manager-1"},
+			assignments: []*domain.AccessReviewItem{{UserId: "manager-1", RoleId: "role-1"}},
+			wantCode:    http.StatusBadRequest,
+		},
+		{
+			name:      "failed - nothing to review",
+			reviewers: []string{"manager-1"},
+			wantCode:  http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserService := mockCore.UserService{}
+			mockUserService.On("GetUser", mock.Anything).Return(&domain.Response{Code: http.StatusOK}, nil)
+
+			mockRepository := mockCore.AccessReviewRepository{}
+			mockRepository.On("GetAccessReviewAssignments", []string{}).Return(tt.assignments, nil)
+			mockRepository.On("CreateAccessReview", mock.Anything).Return(nil)
+
+			s := NewAccessReviewService(&mockRepository, &mockUserService, &mockCore.RoleService{}, &mockCore.UserRoleService{})
+			got, err := s.CreateAccessReview(&domain.CreateAccessReviewRequest{CreatedBy: "admin-1", Name: "Q3", ReviewersId: tt.reviewers})
+			if tt.wantCode != 0 {
+				appErr, ok := err.(*appError.AppError)
+				if !ok || appErr.Code != tt.wantCode {
+					t.Errorf("CreateAccessReview() error = %v, want code %d", err, tt.wantCode)
+				}
+				mockRepository.AssertNotCalled(t, "CreateAccessReview", mock.Anything)
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateAccessReview() error = %v", err)
+			}
+
+			review := got.Data.(*domain.AccessReview)
+			if review.Status != domain.AccessReviewOpen {
+				t.Errorf("CreateAccessReview() status = %s, want %s", review.Status, domain.AccessReviewOpen)
+			}
+			for i, item := range review.Items {
+				if item.ReviewerId != tt.wantReviewers[i] || item.ReviewId != review.Id {
+					t.Errorf("CreateAccessReview() item %d reviewer = %s, want %s", i, item.ReviewerId, tt.wantReviewers[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAccessReviewService_DecideAccessReviewItem(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		reviewerID string
+		itemID     string
+		wantCode   int
+	}{
+		{
+			name:       "success - assigned reviewer decides",
+			status:     domain.AccessReviewOpen,
+			reviewerID: "manager-1",
+			itemID:     "item-1",
+		},
+		{
+			name:       "failed - another reviewer",
+			status:     domain.AccessReviewOpen,
+			reviewerID: "manager-2",
+			itemID:     "item-1",
+			wantCode:   http.StatusForbidden,
+		},
+		{
+			name:       "failed - unknown item",
+			status:     domain.AccessReviewOpen,
+			reviewerID: "manager-1",
+			itemID:     "item-9",
+			wantCode:   http.StatusNotFound,
+		},
+		{
+			name:       "failed - campaign closed",
+			status:     domain.AccessReviewClosed,
+			reviewerID: "manager-1",
+			itemID:     "item-1",
+			wantCode:   http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			review := &domain.AccessReview{Id: "review-1", Status: tt.status, Items: []*domain.AccessReviewItem{
+				{Id: "item-1", ReviewId: "review-1", UserId: "user-1", RoleId: "role-1", ReviewerId: "manager-1"},
+			}}
+
+			mockRepository := mockCore.AccessReviewRepository{}
+			mockRepository.On("GetAccessReviewByID", "review-1").Return(review, nil)
+			mockRepository.On("UpdateAccessReviewItem", mock.Anything).Return(nil)
+
+			s := NewAccessReviewService(&mockRepository, &mockCore.UserService{}, &mockCore.RoleService{}, &mockCore.UserRoleService{})
+			_, err := s.DecideAccessReviewItem(&domain.DecideAccessReviewItemRequest{
+				ReviewerId: tt.reviewerID, Id: "review-1", ItemId: tt.itemID, Decision: domain.ReviewDecisionRevoke,
+			})
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("DecideAccessReviewItem() error = %v", err)
+				}
+				if review.Items[0].Decision == nil || *review.Items[0].Decision != domain.ReviewDecisionRevoke {
+					t.Errorf("DecideAccessReviewItem() decision not recorded")
+				}
+				return
+			}
+
+			appErr, ok := err.(*appError.AppError)
+			if !ok || appErr.Code != tt.wantCode {
+				t.Errorf("DecideAccessReviewItem() error = %v, want code %d", err, tt.wantCode)
+			}
+			mockRepository.AssertNotCalled(t, "UpdateAccessReviewItem", mock.Anything)
+		})
+	}
+}
+
+func TestAccessReviewService_CloseAccessReview(t *testing.T) {
+	keep, revoke := domain.ReviewDecisionKeep, domain.ReviewDecisionRevoke
+	review := &domain.AccessReview{Id: "review-1", Status: domain.AccessReviewOpen, Items: []*domain.AccessReviewItem{
+		{Id: "item-1", UserId: "user-1", RoleId: "role-1", Decision: &keep},
+		{Id: "item-2", UserId: "user-2", RoleId: "role-1", Decision: &revoke},
+		{Id: "item-3", UserId: "user-3", RoleId: "role-admin", Decision: &revoke},
+		{Id: "item-4", UserId: "user-4", RoleId: "role-1"},
+	}}
+
+	mockRepository := mockCore.AccessReviewRepository{}
+	mockRepository.On("GetAccessReviewByID", "review-1").Return(review, nil)
+	mockRepository.On("StartClosingAccessReview", review).Return(nil)
+	mockRepository.On("UpdateAccessReviewItemRevocation", mock.Anything).Return(nil)
+	mockRepository.On("CloseAccessReview", mock.Anything).Return(nil)
+
+	mockUserRoleService := mockCore.UserRoleService{}
+	mockUserRoleService.On("RemoveRolesFromUser", &domain.RemoveRolesFromUserRequest{UserId: "user-2", RolesId: []string{"role-1"}, ActorId: "admin-1"}).
+		Return(&domain.Response{Code: http.StatusOK}, nil)
+	mockUserRoleService.On("RemoveRolesFromUser", &domain.RemoveRolesFromUserRequest{UserId: "user-3", RolesId: []string{"role-admin"}, ActorId: "admin-1"}).
+		Return(nil, errors.New("change would leave no active user holding permission Delete-User"))
+
+	s := NewAccessReviewService(&mockRepository, &mockCore.UserService{}, &mockCore.RoleService{}, &mockUserRoleService)
+	_, err := s.CloseAccessReview(&domain.CloseAccessReviewRequest{ActorId: "admin-1", Id: "review-1"})
+	if err != nil {
+		t.Fatalf("CloseAccessReview() error = %v", err)
+	}
+
+	mockUserRoleService.AssertNumberOfCalls(t, "RemoveRolesFromUser", 2)
+	if review.Status != domain.AccessReviewClosed || review.ClosedAt == nil {
+		t.Errorf("CloseAccessReview() status = %s, want %s", review.Status, domain.AccessReviewClosed)
+	}
+	if review.Items[1].RevokedAt == nil || review.Items[1].RevokeError != nil {
+		t.Errorf("CloseAccessReview() revoked item not marked revoked")
+	}
+	if review.Items[2].RevokedAt != nil || review.Items[2].RevokeError == nil {
+		t.Errorf("CloseAccessReview() failed revocation not recorded")
+	}
+	if review.Items[0].RevokedAt != nil || review.Items[3].RevokedAt != nil {
+		t.Errorf("CloseAccessReview() kept items must not be revoked")
+	}
+	mockRepository.AssertCalled(t, "UpdateAccessReviewItemRevocation", review.Items[1])
+	mockRepository.AssertCalled(t, "UpdateAccessReviewItemRevocation", review.Items[2])
+	mockRepository.AssertNumberOfCalls(t, "UpdateAccessReviewItemRevocation", 2)
+}
+
+func TestAccessReviewService_CloseAccessReview_Concurrent(t *testing.T) {
+	revoke := domain.ReviewDecisionRevoke
+	review := &domain.AccessReview{Id: "review-1", Status: domain.AccessReviewOpen, Items: []*domain.AccessReviewItem{
+		{Id: "item-1", UserId: "user-1", RoleId: "role-1", Decision: &revoke},
+	}}
+
+	mockRepository := mockCore.AccessReviewRepository{}
+	mockRepository.On("GetAccessReviewByID", "review-1").Return(review, nil)
+	mockRepository.On("StartClosingAccessReview", review).Return(domain.ErrAccessReviewNotOpen)
+
+	mockUserRoleService := mockCore.UserRoleService{}
+
+	s := NewAccessReviewService(&mockRepository, &mockCore.UserService{}, &mockCore.RoleService{}, &mockUserRoleService)
+	_, err := s.CloseAccessReview(&domain.CloseAccessReviewRequest{ActorId: "admin-1", Id: "review-1"})
+	appErr, ok := err.(*appError.AppError)
+	if !ok || appErr.Code != http.StatusConflict {
+		t.Fatalf("CloseAccessReview() error = %v, want code %d", err, http.StatusConflict)
+	}
+
+	mockUserRoleService.AssertNotCalled(t, "RemoveRolesFromUser", mock.Anything)
+	mockRepository.AssertNotCalled(t, "CloseAccessReview", mock.Anything)
+}
+
+func TestAccessReviewService_CloseAccessReview_RereadsAfterClaim(t *testing.T) {
+	keep, revoke := domain.ReviewDecisionKeep, domain.ReviewDecisionRevoke
+	read := &domain.AccessReview{Id: "review-1", Status: domain.AccessReviewOpen, Items: []*domain.AccessReviewItem{
+		{Id: "item-1", UserId: "user-1", RoleId: "role-1", Decision: &keep},
+	}}
+	// the decision changed to revoke before the campaign was claimed
+	claimed := &domain.AccessReview{Id: "review-1", Status: domain.AccessReviewClosing, Items: []*domain.AccessReviewItem{
+		{Id: "item-1", UserId: "user-1", RoleId: "role-1", Decision: &revoke},
+	}}
+
+	mockRepository := mockCore.AccessReviewRepository{}
+	mockRepository.On("GetAccessReviewByID", "review-1").Return(read, nil).Once()
+	mockRepository.On("GetAccessReviewByID", "review-1").Return(claimed, nil).Once()
+	mockRepository.On("StartClosingAccessReview", read).Return(nil)
+	mockRepository.On("UpdateAccessReviewItemRevocation", mock.Anything).Return(nil)
+	mockRepository.On("CloseAccessReview", claimed).Return(nil)
+
+	mockUserRoleService := mockCore.UserRoleService{}
+	mockUserRoleService.On("RemoveRolesFromUser", mock.Anything).Return(&domain.Response{Code: http.StatusOK}, nil)
+
+	s := NewAccessReviewService(&mockRepository, &mockCore.UserService{}, &mockCore.RoleService{}, &mockUserRoleService)
+	_, err := s.CloseAccessReview(&domain.CloseAccessReviewRequest{ActorId: "admin-1", Id: "review-1"})
+	if err != nil {
+		t.Fatalf("CloseAccessReview() error = %v", err)
+	}
+
+	mockUserRoleService.AssertNumberOfCalls(t, "RemoveRolesFromUser", 1)
+	if claimed.Items[0].RevokedAt == nil {
+		t.Errorf("CloseAccessReview() decision made before the claim not applied")
+	}
+}
+
+func TestAccessReviewService_CloseAccessReview_Resume(t *testing.T) {
+	revoke := domain.ReviewDecisionRevoke
+	revokedAt, message := time.Now(), "revocation failed"
+	review := &domain.AccessReview{Id: "review-1", Status: domain.AccessReviewClosing, Items: []*domain.AccessReviewItem{
+		{Id: "item-1", UserId: "user-1", RoleId: "role-1", Decision: &revoke, RevokedAt: &revokedAt},
+		{Id: "item-2", UserId: "user-2", RoleId: "role-1", Decision: &revoke, RevokeError: &message},
+		{Id: "item-3", UserId: "user-3", RoleId: "role-1", Decision: &revoke},
+	}}
+
+	mockRepository := mockCore.AccessReviewRepository{}
+	mockRepository.On("GetAccessReviewByID", "review-1").Return(review, nil)
+	mockRepository.On("UpdateAccessReviewItemRevocation", mock.Anything).Return(nil)
+	mockRepository.On("CloseAccessReview", review).Return(nil)
+
+	mockUserRoleService := mockCore.UserRoleService{}
+	mockUserRoleService.On("RemoveRolesFromUser", &domain.RemoveRolesFromUserRequest{UserId: "user-3", RolesId: []string{"role-1"}, ActorId: "admin-1"}).
+		Return(&domain.Response{Code: http.StatusOK}, nil)
+
+	s := NewAccessReviewService(&mockRepository, &mockCore.UserService{}, &mockCore.RoleService{}, &mockUserRoleService)
+	_, err := s.CloseAccessReview(&domain.CloseAccessReviewRequest{ActorId: "admin-1", Id: "review-1"})
+	if err != nil {
+		t.Fatalf("CloseAccessReview() error = %v", err)
+	}
+
+	mockRepository.AssertNotCalled(t, "StartClosingAccessReview", mock.Anything)
+	mockUserRoleService.AssertNumberOfCalls(t, "RemoveRolesFromUser", 1)
+	mockRepository.AssertCalled(t, "UpdateAccessReviewItemRevocation", review.Items[2])
+	mockRepository.AssertNumberOfCalls(t, "UpdateAccessReviewItemRevocation", 1)
+	if review.Status != domain.AccessReviewClosed {
+		t.Errorf("CloseAccessReview() status = %s, want %s", review.Status, domain.AccessReviewClosed)
+	}
+}
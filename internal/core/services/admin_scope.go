@@ -0,0 +1,145 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+)
+
+type AdminScopeService struct {
+	config               *config.Config
+	adminScopeRepository ports.AdminScopeRepository
+	userRoleRepository   ports.UserRoleRepository
+	roleService          ports.RoleService
+	permissionService    ports.PermissionService
+}
+
+func NewAdminScopeService(config *config.Config, adminScopeRepository ports.AdminScopeRepository, userRoleRepository ports.UserRoleRepository, roleService ports.RoleService, permissionService ports.PermissionService) *AdminScopeService {
+	return &AdminScopeService{
+		config:               config,
+		adminScopeRepository: adminScopeRepository,
+		userRoleRepository:   userRoleRepository,
+		roleService:          roleService,
+		permissionService:    permissionService,
+	}
+}
+
+func (s *AdminScopeService) GetAdminScope(roleID string) (*domain.Response, error) {
+	role, err := s.roleService.GetRole(roleID)
+	if err != nil && role == nil {
+		return nil, err
+	}
+
+	result, err := s.adminScopeRepository.GetAdminScope(roleID)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+func (s *AdminScopeService) UpdateAdminScope(request *domain.UpdateAdminScopeRequest) (*domain.Response, error) {
+	role, err := s.roleService.GetRole(request.RoleId)
+	if err != nil && role == nil {
+		return nil, err
+	}
+
+	scope := &domain.AdminScope{
+		RoleId:        request.RoleId,
+		RolesId:       uniqueStrings(request.RolesId),
+		PermissionsId: uniqueStrings(request.PermissionsId),
+	}
+
+	for _, roleID := range scope.RolesId {
+		role, err := s.roleService.GetRole(roleID)
+		if err != nil && role == nil {
+			return nil, err
+		}
+	}
+
+	for _, permissionID := range scope.PermissionsId {
+		permission, err := s.permissionService.GetPermission(permissionID)
+		if err != nil && permission == nil {
+			return nil, err
+		}
+	}
+
+	if err := s.adminScopeRepository.UpdateAdminScope(scope); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    scope,
+	}, nil
+}
+
+// CheckRoleScope refuses to let the acting user grant or revoke a role outside
+// the admin scopes of the roles they hold.
+func (s *AdminScopeService) CheckRoleScope(actorID string, roles []string) error {
+	allowed, err := s.scoped(actorID, s.adminScopeRepository.GetScopedRoles)
+	if err != nil || allowed == nil {
+		return err
+	}
+
+	for _, roleID := range roles {
+		if !contains(allowed, roleID) {
+			return &appError.AppError{Code: http.StatusForbidden, Message: fmt.Sprintf("role with id %s is outside the admin scope of the user", roleID)}
+		}
+	}
+
+	return nil
+}
+
+// CheckPermissionScope refuses to let the acting user attach or detach a
+// permission outside the admin scopes of the roles they hold.
+func (s *AdminScopeService) CheckPermissionScope(actorID string, permissions []string) error {
+	allowed, err := s.scoped(actorID, s.adminScopeRepository.GetScopedPermissions)
+	if err != nil || allowed == nil {
+		return err
+	}
+
+	for _, permissionID := range permissions {
+		if !contains(allowed, permissionID) {
+			return &appError.AppError{Code: http.StatusForbidden, Message: fmt.Sprintf("permission with id %s is outside the admin scope of the user", permissionID)}
+		}
+	}
+
+	return nil
+}
+
+// scoped returns what the acting user may administer according to lookup, or
+// nil when one of their roles is unrestricted.
+func (s *AdminScopeService) scoped(actorID string, lookup func(adminRoles []string) ([]string, error)) ([]string, error) {
+	if actorID == "" {
+		return nil, &appError.AppError{Code: http.StatusForbidden, Message: "acting user is unknown"}
+	}
+
+	roles, err := s.userRoleRepository.GetUserRoles(actorID)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	adminRoles := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if contains(s.config.App.AdminScope.UnrestrictedRoles, role.Name) {
+			return nil, nil
+		}
+		adminRoles = append(adminRoles, role.Id)
+	}
+
+	allowed, err := lookup(adminRoles)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return allowed, nil
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+)
+
+func TestAdminScopeService_CheckRoleScope(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.AdminScope.UnrestrictedRoles = []string{"Admin"}
+
+	tests := []struct {
+		name       string
+		actorID    string
+		actorRoles []*domain.Role
+		scoped     []string
+		roles      []string
+		wantCode   int
+	}{
+		{
+			name:       "success - unrestricted role",
+			actorID:    "admin-1",
+			actorRoles: []*domain.Role{{Id: "role-admin", Name: "Admin", Active: true}},
+			roles:      []string{"role-admin"},
+		},
+		{
+			name:       "success - role within scope",
+			actorID:    "manager-1",
+			actorRoles: []*domain.Role{{Id: "role-manager", Name: "Manager", Active: true}},
+			scoped:     []string{"role-user", "role-guest"},
+			roles:      []string{"role-user"},
+		},
+		{
+			name:       "failed - role outside scope",
+			actorID:    "manager-1",
+			actorRoles: []*domain.Role{{Id: "role-manager", Name: "Manager", Active: true}},
+			scoped:     []string{"role-user"},
+			roles:      []string{"role-user", "role-admin"},
+			wantCode:   http.StatusForbidden,
+		},
+		{
+			name:     "failed - unknown acting user",
+			roles:    []string{"role-user"},
+			wantCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRoleRepository := mockCore.UserRoleRepository{}
+			mockUserRoleRepository.On("GetUserRoles", tt.actorID).Return(tt.actorRoles, nil)
+
+			mockAdminScopeRepository := mockCore.AdminScopeRepository{}
+			mockAdminScopeRepository.On("GetScopedRoles", []string{"role-manager"}).Return(tt.scoped, nil)
+
+			s := NewAdminScopeService(cfg, &mockAdminScopeRepository, &mockUserRoleRepository, &mockCore.RoleService{}, &mockCore.PermissionService{})
+			err := s.CheckRoleScope(tt.actorID, tt.roles)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("CheckRoleScope() error = %v", err)
+				}
+				return
+			}
+
+			appErr, ok := err.(*appError.AppError)
+			if !ok || appErr.Code != tt.wantCode {
+				t.Errorf("CheckRoleScope() error = %v, want code %d", err, tt.wantCode)
+			}
+		})
+	}
+}
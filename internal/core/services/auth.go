@@ -18,20 +18,22 @@ import (
 )
 
 type AuthService struct {
-	config          *config.Config
-	userRepository  ports.UserRepository
-	authRepository  ports.AuthRepository
-	userRoleService ports.UserRoleService
-	hasher          hash.Hasher
+	config               *config.Config
+	userRepository       ports.UserRepository
+	authRepository       ports.AuthRepository
+	userRoleService      ports.UserRoleService
+	userAttributeService ports.UserAttributeService
+	hasher               hash.Hasher
 }
 
-func NewAuthService(config *config.Config, userRepository ports.UserRepository, authRepository ports.AuthRepository, userRoleService ports.UserRoleService, hasher hash.Hasher) *AuthService {
+func NewAuthService(config *config.Config, userRepository ports.UserRepository, authRepository ports.AuthRepository, userRoleService ports.UserRoleService, userAttributeService ports.UserAttributeService, hasher hash.Hasher) *AuthService {
 	return &AuthService{
-		config:          config,
-		userRepository:  userRepository,
-		authRepository:  authRepository,
-		userRoleService: userRoleService,
-		hasher:          hasher,
+		config:               config,
+		userRepository:       userRepository,
+		authRepository:       authRepository,
+		userRoleService:      userRoleService,
+		userAttributeService: userAttributeService,
+		hasher:               hasher,
 	}
 }
 
@@ -67,6 +69,11 @@ func (s *AuthService) Authenticate(request *domain.GetTokenRequest) (*domain.Res
 		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
 	}
 
+	attributeClaims, err := s.userAttributeService.ClaimAttributes(user.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
 	tokenInfo := &domain.TokenInfo{
 		UserID: user.Id,
 		Roles:  roles,
@@ -76,7 +83,7 @@ func (s *AuthService) Authenticate(request *domain.GetTokenRequest) (*domain.Res
 	}
 
 	token := jwt.New(jwt.SigningMethodHS256)
-	accessUUID, generateTime, accessToken, authTokenExpiredIn, err := s.crateAccessToken(token)
+	accessUUID, generateTime, accessToken, authTokenExpiredIn, err := s.crateAccessToken(token, attributeClaims)
 	if err != nil {
 		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
 	}
@@ -138,8 +145,26 @@ func (s *AuthService) Refresh(request *domain.RefreshTokenRequest) (*domain.Resp
 		return nil, &appError.AppError{Code: http.StatusUnauthorized, Message: "invalid refresh token"}
 	}
 
+	// refresh the role snapshot so roles deactivated since login are dropped
+	roles, err := s.getUserRoles(tokenInfo.UserID)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	tokenInfo.Roles = roles
+
+	// the attribute claims are read again too, a deleted user cannot refresh
+	user, err := s.userRepository.GetUserByID(tokenInfo.UserID)
+	if err != nil && user == nil {
+		return nil, &appError.AppError{Code: http.StatusUnauthorized, Message: "invalid refresh token"}
+	}
+
+	attributeClaims, err := s.userAttributeService.ClaimAttributes(user.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
 	regenerateToken := jwt.New(jwt.SigningMethodHS256)
-	accessUUID, generateTime, accessToken, authTokenExpiredIn, err := s.crateAccessToken(regenerateToken)
+	accessUUID, generateTime, accessToken, authTokenExpiredIn, err := s.crateAccessToken(regenerateToken, attributeClaims)
 	if err != nil {
 		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
 	}
@@ -207,7 +232,9 @@ func (s *AuthService) Logout(authID string) (*domain.Response, error) {
 	}, nil
 }
 
-func (s *AuthService) crateAccessToken(token *jwt.Token) (accessUUID string, generateTime int64, tokenString string, expiredIn int64, err error) {
+// crateAccessToken puts the attributes of the user defined as claims in the
+// token, under a single claim.
+func (s *AuthService) crateAccessToken(token *jwt.Token, attributes map[string]interface{}) (accessUUID string, generateTime int64, tokenString string, expiredIn int64, err error) {
 	secretKey := s.config.App.Auth.AccessKey
 	accessUUID = uuid.New().String()
 	expiredAtTime := time.Now().Add(time.Minute * time.Duration(s.config.App.Auth.AccessLifeTime))
@@ -220,6 +247,9 @@ func (s *AuthService) crateAccessToken(token *jwt.Token) (accessUUID string, gen
 		constants.KeyAuthID:       accessUUID,
 		constants.KeyExp:          expiredAtTime.Unix(),
 	}
+	if len(attributes) > 0 {
+		token.Claims.(jwt.MapClaims)[constants.KeyAttributes] = attributes
+	}
 
 	tokenString, err = token.SignedString([]byte(secretKey))
 	return
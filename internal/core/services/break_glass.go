@@ -0,0 +1,391 @@
+package services
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+	"user-svc/internal/shared/totp"
+)
+
+// defaultBreakGlassMaxAttempts failed second factors lock activation when the
+// configured maximum is not positive.
+const defaultBreakGlassMaxAttempts = 5
+
+type BreakGlassService struct {
+	config                    *config.Config
+	breakGlassRepository      ports.BreakGlassRepository
+	breakGlassCacheRepository ports.BreakGlassCacheRepository
+	userRepository            ports.UserRepository
+	roleRepository            ports.RoleRepository
+	userRoleRepository        ports.UserRoleRepository
+	userSearchService         ports.UserSearchService
+	notifier                  ports.Notifier
+}
+
+func NewBreakGlassService(config *config.Config, breakGlassRepository ports.BreakGlassRepository, breakGlassCacheRepository ports.BreakGlassCacheRepository, userRepository ports.UserRepository, roleRepository ports.RoleRepository, userRoleRepository ports.UserRoleRepository, userSearchService ports.UserSearchService, notifier ports.Notifier) *BreakGlassService {
+	return &BreakGlassService{
+		config:                    config,
+		breakGlassRepository:      breakGlassRepository,
+		breakGlassCacheRepository: breakGlassCacheRepository,
+		userRepository:            userRepository,
+		roleRepository:            roleRepository,
+		userRoleRepository:        userRoleRepository,
+		userSearchService:         userSearchService,
+		notifier:                  notifier,
+	}
+}
+
+// EnrollBreakGlass issues a new second factor to a user, replacing the one
+// they had. Users cannot enroll themselves, or a stolen session would be
+// enough to break the glass.
+func (s *BreakGlassService) EnrollBreakGlass(request *domain.EnrollBreakGlassRequest) (*domain.Response, error) {
+	if request.ActorId == request.UserId {
+		return nil, &appError.AppError{Code: http.StatusForbidden, Message: "break-glass second factor cannot be enrolled by its own user"}
+	}
+
+	user, err := s.userRepository.GetUserByID(request.UserId)
+	if err != nil && user == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("user with id %s not exist", request.UserId)}
+	}
+
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	enrollment := &domain.BreakGlassEnrollment{
+		UserId:     user.Id,
+		Secret:     secret,
+		EnrolledBy: request.ActorId,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.breakGlassRepository.SaveBreakGlassEnrollment(enrollment); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	issuer := url.PathEscape(s.config.App.Name)
+	enrollment.URI = fmt.Sprintf("otpauth://totp/%s:%s?secret=%s&issuer=%s", issuer, url.PathEscape(user.Email), secret, url.QueryEscape(s.config.App.Name))
+
+	s.notify("break-glass second factor enrolled", map[string]string{
+		"user_id":     enrollment.UserId,
+		"enrolled_by": enrollment.EnrolledBy,
+	})
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    enrollment,
+	}, nil
+}
+
+// UnenrollBreakGlass removes the second factor of a user, they cannot break
+// the glass until enrolled again.
+func (s *BreakGlassService) UnenrollBreakGlass(request *domain.UnenrollBreakGlassRequest) (*domain.Response, error) {
+	secret, err := s.breakGlassRepository.GetBreakGlassSecret(request.UserId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	if secret == "" {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("user with id %s is not enrolled for break-glass", request.UserId)}
+	}
+
+	if err := s.breakGlassRepository.DeleteBreakGlassEnrollment(request.UserId); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	s.notify("break-glass second factor removed", map[string]string{
+		"user_id":    request.UserId,
+		"removed_by": request.ActorId,
+	})
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    nil,
+	}, nil
+}
+
+// ActivateBreakGlass grants the emergency role to the requester for the
+// configured duration once the one-time code of their own secret checks out.
+// A code is accepted once. Role constraints and admin scopes are bypassed on
+// purpose, security is notified instead. Too many failed second factors within
+// the code window lock activation until the window runs out, so the code
+// cannot be guessed.
+func (s *BreakGlassService) ActivateBreakGlass(request *domain.ActivateBreakGlassRequest) (*domain.Response, error) {
+	cfg := s.config.App.BreakGlass
+	if cfg.Role == "" {
+		return nil, &appError.AppError{Code: http.StatusServiceUnavailable, Message: "break-glass is not configured"}
+	}
+
+	failures, err := s.breakGlassCacheRepository.GetBreakGlassFailures(request.UserId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	if failures >= s.maxAttempts() {
+		return nil, &appError.AppError{Code: http.StatusTooManyRequests, Message: "too many failed second factors, break-glass activation is locked"}
+	}
+
+	secret, err := s.breakGlassRepository.GetBreakGlassSecret(request.UserId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	if secret == "" {
+		s.rejectActivation(request, "not enrolled")
+		return nil, &appError.AppError{Code: http.StatusForbidden, Message: "no break-glass second factor is enrolled for the user"}
+	}
+
+	now := time.Now()
+	counter, ok := totp.Match(secret, request.Code, now)
+	if !ok {
+		return nil, s.failActivation(request, "invalid second factor")
+	}
+
+	fresh, err := s.breakGlassCacheRepository.UseBreakGlassCode(request.UserId, counter, totp.Window)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	if !fresh {
+		return nil, s.failActivation(request, "second factor already used")
+	}
+
+	active, err := s.breakGlassCacheRepository.GetBreakGlassSession(request.UserId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	if active != "" {
+		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("break-glass session %s is already active", active)}
+	}
+
+	role, err := s.roleRepository.GetRoleByName(cfg.Role)
+	if err != nil && role == nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: fmt.Sprintf("break-glass role %s not exist", cfg.Role)}
+	}
+	if !role.Active {
+		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("role %s is not active", role.Name)}
+	}
+
+	// a role already held must not be shortened to the break-glass duration
+	held, err := s.userRoleRepository.GetUserRoles(request.UserId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	for _, heldRole := range held {
+		if heldRole.Id == role.Id {
+			return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("user already holds role %s", role.Name)}
+		}
+	}
+
+	duration := time.Duration(cfg.Duration) * time.Minute
+	session := &domain.BreakGlassSession{
+		Id:          uuid.New().String(),
+		UserId:      request.UserId,
+		RoleId:      role.Id,
+		RoleName:    role.Name,
+		Reason:      request.Reason,
+		Status:      domain.BreakGlassActive,
+		ActivatedAt: now,
+		ExpiresAt:   now.Add(duration),
+	}
+
+	if err := s.userRoleRepository.AddUserRoles(session.UserId, []string{session.RoleId}, &session.ExpiresAt); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	// the grant is only kept once the session is recorded and tagged
+	if err := s.breakGlassRepository.CreateBreakGlassSession(session); err != nil {
+		s.userRoleRepository.RemoveUserRoles(session.UserId, []string{session.RoleId})
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	if err := s.breakGlassCacheRepository.SaveBreakGlassSession(session.UserId, session.Id, duration); err != nil {
+		s.userRoleRepository.RemoveUserRoles(session.UserId, []string{session.RoleId})
+		session.Status = domain.BreakGlassEnded
+		session.EndedAt = &now
+		s.breakGlassRepository.UpdateBreakGlassSession(session)
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	s.userSearchService.IndexUsers([]string{session.UserId})
+	s.notify("break-glass activated", sessionFields(session))
+
+	return &domain.Response{
+		Code:    http.StatusCreated,
+		Message: http.StatusText(http.StatusCreated),
+		Data:    session,
+	}, nil
+}
+
+// EndBreakGlass revokes the emergency role before the session runs out.
+func (s *BreakGlassService) EndBreakGlass(request *domain.EndBreakGlassRequest) (*domain.Response, error) {
+	session, err := s.breakGlassRepository.GetBreakGlassSessionByID(request.Id)
+	if err != nil && session == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("break-glass session with id %s not exist", request.Id)}
+	}
+
+	now := time.Now()
+	if session.Status != domain.BreakGlassActive || !now.Before(session.ExpiresAt) {
+		return nil, &appError.AppError{Code: http.StatusConflict, Message: "break-glass session is not active"}
+	}
+
+	if err := s.userRoleRepository.RemoveUserRoles(session.UserId, []string{session.RoleId}); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	s.userSearchService.IndexUsers([]string{session.UserId})
+
+	session.Status = domain.BreakGlassEnded
+	session.EndedAt = &now
+	session.EndedBy = &request.ActorId
+	if err := s.end(session); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    session,
+	}, nil
+}
+
+func (s *BreakGlassService) GetBreakGlassSessions(request *domain.GetBreakGlassSessionsRequest) (*domain.Response, error) {
+	result, err := s.breakGlassRepository.GetBreakGlassSessions(request.Status)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+func (s *BreakGlassService) GetBreakGlassSession(id string) (*domain.Response, error) {
+	result, err := s.breakGlassRepository.GetBreakGlassSessionByID(id)
+	if err != nil && result == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("break-glass session with id %s not exist", id)}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+// GetActiveBreakGlassSession returns the id of the session the user is in, or
+// an empty string.
+func (s *BreakGlassService) GetActiveBreakGlassSession(userID string) (string, error) {
+	return s.breakGlassCacheRepository.GetBreakGlassSession(userID)
+}
+
+// ExpireBreakGlassSessions revokes the emergency role of sessions that ran
+// out and closes them. The grant carries its own expiry, so this only makes
+// sure it is gone before the session is reported as expired.
+func (s *BreakGlassService) ExpireBreakGlassSessions() error {
+	now := time.Now()
+	sessions, err := s.breakGlassRepository.GetExpiredBreakGlassSessions(now)
+	if err != nil || len(sessions) == 0 {
+		return err
+	}
+
+	expired, err := s.userRoleRepository.RemoveExpiredUserRoles(now)
+	if err != nil {
+		return err
+	}
+	s.userSearchService.IndexUsers(expired)
+
+	for _, session := range sessions {
+		expiredAt := session.ExpiresAt
+		session.Status = domain.BreakGlassExpired
+		session.EndedAt = &expiredAt
+		if err := s.end(session); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *BreakGlassService) end(session *domain.BreakGlassSession) error {
+	if err := s.breakGlassRepository.UpdateBreakGlassSession(session); err != nil {
+		return err
+	}
+	if err := s.breakGlassCacheRepository.DeleteBreakGlassSession(session.UserId); err != nil {
+		return err
+	}
+
+	s.notify(fmt.Sprintf("break-glass %s", session.Status), sessionFields(session))
+	return nil
+}
+
+func (s *BreakGlassService) rejectActivation(request *domain.ActivateBreakGlassRequest, cause string) {
+	s.notify("break-glass activation rejected", map[string]string{
+		"user_id": request.UserId,
+		"reason":  request.Reason,
+		"cause":   cause,
+	})
+}
+
+// failActivation counts a failed second factor. The failure that reaches the
+// maximum locks activation, security is notified of the lock once.
+func (s *BreakGlassService) failActivation(request *domain.ActivateBreakGlassRequest, cause string) error {
+	s.rejectActivation(request, cause)
+
+	failures, err := s.breakGlassCacheRepository.CountBreakGlassFailure(request.UserId, totp.Window)
+	if err != nil {
+		return &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	maxAttempts := s.maxAttempts()
+	if failures < maxAttempts {
+		return &appError.AppError{Code: http.StatusForbidden, Message: "invalid second factor"}
+	}
+	if failures == maxAttempts {
+		s.notify("break-glass activation locked", map[string]string{
+			"user_id":  request.UserId,
+			"failures": strconv.FormatInt(failures, 10),
+		})
+	}
+	return &appError.AppError{Code: http.StatusTooManyRequests, Message: "too many failed second factors, break-glass activation is locked"}
+}
+
+func (s *BreakGlassService) maxAttempts() int64 {
+	if s.config.App.BreakGlass.MaxAttempts <= 0 {
+		return defaultBreakGlassMaxAttempts
+	}
+	return s.config.App.BreakGlass.MaxAttempts
+}
+
+// notify is best effort, emergency access is never held up by the
+// notification sink. The session itself is the audit record.
+func (s *BreakGlassService) notify(subject string, fields map[string]string) {
+	_ = s.notifier.Notify(&domain.Notification{
+		Channel:   domain.NotificationChannelSecurity,
+		Subject:   subject,
+		Fields:    fields,
+		CreatedAt: time.Now(),
+	})
+}
+
+func sessionFields(session *domain.BreakGlassSession) map[string]string {
+	fields := map[string]string{
+		"break_glass_session": session.Id,
+		"user_id":             session.UserId,
+		"role":                session.RoleName,
+		"reason":              session.Reason,
+		"status":              session.Status,
+		"activated_at":        timeValue(&session.ActivatedAt),
+		"expires_at":          timeValue(&session.ExpiresAt),
+	}
+	if session.EndedAt != nil {
+		fields["ended_at"] = timeValue(session.EndedAt)
+	}
+	if session.EndedBy != nil {
+		fields["ended_by"] = *session.EndedBy
+	}
+	return fields
+}
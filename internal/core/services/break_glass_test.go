@@ -0,0 +1,219 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+	"time"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+	"user-svc/internal/shared/totp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const breakGlassSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func breakGlassConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.App.BreakGlass.Role = "Admin"
+	cfg.App.BreakGlass.Duration = 30
+	return cfg
+}
+
+func TestBreakGlassService_ActivateBreakGlass(t *testing.T) {
+	code, err := totp.Generate(breakGlassSecret, time.Now())
+	assert.NoError(t, err)
+	admin := &domain.Role{Id: "role-admin", Name: "Admin", Active: true}
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		used     bool
+		failures int64
+		active   string
+		held     []*domain.Role
+		wantCode int
+	}{
+		{
+			name:   "success - emergency role granted until the session ends",
+			secret: breakGlassSecret,
+			code:   code,
+			held:   []*domain.Role{{Id: "role-oncall", Name: "On-Call", Active: true}},
+		},
+		{
+			name:     "failed - user not enrolled",
+			code:     code,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "failed - invalid second factor",
+			secret:   breakGlassSecret,
+			code:     "000000",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "failed - code of another user's secret",
+			secret:   "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP",
+			code:     code,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "failed - code already used",
+			secret:   breakGlassSecret,
+			code:     code,
+			used:     true,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "failed - failure reaching the maximum locks activation",
+			secret:   breakGlassSecret,
+			code:     "000000",
+			failures: 4,
+			wantCode: http.StatusTooManyRequests,
+		},
+		{
+			name:     "failed - activation locked",
+			secret:   breakGlassSecret,
+			code:     code,
+			failures: 5,
+			wantCode: http.StatusTooManyRequests,
+		},
+		{
+			name:     "failed - session already active",
+			secret:   breakGlassSecret,
+			code:     code,
+			active:   "session-1",
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "failed - role already held",
+			secret:   breakGlassSecret,
+			code:     code,
+			held:     []*domain.Role{admin},
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepository := mockCore.BreakGlassRepository{}
+			mockRepository.On("CreateBreakGlassSession", mock.Anything).Return(nil)
+			mockRepository.On("GetBreakGlassSecret", "user-1").Return(tt.secret, nil)
+			mockCache := mockCore.BreakGlassCacheRepository{}
+			mockCache.On("UseBreakGlassCode", "user-1", mock.Anything, totp.Window).Return(!tt.used, nil)
+			mockCache.On("GetBreakGlassFailures", "user-1").Return(tt.failures, nil)
+			mockCache.On("CountBreakGlassFailure", "user-1", totp.Window).Return(tt.failures+1, nil)
+			mockCache.On("GetBreakGlassSession", "user-1").Return(tt.active, nil)
+			mockCache.On("SaveBreakGlassSession", "user-1", mock.Anything, 30*time.Minute).Return(nil)
+			mockRoleRepository := mockCore.RoleRepository{}
+			mockRoleRepository.On("GetRoleByName", "Admin").Return(admin, nil)
+			mockUserRoleRepository := mockCore.UserRoleRepository{}
+			mockUserRoleRepository.On("GetUserRoles", "user-1").Return(tt.held, nil)
+			mockUserRoleRepository.On("AddUserRoles", "user-1", []string{"role-admin"}, mock.Anything).Return(nil)
+			mockNotifier := mockCore.Notifier{}
+			mockNotifier.On("Notify", mock.Anything).Return(nil)
+
+			s := NewBreakGlassService(breakGlassConfig(), &mockRepository, &mockCache, &mockCore.UserRepository{}, &mockRoleRepository, &mockUserRoleRepository, indexingSearchService(), &mockNotifier)
+			got, err := s.ActivateBreakGlass(&domain.ActivateBreakGlassRequest{UserId: "user-1", Reason: "database outage INC-42", Code: tt.code})
+			if tt.wantCode != 0 {
+				appErr, ok := err.(*appError.AppError)
+				if !ok || appErr.Code != tt.wantCode {
+					t.Errorf("ActivateBreakGlass() error = %v, want code %d", err, tt.wantCode)
+				}
+				mockUserRoleRepository.AssertNotCalled(t, "AddUserRoles", mock.Anything, mock.Anything, mock.Anything)
+				locked := mock.MatchedBy(func(n *domain.Notification) bool { return n.Subject == "break-glass activation locked" })
+				if tt.wantCode == http.StatusTooManyRequests && tt.failures < 5 {
+					mockNotifier.AssertCalled(t, "Notify", locked)
+				} else {
+					mockNotifier.AssertNotCalled(t, "Notify", locked)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			session := got.Data.(*domain.BreakGlassSession)
+			assert.Equal(t, domain.BreakGlassActive, session.Status)
+			assert.Equal(t, 30*time.Minute, session.ExpiresAt.Sub(session.ActivatedAt))
+			mockCache.AssertCalled(t, "SaveBreakGlassSession", "user-1", session.Id, 30*time.Minute)
+			mockNotifier.AssertCalled(t, "Notify", mock.MatchedBy(func(n *domain.Notification) bool {
+				return n.Channel == domain.NotificationChannelSecurity && n.Fields["break_glass_session"] == session.Id
+			}))
+		})
+	}
+}
+
+func TestBreakGlassService_EnrollBreakGlass(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *domain.EnrollBreakGlassRequest
+		wantCode int
+	}{
+		{
+			name:    "success - secret issued to the user",
+			request: &domain.EnrollBreakGlassRequest{ActorId: "admin-1", UserId: "user-1"},
+		},
+		{
+			name:     "failed - user enrolls themselves",
+			request:  &domain.EnrollBreakGlassRequest{ActorId: "user-1", UserId: "user-1"},
+			wantCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepository := mockCore.BreakGlassRepository{}
+			mockRepository.On("SaveBreakGlassEnrollment", mock.Anything).Return(nil)
+			mockUserRepository := mockCore.UserRepository{}
+			mockUserRepository.On("GetUserByID", "user-1").Return(&domain.User{Id: "user-1", Email: "alice@example.com"}, nil)
+			mockNotifier := mockCore.Notifier{}
+			mockNotifier.On("Notify", mock.Anything).Return(nil)
+
+			cfg := breakGlassConfig()
+			cfg.App.Name = "user-svc"
+			s := NewBreakGlassService(cfg, &mockRepository, &mockCore.BreakGlassCacheRepository{}, &mockUserRepository, &mockCore.RoleRepository{}, &mockCore.UserRoleRepository{}, indexingSearchService(), &mockNotifier)
+			got, err := s.EnrollBreakGlass(tt.request)
+			if tt.wantCode != 0 {
+				appErr, ok := err.(*appError.AppError)
+				if !ok || appErr.Code != tt.wantCode {
+					t.Errorf("EnrollBreakGlass() error = %v, want code %d", err, tt.wantCode)
+				}
+				mockRepository.AssertNotCalled(t, "SaveBreakGlassEnrollment", mock.Anything)
+				return
+			}
+
+			assert.NoError(t, err)
+			enrollment := got.Data.(*domain.BreakGlassEnrollment)
+			assert.Equal(t, "admin-1", enrollment.EnrolledBy)
+			assert.Contains(t, enrollment.URI, "otpauth://totp/user-svc:alice@example.com?secret="+enrollment.Secret)
+
+			code, err := totp.Generate(enrollment.Secret, time.Now())
+			assert.NoError(t, err)
+			assert.True(t, totp.Validate(enrollment.Secret, code, time.Now()))
+			mockRepository.AssertCalled(t, "SaveBreakGlassEnrollment", enrollment)
+		})
+	}
+}
+
+func TestBreakGlassService_ExpireBreakGlassSessions(t *testing.T) {
+	expiresAt := time.Now().Add(-time.Minute)
+	session := &domain.BreakGlassSession{Id: "session-1", UserId: "user-1", RoleId: "role-admin", Status: domain.BreakGlassActive, ExpiresAt: expiresAt}
+
+	mockRepository := mockCore.BreakGlassRepository{}
+	mockRepository.On("GetExpiredBreakGlassSessions", mock.Anything).Return([]*domain.BreakGlassSession{session}, nil)
+	mockRepository.On("UpdateBreakGlassSession", session).Return(nil)
+	mockCache := mockCore.BreakGlassCacheRepository{}
+	mockCache.On("DeleteBreakGlassSession", "user-1").Return(nil)
+	mockUserRoleRepository := mockCore.UserRoleRepository{}
+	mockUserRoleRepository.On("RemoveExpiredUserRoles", mock.Anything).Return([]string{"user-1"}, nil)
+	mockNotifier := mockCore.Notifier{}
+	mockNotifier.On("Notify", mock.Anything).Return(nil)
+
+	s := NewBreakGlassService(breakGlassConfig(), &mockRepository, &mockCache, &mockCore.UserRepository{}, &mockCore.RoleRepository{}, &mockUserRoleRepository, indexingSearchService(), &mockNotifier)
+	assert.NoError(t, s.ExpireBreakGlassSessions())
+	assert.Equal(t, domain.BreakGlassExpired, session.Status)
+	assert.Equal(t, expiresAt, *session.EndedAt)
+	mockUserRoleRepository.AssertCalled(t, "RemoveExpiredUserRoles", mock.Anything)
+	mockNotifier.AssertNumberOfCalls(t, "Notify", 1)
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	appError "user-svc/internal/shared/error"
+)
+
+type GroupService struct {
+	groupRepository     ports.GroupRepository
+	groupUserRepository ports.GroupUserRepository
+	safeguardService    ports.SafeguardService
+	userSearchService   ports.UserSearchService
+}
+
+func NewGroupService(groupRepository ports.GroupRepository, groupUserRepository ports.GroupUserRepository, safeguardService ports.SafeguardService, userSearchService ports.UserSearchService) *GroupService {
+	return &GroupService{
+		groupRepository:     groupRepository,
+		groupUserRepository: groupUserRepository,
+		safeguardService:    safeguardService,
+		userSearchService:   userSearchService,
+	}
+}
+
+func (s *GroupService) CreateGroup(request *domain.CreateGroupRequest) (*domain.Response, error) {
+	if exist, err := s.groupRepository.GroupIsExist(request.Name); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	} else if exist {
+		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("group %s already exist", request.Name)}
+	}
+
+	group := &domain.Group{
+		Id:        uuid.New().String(),
+		Name:      request.Name,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := s.groupRepository.CreateGroup(group); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusCreated,
+		Message: http.StatusText(http.StatusCreated),
+		Data:    nil,
+	}, nil
+}
+
+func (s *GroupService) UpdateGroup(request *domain.UpdateGroupRequest) (*domain.Response, error) {
+	group, err := s.groupRepository.GetGroupByID(request.Id)
+	if err != nil && group == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("group with id %s not exist", request.Id)}
+	}
+
+	check, _ := s.groupRepository.GetGroupByName(request.Name)
+	if check != nil && check.Id != group.Id {
+		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("group with name %s already exist", request.Name)}
+	}
+
+	group.Name = request.Name
+	group.UpdatedAt = time.Now()
+
+	err = s.groupRepository.UpdateGroup(group)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    nil,
+	}, nil
+}
+
+func (s *GroupService) DeleteGroup(id string) (*domain.Response, error) {
+	group, err := s.groupRepository.GetGroupByID(id)
+	if err != nil && group == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("group with id %s not exist", id)}
+	}
+
+	if err := s.safeguardService.CheckLockout(&domain.AccessChange{GroupIds: []string{group.Id}}); err != nil {
+		return nil, err
+	}
+
+	// the memberships go with the group, so the members are read first
+	users, err := s.groupUserRepository.GetGroupUsers(group.Id)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	err = s.groupRepository.DeleteGroup(group.Id)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	s.userSearchService.IndexUsers(userIDs(users))
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    nil,
+	}, nil
+}
+
+func (s *GroupService) GetGroups() (*domain.Response, error) {
+	result, err := s.groupRepository.GetAllGroup()
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+func (s *GroupService) GetGroup(id string) (*domain.Response, error) {
+	result, err := s.groupRepository.GetGroupByID(id)
+	if err != nil && result == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("group with id %s not exist", id)}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
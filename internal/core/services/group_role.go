@@ -0,0 +1,133 @@
+package services
+
+import (
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	appError "user-svc/internal/shared/error"
+)
+
+type GroupRoleService struct {
+	groupRoleRepository   ports.GroupRoleRepository
+	groupUserRepository   ports.GroupUserRepository
+	groupService          ports.GroupService
+	roleService           ports.RoleService
+	safeguardService      ports.SafeguardService
+	roleConstraintService ports.RoleConstraintService
+	adminScopeService     ports.AdminScopeService
+	userSearchService     ports.UserSearchService
+}
+
+func NewGroupRoleService(groupRoleRepository ports.GroupRoleRepository, groupUserRepository ports.GroupUserRepository, groupService ports.GroupService, roleService ports.RoleService, safeguardService ports.SafeguardService, roleConstraintService ports.RoleConstraintService, adminScopeService ports.AdminScopeService, userSearchService ports.UserSearchService) *GroupRoleService {
+	return &GroupRoleService{
+		groupRoleRepository:   groupRoleRepository,
+		groupUserRepository:   groupUserRepository,
+		groupService:          groupService,
+		roleService:           roleService,
+		safeguardService:      safeguardService,
+		roleConstraintService: roleConstraintService,
+		adminScopeService:     adminScopeService,
+		userSearchService:     userSearchService,
+	}
+}
+
+func (s *GroupRoleService) GetGroupRoles(request *domain.GetGroupRolesRequest) (*domain.Response, error) {
+	group, err := s.groupService.GetGroup(request.GroupId)
+	if err != nil && group == nil {
+		return nil, err
+	}
+
+	result, err := s.groupRoleRepository.GetGroupRoles(request.GroupId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+func (s *GroupRoleService) AssignRolesToGroup(request *domain.AssignRolesToGroupRequest) (*domain.Response, error) {
+	group, err := s.groupService.GetGroup(request.GroupId)
+	if err != nil && group == nil {
+		return nil, err
+	}
+
+	for _, roleID := range request.RolesId {
+		role, err := s.roleService.GetRole(roleID)
+		if err != nil && role == nil {
+			return nil, err
+		}
+	}
+
+	if err := s.adminScopeService.CheckRoleScope(request.ActorId, request.RolesId); err != nil {
+		return nil, err
+	}
+
+	// every member inherits the roles, so the same constraints as a direct assignment apply
+	users, err := s.groupUserRepository.GetGroupUsers(request.GroupId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	for _, user := range users {
+		if err := s.roleConstraintService.CheckAssignment(user.Id, request.RolesId); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.groupRoleRepository.AddGroupRoles(request.GroupId, request.RolesId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	s.userSearchService.IndexUsers(userIDs(users))
+	return &domain.Response{
+		Code:    http.StatusCreated,
+		Message: http.StatusText(http.StatusCreated),
+		Data:    nil,
+	}, nil
+}
+
+func (s *GroupRoleService) RemoveRolesFromGroup(request *domain.RemoveRolesFromGroupRequest) (*domain.Response, error) {
+	group, err := s.groupService.GetGroup(request.GroupId)
+	if err != nil && group == nil {
+		return nil, err
+	}
+
+	if err := s.adminScopeService.CheckRoleScope(request.ActorId, request.RolesId); err != nil {
+		return nil, err
+	}
+
+	change := &domain.AccessChange{}
+	for _, roleID := range request.RolesId {
+		change.RevokedGroupRoles = append(change.RevokedGroupRoles, &domain.GroupRoleGrant{GroupId: request.GroupId, RoleId: roleID})
+	}
+	if err := s.safeguardService.CheckLockout(change); err != nil {
+		return nil, err
+	}
+
+	users, err := s.groupUserRepository.GetGroupUsers(request.GroupId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	err = s.groupRoleRepository.RemoveGroupRoles(request.GroupId, request.RolesId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	s.userSearchService.IndexUsers(userIDs(users))
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    nil,
+	}, nil
+}
+
+func userIDs(users []*domain.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.Id)
+	}
+	return ids
+}
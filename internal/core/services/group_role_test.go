@@ -0,0 +1,139 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	appError "user-svc/internal/shared/error"
+)
+
+func TestGroupRoleService_AssignRolesToGroup(t *testing.T) {
+	members := []*domain.User{{Id: "user-1"}, {Id: "user-2"}}
+
+	tests := []struct {
+		name       string
+		scope      error
+		constraint error
+		wantCode   int
+	}{
+		{
+			name:     "success - roles assigned",
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:     "failed - role out of scope",
+			scope:    &appError.AppError{Code: http.StatusForbidden, Message: "role Admin is outside the admin scope"},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:       "failed - a member would break a constraint",
+			constraint: &appError.AppError{Code: http.StatusConflict, Message: "role Approver cannot be held along with Requester"},
+			wantCode:   http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &domain.AssignRolesToGroupRequest{GroupId: "group-1", RolesId: []string{"role-1"}, ActorId: "admin-1"}
+
+			mockGroupService := mockCore.GroupService{}
+			mockGroupService.On("GetGroup", "group-1").Return(&domain.Response{Code: http.StatusOK}, nil)
+
+			mockRoleService := mockCore.RoleService{}
+			mockRoleService.On("GetRole", "role-1").Return(&domain.Response{Code: http.StatusOK}, nil)
+
+			mockAdminScopeService := mockCore.AdminScopeService{}
+			mockAdminScopeService.On("CheckRoleScope", "admin-1", []string{"role-1"}).Return(tt.scope)
+
+			mockGroupUserRepository := mockCore.GroupUserRepository{}
+			mockGroupUserRepository.On("GetGroupUsers", "group-1").Return(members, nil)
+
+			mockRoleConstraintService := mockCore.RoleConstraintService{}
+			mockRoleConstraintService.On("CheckAssignment", "user-1", []string{"role-1"}).Return(nil)
+			mockRoleConstraintService.On("CheckAssignment", "user-2", []string{"role-1"}).Return(tt.constraint)
+
+			mockGroupRoleRepository := mockCore.GroupRoleRepository{}
+			mockGroupRoleRepository.On("AddGroupRoles", "group-1", []string{"role-1"}).Return(nil)
+
+			s := NewGroupRoleService(&mockGroupRoleRepository, &mockGroupUserRepository, &mockGroupService, &mockRoleService, &mockCore.SafeguardService{}, &mockRoleConstraintService, &mockAdminScopeService, indexingSearchService())
+			got, err := s.AssignRolesToGroup(request)
+			if tt.wantCode == http.StatusCreated {
+				if err != nil || got.Code != http.StatusCreated {
+					t.Fatalf("AssignRolesToGroup() = %v, %v", got, err)
+				}
+				mockRoleConstraintService.AssertNumberOfCalls(t, "CheckAssignment", len(members))
+				return
+			}
+
+			if appErr, ok := err.(*appError.AppError); !ok || appErr.Code != tt.wantCode {
+				t.Fatalf("AssignRolesToGroup() error = %v, want code %d", err, tt.wantCode)
+			}
+			mockGroupRoleRepository.AssertNotCalled(t, "AddGroupRoles", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGroupRoleService_RemoveRolesFromGroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		scope    error
+		lockout  error
+		wantCode int
+	}{
+		{
+			name:     "success - roles removed",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "failed - role out of scope",
+			scope:    &appError.AppError{Code: http.StatusForbidden, Message: "role Admin is outside the admin scope"},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "failed - group carries the last super-admin grant",
+			lockout:  &appError.AppError{Code: http.StatusConflict, Message: "change would leave no active user holding permission Update-Role"},
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &domain.RemoveRolesFromGroupRequest{GroupId: "group-1", RolesId: []string{"admin"}, ActorId: "admin-1"}
+
+			mockGroupService := mockCore.GroupService{}
+			mockGroupService.On("GetGroup", "group-1").Return(&domain.Response{Code: http.StatusOK}, nil)
+
+			mockAdminScopeService := mockCore.AdminScopeService{}
+			mockAdminScopeService.On("CheckRoleScope", "admin-1", []string{"admin"}).Return(tt.scope)
+
+			mockSafeguardService := mockCore.SafeguardService{}
+			mockSafeguardService.On("CheckLockout", &domain.AccessChange{
+				RevokedGroupRoles: []*domain.GroupRoleGrant{{GroupId: "group-1", RoleId: "admin"}},
+			}).Return(tt.lockout)
+
+			mockGroupRoleRepository := mockCore.GroupRoleRepository{}
+			mockGroupRoleRepository.On("RemoveGroupRoles", "group-1", []string{"admin"}).Return(nil)
+
+			mockGroupUserRepository := mockCore.GroupUserRepository{}
+			mockGroupUserRepository.On("GetGroupUsers", "group-1").Return([]*domain.User{{Id: "user-1"}}, nil)
+
+			mockUserSearchService := indexingSearchService()
+
+			s := NewGroupRoleService(&mockGroupRoleRepository, &mockGroupUserRepository, &mockGroupService, &mockCore.RoleService{}, &mockSafeguardService, &mockCore.RoleConstraintService{}, &mockAdminScopeService, mockUserSearchService)
+			got, err := s.RemoveRolesFromGroup(request)
+			if tt.wantCode == http.StatusOK {
+				if err != nil || got.Code != http.StatusOK {
+					t.Fatalf("RemoveRolesFromGroup() = %v, %v", got, err)
+				}
+				mockGroupRoleRepository.AssertCalled(t, "RemoveGroupRoles", "group-1", []string{"admin"})
+				mockUserSearchService.AssertCalled(t, "IndexUsers", []string{"user-1"})
+				return
+			}
+
+			if appErr, ok := err.(*appError.AppError); !ok || appErr.Code != tt.wantCode {
+				t.Fatalf("RemoveRolesFromGroup() error = %v, want code %d", err, tt.wantCode)
+			}
+			mockGroupRoleRepository.AssertNotCalled(t, "RemoveGroupRoles", mock.Anything, mock.Anything)
+		})
+	}
+}
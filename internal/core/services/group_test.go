@@ -0,0 +1,58 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	appError "user-svc/internal/shared/error"
+)
+
+func TestGroupService_DeleteGroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		lockout  error
+		wantCode int
+	}{
+		{
+			name:     "success - group deleted",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "failed - group carries the last super-admin grant",
+			lockout:  &appError.AppError{Code: http.StatusConflict, Message: "change would leave no active user holding permission Update-Role"},
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGroupRepository := mockCore.GroupRepository{}
+			mockGroupRepository.On("GetGroupByID", "group-1").Return(&domain.Group{Id: "group-1", Name: "Operators"}, nil)
+			mockGroupRepository.On("DeleteGroup", "group-1").Return(nil)
+
+			mockGroupUserRepository := mockCore.GroupUserRepository{}
+			mockGroupUserRepository.On("GetGroupUsers", "group-1").Return([]*domain.User{{Id: "user-1"}}, nil)
+
+			mockSafeguardService := mockCore.SafeguardService{}
+			mockSafeguardService.On("CheckLockout", &domain.AccessChange{GroupIds: []string{"group-1"}}).Return(tt.lockout)
+
+			mockUserSearchService := indexingSearchService()
+
+			s := NewGroupService(&mockGroupRepository, &mockGroupUserRepository, &mockSafeguardService, mockUserSearchService)
+			got, err := s.DeleteGroup("group-1")
+			if tt.wantCode == http.StatusOK {
+				if err != nil || got.Code != http.StatusOK {
+					t.Fatalf("DeleteGroup() = %v, %v", got, err)
+				}
+				mockUserSearchService.AssertCalled(t, "IndexUsers", []string{"user-1"})
+				return
+			}
+
+			if appErr, ok := err.(*appError.AppError); !ok || appErr.Code != tt.wantCode {
+				t.Fatalf("DeleteGroup() error = %v, want code %d", err, tt.wantCode)
+			}
+			mockGroupRepository.AssertNotCalled(t, "DeleteGroup", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	appError "user-svc/internal/shared/error"
+)
+
+type GroupUserService struct {
+	groupUserRepository   ports.GroupUserRepository
+	groupRoleRepository   ports.GroupRoleRepository
+	groupService          ports.GroupService
+	userService           ports.UserService
+	safeguardService      ports.SafeguardService
+	roleConstraintService ports.RoleConstraintService
+	adminScopeService     ports.AdminScopeService
+	userSearchService     ports.UserSearchService
+}
+
+func NewGroupUserService(groupUserRepository ports.GroupUserRepository, groupRoleRepository ports.GroupRoleRepository, groupService ports.GroupService, userService ports.UserService, safeguardService ports.SafeguardService, roleConstraintService ports.RoleConstraintService, adminScopeService ports.AdminScopeService, userSearchService ports.UserSearchService) *GroupUserService {
+	return &GroupUserService{
+		groupUserRepository:   groupUserRepository,
+		groupRoleRepository:   groupRoleRepository,
+		groupService:          groupService,
+		userService:           userService,
+		safeguardService:      safeguardService,
+		roleConstraintService: roleConstraintService,
+		adminScopeService:     adminScopeService,
+		userSearchService:     userSearchService,
+	}
+}
+
+func (s *GroupUserService) GetGroupUsers(request *domain.GetGroupUsersRequest) (*domain.Response, error) {
+	group, err := s.groupService.GetGroup(request.GroupId)
+	if err != nil && group == nil {
+		return nil, err
+	}
+
+	result, err := s.groupUserRepository.GetGroupUsers(request.GroupId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+func (s *GroupUserService) AddUsersToGroup(request *domain.AddUsersToGroupRequest) (*domain.Response, error) {
+	group, err := s.groupService.GetGroup(request.GroupId)
+	if err != nil && group == nil {
+		return nil, err
+	}
+
+	for _, userID := range request.UsersId {
+		user, err := s.userService.GetUser(userID)
+		if err != nil && user == nil {
+			return nil, err
+		}
+	}
+
+	// members inherit the group roles, so the same scope and constraints as a direct assignment apply
+	rolesID, err := s.groupRolesID(request.GroupId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.adminScopeService.CheckRoleScope(request.ActorId, rolesID); err != nil {
+		return nil, err
+	}
+	if len(rolesID) > 0 {
+		for _, userID := range request.UsersId {
+			if err := s.roleConstraintService.CheckAssignment(userID, rolesID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = s.groupUserRepository.AddGroupUsers(request.GroupId, request.UsersId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	s.userSearchService.IndexUsers(request.UsersId)
+	return &domain.Response{
+		Code:    http.StatusCreated,
+		Message: http.StatusText(http.StatusCreated),
+		Data:    nil,
+	}, nil
+}
+
+func (s *GroupUserService) RemoveUsersFromGroup(request *domain.RemoveUsersFromGroupRequest) (*domain.Response, error) {
+	group, err := s.groupService.GetGroup(request.GroupId)
+	if err != nil && group == nil {
+		return nil, err
+	}
+
+	rolesID, err := s.groupRolesID(request.GroupId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.adminScopeService.CheckRoleScope(request.ActorId, rolesID); err != nil {
+		return nil, err
+	}
+
+	change := &domain.AccessChange{}
+	for _, userID := range request.UsersId {
+		change.RevokedGroupUsers = append(change.RevokedGroupUsers, &domain.GroupUserGrant{GroupId: request.GroupId, UserId: userID})
+	}
+	if err := s.safeguardService.CheckLockout(change); err != nil {
+		return nil, err
+	}
+
+	err = s.groupUserRepository.RemoveGroupUsers(request.GroupId, request.UsersId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	s.userSearchService.IndexUsers(request.UsersId)
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    nil,
+	}, nil
+}
+
+func (s *GroupUserService) groupRolesID(groupID string) ([]string, error) {
+	roles, err := s.groupRoleRepository.GetGroupRoles(groupID)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	rolesID := make([]string, 0, len(roles))
+	for _, role := range roles {
+		rolesID = append(rolesID, role.Id)
+	}
+	return rolesID, nil
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	appError "user-svc/internal/shared/error"
+)
+
+func TestGroupUserService_AddUsersToGroup(t *testing.T) {
+	groupRoles := []*domain.Role{{Id: "role-1", Name: "Approver", Active: true}}
+
+	tests := []struct {
+		name       string
+		scope      error
+		constraint error
+		wantCode   int
+	}{
+		{
+			name:     "success - members added",
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:     "failed - group roles out of scope",
+			scope:    &appError.AppError{Code: http.StatusForbidden, Message: "role Approver is outside the admin scope"},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:       "failed - inherited roles break a constraint",
+			constraint: &appError.AppError{Code: http.StatusConflict, Message: "role Approver cannot be held along with Requester"},
+			wantCode:   http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &domain.AddUsersToGroupRequest{GroupId: "group-1", UsersId: []string{"user-1"}, ActorId: "admin-1"}
+
+			mockGroupService := mockCore.GroupService{}
+			mockGroupService.On("GetGroup", "group-1").Return(&domain.Response{Code: http.StatusOK}, nil)
+
+			mockUserService := mockCore.UserService{}
+			mockUserService.On("GetUser", "user-1").Return(&domain.Response{Code: http.StatusOK}, nil)
+
+			mockGroupRoleRepository := mockCore.GroupRoleRepository{}
+			mockGroupRoleRepository.On("GetGroupRoles", "group-1").Return(groupRoles, nil)
+
+			mockAdminScopeService := mockCore.AdminScopeService{}
+			mockAdminScopeService.On("CheckRoleScope", "admin-1", []string{"role-1"}).Return(tt.scope)
+
+			mockRoleConstraintService := mockCore.RoleConstraintService{}
+			mockRoleConstraintService.On("CheckAssignment", "user-1", []string{"role-1"}).Return(tt.constraint)
+
+			mockGroupUserRepository := mockCore.GroupUserRepository{}
+			mockGroupUserRepository.On("AddGroupUsers", "group-1", []string{"user-1"}).Return(nil)
+
+			s := NewGroupUserService(&mockGroupUserRepository, &mockGroupRoleRepository, &mockGroupService, &mockUserService, &mockCore.SafeguardService{}, &mockRoleConstraintService, &mockAdminScopeService, indexingSearchService())
+			got, err := s.AddUsersToGroup(request)
+			if tt.wantCode == http.StatusCreated {
+				if err != nil || got.Code != http.StatusCreated {
+					t.Fatalf("AddUsersToGroup() = %v, %v", got, err)
+				}
+				mockGroupUserRepository.AssertCalled(t, "AddGroupUsers", "group-1", []string{"user-1"})
+				return
+			}
+
+			if appErr, ok := err.(*appError.AppError); !ok || appErr.Code != tt.wantCode {
+				t.Fatalf("AddUsersToGroup() error = %v, want code %d", err, tt.wantCode)
+			}
+			mockGroupUserRepository.AssertNotCalled(t, "AddGroupUsers", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGroupUserService_RemoveUsersFromGroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		scope    error
+		lockout  error
+		wantCode int
+	}{
+		{
+			name:     "success - members removed",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "failed - group roles out of scope",
+			scope:    &appError.AppError{Code: http.StatusForbidden, Message: "role Admin is outside the admin scope"},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "failed - last super-admin leaves the group",
+			lockout:  &appError.AppError{Code: http.StatusConflict, Message: "change would leave no active user holding permission Update-Role"},
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &domain.RemoveUsersFromGroupRequest{GroupId: "group-1", UsersId: []string{"user-1"}, ActorId: "admin-1"}
+
+			mockGroupService := mockCore.GroupService{}
+			mockGroupService.On("GetGroup", "group-1").Return(&domain.Response{Code: http.StatusOK}, nil)
+
+			mockGroupRoleRepository := mockCore.GroupRoleRepository{}
+			mockGroupRoleRepository.On("GetGroupRoles", "group-1").Return([]*domain.Role{{Id: "admin", Name: "Admin", Active: true}}, nil)
+
+			mockAdminScopeService := mockCore.AdminScopeService{}
+			mockAdminScopeService.On("CheckRoleScope", "admin-1", []string{"admin"}).Return(tt.scope)
+
+			mockSafeguardService := mockCore.SafeguardService{}
+			mockSafeguardService.On("CheckLockout", &domain.AccessChange{
+				RevokedGroupUsers: []*domain.GroupUserGrant{{GroupId: "group-1", UserId: "user-1"}},
+			}).Return(tt.lockout)
+
+			mockGroupUserRepository := mockCore.GroupUserRepository{}
+			mockGroupUserRepository.On("RemoveGroupUsers", "group-1", []string{"user-1"}).Return(nil)
+
+			s := NewGroupUserService(&mockGroupUserRepository, &mockGroupRoleRepository, &mockGroupService, &mockCore.UserService{}, &mockSafeguardService, &mockCore.RoleConstraintService{}, &mockAdminScopeService, indexingSearchService())
+			got, err := s.RemoveUsersFromGroup(request)
+			if tt.wantCode == http.StatusOK {
+				if err != nil || got.Code != http.StatusOK {
+					t.Fatalf("RemoveUsersFromGroup() = %v, %v", got, err)
+				}
+				mockGroupUserRepository.AssertCalled(t, "RemoveGroupUsers", "group-1", []string{"user-1"})
+				return
+			}
+
+			if appErr, ok := err.(*appError.AppError); !ok || appErr.Code != tt.wantCode {
+				t.Fatalf("RemoveUsersFromGroup() error = %v, want code %d", err, tt.wantCode)
+			}
+			mockGroupUserRepository.AssertNotCalled(t, "RemoveGroupUsers", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	appError "user-svc/internal/shared/error"
+)
+
+type ManifestService struct {
+	manifestRepository       ports.ManifestRepository
+	roleRepository           ports.RoleRepository
+	permissionRepository     ports.PermissionRepository
+	rolePermissionRepository ports.RolePermissionRepository
+	userRepository           ports.UserRepository
+	userRoleRepository       ports.UserRoleRepository
+	safeguardService         ports.SafeguardService
+	roleConstraintService    ports.RoleConstraintService
+	userSearchService        ports.UserSearchService
+}
+
+func NewManifestService(manifestRepository ports.ManifestRepository, roleRepository ports.RoleRepository, permissionRepository ports.PermissionRepository, rolePermissionRepository ports.RolePermissionRepository, userRepository ports.UserRepository, userRoleRepository ports.UserRoleRepository, safeguardService ports.SafeguardService, roleConstraintService ports.RoleConstraintService, userSearchService ports.UserSearchService) *ManifestService {
+	return &ManifestService{
+		manifestRepository:       manifestRepository,
+		roleRepository:           roleRepository,
+		permissionRepository:     permissionRepository,
+		rolePermissionRepository: rolePermissionRepository,
+		userRepository:           userRepository,
+		userRoleRepository:       userRoleRepository,
+		safeguardService:         safeguardService,
+		roleConstraintService:    roleConstraintService,
+		userSearchService:        userSearchService,
+	}
+}
+
+// Plan diffs the manifest against the live database. Roles and permissions
+// flagged as system are never pruned.
+func (s *ManifestService) Plan(manifest *domain.Manifest, options *domain.ManifestOptions) (*domain.ManifestPlan, error) {
+	if err := validateManifest(manifest); err != nil {
+		return nil, err
+	}
+
+	plan := &domain.ManifestPlan{}
+	now := time.Now()
+
+	permissions, err := s.permissionRepository.GetAllPermission()
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	permissionByName := make(map[string]*domain.Permission, len(permissions))
+	for _, permission := range permissions {
+		permissionByName[permission.Name] = permission
+	}
+
+	// permissions bound to a role are kept even when they are not declared
+	declared := make(map[string]bool)
+	for _, permission := range manifest.Permissions {
+		declared[permission.Name] = true
+	}
+	for _, role := range manifest.Roles {
+		for _, name := range role.Permissions {
+			if !declared[name] && permissionByName[name] == nil {
+				return nil, &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("role %s references undeclared permission %s", role.Name, name)}
+			}
+			declared[name] = true
+		}
+	}
+
+	for _, declaredPermission := range manifest.Permissions {
+		if permissionByName[declaredPermission.Name] != nil {
+			continue
+		}
+		permission := &domain.Permission{
+			Id:        uuid.New().String(),
+			Name:      declaredPermission.Name,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		permissionByName[permission.Name] = permission
+		plan.CreatePermissions = append(plan.CreatePermissions, permission)
+	}
+
+	roles, err := s.roleRepository.GetAllRole()
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	roleByName := make(map[string]*domain.Role, len(roles))
+	for _, role := range roles {
+		roleByName[role.Name] = role
+	}
+
+	for _, declaredRole := range manifest.Roles {
+		active := declaredRole.Active == nil || *declaredRole.Active
+
+		role := roleByName[declaredRole.Name]
+		current := make([]*domain.Permission, 0)
+		if role == nil {
+			role = &domain.Role{
+				Id:        uuid.New().String(),
+				Name:      declaredRole.Name,
+				Active:    active,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			roleByName[role.Name] = role
+			plan.CreateRoles = append(plan.CreateRoles, role)
+		} else {
+			if role.Active != active {
+				plan.UpdateRoles = append(plan.UpdateRoles, &domain.Role{Id: role.Id, Name: role.Name, Active: active, System: role.System, Version: role.Version, UpdatedAt: now})
+			}
+			current, err = s.rolePermissionRepository.GetRolePermissions(role.Id)
+			if err != nil {
+				return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+			}
+		}
+
+		bound := make(map[string]bool, len(current))
+		for _, permission := range current {
+			bound[permission.Name] = true
+		}
+		for _, name := range uniqueStrings(declaredRole.Permissions) {
+			if bound[name] {
+				continue
+			}
+			permission := permissionByName[name]
+			plan.AddRolePermissions = append(plan.AddRolePermissions, &domain.RolePermissionBinding{RoleId: role.Id, RoleName: role.Name, PermissionId: permission.Id, PermissionName: permission.Name})
+		}
+		if options.Prune {
+			for _, permission := range current {
+				if !contains(declaredRole.Permissions, permission.Name) {
+					plan.RemoveRolePermissions = append(plan.RemoveRolePermissions, &domain.RolePermissionBinding{RoleId: role.Id, RoleName: role.Name, PermissionId: permission.Id, PermissionName: permission.Name})
+				}
+			}
+		}
+	}
+
+	for _, declaredUser := range manifest.Users {
+		user, err := s.userRepository.GetUserByEmail(declaredUser.Email)
+		if err != nil && user == nil {
+			return nil, &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("user with email %s not exist", declaredUser.Email)}
+		}
+
+		current, err := s.userRoleRepository.GetDirectUserRoles(user.Id)
+		if err != nil {
+			return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		}
+		bound := make(map[string]bool, len(current))
+		for _, role := range current {
+			bound[role.Name] = true
+		}
+
+		for _, name := range uniqueStrings(declaredUser.Roles) {
+			role := roleByName[name]
+			if role == nil {
+				return nil, &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("user %s references undeclared role %s", declaredUser.Email, name)}
+			}
+			if !bound[name] {
+				plan.AddUserRoles = append(plan.AddUserRoles, &domain.UserRoleBinding{UserId: user.Id, UserEmail: user.Email, RoleId: role.Id, RoleName: role.Name})
+			}
+		}
+		if options.Prune {
+			for _, role := range current {
+				if !contains(declaredUser.Roles, role.Name) {
+					plan.RemoveUserRoles = append(plan.RemoveUserRoles, &domain.UserRoleBinding{UserId: user.Id, UserEmail: user.Email, RoleId: role.Id, RoleName: role.Name})
+				}
+			}
+		}
+	}
+
+	if options.Prune {
+		declaredRoles := make(map[string]bool, len(manifest.Roles))
+		for _, role := range manifest.Roles {
+			declaredRoles[role.Name] = true
+		}
+		for _, role := range roles {
+			if !role.System && !declaredRoles[role.Name] {
+				plan.DeleteRoles = append(plan.DeleteRoles, role)
+			}
+		}
+		for _, permission := range permissions {
+			if !permission.System && !declared[permission.Name] {
+				plan.DeletePermissions = append(plan.DeletePermissions, permission)
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply plans the manifest and applies the plan in a single transaction, after
+// the same lock-out and separation-of-duties checks as the REST endpoints.
+func (s *ManifestService) Apply(manifest *domain.Manifest, options *domain.ManifestOptions) (*domain.ManifestPlan, error) {
+	plan, err := s.Plan(manifest, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan.Empty() {
+		return plan, nil
+	}
+
+	change := &domain.AccessChange{}
+	for _, role := range plan.DeleteRoles {
+		change.RoleIds = append(change.RoleIds, role.Id)
+	}
+	for _, role := range plan.UpdateRoles {
+		if !role.Active {
+			change.RoleIds = append(change.RoleIds, role.Id)
+		}
+	}
+	for _, permission := range plan.DeletePermissions {
+		change.PermissionIds = append(change.PermissionIds, permission.Id)
+	}
+	for _, binding := range plan.RemoveRolePermissions {
+		change.RevokedRolePermissions = append(change.RevokedRolePermissions, &domain.RolePermissionGrant{RoleId: binding.RoleId, PermissionId: binding.PermissionId})
+	}
+	for _, binding := range plan.RemoveUserRoles {
+		change.RevokedUserRoles = append(change.RevokedUserRoles, &domain.UserRoleGrant{UserId: binding.UserId, RoleId: binding.RoleId})
+	}
+	if err := s.safeguardService.CheckLockout(change); err != nil {
+		return nil, err
+	}
+
+	granted := make(map[string][]string)
+	for _, binding := range plan.AddUserRoles {
+		granted[binding.UserId] = append(granted[binding.UserId], binding.RoleId)
+	}
+	for userID, roles := range granted {
+		if err := s.roleConstraintService.CheckAssignment(userID, roles); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.manifestRepository.ApplyManifestPlan(plan, options.DryRun); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	if !options.DryRun {
+		s.reindex(plan)
+	}
+
+	return plan, nil
+}
+
+// reindex refreshes the search documents of the users whose role names or
+// bindings the plan changed.
+func (s *ManifestService) reindex(plan *domain.ManifestPlan) {
+	for _, role := range plan.DeleteRoles {
+		s.userSearchService.IndexRoleHolders(role.Id)
+	}
+	for _, role := range plan.UpdateRoles {
+		s.userSearchService.IndexRoleHolders(role.Id)
+	}
+
+	userIDs := make([]string, 0, len(plan.AddUserRoles)+len(plan.RemoveUserRoles))
+	for _, binding := range plan.AddUserRoles {
+		userIDs = append(userIDs, binding.UserId)
+	}
+	for _, binding := range plan.RemoveUserRoles {
+		userIDs = append(userIDs, binding.UserId)
+	}
+	s.userSearchService.IndexUsers(userIDs)
+}
+
+func validateManifest(manifest *domain.Manifest) error {
+	seen := make(map[string]bool)
+	for _, permission := range manifest.Permissions {
+		if permission.Name == "" {
+			return &appError.AppError{Code: http.StatusBadRequest, Message: "permission name is required"}
+		}
+		if seen["permission:"+permission.Name] {
+			return &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("permission %s is declared more than once", permission.Name)}
+		}
+		seen["permission:"+permission.Name] = true
+	}
+	for _, role := range manifest.Roles {
+		if role.Name == "" {
+			return &appError.AppError{Code: http.StatusBadRequest, Message: "role name is required"}
+		}
+		if seen["role:"+role.Name] {
+			return &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("role %s is declared more than once", role.Name)}
+		}
+		seen["role:"+role.Name] = true
+	}
+	for _, user := range manifest.Users {
+		if user.Email == "" {
+			return &appError.AppError{Code: http.StatusBadRequest, Message: "user email is required"}
+		}
+		if seen["user:"+user.Email] {
+			return &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("user %s is declared more than once", user.Email)}
+		}
+		seen["user:"+user.Email] = true
+	}
+	return nil
+}
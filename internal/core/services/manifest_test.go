@@ -0,0 +1,117 @@
+package services
+
+import (
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+)
+
+func TestManifestService_Plan(t *testing.T) {
+	manifest := &domain.Manifest{
+		Permissions: []*domain.ManifestPermission{{Name: "Export-Report"}},
+		Roles: []*domain.ManifestRole{
+			{Name: "Auditor", Permissions: []string{"View-User", "Export-Report"}},
+		},
+		Users: []*domain.ManifestUser{{Email: "alice@example.com", Roles: []string{"Auditor"}}},
+	}
+
+	permissions := []*domain.Permission{
+		{Id: "perm-view-user", Name: "View-User", System: true},
+		{Id: "perm-delete-user", Name: "Delete-User", System: true},
+		{Id: "perm-legacy", Name: "Legacy-Report"},
+	}
+	roles := []*domain.Role{
+		{Id: "role-admin", Name: "Admin", Active: true, System: true},
+		{Id: "role-auditor", Name: "Auditor", Active: true},
+		{Id: "role-legacy", Name: "Legacy", Active: true},
+	}
+
+	tests := []struct {
+		name       string
+		options    *domain.ManifestOptions
+		wantAdd    int
+		wantRemove int
+		wantDelete int
+	}{
+		{
+			name:    "success - additions only",
+			options: &domain.ManifestOptions{},
+			wantAdd: 1,
+		},
+		{
+			name:       "success - prune removes undeclared entries",
+			options:    &domain.ManifestOptions{Prune: true},
+			wantAdd:    1,
+			wantRemove: 1,
+			wantDelete: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPermissionRepository := mockCore.PermissionRepository{}
+			mockPermissionRepository.On("GetAllPermission").Return(permissions, nil)
+
+			mockRoleRepository := mockCore.RoleRepository{}
+			mockRoleRepository.On("GetAllRole").Return(roles, nil)
+
+			mockRolePermissionRepository := mockCore.RolePermissionRepository{}
+			mockRolePermissionRepository.On("GetRolePermissions", "role-auditor").Return([]*domain.Permission{
+				{Id: "perm-view-user", Name: "View-User"},
+				{Id: "perm-delete-user", Name: "Delete-User"},
+			}, nil)
+
+			mockUserRepository := mockCore.UserRepository{}
+			mockUserRepository.On("GetUserByEmail", "alice@example.com").Return(&domain.User{Id: "user-1", Email: "alice@example.com"}, nil)
+
+			mockUserRoleRepository := mockCore.UserRoleRepository{}
+			mockUserRoleRepository.On("GetDirectUserRoles", "user-1").Return([]*domain.Role{{Id: "role-auditor", Name: "Auditor"}}, nil)
+
+			s := NewManifestService(&mockCore.ManifestRepository{}, &mockRoleRepository, &mockPermissionRepository, &mockRolePermissionRepository,
+				&mockUserRepository, &mockUserRoleRepository, &mockCore.SafeguardService{}, &mockCore.RoleConstraintService{}, indexingSearchService())
+			plan, err := s.Plan(manifest, tt.options)
+			if err != nil {
+				t.Fatalf("Plan() error = %v", err)
+			}
+
+			if len(plan.CreatePermissions) != 1 || plan.CreatePermissions[0].Name != "Export-Report" {
+				t.Errorf("Plan() create permissions = %+v, want Export-Report", plan.CreatePermissions)
+			}
+			if len(plan.AddRolePermissions) != tt.wantAdd {
+				t.Errorf("Plan() add role permissions = %d, want %d", len(plan.AddRolePermissions), tt.wantAdd)
+			}
+			if len(plan.RemoveRolePermissions) != tt.wantRemove {
+				t.Errorf("Plan() remove role permissions = %d, want %d", len(plan.RemoveRolePermissions), tt.wantRemove)
+			}
+			if len(plan.DeleteRoles) != tt.wantDelete || len(plan.DeletePermissions) != tt.wantDelete {
+				t.Errorf("Plan() delete roles = %d, delete permissions = %d, want %d", len(plan.DeleteRoles), len(plan.DeletePermissions), tt.wantDelete)
+			}
+			if len(plan.AddUserRoles) != 0 || len(plan.CreateRoles) != 0 {
+				t.Errorf("Plan() unexpected changes %+v", plan)
+			}
+		})
+	}
+}
+
+func TestManifestService_Apply(t *testing.T) {
+	manifest := &domain.Manifest{Roles: []*domain.ManifestRole{{Name: "Auditor"}}}
+
+	mockPermissionRepository := mockCore.PermissionRepository{}
+	mockPermissionRepository.On("GetAllPermission").Return([]*domain.Permission{}, nil)
+
+	mockRoleRepository := mockCore.RoleRepository{}
+	mockRoleRepository.On("GetAllRole").Return([]*domain.Role{{Id: "role-admin", Name: "Admin", Active: true}}, nil)
+
+	mockSafeguardService := mockCore.SafeguardService{}
+	mockSafeguardService.On("CheckLockout", mock.Anything).Return(errors.New("lock-out"))
+
+	mockManifestRepository := mockCore.ManifestRepository{}
+
+	s := NewManifestService(&mockManifestRepository, &mockRoleRepository, &mockPermissionRepository, &mockCore.RolePermissionRepository{},
+		&mockCore.UserRepository{}, &mockCore.UserRoleRepository{}, &mockSafeguardService, &mockCore.RoleConstraintService{}, indexingSearchService())
+	if _, err := s.Apply(manifest, &domain.ManifestOptions{Prune: true}); err == nil {
+		t.Fatal("Apply() error = nil, want lock-out error")
+	}
+	mockManifestRepository.AssertNotCalled(t, "ApplyManifestPlan", mock.Anything, mock.Anything)
+}
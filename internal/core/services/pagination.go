@@ -0,0 +1,32 @@
+package services
+
+import (
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/shared/cursor"
+	appError "user-svc/internal/shared/error"
+)
+
+// Cursor kinds, a cursor issued for one list is rejected by the others.
+const (
+	userCursor       = "user"
+	roleCursor       = "role"
+	permissionCursor = "permission"
+)
+
+// decodeCursor returns the keyset position a cursor request starts after, nil
+// for the first page. Keyset pages are ordered by creation only.
+func decodeCursor(codec cursor.Codec, kind string, request domain.CursorRequest, sort string) (*domain.Cursor, error) {
+	if sort != "" {
+		return nil, &appError.AppError{Code: http.StatusBadRequest, Message: "sort is not supported with cursor pagination"}
+	}
+	if request.Cursor == "" {
+		return nil, nil
+	}
+
+	createdAt, id, err := codec.Decode(kind, request.Cursor)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusBadRequest, Message: err.Error()}
+	}
+	return &domain.Cursor{CreatedAt: createdAt, Id: id}, nil
+}
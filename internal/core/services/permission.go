@@ -7,16 +7,23 @@ import (
 	"time"
 	"user-svc/internal/core/domain"
 	"user-svc/internal/core/ports"
+	"user-svc/internal/shared/cursor"
 	appError "user-svc/internal/shared/error"
 )
 
 type PermissionService struct {
 	permissionRepository ports.PermissionRepository
+	safeguardService     ports.SafeguardService
+	accessImpactService  ports.AccessImpactService
+	cursorCodec          cursor.Codec
 }
 
-func NewPermissionService(permissionRepository ports.PermissionRepository) *PermissionService {
+func NewPermissionService(permissionRepository ports.PermissionRepository, safeguardService ports.SafeguardService, accessImpactService ports.AccessImpactService, cursorCodec cursor.Codec) *PermissionService {
 	return &PermissionService{
 		permissionRepository: permissionRepository,
+		safeguardService:     safeguardService,
+		accessImpactService:  accessImpactService,
+		cursorCodec:          cursorCodec,
 	}
 }
 
@@ -27,6 +34,11 @@ func (r *PermissionService) CreatePermission(request *domain.CreatePermissionReq
 		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("permission %s already exist", request.Name)}
 	}
 
+	// a new permission is not granted by any role yet
+	if request.DryRun {
+		return dryRun(r.accessImpactService, nil, &domain.AccessChange{})
+	}
+
 	permission := &domain.Permission{
 		Id:        uuid.New().String(),
 		Name:      request.Name,
@@ -51,17 +63,34 @@ func (r *PermissionService) UpdatePermission(request *domain.UpdatePermissionReq
 		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("permission with id %s not exist", request.Id)}
 	}
 
+	if err := checkVersion("permission", permission.Id, request.IfMatch, permission.Version); err != nil {
+		return nil, err
+	}
+
+	if permission.System && permission.Name != request.Name {
+		return nil, &appError.AppError{Code: http.StatusForbidden, Message: fmt.Sprintf("permission %s is a system permission and cannot be renamed", permission.Name)}
+	}
+
 	check, _ := r.permissionRepository.GetPermissionByName(request.Name)
 	if check != nil && check.Id != permission.Id {
 		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("permission with name %s already exist", request.Name)}
 	}
 
+	// routes require permissions by name, so a rename moves access
+	if request.DryRun {
+		change := &domain.AccessChange{}
+		if permission.Name != request.Name {
+			change.RenamedPermissions = []*domain.Permission{{Id: permission.Id, Name: request.Name}}
+		}
+		return dryRun(r.accessImpactService, nil, change)
+	}
+
 	permission.Name = request.Name
 	permission.UpdatedAt = time.Now()
 
 	err = r.permissionRepository.UpdatePermission(permission)
 	if err != nil {
-		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		return nil, writeError("permission", permission.Id, err)
 	}
 
 	return &domain.Response{
@@ -71,16 +100,106 @@ func (r *PermissionService) UpdatePermission(request *domain.UpdatePermissionReq
 	}, nil
 }
 
-func (r *PermissionService) DeletePermission(id string) (*domain.Response, error) {
-	permission, err := r.permissionRepository.GetPermissionByID(id)
+// PatchPermission applies a merge patch, only the members it carries are
+// checked and written.
+func (r *PermissionService) PatchPermission(request *domain.PatchPermissionRequest) (*domain.Response, error) {
+	permission, err := r.permissionRepository.GetPermissionByID(request.Id)
 	if err != nil && permission == nil {
-		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("permission with id %s not exist", id)}
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("permission with id %s not exist", request.Id)}
+	}
+
+	if err := checkVersion("permission", permission.Id, request.IfMatch, permission.Version); err != nil {
+		return nil, err
+	}
+
+	renamed := request.Name != nil && *request.Name != permission.Name
+	if renamed {
+		if permission.System {
+			return nil, &appError.AppError{Code: http.StatusForbidden, Message: fmt.Sprintf("permission %s is a system permission and cannot be renamed", permission.Name)}
+		}
+
+		check, _ := r.permissionRepository.GetPermissionByName(*request.Name)
+		if check != nil && check.Id != permission.Id {
+			return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("permission with name %s already exist", *request.Name)}
+		}
+	}
+
+	// routes require permissions by name, so a rename moves access
+	if request.DryRun {
+		change := &domain.AccessChange{}
+		if renamed {
+			change.RenamedPermissions = []*domain.Permission{{Id: permission.Id, Name: *request.Name}}
+		}
+		return dryRun(r.accessImpactService, nil, change)
+	}
+
+	patch := &domain.PermissionPatch{
+		Name:      request.Name,
+		UpdatedAt: time.Now(),
+	}
+	if err := r.permissionRepository.PatchPermission(permission.Id, permission.Version, patch); err != nil {
+		return nil, writeError("permission", permission.Id, err)
 	}
 
-	err = r.permissionRepository.DeletePermission(permission.Id)
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    nil,
+	}, nil
+}
+
+func (r *PermissionService) DeletePermission(request *domain.DeletePermissionRequest) (*domain.Response, error) {
+	permission, err := r.permissionRepository.GetPermissionByID(request.Id)
+	if err != nil && permission == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("permission with id %s not exist", request.Id)}
+	}
+
+	if err := checkVersion("permission", permission.Id, request.IfMatch, permission.Version); err != nil {
+		return nil, err
+	}
+
+	if permission.System {
+		return nil, &appError.AppError{Code: http.StatusForbidden, Message: fmt.Sprintf("permission %s is a system permission and cannot be deleted", permission.Name)}
+	}
+
+	change := &domain.AccessChange{PermissionIds: []string{permission.Id}}
+	if request.DryRun {
+		return dryRun(r.accessImpactService, r.safeguardService, change)
+	}
+
+	if err := r.safeguardService.CheckLockout(change); err != nil {
+		return nil, err
+	}
+
+	err = r.permissionRepository.DeletePermission(permission.Id, permission.Version, time.Now())
 	if err != nil {
+		return nil, writeError("permission", permission.Id, err)
+	}
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    nil,
+	}, nil
+}
+
+// RestorePermission takes a deleted permission out of the trash, along with the grants it
+// had when it was deleted.
+func (r *PermissionService) RestorePermission(request *domain.RestorePermissionRequest) (*domain.Response, error) {
+	permission, err := r.permissionRepository.GetDeletedPermissionByID(request.Id)
+	if err != nil && permission == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("deleted permission with id %s not exist", request.Id)}
+	}
+
+	if exist, err := r.permissionRepository.PermissionIsExist(permission.Name); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	} else if exist {
+		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("permission %s already exist", permission.Name)}
+	}
+
+	if err := r.permissionRepository.RestorePermission(permission.Id, time.Now()); err != nil {
 		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
 	}
+
 	return &domain.Response{
 		Code:    http.StatusOK,
 		Message: http.StatusText(http.StatusOK),
@@ -88,15 +207,65 @@ func (r *PermissionService) DeletePermission(id string) (*domain.Response, error
 	}, nil
 }
 
-func (r *PermissionService) GetPermissions() (*domain.Response, error) {
-	result, err := r.permissionRepository.GetAllPermission()
+func (r *PermissionService) GetPermissions(request *domain.GetPermissionsRequest) (*domain.Response, error) {
+	filter := &domain.PermissionFilter{
+		NamePrefix:   request.Name,
+		CreatedFrom:  request.CreatedFrom,
+		CreatedTo:    request.CreatedTo,
+		UpdatedSince: request.UpdatedSince,
+		Sort:         request.Sort,
+		Order:        request.Order,
+		Limit:        request.Limit(),
+		Offset:       request.Offset(),
+	}
+	if request.UseCursor() {
+		return r.getPermissionsAfter(request, filter)
+	}
+
+	permissions, total, err := r.permissionRepository.GetPermissions(filter)
 	if err != nil {
 		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
 	}
 	return &domain.Response{
 		Code:    http.StatusOK,
 		Message: http.StatusText(http.StatusOK),
-		Data:    result,
+		Data: &domain.Page{
+			Items:   permissions,
+			Total:   total,
+			Page:    request.CurrentPage(),
+			PerPage: request.Limit(),
+		},
+	}, nil
+}
+
+// getPermissionsAfter serves a keyset page, one extra row tells whether another
+// page follows.
+func (r *PermissionService) getPermissionsAfter(request *domain.GetPermissionsRequest, filter *domain.PermissionFilter) (*domain.Response, error) {
+	after, err := decodeCursor(r.cursorCodec, permissionCursor, request.CursorRequest, request.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := request.CursorLimit()
+	filter.After = after
+	filter.Limit = limit + 1
+	permissions, err := r.permissionRepository.GetPermissionsAfter(filter)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	page := &domain.CursorPage{Limit: limit}
+	if len(permissions) > limit {
+		permissions = permissions[:limit]
+		last := permissions[limit-1]
+		page.NextCursor = r.cursorCodec.Encode(permissionCursor, last.CreatedAt, last.Id)
+	}
+	page.Items = permissions
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    page,
 	}, nil
 }
 
@@ -112,3 +281,69 @@ func (r *PermissionService) GetPermission(id string) (*domain.Response, error) {
 		Data:    result,
 	}, nil
 }
+
+// SyncPermissions creates the registered permissions missing from the
+// permissions table as system permissions, flags the stored ones as system
+// permissions and reports the stored ones that are not in the registry.
+func (r *PermissionService) SyncPermissions(registry []domain.PermissionName) (*domain.PermissionSync, error) {
+	permissions, err := r.permissionRepository.GetAllPermission()
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		stored[permission.Name] = true
+	}
+
+	sync := &domain.PermissionSync{
+		Created: make([]string, 0),
+		Marked:  make([]string, 0),
+		Orphans: make([]*domain.Permission, 0),
+	}
+
+	// permissions stored before they were registered, or before the system
+	// flag existed, would otherwise stay deletable
+	registered := make(map[string]bool, len(registry))
+	for _, name := range registry {
+		registered[string(name)] = true
+	}
+	ids := make([]string, 0)
+	for _, permission := range permissions {
+		if registered[permission.Name] && !permission.System {
+			ids = append(ids, permission.Id)
+			sync.Marked = append(sync.Marked, permission.Name)
+		}
+	}
+	if len(ids) > 0 {
+		if err := r.permissionRepository.MarkSystemPermissions(ids, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range registry {
+		if stored[string(name)] {
+			continue
+		}
+		permission := &domain.Permission{
+			Id:        uuid.New().String(),
+			Name:      string(name),
+			System:    true,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		if err := r.permissionRepository.CreatePermission(permission); err != nil {
+			return nil, err
+		}
+		stored[permission.Name] = true
+		sync.Created = append(sync.Created, permission.Name)
+	}
+
+	for _, permission := range permissions {
+		if !domain.IsRegisteredPermission(domain.PermissionName(permission.Name)) {
+			sync.Orphans = append(sync.Orphans, permission)
+		}
+	}
+
+	return sync, nil
+}
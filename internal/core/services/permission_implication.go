@@ -0,0 +1,268 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	appError "user-svc/internal/shared/error"
+)
+
+type PermissionImplicationService struct {
+	permissionImplicationRepository ports.PermissionImplicationRepository
+	permissionService               ports.PermissionService
+}
+
+func NewPermissionImplicationService(permissionImplicationRepository ports.PermissionImplicationRepository, permissionService ports.PermissionService) *PermissionImplicationService {
+	return &PermissionImplicationService{
+		permissionImplicationRepository: permissionImplicationRepository,
+		permissionService:               permissionService,
+	}
+}
+
+func (s *PermissionImplicationService) GetPermissionImplications(request *domain.GetPermissionImplicationsRequest) (*domain.Response, error) {
+	permission, err := s.permissionService.GetPermission(request.PermissionId)
+	if err != nil && permission == nil {
+		return nil, err
+	}
+
+	result, err := s.permissionImplicationRepository.GetPermissionImplications(request.PermissionId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+func (s *PermissionImplicationService) AddPermissionImplications(request *domain.AddPermissionImplicationsRequest) (*domain.Response, error) {
+	result, err := s.permissionService.GetPermission(request.PermissionId)
+	if err != nil && result == nil {
+		return nil, err
+	}
+	permission := result.Data.(*domain.Permission)
+
+	implied := uniqueStrings(request.ImpliedPermissionsId)
+	for _, impliedID := range implied {
+		impliedPermission, err := s.permissionService.GetPermission(impliedID)
+		if err != nil && impliedPermission == nil {
+			return nil, err
+		}
+		if impliedID == permission.Id {
+			return nil, &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("permission %s cannot imply itself", permission.Name)}
+		}
+	}
+
+	implications, err := s.permissionImplicationRepository.GetAllPermissionImplications()
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	// the new edges only close a cycle when an implied permission already
+	// reaches the permission that implies it
+	graph := implicationGraph(implications)
+	for _, impliedID := range implied {
+		if path := findImplicationPath(graph, impliedID, permission.Id); path != nil {
+			names := implicationNames(implications)
+			return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("implication would create a cycle: %s -> %s", permission.Name, joinImplicationPath(path, names))}
+		}
+	}
+
+	err = s.permissionImplicationRepository.AddPermissionImplications(permission.Id, implied)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	return &domain.Response{
+		Code:    http.StatusCreated,
+		Message: http.StatusText(http.StatusCreated),
+		Data:    nil,
+	}, nil
+}
+
+func (s *PermissionImplicationService) RemovePermissionImplications(request *domain.RemovePermissionImplicationsRequest) (*domain.Response, error) {
+	permission, err := s.permissionService.GetPermission(request.PermissionId)
+	if err != nil && permission == nil {
+		return nil, err
+	}
+
+	err = s.permissionImplicationRepository.RemovePermissionImplications(request.PermissionId, uniqueStrings(request.ImpliedPermissionsId))
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    nil,
+	}, nil
+}
+
+// ExpandPermissions returns the given permission names together with every
+// permission they imply, directly or transitively.
+func (s *PermissionImplicationService) ExpandPermissions(permissions []string) ([]string, error) {
+	implications, err := s.permissionImplicationRepository.GetAllPermissionImplications()
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return expandPermissionNames(implications, permissions), nil
+}
+
+// GetImpliedPermissions returns the permissions implied by the given ones
+// that are not part of the given list themselves.
+func (s *PermissionImplicationService) GetImpliedPermissions(permissionsID []string) ([]*domain.Permission, error) {
+	implications, err := s.permissionImplicationRepository.GetAllPermissionImplications()
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	names := implicationNames(implications)
+	result := make([]*domain.Permission, 0)
+	for _, id := range reachable(implicationGraph(implications), permissionsID) {
+		if contains(permissionsID, id) {
+			continue
+		}
+		result = append(result, &domain.Permission{Id: id, Name: names[id]})
+	}
+
+	return result, nil
+}
+
+// TraceImpliedPermissions walks the implications from each given permission
+// on its own, so every implied permission knows which given ones reach it.
+func (s *PermissionImplicationService) TraceImpliedPermissions(permissionsID []string) ([]*domain.ImpliedPermission, error) {
+	implications, err := s.permissionImplicationRepository.GetAllPermissionImplications()
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	graph := implicationGraph(implications)
+	names := implicationNames(implications)
+	result := make([]*domain.ImpliedPermission, 0)
+	index := make(map[string]*domain.ImpliedPermission)
+	for _, id := range permissionsID {
+		for _, implied := range reachable(graph, []string{id}) {
+			if contains(permissionsID, implied) {
+				continue
+			}
+			permission, ok := index[implied]
+			if !ok {
+				permission = &domain.ImpliedPermission{Id: implied, Name: names[implied]}
+				index[implied] = permission
+				result = append(result, permission)
+			}
+			permission.ImpliedBy = append(permission.ImpliedBy, names[id])
+		}
+	}
+
+	return result, nil
+}
+
+// GetImplyingPermissions walks the implications backwards from the
+// permission.
+func (s *PermissionImplicationService) GetImplyingPermissions(permissionID string) ([]*domain.Permission, error) {
+	implications, err := s.permissionImplicationRepository.GetAllPermissionImplications()
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	reverse := make(map[string][]string)
+	for _, implication := range implications {
+		reverse[implication.ImpliedPermissionId] = append(reverse[implication.ImpliedPermissionId], implication.PermissionId)
+	}
+	names := implicationNames(implications)
+	result := make([]*domain.Permission, 0)
+	for _, id := range reachable(reverse, []string{permissionID}) {
+		if id != permissionID {
+			result = append(result, &domain.Permission{Id: id, Name: names[id]})
+		}
+	}
+
+	return result, nil
+}
+
+func implicationGraph(implications []*domain.PermissionImplication) map[string][]string {
+	graph := make(map[string][]string)
+	for _, implication := range implications {
+		graph[implication.PermissionId] = append(graph[implication.PermissionId], implication.ImpliedPermissionId)
+	}
+	return graph
+}
+
+func expandPermissionNames(implications []*domain.PermissionImplication, permissions []string) []string {
+	graph := make(map[string][]string)
+	for _, implication := range implications {
+		graph[implication.PermissionName] = append(graph[implication.PermissionName], implication.ImpliedPermissionName)
+	}
+	return reachable(graph, permissions)
+}
+
+func implicationNames(implications []*domain.PermissionImplication) map[string]string {
+	names := make(map[string]string)
+	for _, implication := range implications {
+		names[implication.PermissionId] = implication.PermissionName
+		names[implication.ImpliedPermissionId] = implication.ImpliedPermissionName
+	}
+	return names
+}
+
+// reachable walks the graph breadth first and returns the start nodes followed
+// by every node they reach, each once.
+func reachable(graph map[string][]string, start []string) []string {
+	seen := make(map[string]bool)
+	queue := make([]string, 0, len(start))
+	for _, node := range start {
+		if !seen[node] {
+			seen[node] = true
+			queue = append(queue, node)
+		}
+	}
+
+	for i := 0; i < len(queue); i++ {
+		for _, next := range graph[queue[i]] {
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return queue
+}
+
+// findImplicationPath returns the nodes from one permission to another along
+// the implication graph, or nil when the target is not reachable.
+func findImplicationPath(graph map[string][]string, from, to string) []string {
+	parent := map[string]string{from: ""}
+	queue := []string{from}
+	for i := 0; i < len(queue); i++ {
+		node := queue[i]
+		if node == to {
+			path := make([]string, 0)
+			for ; node != ""; node = parent[node] {
+				path = append([]string{node}, path...)
+			}
+			return path
+		}
+		for _, next := range graph[node] {
+			if _, ok := parent[next]; !ok {
+				parent[next] = node
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
+
+func joinImplicationPath(path []string, names map[string]string) string {
+	result := ""
+	for i, id := range path {
+		if i > 0 {
+			result += " -> "
+		}
+		result += names[id]
+	}
+	return result
+}
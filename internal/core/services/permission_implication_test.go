@@ -0,0 +1,128 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	appError "user-svc/internal/shared/error"
+
+	"github.com/stretchr/testify/mock"
+)
+
+var testImplications = []*domain.PermissionImplication{
+	{PermissionId: "p-update", PermissionName: "Update-User", ImpliedPermissionId: "p-view", ImpliedPermissionName: "View-User"},
+	{PermissionId: "p-view", PermissionName: "View-User", ImpliedPermissionId: "p-list", ImpliedPermissionName: "List-User"},
+}
+
+func TestPermissionImplicationService_AddPermissionImplications(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *domain.AddPermissionImplicationsRequest
+		wantCode int
+	}{
+		{
+			name:    "success - new implication",
+			request: &domain.AddPermissionImplicationsRequest{PermissionId: "p-delete", ImpliedPermissionsId: []string{"p-update"}},
+		},
+		{
+			name:     "failed - implies itself",
+			request:  &domain.AddPermissionImplicationsRequest{PermissionId: "p-view", ImpliedPermissionsId: []string{"p-view"}},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "failed - transitive cycle",
+			request:  &domain.AddPermissionImplicationsRequest{PermissionId: "p-list", ImpliedPermissionsId: []string{"p-update"}},
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPermissionService := mockCore.PermissionService{}
+			mockPermissionService.On("GetPermission", mock.Anything).Return(func(id string) *domain.Response {
+				return &domain.Response{Code: http.StatusOK, Data: &domain.Permission{Id: id, Name: id}}
+			}, nil)
+
+			mockRepository := mockCore.PermissionImplicationRepository{}
+			mockRepository.On("GetAllPermissionImplications").Return(testImplications, nil)
+			mockRepository.On("AddPermissionImplications", tt.request.PermissionId, tt.request.ImpliedPermissionsId).Return(nil)
+
+			s := NewPermissionImplicationService(&mockRepository, &mockPermissionService)
+			_, err := s.AddPermissionImplications(tt.request)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("AddPermissionImplications() error = %v", err)
+				}
+				mockRepository.AssertCalled(t, "AddPermissionImplications", tt.request.PermissionId, tt.request.ImpliedPermissionsId)
+				return
+			}
+
+			appErr, ok := err.(*appError.AppError)
+			if !ok || appErr.Code != tt.wantCode {
+				t.Errorf("AddPermissionImplications() error = %v, want code %d", err, tt.wantCode)
+			}
+			mockRepository.AssertNotCalled(t, "AddPermissionImplications", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPermissionImplicationService_ExpandPermissions(t *testing.T) {
+	mockRepository := mockCore.PermissionImplicationRepository{}
+	mockRepository.On("GetAllPermissionImplications").Return(testImplications, nil)
+
+	s := NewPermissionImplicationService(&mockRepository, &mockCore.PermissionService{})
+	got, err := s.ExpandPermissions([]string{"Update-User"})
+	if err != nil {
+		t.Fatalf("ExpandPermissions() error = %v", err)
+	}
+
+	want := []string{"Update-User", "View-User", "List-User"}
+	if len(got) != len(want) {
+		t.Fatalf("ExpandPermissions() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ExpandPermissions() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestPermissionImplicationService_TraceImpliedPermissions(t *testing.T) {
+	mockRepository := mockCore.PermissionImplicationRepository{}
+	mockRepository.On("GetAllPermissionImplications").Return(testImplications, nil)
+
+	s := NewPermissionImplicationService(&mockRepository, &mockCore.PermissionService{})
+	got, err := s.TraceImpliedPermissions([]string{"p-update", "p-view"})
+	if err != nil {
+		t.Fatalf("TraceImpliedPermissions() error = %v", err)
+	}
+
+	// View-User is granted itself, List-User is reached from both
+	if len(got) != 1 || got[0].Id != "p-list" || got[0].Name != "List-User" {
+		t.Fatalf("TraceImpliedPermissions() = %v, want only List-User", got)
+	}
+	if len(got[0].ImpliedBy) != 2 || got[0].ImpliedBy[0] != "Update-User" || got[0].ImpliedBy[1] != "View-User" {
+		t.Errorf("TraceImpliedPermissions() implied by = %v, want [Update-User View-User]", got[0].ImpliedBy)
+	}
+}
+
+func TestPermissionImplicationService_GetImplyingPermissions(t *testing.T) {
+	mockRepository := mockCore.PermissionImplicationRepository{}
+	mockRepository.On("GetAllPermissionImplications").Return(testImplications, nil)
+
+	s := NewPermissionImplicationService(&mockRepository, &mockCore.PermissionService{})
+	got, err := s.GetImplyingPermissions("p-list")
+	if err != nil {
+		t.Fatalf("GetImplyingPermissions() error = %v", err)
+	}
+
+	want := []string{"View-User", "Update-User"}
+	if len(got) != len(want) {
+		t.Fatalf("GetImplyingPermissions() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i].Name != want[i] {
+			t.Errorf("GetImplyingPermissions() = %v, want %v", got, want)
+		}
+	}
+}
@@ -1 +1,84 @@
 package services
+
+import (
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	mockCursor "user-svc/internal/mocks/shared/cursor"
+)
+
+func TestPermissionService_SyncPermissions(t *testing.T) {
+	registry := []domain.PermissionName{domain.PermissionListUser, domain.PermissionViewUser}
+
+	tests := []struct {
+		name        string
+		stored      []*domain.Permission
+		wantCreated []string
+		wantMarked  []string
+		wantOrphans int
+		wantErr     bool
+	}{
+		{
+			name:        "success - creates missing permissions",
+			stored:      []*domain.Permission{{Id: "1", Name: "List-User", System: true}},
+			wantCreated: []string{"View-User"},
+		},
+		{
+			name:        "success - marks stored registered permissions as system",
+			stored:      []*domain.Permission{{Id: "1", Name: "List-User"}, {Id: "2", Name: "View-User", System: true}, {Id: "3", Name: "Export-Report"}},
+			wantCreated: []string{},
+			wantMarked:  []string{"List-User"},
+			wantOrphans: 1,
+		},
+		{
+			name:        "success - reports orphan permissions",
+			stored:      []*domain.Permission{{Id: "1", Name: "List-User", System: true}, {Id: "2", Name: "View-User", System: true}, {Id: "3", Name: "Export-Report"}},
+			wantCreated: []string{},
+			wantOrphans: 1,
+		},
+		{
+			name:    "failed - unable to load permissions",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPermissionRepository := mockCore.PermissionRepository{}
+			if tt.wantErr {
+				mockPermissionRepository.On("GetAllPermission").Return(nil, errors.New("error"))
+			} else {
+				mockPermissionRepository.On("GetAllPermission").Return(tt.stored, nil)
+			}
+			mockPermissionRepository.On("CreatePermission", mock.Anything).Return(nil)
+			mockPermissionRepository.On("MarkSystemPermissions", []string{"1"}, mock.Anything).Return(nil)
+
+			s := NewPermissionService(&mockPermissionRepository, &mockCore.SafeguardService{}, &mockCore.AccessImpactService{}, &mockCursor.Codec{})
+			got, err := s.SyncPermissions(registry)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SyncPermissions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(got.Created) != len(tt.wantCreated) {
+				t.Fatalf("SyncPermissions() created = %v, want %v", got.Created, tt.wantCreated)
+			}
+			for i, name := range tt.wantCreated {
+				if got.Created[i] != name {
+					t.Errorf("SyncPermissions() created = %v, want %v", got.Created, tt.wantCreated)
+				}
+			}
+			if len(got.Marked) != len(tt.wantMarked) {
+				t.Errorf("SyncPermissions() marked = %v, want %v", got.Marked, tt.wantMarked)
+			}
+			if len(got.Orphans) != tt.wantOrphans {
+				t.Errorf("SyncPermissions() orphans = %d, want %d", len(got.Orphans), tt.wantOrphans)
+			}
+			mockPermissionRepository.AssertNumberOfCalls(t, "CreatePermission", len(tt.wantCreated))
+			mockPermissionRepository.AssertNumberOfCalls(t, "MarkSystemPermissions", len(tt.wantMarked))
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+)
+
+type PermissionUsageService struct {
+	config                         *config.Config
+	permissionUsageRepository      ports.PermissionUsageRepository
+	permissionUsageCacheRepository ports.PermissionUsageCacheRepository
+}
+
+func NewPermissionUsageService(config *config.Config, permissionUsageRepository ports.PermissionUsageRepository, permissionUsageCacheRepository ports.PermissionUsageCacheRepository) *PermissionUsageService {
+	return &PermissionUsageService{
+		config:                         config,
+		permissionUsageRepository:      permissionUsageRepository,
+		permissionUsageCacheRepository: permissionUsageCacheRepository,
+	}
+}
+
+// RecordUsage only touches the cache, the usage reaches postgres with the
+// next flush.
+func (s *PermissionUsageService) RecordUsage(userID string, permission string) error {
+	return s.permissionUsageCacheRepository.RecordPermissionUsage(userID, permission, time.Now())
+}
+
+// FlushUsage moves the usage aggregated in the cache to postgres. The cache
+// keeps the pending usage until it is saved, so a failed flush is retried.
+func (s *PermissionUsageService) FlushUsage() error {
+	usages, err := s.permissionUsageCacheRepository.GetPendingPermissionUsage()
+	if err != nil {
+		return err
+	}
+
+	if len(usages) > 0 {
+		if err := s.permissionUsageRepository.SavePermissionUsage(usages); err != nil {
+			return err
+		}
+	}
+
+	return s.permissionUsageCacheRepository.ClearPendingPermissionUsage()
+}
+
+// GetUnusedGrants reports, per role, the permissions none of its holders used
+// in the last days, with a suggestion to right-size the role. A reported grant
+// is unused in every role granting it, but a grant reported in use may only be
+// used through another role, the report notes this.
+func (s *PermissionUsageService) GetUnusedGrants(request *domain.GetUnusedGrantsRequest) (*domain.Response, error) {
+	days := request.Days
+	if days == 0 {
+		days = s.config.App.PermissionUsage.UnusedDays
+	}
+	since := time.Now().AddDate(0, 0, -days)
+
+	grants, err := s.permissionUsageRepository.GetGrantUsage(request.RoleId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	report := &domain.UnusedGrantsReport{Days: days, Since: since, Note: domain.UnusedGrantsAttributionNote, Roles: make([]*domain.RoleRightSizing, 0)}
+	var role *domain.RoleRightSizing
+	for _, grant := range grants {
+		if role == nil || role.RoleId != grant.RoleId {
+			role = &domain.RoleRightSizing{
+				RoleId:            grant.RoleId,
+				RoleName:          grant.RoleName,
+				Holders:           grant.Holders,
+				UnusedGrants:      make([]*domain.GrantUsage, 0),
+				KeepPermissions:   make([]string, 0),
+				RemovePermissions: make([]string, 0),
+			}
+			report.Roles = append(report.Roles, role)
+		}
+
+		if grant.LastUsedAt == nil || grant.LastUsedAt.Before(since) {
+			role.UnusedGrants = append(role.UnusedGrants, grant)
+			role.RemovePermissions = append(role.RemovePermissions, grant.PermissionName)
+			continue
+		}
+		role.KeepPermissions = append(role.KeepPermissions, grant.PermissionName)
+	}
+
+	// roles whose grants are all in use need no right-sizing
+	roles := make([]*domain.RoleRightSizing, 0, len(report.Roles))
+	for _, role := range report.Roles {
+		if len(role.UnusedGrants) == 0 {
+			continue
+		}
+		role.Suggestion = rightSizingSuggestion(role, days)
+		roles = append(roles, role)
+	}
+	report.Roles = roles
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    report,
+	}, nil
+}
+
+func rightSizingSuggestion(role *domain.RoleRightSizing, days int) string {
+	switch {
+	case role.Holders == 0:
+		return fmt.Sprintf("role %s has no holders, consider deleting it", role.RoleName)
+	case len(role.KeepPermissions) == 0:
+		return fmt.Sprintf("none of the %d holders of role %s used it in the last %d days, consider revoking the role", role.Holders, role.RoleName, days)
+	default:
+		return fmt.Sprintf("remove %d of %d permissions from role %s, its %d holders did not use them in the last %d days",
+			len(role.RemovePermissions), len(role.RemovePermissions)+len(role.KeepPermissions), role.RoleName, role.Holders, days)
+	}
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	"user-svc/internal/shared/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPermissionUsageService_FlushUsage(t *testing.T) {
+	usages := []*domain.PermissionUsage{{UserId: "user-1", Permission: "View-User", UsedAt: time.Now()}}
+
+	t.Run("success - pending usage saved and cleared", func(t *testing.T) {
+		mockCache := mockCore.PermissionUsageCacheRepository{}
+		mockCache.On("GetPendingPermissionUsage").Return(usages, nil)
+		mockCache.On("ClearPendingPermissionUsage").Return(nil)
+		mockRepository := mockCore.PermissionUsageRepository{}
+		mockRepository.On("SavePermissionUsage", usages).Return(nil)
+
+		s := NewPermissionUsageService(&config.Config{}, &mockRepository, &mockCache)
+		assert.NoError(t, s.FlushUsage())
+		mockCache.AssertCalled(t, "ClearPendingPermissionUsage")
+	})
+
+	t.Run("failed - pending usage kept when saving fails", func(t *testing.T) {
+		mockCache := mockCore.PermissionUsageCacheRepository{}
+		mockCache.On("GetPendingPermissionUsage").Return(usages, nil)
+		mockRepository := mockCore.PermissionUsageRepository{}
+		mockRepository.On("SavePermissionUsage", usages).Return(errors.New("error"))
+
+		s := NewPermissionUsageService(&config.Config{}, &mockRepository, &mockCache)
+		assert.Error(t, s.FlushUsage())
+		mockCache.AssertNotCalled(t, "ClearPendingPermissionUsage")
+	})
+}
+
+func TestPermissionUsageService_GetUnusedGrants(t *testing.T) {
+	recent := time.Now().AddDate(0, 0, -1)
+	stale := time.Now().AddDate(0, 0, -120)
+	grants := []*domain.GrantUsage{
+		{RoleId: "role-1", RoleName: "Manager", PermissionName: "List-User", Holders: 3, LastUsedAt: &recent},
+		{RoleId: "role-1", RoleName: "Manager", PermissionName: "Delete-User", Holders: 3, LastUsedAt: &stale},
+		{RoleId: "role-1", RoleName: "Manager", PermissionName: "Update-User", Holders: 3},
+		{RoleId: "role-2", RoleName: "Viewer", PermissionName: "View-User", Holders: 5, LastUsedAt: &recent},
+		{RoleId: "role-3", RoleName: "Auditor", PermissionName: "List-Role", Holders: 2, LastUsedAt: &stale},
+	}
+
+	mockRepository := mockCore.PermissionUsageRepository{}
+	mockRepository.On("GetGrantUsage", "").Return(grants, nil)
+
+	cfg := &config.Config{}
+	cfg.App.PermissionUsage.UnusedDays = 90
+	s := NewPermissionUsageService(cfg, &mockRepository, &mockCore.PermissionUsageCacheRepository{})
+	got, err := s.GetUnusedGrants(&domain.GetUnusedGrantsRequest{})
+	assert.NoError(t, err)
+
+	report := got.Data.(*domain.UnusedGrantsReport)
+	assert.Equal(t, 90, report.Days)
+	assert.Equal(t, domain.UnusedGrantsAttributionNote, report.Note)
+	assert.Len(t, report.Roles, 2)
+
+	manager := report.Roles[0]
+	assert.Equal(t, "Manager", manager.RoleName)
+	assert.Equal(t, []string{"List-User"}, manager.KeepPermissions)
+	assert.Equal(t, []string{"Delete-User", "Update-User"}, manager.RemovePermissions)
+	assert.Contains(t, manager.Suggestion, "remove 2 of 3 permissions")
+
+	auditor := report.Roles[1]
+	assert.Equal(t, "Auditor", auditor.RoleName)
+	assert.Contains(t, auditor.Suggestion, "consider revoking the role")
+
+	mockRepository.AssertCalled(t, "GetGrantUsage", mock.Anything)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"time"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	"user-svc/internal/shared/config"
+)
+
+type PurgeService struct {
+	config               *config.Config
+	userRepository       ports.UserRepository
+	roleRepository       ports.RoleRepository
+	permissionRepository ports.PermissionRepository
+	relationRepository   ports.RelationRepository
+}
+
+func NewPurgeService(config *config.Config, userRepository ports.UserRepository, roleRepository ports.RoleRepository, permissionRepository ports.PermissionRepository, relationRepository ports.RelationRepository) *PurgeService {
+	return &PurgeService{
+		config:               config,
+		userRepository:       userRepository,
+		roleRepository:       roleRepository,
+		permissionRepository: permissionRepository,
+		relationRepository:   relationRepository,
+	}
+}
+
+// PurgeDeleted removes for good the users, roles and permissions that stayed
+// deleted longer than the retention. Their assignments cascade with them, so
+// they can no longer be restored. Deleted relation tuples have no retention,
+// they go as soon as no read can see them.
+func (s *PurgeService) PurgeDeleted() (*domain.PurgeResult, error) {
+	result := &domain.PurgeResult{Before: time.Now().AddDate(0, 0, -s.config.App.SoftDelete.RetentionDays)}
+
+	var err error
+	if result.Users, err = s.userRepository.PurgeUsers(result.Before); err != nil {
+		return result, err
+	}
+	if result.Roles, err = s.roleRepository.PurgeRoles(result.Before); err != nil {
+		return result, err
+	}
+	if result.Permissions, err = s.permissionRepository.PurgePermissions(result.Before); err != nil {
+		return result, err
+	}
+	if result.RelationTuples, err = s.relationRepository.PruneRelationTuples(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
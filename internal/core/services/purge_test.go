@@ -0,0 +1,48 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+	mockCore "user-svc/internal/mocks/core/ports"
+	"user-svc/internal/shared/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPurgeService_PurgeDeleted(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.SoftDelete.RetentionDays = 30
+
+	t.Run("success - deletions past the retention purged", func(t *testing.T) {
+		mockUserRepository := mockCore.UserRepository{}
+		mockUserRepository.On("PurgeUsers", mock.Anything).Return(int64(2), nil)
+		mockRoleRepository := mockCore.RoleRepository{}
+		mockRoleRepository.On("PurgeRoles", mock.Anything).Return(int64(1), nil)
+		mockPermissionRepository := mockCore.PermissionRepository{}
+		mockPermissionRepository.On("PurgePermissions", mock.Anything).Return(int64(0), nil)
+		mockRelationRepository := mockCore.RelationRepository{}
+		mockRelationRepository.On("PruneRelationTuples").Return(int64(4), nil)
+
+		s := NewPurgeService(cfg, &mockUserRepository, &mockRoleRepository, &mockPermissionRepository, &mockRelationRepository)
+		got, err := s.PurgeDeleted()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), got.Users)
+		assert.Equal(t, int64(1), got.Roles)
+		assert.Equal(t, int64(0), got.Permissions)
+		assert.Equal(t, int64(4), got.RelationTuples)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), got.Before, time.Minute)
+	})
+
+	t.Run("failed - stops at the first error", func(t *testing.T) {
+		mockUserRepository := mockCore.UserRepository{}
+		mockUserRepository.On("PurgeUsers", mock.Anything).Return(int64(0), errors.New("error"))
+		mockRoleRepository := mockCore.RoleRepository{}
+
+		s := NewPurgeService(cfg, &mockUserRepository, &mockRoleRepository, &mockCore.PermissionRepository{}, &mockCore.RelationRepository{})
+		_, err := s.PurgeDeleted()
+		assert.Error(t, err)
+		mockRoleRepository.AssertNotCalled(t, "PurgeRoles", mock.Anything)
+	})
+}
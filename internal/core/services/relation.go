@@ -0,0 +1,527 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+)
+
+// relationRewrite is the namespace config of a relation: besides its own
+// tuples, a relation is granted by the union of these rewrites.
+type relationRewrite struct {
+	computedUsersets []string
+	tupleToUsersets  []tupleToUserset
+}
+
+type tupleToUserset struct {
+	tupleset        string
+	computedUserset string
+}
+
+// reverseRewrite is a rewrite seen from the relation it computes from: holding
+// that relation on an object grants relation on the same object, or on the
+// objects of namespace whose tupleset tuples point to it.
+type reverseRewrite struct {
+	namespace string
+	relation  string
+	tupleset  string
+}
+
+// userset is an object along with a relation on it.
+type userset struct {
+	namespace string
+	id        string
+	relation  string
+}
+
+// subjectRef is the subject a check is evaluated for, an object when relation
+// is empty, otherwise a userset.
+type subjectRef struct {
+	namespace string
+	id        string
+	relation  string
+}
+
+type RelationService struct {
+	config             *config.Config
+	relationRepository ports.RelationRepository
+	namespaces         map[string]map[string]*relationRewrite
+	// computedBy maps a namespace and relation to the relations of the
+	// namespace computed from it
+	computedBy map[string]map[string][]string
+	// tupleToUsersetsBy maps a computed userset to the rewrites that follow a
+	// tupleset to it
+	tupleToUsersetsBy map[string][]reverseRewrite
+}
+
+func NewRelationService(config *config.Config, relationRepository ports.RelationRepository) *RelationService {
+	namespaces := make(map[string]map[string]*relationRewrite)
+	computedBy := make(map[string]map[string][]string)
+	tupleToUsersetsBy := make(map[string][]reverseRewrite)
+	for _, namespace := range config.App.Rebac.Namespaces {
+		relations := make(map[string]*relationRewrite)
+		computedBy[namespace.Name] = make(map[string][]string)
+		for _, relation := range namespace.Relations {
+			rewrite := &relationRewrite{computedUsersets: relation.ComputedUsersets}
+			for _, computed := range relation.ComputedUsersets {
+				computedBy[namespace.Name][computed] = append(computedBy[namespace.Name][computed], relation.Name)
+			}
+			for _, ttu := range relation.TupleToUsersets {
+				rewrite.tupleToUsersets = append(rewrite.tupleToUsersets, tupleToUserset{tupleset: ttu.Tupleset, computedUserset: ttu.ComputedUserset})
+				tupleToUsersetsBy[ttu.ComputedUserset] = append(tupleToUsersetsBy[ttu.ComputedUserset],
+					reverseRewrite{namespace: namespace.Name, relation: relation.Name, tupleset: ttu.Tupleset})
+			}
+			relations[relation.Name] = rewrite
+		}
+		namespaces[namespace.Name] = relations
+	}
+
+	return &RelationService{
+		config:             config,
+		relationRepository: relationRepository,
+		namespaces:         namespaces,
+		computedBy:         computedBy,
+		tupleToUsersetsBy:  tupleToUsersetsBy,
+	}
+}
+
+func (s *RelationService) WriteRelationTuples(request *domain.WriteRelationTuplesRequest) (*domain.Response, error) {
+	tuples, err := s.parseTuples(request.Tuples)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := s.relationRepository.WriteRelationTuples(tuples, nil)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusCreated,
+		Message: http.StatusText(http.StatusCreated),
+		Data:    &domain.RelationTuples{Tuples: tuples, ConsistencyToken: domain.EncodeConsistencyToken(revision)},
+	}, nil
+}
+
+func (s *RelationService) DeleteRelationTuples(request *domain.WriteRelationTuplesRequest) (*domain.Response, error) {
+	tuples, err := s.parseTuples(request.Tuples)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := s.relationRepository.WriteRelationTuples(nil, tuples)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    &domain.RelationTuples{Tuples: tuples, ConsistencyToken: domain.EncodeConsistencyToken(revision)},
+	}, nil
+}
+
+func (s *RelationService) GetRelationTuples(request *domain.GetRelationTuplesRequest) (*domain.Response, error) {
+	filter := &domain.RelationTupleFilter{
+		Namespace: request.Namespace,
+		Relation:  request.Relation,
+	}
+	if request.Object != "" {
+		namespace, id, err := domain.ParseObject(request.Object)
+		if err != nil {
+			return nil, &appError.AppError{Code: http.StatusBadRequest, Message: err.Error()}
+		}
+		filter.Namespace = namespace
+		filter.ObjectId = id
+	}
+	if request.Subject != "" {
+		namespace, id, relation, err := domain.ParseSubject(request.Subject)
+		if err != nil {
+			return nil, &appError.AppError{Code: http.StatusBadRequest, Message: err.Error()}
+		}
+		filter.SubjectNamespace = namespace
+		filter.SubjectId = id
+		filter.SubjectRelation = &relation
+	}
+
+	revision, err := s.revision(request.ConsistencyToken)
+	if err != nil {
+		return nil, err
+	}
+
+	tuples, err := s.relationRepository.GetRelationTuples(filter, revision)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    &domain.RelationTuples{Tuples: tuples, ConsistencyToken: domain.EncodeConsistencyToken(revision)},
+	}, nil
+}
+
+// Check reports whether subject holds relation on object, directly, through a
+// userset or through the relation rewrites of the namespace.
+func (s *RelationService) Check(request *domain.CheckRelationRequest) (*domain.Response, error) {
+	namespace, id, err := s.parseObjectRelation(request.Object, request.Relation)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := s.parseSubject(request.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := s.revision(request.ConsistencyToken)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := s.check(namespace, id, request.Relation, subject, revision, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    &domain.RelationCheck{Allowed: allowed, ConsistencyToken: domain.EncodeConsistencyToken(revision)},
+	}, nil
+}
+
+func (s *RelationService) Expand(request *domain.ExpandRelationRequest) (*domain.Response, error) {
+	namespace, id, err := s.parseObjectRelation(request.Object, request.Relation)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := s.revision(request.ConsistencyToken)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := s.expand(namespace, id, request.Relation, revision, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    &domain.RelationExpansion{Tree: tree, ConsistencyToken: domain.EncodeConsistencyToken(revision)},
+	}, nil
+}
+
+// ListObjects returns the objects of a namespace on which subject holds
+// relation. It walks the tuples and rewrites back from the subject, so its
+// cost follows what the subject can reach rather than the size of the
+// namespace.
+func (s *RelationService) ListObjects(request *domain.ListObjectsRequest) (*domain.Response, error) {
+	if !s.relationExists(request.Namespace, request.Relation) {
+		return nil, &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("relation %s is not defined in namespace %s", request.Relation, request.Namespace)}
+	}
+
+	subject, err := s.parseSubject(request.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := s.revision(request.ConsistencyToken)
+	if err != nil {
+		return nil, err
+	}
+
+	reached, err := s.reach(subject, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]string, 0)
+	for _, held := range reached {
+		if held.namespace == request.Namespace && held.relation == request.Relation {
+			objects = append(objects, held.namespace+":"+held.id)
+		}
+	}
+	sort.Strings(objects)
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    &domain.RelationObjects{Objects: objects, ConsistencyToken: domain.EncodeConsistencyToken(revision)},
+	}, nil
+}
+
+// reach returns every userset subject holds, found level by level the way
+// check would find subject from it. Levels past the maximum depth are not
+// followed, check would refuse them as well.
+func (s *RelationService) reach(subject *subjectRef, revision int64) ([]userset, error) {
+	reached := make([]userset, 0)
+	seen := make(map[userset]bool)
+	level := make([]userset, 0)
+	add := func(held userset) {
+		if !seen[held] {
+			seen[held] = true
+			reached = append(reached, held)
+			level = append(level, held)
+		}
+	}
+
+	if subject.relation != "" {
+		add(userset{namespace: subject.namespace, id: subject.id, relation: subject.relation})
+	} else {
+		direct := ""
+		tuples, err := s.relationRepository.GetRelationTuples(&domain.RelationTupleFilter{SubjectNamespace: subject.namespace, SubjectId: subject.id, SubjectRelation: &direct}, revision)
+		if err != nil {
+			return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		}
+		for _, tuple := range tuples {
+			add(userset{namespace: tuple.Namespace, id: tuple.ObjectId, relation: tuple.Relation})
+		}
+	}
+
+	for depth := 0; len(level) > 0 && depth < s.config.App.Rebac.MaxDepth; depth++ {
+		current := level
+		level = make([]userset, 0)
+		for _, held := range current {
+			if err := s.reachFrom(held, revision, add); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return reached, nil
+}
+
+// reachFrom adds the usersets holding held grants: the tuples naming it as
+// their subject, the relations computed from it and the relations following a
+// tupleset to its object.
+func (s *RelationService) reachFrom(held userset, revision int64, add func(userset)) error {
+	relation := held.relation
+	tuples, err := s.relationRepository.GetRelationTuples(&domain.RelationTupleFilter{SubjectNamespace: held.namespace, SubjectId: held.id, SubjectRelation: &relation}, revision)
+	if err != nil {
+		return &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	for _, tuple := range tuples {
+		add(userset{namespace: tuple.Namespace, id: tuple.ObjectId, relation: tuple.Relation})
+	}
+
+	for _, computed := range s.computedBy[held.namespace][held.relation] {
+		add(userset{namespace: held.namespace, id: held.id, relation: computed})
+	}
+
+	for _, ttu := range s.tupleToUsersetsBy[held.relation] {
+		tuples, err := s.relationRepository.GetRelationTuples(&domain.RelationTupleFilter{Namespace: ttu.namespace, Relation: ttu.tupleset, SubjectNamespace: held.namespace, SubjectId: held.id}, revision)
+		if err != nil {
+			return &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		}
+		for _, tuple := range tuples {
+			add(userset{namespace: tuple.Namespace, id: tuple.ObjectId, relation: ttu.relation})
+		}
+	}
+
+	return nil
+}
+
+func (s *RelationService) check(namespace string, id string, relation string, subject *subjectRef, revision int64, depth int) (bool, error) {
+	if depth > s.config.App.Rebac.MaxDepth {
+		return false, &appError.AppError{Code: http.StatusBadRequest, Message: "relation check exceeded the maximum depth"}
+	}
+
+	// a userset subject holds the relation it names
+	if subject.namespace == namespace && subject.id == id && subject.relation == relation {
+		return true, nil
+	}
+
+	tuples, err := s.relationRepository.GetRelationTuples(&domain.RelationTupleFilter{Namespace: namespace, ObjectId: id, Relation: relation}, revision)
+	if err != nil {
+		return false, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	for _, tuple := range tuples {
+		if tuple.SubjectRelation == "" {
+			if subject.relation == "" && tuple.SubjectNamespace == subject.namespace && tuple.SubjectId == subject.id {
+				return true, nil
+			}
+			continue
+		}
+		if allowed, err := s.check(tuple.SubjectNamespace, tuple.SubjectId, tuple.SubjectRelation, subject, revision, depth+1); err != nil || allowed {
+			return allowed, err
+		}
+	}
+
+	rewrite := s.namespaces[namespace][relation]
+	if rewrite == nil {
+		return false, nil
+	}
+
+	for _, computed := range rewrite.computedUsersets {
+		if allowed, err := s.check(namespace, id, computed, subject, revision, depth+1); err != nil || allowed {
+			return allowed, err
+		}
+	}
+
+	for _, ttu := range rewrite.tupleToUsersets {
+		tuples, err := s.relationRepository.GetRelationTuples(&domain.RelationTupleFilter{Namespace: namespace, ObjectId: id, Relation: ttu.tupleset}, revision)
+		if err != nil {
+			return false, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		}
+		for _, tuple := range tuples {
+			if !s.relationExists(tuple.SubjectNamespace, ttu.computedUserset) {
+				continue
+			}
+			if allowed, err := s.check(tuple.SubjectNamespace, tuple.SubjectId, ttu.computedUserset, subject, revision, depth+1); err != nil || allowed {
+				return allowed, err
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (s *RelationService) expand(namespace string, id string, relation string, revision int64, depth int) (*domain.RelationTree, error) {
+	if depth > s.config.App.Rebac.MaxDepth {
+		return nil, &appError.AppError{Code: http.StatusBadRequest, Message: "relation expand exceeded the maximum depth"}
+	}
+
+	tree := &domain.RelationTree{
+		Object:   namespace + ":" + id,
+		Relation: relation,
+		Subjects: make([]string, 0),
+	}
+
+	tuples, err := s.relationRepository.GetRelationTuples(&domain.RelationTupleFilter{Namespace: namespace, ObjectId: id, Relation: relation}, revision)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	for _, tuple := range tuples {
+		tree.Subjects = append(tree.Subjects, tuple.Subject())
+		if tuple.SubjectRelation == "" {
+			continue
+		}
+		child, err := s.expand(tuple.SubjectNamespace, tuple.SubjectId, tuple.SubjectRelation, revision, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		tree.Children = append(tree.Children, child)
+	}
+
+	rewrite := s.namespaces[namespace][relation]
+	if rewrite == nil {
+		return tree, nil
+	}
+
+	for _, computed := range rewrite.computedUsersets {
+		child, err := s.expand(namespace, id, computed, revision, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		tree.Children = append(tree.Children, child)
+	}
+
+	for _, ttu := range rewrite.tupleToUsersets {
+		tuples, err := s.relationRepository.GetRelationTuples(&domain.RelationTupleFilter{Namespace: namespace, ObjectId: id, Relation: ttu.tupleset}, revision)
+		if err != nil {
+			return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		}
+		for _, tuple := range tuples {
+			if !s.relationExists(tuple.SubjectNamespace, ttu.computedUserset) {
+				continue
+			}
+			child, err := s.expand(tuple.SubjectNamespace, tuple.SubjectId, ttu.computedUserset, revision, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			tree.Children = append(tree.Children, child)
+		}
+	}
+
+	return tree, nil
+}
+
+// revision picks the revision a read is evaluated at, the last committed one.
+// It is at least as fresh as any token handed out by a committed write, a
+// token ahead of it was not issued by this store.
+func (s *RelationService) revision(token string) (int64, error) {
+	latest, err := s.relationRepository.GetRelationRevision()
+	if err != nil {
+		return 0, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	if token == "" {
+		return latest, nil
+	}
+
+	revision, err := domain.DecodeConsistencyToken(token)
+	if err != nil {
+		return 0, &appError.AppError{Code: http.StatusBadRequest, Message: err.Error()}
+	}
+	if revision > latest {
+		return 0, &appError.AppError{Code: http.StatusBadRequest, Message: "consistency token is ahead of the tuple store"}
+	}
+
+	return latest, nil
+}
+
+func (s *RelationService) parseTuples(requests []*domain.RelationTupleRequest) ([]*domain.RelationTuple, error) {
+	tuples := make([]*domain.RelationTuple, 0, len(requests))
+	for _, request := range requests {
+		namespace, id, err := s.parseObjectRelation(request.Object, request.Relation)
+		if err != nil {
+			return nil, err
+		}
+
+		subject, err := s.parseSubject(request.Subject)
+		if err != nil {
+			return nil, err
+		}
+
+		tuples = append(tuples, &domain.RelationTuple{
+			Namespace:        namespace,
+			ObjectId:         id,
+			Relation:         request.Relation,
+			SubjectNamespace: subject.namespace,
+			SubjectId:        subject.id,
+			SubjectRelation:  subject.relation,
+		})
+	}
+	return tuples, nil
+}
+
+func (s *RelationService) parseObjectRelation(object string, relation string) (string, string, error) {
+	namespace, id, err := domain.ParseObject(object)
+	if err != nil {
+		return "", "", &appError.AppError{Code: http.StatusBadRequest, Message: err.Error()}
+	}
+
+	if !s.relationExists(namespace, relation) {
+		return "", "", &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("relation %s is not defined in namespace %s", relation, namespace)}
+	}
+
+	return namespace, id, nil
+}
+
+func (s *RelationService) parseSubject(subject string) (*subjectRef, error) {
+	namespace, id, relation, err := domain.ParseSubject(subject)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusBadRequest, Message: err.Error()}
+	}
+
+	if _, ok := s.namespaces[namespace]; !ok {
+		return nil, &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("namespace %s is not defined", namespace)}
+	}
+	if relation != "" && !s.relationExists(namespace, relation) {
+		return nil, &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("relation %s is not defined in namespace %s", relation, namespace)}
+	}
+
+	return &subjectRef{namespace: namespace, id: id, relation: relation}, nil
+}
+
+func (s *RelationService) relationExists(namespace string, relation string) bool {
+	_, ok := s.namespaces[namespace][relation]
+	return ok
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"reflect"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+)
+
+// relationConfig has documents viewed through their editors and through
+// their parent folder.
+func relationConfig(t *testing.T) *config.Config {
+	cfg := &config.Config{}
+	namespaces := `{
+		"maxDepth": 25,
+		"namespaces": [
+			{"name": "user"},
+			{"name": "group", "relations": [{"name": "member"}]},
+			{"name": "folder", "relations": [{"name": "viewer"}]},
+			{"name": "document", "relations": [
+				{"name": "parent"},
+				{"name": "owner"},
+				{"name": "editor", "computedUsersets": ["owner"]},
+				{"name": "viewer", "computedUsersets": ["editor"], "tupleToUsersets": [{"tupleset": "parent", "computedUserset": "viewer"}]}
+			]}
+		]
+	}`
+	if err := json.Unmarshal([]byte(namespaces), &cfg.App.Rebac); err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+// relationStore answers tuple reads from store, the way the repository
+// filters them.
+func relationStore(store []*domain.RelationTuple) func(filter *domain.RelationTupleFilter, revision int64) []*domain.RelationTuple {
+	return func(filter *domain.RelationTupleFilter, revision int64) []*domain.RelationTuple {
+		tuples := make([]*domain.RelationTuple, 0)
+		for _, tuple := range store {
+			if (filter.Namespace == "" || tuple.Namespace == filter.Namespace) &&
+				(filter.ObjectId == "" || tuple.ObjectId == filter.ObjectId) &&
+				(filter.Relation == "" || tuple.Relation == filter.Relation) &&
+				(filter.SubjectNamespace == "" || tuple.SubjectNamespace == filter.SubjectNamespace) &&
+				(filter.SubjectId == "" || tuple.SubjectId == filter.SubjectId) &&
+				(filter.SubjectRelation == nil || tuple.SubjectRelation == *filter.SubjectRelation) {
+				tuples = append(tuples, tuple)
+			}
+		}
+		return tuples
+	}
+}
+
+func TestRelationService_Check(t *testing.T) {
+	cfg := relationConfig(t)
+
+	store := []*domain.RelationTuple{
+		{Namespace: "document", ObjectId: "readme", Relation: "owner", SubjectNamespace: "user", SubjectId: "alice"},
+		{Namespace: "document", ObjectId: "readme", Relation: "editor", SubjectNamespace: "group", SubjectId: "eng", SubjectRelation: "member"},
+		{Namespace: "document", ObjectId: "readme", Relation: "parent", SubjectNamespace: "folder", SubjectId: "docs"},
+		{Namespace: "group", ObjectId: "eng", Relation: "member", SubjectNamespace: "user", SubjectId: "bob"},
+		{Namespace: "folder", ObjectId: "docs", Relation: "viewer", SubjectNamespace: "user", SubjectId: "carol"},
+	}
+	lookup := relationStore(store)
+
+	tests := []struct {
+		name     string
+		request  *domain.CheckRelationRequest
+		want     bool
+		wantCode int
+	}{
+		{
+			name:    "success - direct tuple",
+			request: &domain.CheckRelationRequest{Object: "document:readme", Relation: "owner", Subject: "user:alice"},
+			want:    true,
+		},
+		{
+			name:    "success - computed userset",
+			request: &domain.CheckRelationRequest{Object: "document:readme", Relation: "viewer", Subject: "user:alice"},
+			want:    true,
+		},
+		{
+			name:    "success - group userset",
+			request: &domain.CheckRelationRequest{Object: "document:readme", Relation: "editor", Subject: "user:bob"},
+			want:    true,
+		},
+		{
+			name:    "success - tuple to userset",
+			request: &domain.CheckRelationRequest{Object: "document:readme", Relation: "viewer", Subject: "user:carol"},
+			want:    true,
+		},
+		{
+			name:    "success - not allowed",
+			request: &domain.CheckRelationRequest{Object: "document:readme", Relation: "editor", Subject: "user:carol"},
+			want:    false,
+		},
+		{
+			name:     "failed - unknown relation",
+			request:  &domain.CheckRelationRequest{Object: "document:readme", Relation: "admin", Subject: "user:alice"},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "success - token at the committed revision",
+			request: &domain.CheckRelationRequest{Object: "document:readme", Relation: "owner", Subject: "user:alice", ConsistencyToken: domain.EncodeConsistencyToken(3)},
+			want:    true,
+		},
+		{
+			name:     "failed - token ahead of the store",
+			request:  &domain.CheckRelationRequest{Object: "document:readme", Relation: "owner", Subject: "user:alice", ConsistencyToken: domain.EncodeConsistencyToken(9)},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRelationRepository := mockCore.RelationRepository{}
+			mockRelationRepository.On("GetRelationRevision").Return(int64(3), nil)
+			mockRelationRepository.On("GetRelationTuples", mock.Anything, int64(3)).Return(lookup, nil)
+
+			s := NewRelationService(cfg, &mockRelationRepository)
+			got, err := s.Check(tt.request)
+			if tt.wantCode != 0 {
+				appErr, ok := err.(*appError.AppError)
+				if !ok || appErr.Code != tt.wantCode {
+					t.Errorf("Check() error = %v, want code %d", err, tt.wantCode)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			check := got.Data.(*domain.RelationCheck)
+			if check.Allowed != tt.want {
+				t.Errorf("Check() allowed = %v, want %v", check.Allowed, tt.want)
+			}
+			if check.ConsistencyToken != domain.EncodeConsistencyToken(3) {
+				t.Errorf("Check() consistency token = %s, want revision 3", check.ConsistencyToken)
+			}
+		})
+	}
+}
+
+func TestRelationService_ListObjects(t *testing.T) {
+	cfg := relationConfig(t)
+
+	store := []*domain.RelationTuple{
+		{Namespace: "document", ObjectId: "readme", Relation: "owner", SubjectNamespace: "user", SubjectId: "alice"},
+		{Namespace: "document", ObjectId: "readme", Relation: "editor", SubjectNamespace: "group", SubjectId: "eng", SubjectRelation: "member"},
+		{Namespace: "document", ObjectId: "readme", Relation: "parent", SubjectNamespace: "folder", SubjectId: "docs"},
+		{Namespace: "document", ObjectId: "guide", Relation: "parent", SubjectNamespace: "folder", SubjectId: "docs"},
+		{Namespace: "document", ObjectId: "roadmap", Relation: "owner", SubjectNamespace: "user", SubjectId: "dave"},
+		{Namespace: "group", ObjectId: "eng", Relation: "member", SubjectNamespace: "user", SubjectId: "bob"},
+		{Namespace: "folder", ObjectId: "docs", Relation: "viewer", SubjectNamespace: "user", SubjectId: "carol"},
+		{Namespace: "folder", ObjectId: "docs", Relation: "viewer", SubjectNamespace: "group", SubjectId: "eng", SubjectRelation: "member"},
+	}
+
+	tests := []struct {
+		name     string
+		request  *domain.ListObjectsRequest
+		want     []string
+		wantCode int
+	}{
+		{
+			name:    "success - direct tuple and computed userset",
+			request: &domain.ListObjectsRequest{Namespace: "document", Relation: "viewer", Subject: "user:alice"},
+			want:    []string{"document:readme"},
+		},
+		{
+			name:    "success - through a group and a parent folder",
+			request: &domain.ListObjectsRequest{Namespace: "document", Relation: "viewer", Subject: "user:bob"},
+			want:    []string{"document:guide", "document:readme"},
+		},
+		{
+			name:    "success - userset subject",
+			request: &domain.ListObjectsRequest{Namespace: "document", Relation: "editor", Subject: "group:eng#member"},
+			want:    []string{"document:readme"},
+		},
+		{
+			name:    "success - nothing reached",
+			request: &domain.ListObjectsRequest{Namespace: "document", Relation: "editor", Subject: "user:carol"},
+			want:    []string{},
+		},
+		{
+			name:     "failed - unknown relation",
+			request:  &domain.ListObjectsRequest{Namespace: "document", Relation: "admin", Subject: "user:alice"},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRelationRepository := mockCore.RelationRepository{}
+			mockRelationRepository.On("GetRelationRevision").Return(int64(3), nil)
+			mockRelationRepository.On("GetRelationTuples", mock.Anything, int64(3)).Return(relationStore(store), nil)
+
+			s := NewRelationService(cfg, &mockRelationRepository)
+			got, err := s.ListObjects(tt.request)
+			if tt.wantCode != 0 {
+				appErr, ok := err.(*appError.AppError)
+				if !ok || appErr.Code != tt.wantCode {
+					t.Errorf("ListObjects() error = %v, want code %d", err, tt.wantCode)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ListObjects() error = %v", err)
+			}
+			objects := got.Data.(*domain.RelationObjects).Objects
+			if !reflect.DeepEqual(objects, tt.want) {
+				t.Errorf("ListObjects() objects = %v, want %v", objects, tt.want)
+			}
+			// every read starts from the subject, none lists a whole namespace
+			for _, call := range mockRelationRepository.Calls {
+				if call.Method != "GetRelationTuples" {
+					continue
+				}
+				if filter := call.Arguments.Get(0).(*domain.RelationTupleFilter); filter.SubjectId == "" {
+					t.Errorf("ListObjects() read tuples without a subject: %+v", filter)
+				}
+			}
+		})
+	}
+}
@@ -7,16 +7,25 @@ import (
 	"time"
 	"user-svc/internal/core/domain"
 	"user-svc/internal/core/ports"
+	"user-svc/internal/shared/cursor"
 	appError "user-svc/internal/shared/error"
 )
 
 type RoleService struct {
-	roleRepository ports.RoleRepository
+	roleRepository      ports.RoleRepository
+	safeguardService    ports.SafeguardService
+	accessImpactService ports.AccessImpactService
+	userSearchService   ports.UserSearchService
+	cursorCodec         cursor.Codec
 }
 
-func NewRoleService(roleRepository ports.RoleRepository) *RoleService {
+func NewRoleService(roleRepository ports.RoleRepository, safeguardService ports.SafeguardService, accessImpactService ports.AccessImpactService, userSearchService ports.UserSearchService, cursorCodec cursor.Codec) *RoleService {
 	return &RoleService{
-		roleRepository: roleRepository,
+		roleRepository:      roleRepository,
+		safeguardService:    safeguardService,
+		accessImpactService: accessImpactService,
+		userSearchService:   userSearchService,
+		cursorCodec:         cursorCodec,
 	}
 }
 
@@ -27,6 +36,11 @@ func (r *RoleService) CreateRole(request *domain.CreateRoleRequest) (*domain.Res
 		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("role %s already exist", request.Name)}
 	}
 
+	// a new role has no holders, so it changes nobody's access
+	if request.DryRun {
+		return dryRun(r.accessImpactService, nil, &domain.AccessChange{})
+	}
+
 	role := &domain.Role{
 		Id:        uuid.New().String(),
 		Name:      request.Name,
@@ -52,18 +66,49 @@ func (r *RoleService) UpdateRole(request *domain.UpdateRoleRequest) (*domain.Res
 		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("role with id %s not exist", request.Id)}
 	}
 
+	if err := checkVersion("role", role.Id, request.IfMatch, role.Version); err != nil {
+		return nil, err
+	}
+
+	if role.System && role.Name != request.Name {
+		return nil, &appError.AppError{Code: http.StatusForbidden, Message: fmt.Sprintf("role %s is a system role and cannot be renamed", role.Name)}
+	}
+
 	check, _ := r.roleRepository.GetRoleByName(request.Name)
 	if check != nil && check.Id != role.Id {
 		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("role with name %s already exist", request.Name)}
 	}
 
+	change := &domain.AccessChange{}
+	if role.Active && !*request.Active {
+		change.RoleIds = []string{role.Id}
+	} else if !role.Active && *request.Active {
+		change.ActivatedRoleIds = []string{role.Id}
+	}
+
+	if request.DryRun {
+		return dryRun(r.accessImpactService, r.safeguardService, change)
+	}
+
+	if len(change.RoleIds) > 0 {
+		if err := r.safeguardService.CheckLockout(change); err != nil {
+			return nil, err
+		}
+	}
+
+	// the search index carries the names of the active roles of each user
+	reindex := role.Name != request.Name || role.Active != *request.Active
+
 	role.Name = request.Name
 	role.Active = *request.Active
 	role.UpdatedAt = time.Now()
 
 	err = r.roleRepository.UpdateRole(role)
 	if err != nil {
-		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		return nil, writeError("role", role.Id, err)
+	}
+	if reindex {
+		r.userSearchService.IndexRoleHolders(role.Id)
 	}
 
 	return &domain.Response{
@@ -73,16 +118,121 @@ func (r *RoleService) UpdateRole(request *domain.UpdateRoleRequest) (*domain.Res
 	}, nil
 }
 
-func (r *RoleService) DeleteRole(id string) (*domain.Response, error) {
-	role, err := r.roleRepository.GetRoleByID(id)
+// PatchRole applies a merge patch, only the members it carries are checked and
+// written.
+func (r *RoleService) PatchRole(request *domain.PatchRoleRequest) (*domain.Response, error) {
+	role, err := r.roleRepository.GetRoleByID(request.Id)
 	if err != nil && role == nil {
-		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("role with id %s not exist", id)}
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("role with id %s not exist", request.Id)}
+	}
+
+	if err := checkVersion("role", role.Id, request.IfMatch, role.Version); err != nil {
+		return nil, err
+	}
+
+	if request.Name != nil && *request.Name != role.Name {
+		if role.System {
+			return nil, &appError.AppError{Code: http.StatusForbidden, Message: fmt.Sprintf("role %s is a system role and cannot be renamed", role.Name)}
+		}
+
+		check, _ := r.roleRepository.GetRoleByName(*request.Name)
+		if check != nil && check.Id != role.Id {
+			return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("role with name %s already exist", *request.Name)}
+		}
+	}
+
+	change := &domain.AccessChange{}
+	if request.Active != nil {
+		if role.Active && !*request.Active {
+			change.RoleIds = []string{role.Id}
+		} else if !role.Active && *request.Active {
+			change.ActivatedRoleIds = []string{role.Id}
+		}
+	}
+
+	if request.DryRun {
+		return dryRun(r.accessImpactService, r.safeguardService, change)
+	}
+
+	if len(change.RoleIds) > 0 {
+		if err := r.safeguardService.CheckLockout(change); err != nil {
+			return nil, err
+		}
+	}
+
+	patch := &domain.RolePatch{
+		Name:      request.Name,
+		Active:    request.Active,
+		UpdatedAt: time.Now(),
+	}
+	if err := r.roleRepository.PatchRole(role.Id, role.Version, patch); err != nil {
+		return nil, writeError("role", role.Id, err)
+	}
+	if (request.Name != nil && *request.Name != role.Name) || (request.Active != nil && *request.Active != role.Active) {
+		r.userSearchService.IndexRoleHolders(role.Id)
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    nil,
+	}, nil
+}
+
+func (r *RoleService) DeleteRole(request *domain.DeleteRoleRequest) (*domain.Response, error) {
+	role, err := r.roleRepository.GetRoleByID(request.Id)
+	if err != nil && role == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("role with id %s not exist", request.Id)}
+	}
+
+	if err := checkVersion("role", role.Id, request.IfMatch, role.Version); err != nil {
+		return nil, err
+	}
+
+	if role.System {
+		return nil, &appError.AppError{Code: http.StatusForbidden, Message: fmt.Sprintf("role %s is a system role and cannot be deleted", role.Name)}
 	}
 
-	err = r.roleRepository.DeleteRole(role.Id)
+	change := &domain.AccessChange{RoleIds: []string{role.Id}}
+	if request.DryRun {
+		return dryRun(r.accessImpactService, r.safeguardService, change)
+	}
+
+	if err := r.safeguardService.CheckLockout(change); err != nil {
+		return nil, err
+	}
+
+	err = r.roleRepository.DeleteRole(role.Id, role.Version, time.Now())
 	if err != nil {
+		return nil, writeError("role", role.Id, err)
+	}
+	r.userSearchService.IndexRoleHolders(role.Id)
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    nil,
+	}, nil
+}
+
+// RestoreRole takes a deleted role out of the trash, along with the grants it
+// had when it was deleted.
+func (r *RoleService) RestoreRole(request *domain.RestoreRoleRequest) (*domain.Response, error) {
+	role, err := r.roleRepository.GetDeletedRoleByID(request.Id)
+	if err != nil && role == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("deleted role with id %s not exist", request.Id)}
+	}
+
+	if exist, err := r.roleRepository.RoleIsExist(role.Name); err != nil {
 		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	} else if exist {
+		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("role %s already exist", role.Name)}
 	}
+
+	if err := r.roleRepository.RestoreRole(role.Id, time.Now()); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	r.userSearchService.IndexRoleHolders(role.Id)
+
 	return &domain.Response{
 		Code:    http.StatusOK,
 		Message: http.StatusText(http.StatusOK),
@@ -90,15 +240,66 @@ func (r *RoleService) DeleteRole(id string) (*domain.Response, error) {
 	}, nil
 }
 
-func (r *RoleService) GetRoles() (*domain.Response, error) {
-	result, err := r.roleRepository.GetAllRole()
+func (r *RoleService) GetRoles(request *domain.GetRolesRequest) (*domain.Response, error) {
+	filter := &domain.RoleFilter{
+		Active:       request.Active,
+		NamePrefix:   request.Name,
+		CreatedFrom:  request.CreatedFrom,
+		CreatedTo:    request.CreatedTo,
+		UpdatedSince: request.UpdatedSince,
+		Sort:         request.Sort,
+		Order:        request.Order,
+		Limit:        request.Limit(),
+		Offset:       request.Offset(),
+	}
+	if request.UseCursor() {
+		return r.getRolesAfter(request, filter)
+	}
+
+	roles, total, err := r.roleRepository.GetRoles(filter)
 	if err != nil {
 		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
 	}
 	return &domain.Response{
 		Code:    http.StatusOK,
 		Message: http.StatusText(http.StatusOK),
-		Data:    result,
+		Data: &domain.Page{
+			Items:   roles,
+			Total:   total,
+			Page:    request.CurrentPage(),
+			PerPage: request.Limit(),
+		},
+	}, nil
+}
+
+// getRolesAfter serves a keyset page, one extra row tells whether another
+// page follows.
+func (r *RoleService) getRolesAfter(request *domain.GetRolesRequest, filter *domain.RoleFilter) (*domain.Response, error) {
+	after, err := decodeCursor(r.cursorCodec, roleCursor, request.CursorRequest, request.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := request.CursorLimit()
+	filter.After = after
+	filter.Limit = limit + 1
+	roles, err := r.roleRepository.GetRolesAfter(filter)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	page := &domain.CursorPage{Limit: limit}
+	if len(roles) > limit {
+		roles = roles[:limit]
+		last := roles[limit-1]
+		page.NextCursor = r.cursorCodec.Encode(roleCursor, last.CreatedAt, last.Id)
+	}
+	page.Items = roles
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    page,
 	}, nil
 }
 
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"net/http"
+	"sort"
+	"time"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	appError "user-svc/internal/shared/error"
+)
+
+type RoleConstraintService struct {
+	roleConstraintRepository ports.RoleConstraintRepository
+	roleService              ports.RoleService
+}
+
+func NewRoleConstraintService(roleConstraintRepository ports.RoleConstraintRepository, roleService ports.RoleService) *RoleConstraintService {
+	return &RoleConstraintService{
+		roleConstraintRepository: roleConstraintRepository,
+		roleService:              roleService,
+	}
+}
+
+func (s *RoleConstraintService) CreateRoleConstraint(request *domain.CreateRoleConstraintRequest) (*domain.Response, error) {
+	roles := uniqueStrings(request.RolesId)
+	if request.Type == domain.ConstraintMutuallyExclusive && request.MaxCount >= len(roles) {
+		return nil, &appError.AppError{Code: http.StatusBadRequest, Message: "max_count of a mutually exclusive constraint must be lower than the number of roles"}
+	}
+
+	for _, roleID := range roles {
+		role, err := s.roleService.GetRole(roleID)
+		if err != nil && role == nil {
+			return nil, err
+		}
+	}
+
+	constraint := &domain.RoleConstraint{
+		Id:        uuid.New().String(),
+		Name:      request.Name,
+		Type:      request.Type,
+		RolesId:   roles,
+		MaxCount:  request.MaxCount,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := s.roleConstraintRepository.CreateRoleConstraint(constraint); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusCreated,
+		Message: http.StatusText(http.StatusCreated),
+		Data:    nil,
+	}, nil
+}
+
+func (s *RoleConstraintService) DeleteRoleConstraint(id string) (*domain.Response, error) {
+	constraint, err := s.roleConstraintRepository.GetRoleConstraintByID(id)
+	if err != nil && constraint == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("role constraint with id %s not exist", id)}
+	}
+
+	err = s.roleConstraintRepository.DeleteRoleConstraint(constraint.Id)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    nil,
+	}, nil
+}
+
+func (s *RoleConstraintService) GetRoleConstraints() (*domain.Response, error) {
+	result, err := s.roleConstraintRepository.GetAllRoleConstraint()
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+func (s *RoleConstraintService) GetRoleConstraint(id string) (*domain.Response, error) {
+	result, err := s.roleConstraintRepository.GetRoleConstraintByID(id)
+	if err != nil && result == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("role constraint with id %s not exist", id)}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+// GetViolations reports existing assignments that break a constraint,
+// typically ones made before the constraint was defined.
+func (s *RoleConstraintService) GetViolations() (*domain.Response, error) {
+	constraints, err := s.roleConstraintRepository.GetAllRoleConstraint()
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	grants, err := s.roleConstraintRepository.GetRoleGrants(constraintRoles(constraints))
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    evaluateConstraints(constraints, grants),
+	}, nil
+}
+
+// CheckAssignment refuses granting roles to a user when the resulting
+// assignments would break a constraint involving those roles.
+func (s *RoleConstraintService) CheckAssignment(userID string, roles []string) error {
+	constraints, err := s.roleConstraintRepository.GetRoleConstraintsByRoles(roles)
+	if err != nil {
+		return &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	grants, err := s.roleConstraintRepository.GetRoleGrants(constraintRoles(constraints))
+	if err != nil {
+		return &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	held := make(map[string]bool)
+	for _, grant := range grants {
+		if grant.UserId == userID {
+			held[grant.RoleId] = true
+		}
+	}
+
+	added := make(map[string]bool)
+	for _, roleID := range uniqueStrings(roles) {
+		if !held[roleID] {
+			added[roleID] = true
+			grants = append(grants, &domain.UserRoleGrant{UserId: userID, RoleId: roleID})
+		}
+	}
+
+	for _, violation := range evaluateConstraints(constraints, grants) {
+		if violation.UserId == userID || added[violation.RoleId] {
+			return &appError.AppError{
+				Code:    http.StatusConflict,
+				Message: fmt.Sprintf("role assignment violates %s constraint %s", violation.Type, violation.ConstraintName),
+				Data:    violation,
+			}
+		}
+	}
+
+	return nil
+}
+
+func evaluateConstraints(constraints []*domain.RoleConstraint, grants []*domain.UserRoleGrant) []*domain.ConstraintViolation {
+	rolesByUser := make(map[string][]string)
+	usersByRole := make(map[string][]string)
+	for _, grant := range grants {
+		rolesByUser[grant.UserId] = append(rolesByUser[grant.UserId], grant.RoleId)
+		usersByRole[grant.RoleId] = append(usersByRole[grant.RoleId], grant.UserId)
+	}
+
+	users := make([]string, 0, len(rolesByUser))
+	for userID := range rolesByUser {
+		users = append(users, userID)
+	}
+	sort.Strings(users)
+
+	violations := make([]*domain.ConstraintViolation, 0)
+	for _, constraint := range constraints {
+		switch constraint.Type {
+		case domain.ConstraintMutuallyExclusive:
+			for _, userID := range users {
+				held := intersect(rolesByUser[userID], constraint.RolesId)
+				if len(held) > constraint.MaxCount {
+					violations = append(violations, &domain.ConstraintViolation{
+						ConstraintId:   constraint.Id,
+						ConstraintName: constraint.Name,
+						Type:           constraint.Type,
+						MaxCount:       constraint.MaxCount,
+						UserId:         userID,
+						RolesId:        held,
+					})
+				}
+			}
+		case domain.ConstraintCardinality:
+			for _, roleID := range constraint.RolesId {
+				holders := uniqueStrings(usersByRole[roleID])
+				if len(holders) > constraint.MaxCount {
+					sort.Strings(holders)
+					violations = append(violations, &domain.ConstraintViolation{
+						ConstraintId:   constraint.Id,
+						ConstraintName: constraint.Name,
+						Type:           constraint.Type,
+						MaxCount:       constraint.MaxCount,
+						RoleId:         roleID,
+						UsersId:        holders,
+					})
+				}
+			}
+		}
+	}
+
+	return violations
+}
+
+func constraintRoles(constraints []*domain.RoleConstraint) []string {
+	roles := make([]string, 0)
+	for _, constraint := range constraints {
+		roles = append(roles, constraint.RolesId...)
+	}
+	return uniqueStrings(roles)
+}
+
+func intersect(values []string, set []string) []string {
+	result := make([]string, 0)
+	for _, value := range uniqueStrings(values) {
+		if contains(set, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	appError "user-svc/internal/shared/error"
+)
+
+func TestRoleConstraintService_CheckAssignment(t *testing.T) {
+	exclusive := &domain.RoleConstraint{
+		Id:       "constraint-1",
+		Name:     "payment-duties",
+		Type:     domain.ConstraintMutuallyExclusive,
+		RolesId:  []string{"payment-creator", "payment-approver"},
+		MaxCount: 1,
+	}
+	cardinality := &domain.RoleConstraint{
+		Id:       "constraint-2",
+		Name:     "few-approvers",
+		Type:     domain.ConstraintCardinality,
+		RolesId:  []string{"payment-approver"},
+		MaxCount: 2,
+	}
+
+	type args struct {
+		userID string
+		roles  []string
+	}
+	tests := []struct {
+		name              string
+		args              args
+		constraintsResult []interface{}
+		grantsResult      []interface{}
+		wantCode          int
+	}{
+		{
+			name:              "success - no constraint on roles",
+			args:              args{userID: "user-1", roles: []string{"viewer"}},
+			constraintsResult: []interface{}{[]*domain.RoleConstraint{}, nil},
+			grantsResult:      []interface{}{[]*domain.UserRoleGrant{}, nil},
+		},
+		{
+			name:              "success - constraints satisfied",
+			args:              args{userID: "user-1", roles: []string{"payment-approver"}},
+			constraintsResult: []interface{}{[]*domain.RoleConstraint{exclusive, cardinality}, nil},
+			grantsResult: []interface{}{[]*domain.UserRoleGrant{
+				{UserId: "user-2", RoleId: "payment-approver"},
+			}, nil},
+		},
+		{
+			name:              "failed - user already holds an exclusive role",
+			args:              args{userID: "user-1", roles: []string{"payment-approver"}},
+			constraintsResult: []interface{}{[]*domain.RoleConstraint{exclusive}, nil},
+			grantsResult: []interface{}{[]*domain.UserRoleGrant{
+				{UserId: "user-1", RoleId: "payment-creator"},
+			}, nil},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:              "failed - role already has the maximum holders",
+			args:              args{userID: "user-1", roles: []string{"payment-approver"}},
+			constraintsResult: []interface{}{[]*domain.RoleConstraint{cardinality}, nil},
+			grantsResult: []interface{}{[]*domain.UserRoleGrant{
+				{UserId: "user-2", RoleId: "payment-approver"},
+				{UserId: "user-3", RoleId: "payment-approver"},
+			}, nil},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:              "failed - unable to load constraints",
+			args:              args{userID: "user-1", roles: []string{"payment-approver"}},
+			constraintsResult: []interface{}{nil, errors.New("error")},
+			grantsResult:      []interface{}{[]*domain.UserRoleGrant{}, nil},
+			wantCode:          http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRoleConstraintRepository := mockCore.RoleConstraintRepository{}
+			mockRoleConstraintRepository.On("GetRoleConstraintsByRoles", mock.Anything).Return(tt.constraintsResult...)
+			mockRoleConstraintRepository.On("GetRoleGrants", mock.Anything).Return(tt.grantsResult...)
+
+			s := NewRoleConstraintService(&mockRoleConstraintRepository, &mockCore.RoleService{})
+			err := s.CheckAssignment(tt.args.userID, tt.args.roles)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("CheckAssignment() error = %v, want nil", err)
+				}
+				return
+			}
+
+			appErr, ok := err.(*appError.AppError)
+			if !ok || appErr.Code != tt.wantCode {
+				t.Errorf("CheckAssignment() error = %v, want code %d", err, tt.wantCode)
+			}
+		})
+	}
+}
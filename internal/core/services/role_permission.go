@@ -11,13 +11,21 @@ type RolePermissionService struct {
 	rolePermissionRepository ports.RolePermissionRepository
 	roleService              ports.RoleService
 	permissionService        ports.PermissionService
+	safeguardService         ports.SafeguardService
+	adminScopeService        ports.AdminScopeService
+	implicationService       ports.PermissionImplicationService
+	accessImpactService      ports.AccessImpactService
 }
 
-func NewRolePermissionService(rolePermissionRepository ports.RolePermissionRepository, roleService ports.RoleService, permissionService ports.PermissionService) *RolePermissionService {
+func NewRolePermissionService(rolePermissionRepository ports.RolePermissionRepository, roleService ports.RoleService, permissionService ports.PermissionService, safeguardService ports.SafeguardService, adminScopeService ports.AdminScopeService, implicationService ports.PermissionImplicationService, accessImpactService ports.AccessImpactService) *RolePermissionService {
 	return &RolePermissionService{
 		rolePermissionRepository: rolePermissionRepository,
 		roleService:              roleService,
 		permissionService:        permissionService,
+		safeguardService:         safeguardService,
+		adminScopeService:        adminScopeService,
+		implicationService:       implicationService,
+		accessImpactService:      accessImpactService,
 	}
 }
 
@@ -52,15 +60,78 @@ func (s *RolePermissionService) AssignPermissionsToRole(request *domain.AssignPe
 		}
 	}
 
-	err = s.rolePermissionRepository.AddRolePermissions(request.RoleId, request.PermissionsId)
+	missing, err := s.missingImpliedPermissions(request.RoleId, request.PermissionsId)
+	if err != nil {
+		return nil, err
+	}
+
+	// prerequisites are only added on request, otherwise they are reported
+	// back so the caller can decide
+	permissions := request.PermissionsId
+	var implied *domain.ImpliedPermissions
+	if len(missing) > 0 {
+		if request.AddImplied {
+			for _, permission := range missing {
+				permissions = append(permissions, permission.Id)
+			}
+			implied = &domain.ImpliedPermissions{Added: missing}
+		} else {
+			implied = &domain.ImpliedPermissions{Missing: missing}
+		}
+	}
+
+	if err := s.adminScopeService.CheckPermissionScope(request.ActorId, permissions); err != nil {
+		return nil, err
+	}
+
+	if request.DryRun {
+		change := &domain.AccessChange{}
+		for _, permissionID := range permissions {
+			change.GrantedRolePermissions = append(change.GrantedRolePermissions, &domain.RolePermissionGrant{RoleId: request.RoleId, PermissionId: permissionID})
+		}
+		return dryRun(s.accessImpactService, nil, change)
+	}
+
+	err = s.rolePermissionRepository.AddRolePermissions(request.RoleId, permissions)
 	if err != nil {
 		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
 	}
-	return &domain.Response{
+	response := &domain.Response{
 		Code:    http.StatusCreated,
 		Message: http.StatusText(http.StatusCreated),
 		Data:    nil,
-	}, nil
+	}
+	if implied != nil {
+		response.Data = implied
+	}
+	return response, nil
+}
+
+// missingImpliedPermissions returns the permissions implied by the assigned
+// ones that the role does not hold yet.
+func (s *RolePermissionService) missingImpliedPermissions(roleID string, permissionsID []string) ([]*domain.Permission, error) {
+	implied, err := s.implicationService.GetImpliedPermissions(permissionsID)
+	if err != nil || len(implied) == 0 {
+		return nil, err
+	}
+
+	current, err := s.rolePermissionRepository.GetRolePermissions(roleID)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	held := make(map[string]bool, len(current))
+	for _, permission := range current {
+		held[permission.Id] = true
+	}
+
+	missing := make([]*domain.Permission, 0)
+	for _, permission := range implied {
+		if !held[permission.Id] {
+			missing = append(missing, permission)
+		}
+	}
+	return missing, nil
 }
 
 func (s *RolePermissionService) RemovePermissionsFromRole(request *domain.RemovePermissionFromRoleRequest) (*domain.Response, error) {
@@ -69,11 +140,25 @@ func (s *RolePermissionService) RemovePermissionsFromRole(request *domain.Remove
 		return nil, err
 	}
 
+	change := &domain.AccessChange{}
 	for _, permissionID := range request.PermissionsId {
-		role, err := s.roleService.GetRole(permissionID)
-		if err != nil && role == nil {
+		permission, err := s.permissionService.GetPermission(permissionID)
+		if err != nil && permission == nil {
 			return nil, err
 		}
+		change.RevokedRolePermissions = append(change.RevokedRolePermissions, &domain.RolePermissionGrant{RoleId: request.RoleId, PermissionId: permissionID})
+	}
+
+	if err := s.adminScopeService.CheckPermissionScope(request.ActorId, request.PermissionsId); err != nil {
+		return nil, err
+	}
+
+	if request.DryRun {
+		return dryRun(s.accessImpactService, s.safeguardService, change)
+	}
+
+	if err := s.safeguardService.CheckLockout(change); err != nil {
+		return nil, err
 	}
 
 	err = s.rolePermissionRepository.RemoveRolePermissions(request.RoleId, request.PermissionsId)
@@ -86,3 +171,47 @@ func (s *RolePermissionService) RemovePermissionsFromRole(request *domain.Remove
 		Data:    nil,
 	}, nil
 }
+
+// GetPermissionUsers pages the users holding the permission, granted through
+// their roles or implied by another permission they hold.
+func (s *RolePermissionService) GetPermissionUsers(request *domain.GetPermissionUsersRequest) (*domain.Response, error) {
+	result, err := s.permissionService.GetPermission(request.PermissionId)
+	if err != nil && result == nil {
+		return nil, err
+	}
+	permission := result.Data.(*domain.Permission)
+
+	implying, err := s.implicationService.GetImplyingPermissions(permission.Id)
+	if err != nil {
+		return nil, err
+	}
+	permissionIDs := []string{permission.Id}
+	for _, implied := range implying {
+		permissionIDs = append(permissionIDs, implied.Id)
+	}
+
+	users, total, err := s.rolePermissionRepository.GetPermissionUsers(permissionIDs, request.Limit(), request.Offset())
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	for _, user := range users {
+		for _, name := range user.Permissions {
+			if name == permission.Name {
+				user.Direct = true
+				continue
+			}
+			user.ImpliedBy = append(user.ImpliedBy, name)
+		}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data: &domain.Page{
+			Items:   users,
+			Total:   total,
+			Page:    request.CurrentPage(),
+			PerPage: request.Limit(),
+		},
+	}, nil
+}
@@ -1 +1,118 @@
 package services
+
+import (
+	"net/http"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRolePermissionService_AssignPermissionsToRole(t *testing.T) {
+	implied := []*domain.Permission{{Id: "p-view", Name: "View-User"}, {Id: "p-list", Name: "List-User"}}
+
+	tests := []struct {
+		name        string
+		addImplied  bool
+		current     []*domain.Permission
+		wantAssign  []string
+		wantAdded   int
+		wantMissing int
+	}{
+		{
+			name:        "success - missing prerequisites reported",
+			current:     []*domain.Permission{{Id: "p-list", Name: "List-User"}},
+			wantAssign:  []string{"p-update"},
+			wantMissing: 1,
+		},
+		{
+			name:       "success - missing prerequisites added",
+			addImplied: true,
+			current:    []*domain.Permission{},
+			wantAssign: []string{"p-update", "p-view", "p-list"},
+			wantAdded:  2,
+		},
+		{
+			name:       "success - prerequisites already held",
+			current:    implied,
+			wantAssign: []string{"p-update"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &domain.AssignPermissionToRoleRequest{RoleId: "role-1", PermissionsId: []string{"p-update"}, AddImplied: tt.addImplied, ActorId: "admin-1"}
+
+			mockRoleService := mockCore.RoleService{}
+			mockRoleService.On("GetRole", "role-1").Return(&domain.Response{Code: http.StatusOK}, nil)
+
+			mockPermissionService := mockCore.PermissionService{}
+			mockPermissionService.On("GetPermission", "p-update").Return(&domain.Response{Code: http.StatusOK}, nil)
+
+			mockImplicationService := mockCore.PermissionImplicationService{}
+			mockImplicationService.On("GetImpliedPermissions", []string{"p-update"}).Return(implied, nil)
+
+			mockAdminScopeService := mockCore.AdminScopeService{}
+			mockAdminScopeService.On("CheckPermissionScope", "admin-1", mock.Anything).Return(nil)
+
+			mockRolePermissionRepository := mockCore.RolePermissionRepository{}
+			mockRolePermissionRepository.On("GetRolePermissions", "role-1").Return(tt.current, nil)
+			mockRolePermissionRepository.On("AddRolePermissions", "role-1", tt.wantAssign).Return(nil)
+
+			s := NewRolePermissionService(&mockRolePermissionRepository, &mockRoleService, &mockPermissionService, &mockCore.SafeguardService{}, &mockAdminScopeService, &mockImplicationService, &mockCore.AccessImpactService{})
+			got, err := s.AssignPermissionsToRole(request)
+			if err != nil {
+				t.Fatalf("AssignPermissionsToRole() error = %v", err)
+			}
+
+			mockRolePermissionRepository.AssertCalled(t, "AddRolePermissions", "role-1", tt.wantAssign)
+			mockAdminScopeService.AssertCalled(t, "CheckPermissionScope", "admin-1", tt.wantAssign)
+			if tt.wantAdded == 0 && tt.wantMissing == 0 {
+				if got.Data != nil {
+					t.Errorf("AssignPermissionsToRole() data = %v, want nil", got.Data)
+				}
+				return
+			}
+
+			result := got.Data.(*domain.ImpliedPermissions)
+			if len(result.Added) != tt.wantAdded || len(result.Missing) != tt.wantMissing {
+				t.Errorf("AssignPermissionsToRole() added = %d, missing = %d, want %d, %d", len(result.Added), len(result.Missing), tt.wantAdded, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestRolePermissionService_GetPermissionUsers(t *testing.T) {
+	request := &domain.GetPermissionUsersRequest{PermissionId: "p-list"}
+
+	mockPermissionService := mockCore.PermissionService{}
+	mockPermissionService.On("GetPermission", "p-list").Return(&domain.Response{Code: http.StatusOK, Data: &domain.Permission{Id: "p-list", Name: "List-User"}}, nil)
+
+	mockImplicationService := mockCore.PermissionImplicationService{}
+	mockImplicationService.On("GetImplyingPermissions", "p-list").Return([]*domain.Permission{{Id: "p-view", Name: "View-User"}}, nil)
+
+	users := []*domain.PermissionUser{
+		{User: &domain.User{Id: "user-1"}, Permissions: []string{"List-User"}},
+		{User: &domain.User{Id: "user-2"}, Permissions: []string{"List-User", "View-User"}},
+		{User: &domain.User{Id: "user-3"}, Permissions: []string{"View-User"}},
+	}
+	mockRolePermissionRepository := mockCore.RolePermissionRepository{}
+	mockRolePermissionRepository.On("GetPermissionUsers", []string{"p-list", "p-view"}, request.Limit(), request.Offset()).Return(users, int64(3), nil)
+
+	s := NewRolePermissionService(&mockRolePermissionRepository, &mockCore.RoleService{}, &mockPermissionService, &mockCore.SafeguardService{}, &mockCore.AdminScopeService{}, &mockImplicationService, &mockCore.AccessImpactService{})
+	got, err := s.GetPermissionUsers(request)
+	if err != nil {
+		t.Fatalf("GetPermissionUsers() error = %v", err)
+	}
+
+	items := got.Data.(*domain.Page).Items.([]*domain.PermissionUser)
+	want := []struct {
+		direct    bool
+		impliedBy int
+	}{{true, 0}, {true, 1}, {false, 1}}
+	for i, w := range want {
+		if items[i].Direct != w.direct || len(items[i].ImpliedBy) != w.impliedBy {
+			t.Errorf("GetPermissionUsers() user %s direct = %v, implied by = %v", items[i].Id, items[i].Direct, items[i].ImpliedBy)
+		}
+	}
+}
@@ -1 +1,154 @@
 package services
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	mockCursor "user-svc/internal/mocks/shared/cursor"
+	appError "user-svc/internal/shared/error"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRoleService_DeleteRole(t *testing.T) {
+	lockout := &appError.AppError{Code: http.StatusConflict, Message: "change would leave no active user holding permission Update-Role"}
+
+	tests := []struct {
+		name        string
+		dryRun      bool
+		lockout     error
+		deleteErr   error
+		wantDelete  bool
+		wantCode    int
+		wantLockout string
+	}{
+		{
+			name:       "success - role deleted",
+			wantDelete: true,
+		},
+		{
+			name:   "success - dry run reports impact only",
+			dryRun: true,
+		},
+		{
+			name:        "success - dry run reports the lock-out",
+			dryRun:      true,
+			lockout:     lockout,
+			wantLockout: lockout.Message,
+		},
+		{
+			name:     "failed - lock-out refused",
+			lockout:  lockout,
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:      "failed - role changed since it was read",
+			deleteErr: domain.ErrVersionMismatch,
+			wantCode:  http.StatusPreconditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRoleRepository := mockCore.RoleRepository{}
+			mockRoleRepository.On("GetRoleByID", "role-1").Return(&domain.Role{Id: "role-1", Name: "Editor", Active: true, Version: 3}, nil)
+			mockRoleRepository.On("DeleteRole", "role-1", int64(3), mock.Anything).Return(tt.deleteErr)
+
+			mockSafeguardService := mockCore.SafeguardService{}
+			mockSafeguardService.On("CheckLockout", mock.Anything).Return(tt.lockout)
+
+			mockAccessImpactService := mockCore.AccessImpactService{}
+			mockAccessImpactService.On("Simulate", &domain.AccessChange{RoleIds: []string{"role-1"}}).Return(&domain.AccessImpact{DryRun: true}, nil)
+
+			s := NewRoleService(&mockRoleRepository, &mockSafeguardService, &mockAccessImpactService, indexingSearchService(), &mockCursor.Codec{})
+			got, err := s.DeleteRole(&domain.DeleteRoleRequest{Id: "role-1", DryRun: tt.dryRun})
+			if tt.wantCode != 0 {
+				var appErr *appError.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Fatalf("DeleteRole() error = %v, want code %d", err, tt.wantCode)
+				}
+				if tt.deleteErr == nil {
+					mockRoleRepository.AssertNotCalled(t, "DeleteRole", mock.Anything, mock.Anything, mock.Anything)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DeleteRole() error = %v", err)
+			}
+
+			if tt.wantDelete {
+				mockRoleRepository.AssertCalled(t, "DeleteRole", "role-1", int64(3), mock.Anything)
+				mockAccessImpactService.AssertNotCalled(t, "Simulate", mock.Anything)
+				return
+			}
+			mockRoleRepository.AssertNotCalled(t, "DeleteRole", mock.Anything, mock.Anything, mock.Anything)
+			impact, ok := got.Data.(*domain.AccessImpact)
+			if !ok || !impact.DryRun || impact.Lockout != tt.wantLockout {
+				t.Errorf("DeleteRole() data = %+v, want dry run impact with lock-out %q", got.Data, tt.wantLockout)
+			}
+		})
+	}
+}
+
+func TestRoleService_UpdateRole(t *testing.T) {
+	active := true
+	tests := []struct {
+		name       string
+		ifMatch    []int64
+		updateErr  error
+		wantCode   int
+		wantUpdate bool
+	}{
+		{
+			name:       "success - unconditional update",
+			wantCode:   http.StatusOK,
+			wantUpdate: true,
+		},
+		{
+			name:       "success - If-Match on the current version",
+			ifMatch:    []int64{1, 4},
+			wantCode:   http.StatusOK,
+			wantUpdate: true,
+		},
+		{
+			name:     "failed - If-Match on a stale version",
+			ifMatch:  []int64{3},
+			wantCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "failed - role changed concurrently",
+			ifMatch:    []int64{4},
+			updateErr:  domain.ErrVersionMismatch,
+			wantCode:   http.StatusPreconditionFailed,
+			wantUpdate: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRoleRepository := mockCore.RoleRepository{}
+			mockRoleRepository.On("GetRoleByID", "role-1").Return(&domain.Role{Id: "role-1", Name: "Editor", Active: true, Version: 4}, nil)
+			mockRoleRepository.On("GetRoleByName", "Editor").Return(nil, errors.New("sql: no rows in result set"))
+			mockRoleRepository.On("UpdateRole", mock.Anything).Return(tt.updateErr)
+
+			s := NewRoleService(&mockRoleRepository, &mockCore.SafeguardService{}, &mockCore.AccessImpactService{}, indexingSearchService(), &mockCursor.Codec{})
+			got, err := s.UpdateRole(&domain.UpdateRoleRequest{Id: "role-1", Name: "Editor", Active: &active, IfMatch: tt.ifMatch})
+			if tt.wantCode == http.StatusOK {
+				if err != nil || got.Code != http.StatusOK {
+					t.Fatalf("UpdateRole() = %v, %v", got, err)
+				}
+			} else {
+				var appErr *appError.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Fatalf("UpdateRole() error = %v, want code %d", err, tt.wantCode)
+				}
+			}
+
+			if tt.wantUpdate {
+				mockRoleRepository.AssertCalled(t, "UpdateRole", mock.Anything)
+				return
+			}
+			mockRoleRepository.AssertNotCalled(t, "UpdateRole", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+)
+
+type SafeguardService struct {
+	config             *config.Config
+	userRoleRepository ports.UserRoleRepository
+}
+
+func NewSafeguardService(config *config.Config, userRoleRepository ports.UserRoleRepository) *SafeguardService {
+	return &SafeguardService{
+		config:             config,
+		userRoleRepository: userRoleRepository,
+	}
+}
+
+// CheckLockout refuses a change that would leave a super-admin permission
+// without any active holder. Permissions that have no holder yet are ignored,
+// since the change is not what locks them out.
+func (s *SafeguardService) CheckLockout(change *domain.AccessChange) error {
+	permissions := s.config.App.SuperAdminPermissions
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	holders, err := s.userRoleRepository.GetPermissionHolders(permissions)
+	if err != nil {
+		return &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	before := make(map[string]int)
+	after := make(map[string]int)
+	for _, holder := range holders {
+		before[holder.PermissionName]++
+		if !isRevoked(change, holder) {
+			after[holder.PermissionName]++
+		}
+	}
+
+	for _, permission := range permissions {
+		if before[permission] > 0 && after[permission] == 0 {
+			return &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("change would leave no active user holding permission %s", permission)}
+		}
+	}
+
+	return nil
+}
+
+func isRevoked(change *domain.AccessChange, holder *domain.PermissionHolder) bool {
+	if contains(change.UserIds, holder.UserId) || contains(change.RoleIds, holder.RoleId) || contains(change.PermissionIds, holder.PermissionId) {
+		return true
+	}
+	if holder.GroupId == "" {
+		for _, grant := range change.RevokedUserRoles {
+			if grant.UserId == holder.UserId && grant.RoleId == holder.RoleId {
+				return true
+			}
+		}
+	} else {
+		if contains(change.GroupIds, holder.GroupId) {
+			return true
+		}
+		for _, grant := range change.RevokedGroupUsers {
+			if grant.GroupId == holder.GroupId && grant.UserId == holder.UserId {
+				return true
+			}
+		}
+		for _, grant := range change.RevokedGroupRoles {
+			if grant.GroupId == holder.GroupId && grant.RoleId == holder.RoleId {
+				return true
+			}
+		}
+	}
+	for _, grant := range change.RevokedRolePermissions {
+		if grant.RoleId == holder.RoleId && grant.PermissionId == holder.PermissionId {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
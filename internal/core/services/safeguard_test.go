@@ -0,0 +1,99 @@
+package services
+
+import (
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	"user-svc/internal/shared/config"
+)
+
+func TestSafeguardService_CheckLockout(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.SuperAdminPermissions = []string{"Update-Role"}
+
+	holders := []*domain.PermissionHolder{
+		{UserId: "user-1", RoleId: "role-admin", PermissionId: "perm-update-role", PermissionName: "Update-Role"},
+		{UserId: "user-2", RoleId: "role-admin", PermissionId: "perm-update-role", PermissionName: "Update-Role"},
+	}
+	groupHolders := []*domain.PermissionHolder{
+		{UserId: "user-1", RoleId: "role-admin", GroupId: "group-admins", PermissionId: "perm-update-role", PermissionName: "Update-Role"},
+	}
+
+	tests := []struct {
+		name          string
+		change        *domain.AccessChange
+		holdersResult []interface{}
+		wantErr       bool
+	}{
+		{
+			name:          "success - another user still holds the permission",
+			change:        &domain.AccessChange{UserIds: []string{"user-1"}},
+			holdersResult: []interface{}{holders, nil},
+			wantErr:       false,
+		},
+		{
+			name:          "success - permission has no holder yet",
+			change:        &domain.AccessChange{RoleIds: []string{"role-admin"}},
+			holdersResult: []interface{}{[]*domain.PermissionHolder{}, nil},
+			wantErr:       false,
+		},
+		{
+			name:          "failed - deleting the only admin role",
+			change:        &domain.AccessChange{RoleIds: []string{"role-admin"}},
+			holdersResult: []interface{}{holders, nil},
+			wantErr:       true,
+		},
+		{
+			name:          "failed - revoking the permission from the admin role",
+			change:        &domain.AccessChange{RevokedRolePermissions: []*domain.RolePermissionGrant{{RoleId: "role-admin", PermissionId: "perm-update-role"}}},
+			holdersResult: []interface{}{holders, nil},
+			wantErr:       true,
+		},
+		{
+			name: "failed - revoking the admin role from every holder",
+			change: &domain.AccessChange{RevokedUserRoles: []*domain.UserRoleGrant{
+				{UserId: "user-1", RoleId: "role-admin"},
+				{UserId: "user-2", RoleId: "role-admin"},
+			}},
+			holdersResult: []interface{}{holders, nil},
+			wantErr:       true,
+		},
+		{
+			name:          "success - direct revoke leaves the group grant",
+			change:        &domain.AccessChange{RevokedUserRoles: []*domain.UserRoleGrant{{UserId: "user-1", RoleId: "role-admin"}}},
+			holdersResult: []interface{}{groupHolders, nil},
+			wantErr:       false,
+		},
+		{
+			name:          "failed - deleting the only admin group",
+			change:        &domain.AccessChange{GroupIds: []string{"group-admins"}},
+			holdersResult: []interface{}{groupHolders, nil},
+			wantErr:       true,
+		},
+		{
+			name:          "failed - removing the last member of the admin group",
+			change:        &domain.AccessChange{RevokedGroupUsers: []*domain.GroupUserGrant{{GroupId: "group-admins", UserId: "user-1"}}},
+			holdersResult: []interface{}{groupHolders, nil},
+			wantErr:       true,
+		},
+		{
+			name:          "failed - unable to load holders",
+			change:        &domain.AccessChange{UserIds: []string{"user-1"}},
+			holdersResult: []interface{}{nil, errors.New("error")},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRoleRepository := mockCore.UserRoleRepository{}
+			mockUserRoleRepository.On("GetPermissionHolders", mock.Anything).Return(tt.holdersResult...)
+
+			s := NewSafeguardService(cfg, &mockUserRoleRepository)
+			if err := s.CheckLockout(tt.change); (err != nil) != tt.wantErr {
+				t.Errorf("CheckLockout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
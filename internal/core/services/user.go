@@ -8,22 +8,31 @@ import (
 	"time"
 	"user-svc/internal/core/domain"
 	"user-svc/internal/core/ports"
+	"user-svc/internal/shared/cursor"
 	appError "user-svc/internal/shared/error"
 	"user-svc/internal/shared/hash"
 	"user-svc/internal/shared/logger"
 )
 
 type UserService struct {
-	userRepository ports.UserRepository
-	hasher         hash.Hasher
-	logger         logger.Logger
+	userRepository       ports.UserRepository
+	hasher               hash.Hasher
+	logger               logger.Logger
+	safeguardService     ports.SafeguardService
+	cursorCodec          cursor.Codec
+	userSearchService    ports.UserSearchService
+	userAttributeService ports.UserAttributeService
 }
 
-func NewUserService(userRepository ports.UserRepository, hasher hash.Hasher, logger logger.Logger) *UserService {
+func NewUserService(userRepository ports.UserRepository, hasher hash.Hasher, logger logger.Logger, safeguardService ports.SafeguardService, cursorCodec cursor.Codec, userSearchService ports.UserSearchService, userAttributeService ports.UserAttributeService) *UserService {
 	return &UserService{
-		userRepository: userRepository,
-		hasher:         hasher,
-		logger:         logger,
+		userRepository:       userRepository,
+		hasher:               hasher,
+		logger:               logger,
+		safeguardService:     safeguardService,
+		cursorCodec:          cursorCodec,
+		userSearchService:    userSearchService,
+		userAttributeService: userAttributeService,
 	}
 }
 
@@ -34,6 +43,10 @@ func (u *UserService) CreateUser(request *domain.CreateUserRequest) (*domain.Res
 		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("user %s already exist", request.Email)}
 	}
 
+	if err := u.userAttributeService.ValidateAttributes(request.Attributes); err != nil {
+		return nil, err
+	}
+
 	salt, err := u.hasher.GenerateRandomSalt()
 	if err != nil {
 		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
@@ -42,18 +55,20 @@ func (u *UserService) CreateUser(request *domain.CreateUserRequest) (*domain.Res
 	hashedPassword := u.hasher.HashPassword(request.Password, salt)
 
 	user := &domain.User{
-		Id:        uuid.New().String(),
-		Name:      request.Name,
-		Email:     request.Email,
-		Salt:      base64.URLEncoding.EncodeToString(salt),
-		Password:  hashedPassword,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		Id:         uuid.New().String(),
+		Name:       request.Name,
+		Email:      request.Email,
+		Attributes: request.Attributes,
+		Salt:       base64.URLEncoding.EncodeToString(salt),
+		Password:   hashedPassword,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
 	}
 
 	if err := u.userRepository.CreateUser(user); err != nil {
 		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
 	}
+	u.indexUser(user.Id)
 
 	return &domain.Response{
 		Code:    http.StatusCreated,
@@ -68,11 +83,28 @@ func (u *UserService) UpdateUser(request *domain.UpdateUserRequest) (*domain.Res
 		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("user with id %s not exist", request.Id)}
 	}
 
+	if err := checkVersion("user", user.Id, request.IfMatch, user.Version); err != nil {
+		return nil, err
+	}
+
 	check, _ := u.userRepository.GetUserByEmail(request.Email)
 	if check != nil && check.Id != user.Id {
 		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("user with email %s already exist", request.Email)}
 	}
 
+	if request.Attributes != nil {
+		if err := u.userAttributeService.ValidateAttributes(request.Attributes); err != nil {
+			return nil, err
+		}
+		user.Attributes = request.Attributes
+	}
+
+	if user.Active && !*request.Active {
+		if err := u.safeguardService.CheckLockout(&domain.AccessChange{UserIds: []string{user.Id}}); err != nil {
+			return nil, err
+		}
+	}
+
 	if request.Password != "" {
 		salt, err := u.hasher.GenerateRandomSalt()
 		if err != nil {
@@ -91,8 +123,9 @@ func (u *UserService) UpdateUser(request *domain.UpdateUserRequest) (*domain.Res
 
 	err = u.userRepository.UpdateUser(user)
 	if err != nil {
-		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		return nil, writeError("user", user.Id, err)
 	}
+	u.indexUser(user.Id)
 
 	return &domain.Response{
 		Code:    http.StatusOK,
@@ -101,16 +134,117 @@ func (u *UserService) UpdateUser(request *domain.UpdateUserRequest) (*domain.Res
 	}, nil
 }
 
-func (u *UserService) DeleteUser(id string) (*domain.Response, error) {
-	user, err := u.userRepository.GetUserByID(id)
+// PatchUser applies a merge patch, only the members it carries are checked and
+// written.
+func (u *UserService) PatchUser(request *domain.PatchUserRequest) (*domain.Response, error) {
+	user, err := u.userRepository.GetUserByID(request.Id)
 	if err != nil && user == nil {
-		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("user with id %s not exist", id)}
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("user with id %s not exist", request.Id)}
+	}
+
+	if err := checkVersion("user", user.Id, request.IfMatch, user.Version); err != nil {
+		return nil, err
+	}
+
+	if request.Email != nil && *request.Email != user.Email {
+		check, _ := u.userRepository.GetUserByEmail(*request.Email)
+		if check != nil && check.Id != user.Id {
+			return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("user with email %s already exist", *request.Email)}
+		}
+	}
+
+	var attributes map[string]interface{}
+	if request.Attributes != nil {
+		attributes = mergeAttributes(user.Attributes, request.Attributes)
+		if err := u.userAttributeService.ValidateAttributes(attributes); err != nil {
+			return nil, err
+		}
+	}
+
+	if request.Active != nil && user.Active && !*request.Active {
+		if err := u.safeguardService.CheckLockout(&domain.AccessChange{UserIds: []string{user.Id}}); err != nil {
+			return nil, err
+		}
+	}
+
+	patch := &domain.UserPatch{
+		Name:       request.Name,
+		Email:      request.Email,
+		Active:     request.Active,
+		Attributes: attributes,
+		UpdatedAt:  time.Now(),
+	}
+	if request.Password != nil {
+		salt, err := u.hasher.GenerateRandomSalt()
+		if err != nil {
+			return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		}
+
+		hashedPassword := u.hasher.HashPassword(*request.Password, salt)
+		encodedSalt := base64.URLEncoding.EncodeToString(salt)
+		patch.Password = &hashedPassword
+		patch.Salt = &encodedSalt
+	}
+
+	if err := u.userRepository.PatchUser(user.Id, user.Version, patch); err != nil {
+		return nil, writeError("user", user.Id, err)
+	}
+	u.indexUser(user.Id)
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    nil,
+	}, nil
+}
+
+func (u *UserService) DeleteUser(request *domain.DeleteUserRequest) (*domain.Response, error) {
+	user, err := u.userRepository.GetUserByID(request.Id)
+	if err != nil && user == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("user with id %s not exist", request.Id)}
+	}
+
+	if err := checkVersion("user", user.Id, request.IfMatch, user.Version); err != nil {
+		return nil, err
 	}
 
-	err = u.userRepository.DeleteUser(user.Id)
+	if err := u.safeguardService.CheckLockout(&domain.AccessChange{UserIds: []string{user.Id}}); err != nil {
+		return nil, err
+	}
+
+	err = u.userRepository.DeleteUser(user.Id, user.Version, time.Now())
 	if err != nil {
+		return nil, writeError("user", user.Id, err)
+	}
+	if err := u.userSearchService.RemoveUser(user.Id); err != nil {
+		u.logger.WithFields(logger.FieldMap{"user_id": user.Id}).Warn("failed to remove user from search index: ", err)
+	}
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    nil,
+	}, nil
+}
+
+// RestoreUser takes a deleted user out of the trash, along with the roles and
+// groups it held when it was deleted.
+func (u *UserService) RestoreUser(request *domain.RestoreUserRequest) (*domain.Response, error) {
+	user, err := u.userRepository.GetDeletedUserByID(request.Id)
+	if err != nil && user == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("deleted user with id %s not exist", request.Id)}
+	}
+
+	if exist, err := u.userRepository.UserIsExist(user.Email); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	} else if exist {
+		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("user %s already exist", user.Email)}
+	}
+
+	if err := u.userRepository.RestoreUser(user.Id, time.Now()); err != nil {
 		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
 	}
+	u.indexUser(user.Id)
+
 	return &domain.Response{
 		Code:    http.StatusOK,
 		Message: http.StatusText(http.StatusOK),
@@ -118,15 +252,73 @@ func (u *UserService) DeleteUser(id string) (*domain.Response, error) {
 	}, nil
 }
 
-func (u *UserService) GetUsers() (*domain.Response, error) {
-	result, err := u.userRepository.GetAllUsers()
+func (u *UserService) GetUsers(request *domain.GetUsersRequest) (*domain.Response, error) {
+	attributes, err := u.userAttributeService.ParseAttributeFilters(request.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &domain.UserFilter{
+		Active:       request.Active,
+		EmailPrefix:  request.Email,
+		CreatedFrom:  request.CreatedFrom,
+		CreatedTo:    request.CreatedTo,
+		UpdatedSince: request.UpdatedSince,
+		HasRole:      request.HasRole,
+		Attributes:   attributes,
+		Sort:         request.Sort,
+		Order:        request.Order,
+		Limit:        request.Limit(),
+		Offset:       request.Offset(),
+	}
+	if request.UseCursor() {
+		return u.getUsersAfter(request, filter)
+	}
+
+	users, total, err := u.userRepository.GetUsers(filter)
 	if err != nil {
 		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
 	}
 	return &domain.Response{
 		Code:    http.StatusOK,
 		Message: http.StatusText(http.StatusOK),
-		Data:    result,
+		Data: &domain.Page{
+			Items:   users,
+			Total:   total,
+			Page:    request.CurrentPage(),
+			PerPage: request.Limit(),
+		},
+	}, nil
+}
+
+// getUsersAfter serves a keyset page, one extra row tells whether another
+// page follows.
+func (u *UserService) getUsersAfter(request *domain.GetUsersRequest, filter *domain.UserFilter) (*domain.Response, error) {
+	after, err := decodeCursor(u.cursorCodec, userCursor, request.CursorRequest, request.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := request.CursorLimit()
+	filter.After = after
+	filter.Limit = limit + 1
+	users, err := u.userRepository.GetUsersAfter(filter)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	page := &domain.CursorPage{Limit: limit}
+	if len(users) > limit {
+		users = users[:limit]
+		last := users[limit-1]
+		page.NextCursor = u.cursorCodec.Encode(userCursor, last.CreatedAt, last.Id)
+	}
+	page.Items = users
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    page,
 	}, nil
 }
 
@@ -142,3 +334,28 @@ func (u *UserService) GetUser(id string) (*domain.Response, error) {
 		Data:    result,
 	}, nil
 }
+
+// indexUser keeps the search index in step with postgres. It is best effort,
+// the write already happened and a stale document is fixed by a reindex.
+func (u *UserService) indexUser(id string) {
+	if err := u.userSearchService.IndexUser(id); err != nil {
+		u.logger.WithFields(logger.FieldMap{"user_id": id}).Warn("failed to index user: ", err)
+	}
+}
+
+// mergeAttributes applies a merge patch to the attributes of a user, a null
+// removes the attribute. The attributes of the user are left untouched.
+func mergeAttributes(attributes map[string]interface{}, patch map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(attributes)+len(patch))
+	for name, value := range attributes {
+		merged[name] = value
+	}
+	for name, value := range patch {
+		if value == nil {
+			delete(merged, name)
+		} else {
+			merged[name] = value
+		}
+	}
+	return merged
+}
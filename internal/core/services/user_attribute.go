@@ -0,0 +1,326 @@
+package services
+
+import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	appError "user-svc/internal/shared/error"
+)
+
+// attributeNamePattern keeps attribute names usable as JSON members, query
+// parameters and token claims alike.
+var attributeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+type UserAttributeService struct {
+	validate                *validator.Validate
+	userAttributeRepository ports.UserAttributeRepository
+}
+
+func NewUserAttributeService(validate *validator.Validate, userAttributeRepository ports.UserAttributeRepository) *UserAttributeService {
+	return &UserAttributeService{
+		validate:                validate,
+		userAttributeRepository: userAttributeRepository,
+	}
+}
+
+func (s *UserAttributeService) CreateUserAttribute(request *domain.CreateUserAttributeRequest) (*domain.Response, error) {
+	if !attributeNamePattern.MatchString(request.Name) {
+		return nil, &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("attribute name %s must be lowercase letters, digits and underscores, starting with a letter", request.Name)}
+	}
+
+	if err := s.checkRules(request.Type, request.Rules); err != nil {
+		return nil, err
+	}
+
+	existing, _ := s.userAttributeRepository.GetUserAttributeByName(request.Name)
+	if existing != nil {
+		return nil, &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("attribute %s already exist", request.Name)}
+	}
+
+	if request.Required {
+		if err := s.checkRequired(request.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	attribute := &domain.UserAttribute{
+		Name:        request.Name,
+		Type:        request.Type,
+		Required:    request.Required,
+		Rules:       request.Rules,
+		Claim:       request.Claim,
+		Description: request.Description,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := s.userAttributeRepository.CreateUserAttribute(attribute); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusCreated,
+		Message: http.StatusText(http.StatusCreated),
+		Data:    nil,
+	}, nil
+}
+
+// UpdateUserAttribute replaces the definition. New rules apply to the values
+// written from then on, the ones users already hold are not checked again.
+func (s *UserAttributeService) UpdateUserAttribute(request *domain.UpdateUserAttributeRequest) (*domain.Response, error) {
+	attribute, err := s.userAttributeRepository.GetUserAttributeByName(request.Name)
+	if err != nil && attribute == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("attribute %s not exist", request.Name)}
+	}
+
+	if err := s.checkRules(attribute.Type, request.Rules); err != nil {
+		return nil, err
+	}
+
+	if *request.Required && !attribute.Required {
+		if err := s.checkRequired(attribute.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	attribute.Required = *request.Required
+	attribute.Rules = request.Rules
+	attribute.Claim = *request.Claim
+	attribute.Description = request.Description
+	attribute.UpdatedAt = time.Now()
+
+	if err := s.userAttributeRepository.UpdateUserAttribute(attribute); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    nil,
+	}, nil
+}
+
+// DeleteUserAttribute removes the attribute from every user holding it.
+func (s *UserAttributeService) DeleteUserAttribute(name string) (*domain.Response, error) {
+	attribute, err := s.userAttributeRepository.GetUserAttributeByName(name)
+	if err != nil && attribute == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("attribute %s not exist", name)}
+	}
+
+	if err := s.userAttributeRepository.DeleteUserAttribute(attribute.Name); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    nil,
+	}, nil
+}
+
+func (s *UserAttributeService) GetUserAttributes() (*domain.Response, error) {
+	result, err := s.userAttributeRepository.GetUserAttributes()
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+func (s *UserAttributeService) GetUserAttribute(name string) (*domain.Response, error) {
+	result, err := s.userAttributeRepository.GetUserAttributeByName(name)
+	if err != nil && result == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("attribute %s not exist", name)}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+// ValidateAttributes refuses attributes that are not defined, values of the
+// wrong type or breaking the rules, and required attributes left out. Every
+// problem is reported at once.
+func (s *UserAttributeService) ValidateAttributes(attributes map[string]interface{}) error {
+	definitions, err := s.definitions()
+	if err != nil {
+		return err
+	}
+
+	messages := make([]string, 0)
+	for name, value := range attributes {
+		attribute, ok := definitions[name]
+		if !ok {
+			messages = append(messages, fmt.Sprintf("attributes.%s: attribute not exist", name))
+			continue
+		}
+		if message := s.checkValue(attribute, value); message != "" {
+			messages = append(messages, message)
+		}
+	}
+	for name, attribute := range definitions {
+		if _, ok := attributes[name]; attribute.Required && !ok {
+			messages = append(messages, fmt.Sprintf("attributes.%s: attribute is required", name))
+		}
+	}
+
+	if len(messages) > 0 {
+		sort.Strings(messages)
+		return &appError.AppError{Code: http.StatusBadRequest, Message: strings.Join(messages, ", ")}
+	}
+	return nil
+}
+
+// ParseAttributeFilters splits each filter on its first colon, the value may
+// hold colons of its own.
+func (s *UserAttributeService) ParseAttributeFilters(filters []string) ([]*domain.AttributeFilter, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	definitions, err := s.definitions()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.AttributeFilter, 0, len(filters))
+	for _, filter := range filters {
+		name, value, _ := strings.Cut(filter, ":")
+		if _, ok := definitions[name]; !ok {
+			return nil, &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("attribute %s not exist", name)}
+		}
+		result = append(result, &domain.AttributeFilter{Name: name, Value: value})
+	}
+	return result, nil
+}
+
+// ClaimAttributes keeps the attributes defined as claims, nil when the user
+// holds none of them.
+func (s *UserAttributeService) ClaimAttributes(attributes map[string]interface{}) (map[string]interface{}, error) {
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+
+	definitions, err := s.definitions()
+	if err != nil {
+		return nil, err
+	}
+
+	var claims map[string]interface{}
+	for name, value := range attributes {
+		if attribute, ok := definitions[name]; ok && attribute.Claim {
+			if claims == nil {
+				claims = make(map[string]interface{})
+			}
+			claims[name] = value
+		}
+	}
+	return claims, nil
+}
+
+func (s *UserAttributeService) definitions() (map[string]*domain.UserAttribute, error) {
+	attributes, err := s.userAttributeRepository.GetUserAttributes()
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	definitions := make(map[string]*domain.UserAttribute, len(attributes))
+	for _, attribute := range attributes {
+		definitions[attribute.Name] = attribute
+	}
+	return definitions, nil
+}
+
+// checkRequired refuses to require an attribute some users do not hold yet,
+// they would no longer be valid.
+func (s *UserAttributeService) checkRequired(name string) error {
+	count, err := s.userAttributeRepository.CountUsersWithoutAttribute(name)
+	if err != nil {
+		return &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	if count > 0 {
+		return &appError.AppError{Code: http.StatusConflict, Message: fmt.Sprintf("attribute %s cannot be required, %d users do not have it", name, count)}
+	}
+	return nil
+}
+
+// checkRules runs the rules on the zero value of the type, the validator
+// panics on a tag it does not know or that does not apply to the type.
+func (s *UserAttributeService) checkRules(attributeType string, rules string) error {
+	if rules == "" {
+		return nil
+	}
+
+	var zero interface{}
+	switch attributeType {
+	case domain.UserAttributeNumber, domain.UserAttributeInteger:
+		zero = float64(0)
+	case domain.UserAttributeBoolean:
+		zero = false
+	default:
+		zero = ""
+	}
+
+	if _, err := s.applyRules(zero, rules); err != nil {
+		return &appError.AppError{Code: http.StatusBadRequest, Message: fmt.Sprintf("invalid rules %s: %s", rules, err.Error())}
+	}
+	return nil
+}
+
+// checkValue returns what is wrong with the value, nothing when it is valid.
+func (s *UserAttributeService) checkValue(attribute *domain.UserAttribute, value interface{}) string {
+	valid := false
+	switch attribute.Type {
+	case domain.UserAttributeString:
+		_, valid = value.(string)
+	case domain.UserAttributeNumber:
+		_, valid = value.(float64)
+	case domain.UserAttributeInteger:
+		number, ok := value.(float64)
+		valid = ok && number == math.Trunc(number)
+	case domain.UserAttributeBoolean:
+		_, valid = value.(bool)
+	case domain.UserAttributeDate:
+		date, ok := value.(string)
+		if ok {
+			_, err := time.Parse(domain.UserAttributeDateLayout, date)
+			valid = err == nil
+		}
+	}
+	if !valid {
+		return fmt.Sprintf("attributes.%s: must be of type %s", attribute.Name, attribute.Type)
+	}
+
+	if attribute.Rules == "" {
+		return ""
+	}
+	if passed, err := s.applyRules(value, attribute.Rules); err != nil || !passed {
+		return fmt.Sprintf("attributes.%s: invalid value '%v'", attribute.Name, value)
+	}
+	return ""
+}
+
+// applyRules tells whether the value passes the rules, a panic of the
+// validator is returned as an error.
+func (s *UserAttributeService) applyRules(value interface{}, rules string) (passed bool, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("%v", rec)
+		}
+	}()
+
+	return s.validate.Var(value, rules) == nil, nil
+}
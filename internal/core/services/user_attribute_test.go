@@ -0,0 +1,195 @@
+package services
+
+import (
+	"database/sql"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"reflect"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	appError "user-svc/internal/shared/error"
+)
+
+var userAttributeDefinitions = []*domain.UserAttribute{
+	{Name: "department", Type: domain.UserAttributeString, Required: true, Rules: "min=2,max=32", Claim: true},
+	{Name: "employee_id", Type: domain.UserAttributeInteger, Rules: "gte=1"},
+	{Name: "hired_on", Type: domain.UserAttributeDate},
+	{Name: "remote", Type: domain.UserAttributeBoolean, Claim: true},
+	{Name: "score", Type: domain.UserAttributeNumber},
+}
+
+func TestUserAttributeService_ValidateAttributes(t *testing.T) {
+	mockUserAttributeRepository := mockCore.UserAttributeRepository{}
+	mockUserAttributeRepository.On("GetUserAttributes").Return(userAttributeDefinitions, nil)
+	s := NewUserAttributeService(validator.New(), &mockUserAttributeRepository)
+
+	tests := []struct {
+		name       string
+		attributes map[string]interface{}
+		wantErr    string
+	}{
+		{
+			name:       "success",
+			attributes: map[string]interface{}{"department": "sales", "employee_id": float64(42), "hired_on": "2024-02-29", "remote": true, "score": 4.5},
+		},
+		{
+			name:       "failed - required attribute left out",
+			attributes: map[string]interface{}{"remote": false},
+			wantErr:    "attributes.department: attribute is required",
+		},
+		{
+			name:       "failed - unknown attribute",
+			attributes: map[string]interface{}{"department": "sales", "shoe_size": float64(44)},
+			wantErr:    "attributes.shoe_size: attribute not exist",
+		},
+		{
+			name:       "failed - wrong types",
+			attributes: map[string]interface{}{"department": "sales", "employee_id": 4.2, "hired_on": "29/02/2024", "remote": "yes"},
+			wantErr:    "attributes.employee_id: must be of type integer, attributes.hired_on: must be of type date, attributes.remote: must be of type boolean",
+		},
+		{
+			name:       "failed - rules broken",
+			attributes: map[string]interface{}{"department": "x", "employee_id": float64(0)},
+			wantErr:    "attributes.department: invalid value 'x', attributes.employee_id: invalid value '0'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.ValidateAttributes(tt.attributes)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateAttributes() error = %v", err)
+				}
+				return
+			}
+			appErr, ok := err.(*appError.AppError)
+			if !ok || appErr.Code != http.StatusBadRequest || appErr.Message != tt.wantErr {
+				t.Errorf("ValidateAttributes() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserAttributeService_CreateUserAttribute(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *domain.CreateUserAttributeRequest
+		existing *domain.UserAttribute
+		without  int64
+		wantCode int
+	}{
+		{name: "success", request: &domain.CreateUserAttributeRequest{Name: "cost_center", Type: domain.UserAttributeString, Rules: "len=6"}, wantCode: http.StatusCreated},
+		{name: "failed - invalid name", request: &domain.CreateUserAttributeRequest{Name: "Cost-Center", Type: domain.UserAttributeString}, wantCode: http.StatusBadRequest},
+		{name: "failed - unknown rule", request: &domain.CreateUserAttributeRequest{Name: "cost_center", Type: domain.UserAttributeString, Rules: "shiny"}, wantCode: http.StatusBadRequest},
+		{name: "failed - rule not for the type", request: &domain.CreateUserAttributeRequest{Name: "remote", Type: domain.UserAttributeBoolean, Rules: "min=1"}, wantCode: http.StatusBadRequest},
+		{name: "failed - already exist", request: &domain.CreateUserAttributeRequest{Name: "department", Type: domain.UserAttributeString}, existing: userAttributeDefinitions[0], wantCode: http.StatusConflict},
+		{name: "failed - required while users lack it", request: &domain.CreateUserAttributeRequest{Name: "cost_center", Type: domain.UserAttributeString, Required: true}, without: 3, wantCode: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserAttributeRepository := mockCore.UserAttributeRepository{}
+			if tt.existing != nil {
+				mockUserAttributeRepository.On("GetUserAttributeByName", tt.request.Name).Return(tt.existing, nil)
+			} else {
+				mockUserAttributeRepository.On("GetUserAttributeByName", tt.request.Name).Return(nil, sql.ErrNoRows)
+			}
+			mockUserAttributeRepository.On("CountUsersWithoutAttribute", tt.request.Name).Return(tt.without, nil)
+			mockUserAttributeRepository.On("CreateUserAttribute", mock.Anything).Return(nil)
+
+			s := NewUserAttributeService(validator.New(), &mockUserAttributeRepository)
+			got, err := s.CreateUserAttribute(tt.request)
+			if tt.wantCode == http.StatusCreated {
+				if err != nil || got.Code != http.StatusCreated {
+					t.Fatalf("CreateUserAttribute() = %v, %v", got, err)
+				}
+				return
+			}
+			if appErr, ok := err.(*appError.AppError); !ok || appErr.Code != tt.wantCode {
+				t.Fatalf("CreateUserAttribute() error = %v, want code %d", err, tt.wantCode)
+			}
+			mockUserAttributeRepository.AssertNotCalled(t, "CreateUserAttribute", mock.Anything)
+		})
+	}
+}
+
+func TestUserAttributeService_UpdateUserAttribute(t *testing.T) {
+	yes, no := true, false
+
+	t.Run("failed - required while users lack it", func(t *testing.T) {
+		mockUserAttributeRepository := mockCore.UserAttributeRepository{}
+		mockUserAttributeRepository.On("GetUserAttributeByName", "remote").Return(&domain.UserAttribute{Name: "remote", Type: domain.UserAttributeBoolean}, nil)
+		mockUserAttributeRepository.On("CountUsersWithoutAttribute", "remote").Return(int64(1), nil)
+
+		s := NewUserAttributeService(validator.New(), &mockUserAttributeRepository)
+		_, err := s.UpdateUserAttribute(&domain.UpdateUserAttributeRequest{Name: "remote", Required: &yes, Claim: &no})
+		if appErr, ok := err.(*appError.AppError); !ok || appErr.Code != http.StatusConflict {
+			t.Fatalf("UpdateUserAttribute() error = %v, want conflict", err)
+		}
+		mockUserAttributeRepository.AssertNotCalled(t, "UpdateUserAttribute", mock.Anything)
+	})
+
+	t.Run("success - type is kept", func(t *testing.T) {
+		mockUserAttributeRepository := mockCore.UserAttributeRepository{}
+		mockUserAttributeRepository.On("GetUserAttributeByName", "remote").Return(&domain.UserAttribute{Name: "remote", Type: domain.UserAttributeBoolean, Required: true}, nil)
+		mockUserAttributeRepository.On("UpdateUserAttribute", mock.Anything).Return(nil)
+
+		s := NewUserAttributeService(validator.New(), &mockUserAttributeRepository)
+		_, err := s.UpdateUserAttribute(&domain.UpdateUserAttributeRequest{Name: "remote", Required: &yes, Claim: &yes, Description: "Works remotely"})
+		if err != nil {
+			t.Fatalf("UpdateUserAttribute() error = %v", err)
+		}
+
+		updated := mockUserAttributeRepository.Calls[1].Arguments.Get(0).(*domain.UserAttribute)
+		if updated.Type != domain.UserAttributeBoolean || !updated.Claim || updated.Description != "Works remotely" {
+			t.Errorf("UpdateUserAttribute() attribute = %+v", updated)
+		}
+		mockUserAttributeRepository.AssertNotCalled(t, "CountUsersWithoutAttribute", mock.Anything)
+	})
+
+	t.Run("failed - not exist", func(t *testing.T) {
+		mockUserAttributeRepository := mockCore.UserAttributeRepository{}
+		mockUserAttributeRepository.On("GetUserAttributeByName", "team").Return(nil, sql.ErrNoRows)
+
+		s := NewUserAttributeService(validator.New(), &mockUserAttributeRepository)
+		_, err := s.UpdateUserAttribute(&domain.UpdateUserAttributeRequest{Name: "team", Required: &no, Claim: &no})
+		if appErr, ok := err.(*appError.AppError); !ok || appErr.Code != http.StatusNotFound {
+			t.Fatalf("UpdateUserAttribute() error = %v, want not found", err)
+		}
+	})
+}
+
+func TestUserAttributeService_ParseAttributeFilters(t *testing.T) {
+	mockUserAttributeRepository := mockCore.UserAttributeRepository{}
+	mockUserAttributeRepository.On("GetUserAttributes").Return(userAttributeDefinitions, nil)
+	s := NewUserAttributeService(validator.New(), &mockUserAttributeRepository)
+
+	got, err := s.ParseAttributeFilters([]string{"department:sales:emea", "remote:true"})
+	want := []*domain.AttributeFilter{{Name: "department", Value: "sales:emea"}, {Name: "remote", Value: "true"}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseAttributeFilters() = %v, %v, want %v", got, err, want)
+	}
+
+	_, err = s.ParseAttributeFilters([]string{"team:blue"})
+	if appErr, ok := err.(*appError.AppError); !ok || appErr.Code != http.StatusBadRequest {
+		t.Errorf("ParseAttributeFilters() error = %v, want bad request", err)
+	}
+}
+
+func TestUserAttributeService_ClaimAttributes(t *testing.T) {
+	mockUserAttributeRepository := mockCore.UserAttributeRepository{}
+	mockUserAttributeRepository.On("GetUserAttributes").Return(userAttributeDefinitions, nil)
+	s := NewUserAttributeService(validator.New(), &mockUserAttributeRepository)
+
+	got, err := s.ClaimAttributes(map[string]interface{}{"department": "sales", "employee_id": float64(42), "remote": false})
+	want := map[string]interface{}{"department": "sales", "remote": false}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("ClaimAttributes() = %v, %v, want %v", got, err, want)
+	}
+
+	got, err = s.ClaimAttributes(map[string]interface{}{"employee_id": float64(42)})
+	if err != nil || got != nil {
+		t.Errorf("ClaimAttributes() = %v, %v, want no claims", got, err)
+	}
+}
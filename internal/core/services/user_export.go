@@ -0,0 +1,79 @@
+package services
+
+import (
+	"net/http"
+	"sort"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+)
+
+// defaultUserExportFetchSize is read from the cursor at a time when the
+// configured fetch size is not positive.
+const defaultUserExportFetchSize = 500
+
+type UserExportService struct {
+	config                          *config.Config
+	userExportRepository            ports.UserExportRepository
+	userAttributeService            ports.UserAttributeService
+	permissionImplicationRepository ports.PermissionImplicationRepository
+}
+
+func NewUserExportService(config *config.Config, userExportRepository ports.UserExportRepository, userAttributeService ports.UserAttributeService, permissionImplicationRepository ports.PermissionImplicationRepository) *UserExportService {
+	return &UserExportService{
+		config:                          config,
+		userExportRepository:            userExportRepository,
+		userAttributeService:            userAttributeService,
+		permissionImplicationRepository: permissionImplicationRepository,
+	}
+}
+
+// ExportUsers streams the users matching the filters of the listing to write.
+// Once a user is written the response is under way, so a later failure can
+// only cut the export short.
+func (s *UserExportService) ExportUsers(request *domain.ExportUsersRequest, write func(user *domain.UserExport) error) error {
+	attributes, err := s.userAttributeService.ParseAttributeFilters(request.Attributes)
+	if err != nil {
+		return err
+	}
+
+	query := &domain.UserExportQuery{
+		Filter: &domain.UserFilter{
+			Active:       request.Active,
+			EmailPrefix:  request.Email,
+			CreatedFrom:  request.CreatedFrom,
+			CreatedTo:    request.CreatedTo,
+			UpdatedSince: request.UpdatedSince,
+			HasRole:      request.HasRole,
+			Attributes:   attributes,
+		},
+		IncludeRoles:       request.IncludeRoles,
+		IncludePermissions: request.IncludePermissions,
+		FetchSize:          s.config.App.UserExport.FetchSize,
+	}
+	if query.FetchSize <= 0 {
+		query.FetchSize = defaultUserExportFetchSize
+	}
+
+	// the permissions are the ones the permission middleware grants, the
+	// implication graph is read once for the whole export
+	if query.IncludePermissions {
+		implications, err := s.permissionImplicationRepository.GetAllPermissionImplications()
+		if err != nil {
+			return &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		}
+		next := write
+		write = func(user *domain.UserExport) error {
+			user.Permissions = expandPermissionNames(implications, user.Permissions)
+			sort.Strings(user.Permissions)
+			return next(user)
+		}
+	}
+
+	if err := s.userExportRepository.ExportUsers(query, write); err != nil {
+		return &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return nil
+}
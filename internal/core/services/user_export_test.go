@@ -0,0 +1,99 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserExportService_ExportUsers(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.UserExport.FetchSize = 250
+	active := false
+	request := &domain.ExportUsersRequest{
+		Format:             domain.UserExportCSV,
+		Active:             &active,
+		Email:              "ann",
+		HasRole:            "role-1",
+		Attributes:         []string{"department:sales"},
+		IncludePermissions: true,
+	}
+	department := []*domain.AttributeFilter{{Name: "department", Value: "sales"}}
+	write := func(user *domain.UserExport) error { return nil }
+	expected := &domain.UserExportQuery{
+		Filter:             &domain.UserFilter{Active: &active, EmailPrefix: "ann", HasRole: "role-1", Attributes: department},
+		IncludePermissions: true,
+		FetchSize:          250,
+	}
+
+	mockUserAttributeService := mockCore.UserAttributeService{}
+	mockUserAttributeService.On("ParseAttributeFilters", request.Attributes).Return(department, nil)
+
+	mockPermissionImplicationRepository := mockCore.PermissionImplicationRepository{}
+	mockPermissionImplicationRepository.On("GetAllPermissionImplications").Return([]*domain.PermissionImplication{
+		{PermissionName: "Update-User", ImpliedPermissionName: "View-User"},
+	}, nil)
+
+	t.Run("success", func(t *testing.T) {
+		mockUserExportRepository := mockCore.UserExportRepository{}
+		mockUserExportRepository.On("ExportUsers", expected, mock.Anything).Return(nil)
+
+		s := NewUserExportService(cfg, &mockUserExportRepository, &mockUserAttributeService, &mockPermissionImplicationRepository)
+		assert.NoError(t, s.ExportUsers(request, write))
+		mockUserExportRepository.AssertExpectations(t)
+	})
+
+	t.Run("success - implied permissions exported", func(t *testing.T) {
+		mockUserExportRepository := mockCore.UserExportRepository{}
+		mockUserExportRepository.On("ExportUsers", expected, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			write := args.Get(1).(func(user *domain.UserExport) error)
+			assert.NoError(t, write(&domain.UserExport{Id: "user-1", Permissions: []string{"Update-User"}}))
+		})
+
+		var written *domain.UserExport
+		s := NewUserExportService(cfg, &mockUserExportRepository, &mockUserAttributeService, &mockPermissionImplicationRepository)
+		assert.NoError(t, s.ExportUsers(request, func(user *domain.UserExport) error {
+			written = user
+			return nil
+		}))
+		assert.Equal(t, []string{"Update-User", "View-User"}, written.Permissions)
+	})
+
+	t.Run("success - default fetch size", func(t *testing.T) {
+		mockUserExportRepository := mockCore.UserExportRepository{}
+		mockUserExportRepository.On("ExportUsers", mock.MatchedBy(func(query *domain.UserExportQuery) bool {
+			return query.FetchSize == defaultUserExportFetchSize
+		}), mock.Anything).Return(nil)
+
+		s := NewUserExportService(&config.Config{}, &mockUserExportRepository, &mockUserAttributeService, &mockPermissionImplicationRepository)
+		assert.NoError(t, s.ExportUsers(request, write))
+		mockUserExportRepository.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockUserExportRepository := mockCore.UserExportRepository{}
+		mockUserExportRepository.On("ExportUsers", expected, mock.Anything).Return(errors.New("connection refused"))
+
+		s := NewUserExportService(cfg, &mockUserExportRepository, &mockUserAttributeService, &mockPermissionImplicationRepository)
+		err := s.ExportUsers(request, write)
+		assert.Equal(t, &appError.AppError{Code: http.StatusInternalServerError, Message: "connection refused"}, err)
+	})
+	t.Run("unknown attribute", func(t *testing.T) {
+		mockUserExportRepository := mockCore.UserExportRepository{}
+		mockUserAttributeService := mockCore.UserAttributeService{}
+		mockUserAttributeService.On("ParseAttributeFilters", []string{"team:blue"}).
+			Return(nil, &appError.AppError{Code: http.StatusBadRequest, Message: "attribute team not exist"})
+
+		s := NewUserExportService(cfg, &mockUserExportRepository, &mockUserAttributeService, &mockPermissionImplicationRepository)
+		err := s.ExportUsers(&domain.ExportUsersRequest{Format: domain.UserExportCSV, Attributes: []string{"team:blue"}}, write)
+		assert.Equal(t, http.StatusBadRequest, err.(*appError.AppError).Code)
+		mockUserExportRepository.AssertNotCalled(t, "ExportUsers", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,586 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+	"user-svc/internal/shared/hash"
+	"user-svc/internal/shared/logger"
+)
+
+// staleUserImportAfter is how long a running import may go without recording
+// progress before it is taken for one whose process stopped.
+const staleUserImportAfter = 15 * time.Minute
+
+// defaultUserImportBatchSize users are written per transaction when the
+// configured batch size is not positive.
+const defaultUserImportBatchSize = 500
+
+type UserImportService struct {
+	config                *config.Config
+	validate              *validator.Validate
+	userImportRepository  ports.UserImportRepository
+	userRepository        ports.UserRepository
+	roleRepository        ports.RoleRepository
+	roleConstraintService ports.RoleConstraintService
+	adminScopeService     ports.AdminScopeService
+	userAttributeService  ports.UserAttributeService
+	userSearchService     ports.UserSearchService
+	hasher                hash.Hasher
+	logger                logger.Logger
+}
+
+func NewUserImportService(config *config.Config, validate *validator.Validate, userImportRepository ports.UserImportRepository, userRepository ports.UserRepository, roleRepository ports.RoleRepository, roleConstraintService ports.RoleConstraintService, adminScopeService ports.AdminScopeService, userAttributeService ports.UserAttributeService, userSearchService ports.UserSearchService, hasher hash.Hasher, logger logger.Logger) *UserImportService {
+	return &UserImportService{
+		config:                config,
+		validate:              validate,
+		userImportRepository:  userImportRepository,
+		userRepository:        userRepository,
+		roleRepository:        roleRepository,
+		roleConstraintService: roleConstraintService,
+		adminScopeService:     adminScopeService,
+		userAttributeService:  userAttributeService,
+		userSearchService:     userSearchService,
+		hasher:                hasher,
+		logger:                logger,
+	}
+}
+
+// ImportUsers validates every row of the file, then starts writing them in the
+// background. Nothing is written when a row is invalid, the report tells which
+// and why. A dry run stops after the validation.
+func (s *UserImportService) ImportUsers(request *domain.ImportUsersRequest) (*domain.Response, error) {
+	rows, err := parseUserImport(request.Format, request.Content)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusBadRequest, Message: err.Error()}
+	}
+	if len(rows) == 0 {
+		return nil, &appError.AppError{Code: http.StatusBadRequest, Message: "import has no rows"}
+	}
+	if maxRows := s.config.App.UserImport.MaxRows; len(rows) > maxRows {
+		return nil, &appError.AppError{Code: http.StatusRequestEntityTooLarge, Message: fmt.Sprintf("import has %d rows, at most %d are accepted", len(rows), maxRows)}
+	}
+
+	if request.Format != domain.UserImportNDJSON {
+		if err := s.typeAttributes(rows); err != nil {
+			return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+		}
+	}
+
+	roleIDs, err := s.validateRows(request.ActorId, rows)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	now := time.Now()
+	job := &domain.UserImport{
+		Id:        uuid.New().String(),
+		Status:    domain.UserImportRunning,
+		Format:    request.Format,
+		DryRun:    request.DryRun,
+		CreatedBy: request.ActorId,
+		Total:     len(rows),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Rows:      rows,
+	}
+	for _, row := range rows {
+		if row.Status == domain.UserImportRowInvalid {
+			job.Invalid++
+		}
+	}
+	if job.Invalid > 0 {
+		job.Status = domain.UserImportRejected
+		job.FinishedAt = &now
+	} else if job.DryRun {
+		job.Status = domain.UserImportCompleted
+		job.FinishedAt = &now
+	}
+
+	if err := s.userImportRepository.CreateUserImport(job); err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	if job.DryRun {
+		return &domain.Response{
+			Code:    http.StatusOK,
+			Message: http.StatusText(http.StatusOK),
+			Data:    job,
+		}, nil
+	}
+	if job.Status == domain.UserImportRejected {
+		return nil, &appError.AppError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: fmt.Sprintf("%d of %d rows are invalid, no user was imported", job.Invalid, job.Total),
+			Data:    job,
+		}
+	}
+
+	// the job owns the rows from here on, the report is polled
+	accepted := *job
+	accepted.Rows = nil
+	go s.run(job, roleIDs)
+
+	return &domain.Response{
+		Code:    http.StatusAccepted,
+		Message: http.StatusText(http.StatusAccepted),
+		Data:    &accepted,
+	}, nil
+}
+
+func (s *UserImportService) GetUserImport(id string) (*domain.Response, error) {
+	result, err := s.userImportRepository.GetUserImportByID(id)
+	if err != nil && result == nil {
+		return nil, &appError.AppError{Code: http.StatusNotFound, Message: fmt.Sprintf("user import with id %s not exist", id)}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+// FailStaleUserImports marks the imports left running by a process that
+// stopped as failed. The rows of a job only live in the memory of the process
+// that started it, so such an import cannot be resumed.
+func (s *UserImportService) FailStaleUserImports() error {
+	now := time.Now()
+	failed, err := s.userImportRepository.FailStaleUserImports(now.Add(-staleUserImportAfter), now)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		s.logger.Warn(fmt.Sprintf("marked %d interrupted user imports as failed", failed))
+	}
+	return nil
+}
+
+// typeAttributes reads the attribute cells of a CSV file as the type of their
+// definition, a CSV cell is always text. A cell that does not read as its type
+// is left as it is, the validation reports it.
+func (s *UserImportService) typeAttributes(rows []*domain.UserImportRow) error {
+	result, err := s.userAttributeService.GetUserAttributes()
+	if err != nil {
+		return err
+	}
+	types := make(map[string]string)
+	for _, attribute := range result.Data.([]*domain.UserAttribute) {
+		types[attribute.Name] = attribute.Type
+	}
+
+	for _, row := range rows {
+		for name, value := range row.Attributes {
+			text, ok := value.(string)
+			if !ok {
+				continue
+			}
+			switch types[name] {
+			case domain.UserAttributeNumber, domain.UserAttributeInteger:
+				if number, err := strconv.ParseFloat(text, 64); err == nil {
+					row.Attributes[name] = number
+				}
+			case domain.UserAttributeBoolean:
+				if boolean, err := strconv.ParseBool(text); err == nil {
+					row.Attributes[name] = boolean
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateRows reports on each row what keeps it from being imported and
+// resolves the role names to ids. Rows are checked against each other as well
+// as against the users, roles, admin scopes, attribute definitions and role
+// constraints in place. Only a failure to check is returned.
+func (s *UserImportService) validateRows(actorID string, rows []*domain.UserImportRow) (map[string]string, error) {
+	emails := make([]string, 0, len(rows))
+	lines := make(map[string]int)
+	for _, row := range rows {
+		if len(row.Errors) > 0 {
+			continue
+		}
+
+		if err := s.validate.Struct(row); err != nil {
+			var fields validator.ValidationErrors
+			if !errors.As(err, &fields) {
+				return nil, err
+			}
+			for _, field := range fields {
+				row.Errors = append(row.Errors, fmt.Sprintf("%s: invalid value '%v'", field.Field(), field.Value()))
+			}
+			continue
+		}
+
+		key := strings.ToLower(row.Email)
+		if line, ok := lines[key]; ok {
+			row.Errors = append(row.Errors, fmt.Sprintf("email %s is already on line %d", row.Email, line))
+			continue
+		}
+		lines[key] = row.Line
+		emails = append(emails, row.Email)
+	}
+
+	existing, err := s.userRepository.GetExistingEmails(emails)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, email := range existing {
+		taken[email] = true
+	}
+
+	roleIDs := make(map[string]string)
+	roleErrors := make(map[string]string)
+	checked := make(map[string]error)
+	checkedAttributes := make(map[string]error)
+	for _, row := range rows {
+		if taken[row.Email] {
+			row.Errors = append(row.Errors, fmt.Sprintf("user %s already exist", row.Email))
+		}
+
+		// rows tend to repeat the same attributes, like a department
+		if len(row.Errors) == 0 {
+			raw, err := json.Marshal(row.Attributes)
+			if err != nil {
+				return nil, err
+			}
+			key := string(raw)
+			if _, ok := checkedAttributes[key]; !ok {
+				checkedAttributes[key] = s.userAttributeService.ValidateAttributes(row.Attributes)
+			}
+			if err := checkedAttributes[key]; err != nil {
+				message, rejected := rejection(err)
+				if !rejected {
+					return nil, err
+				}
+				row.Errors = append(row.Errors, message)
+			}
+		}
+
+		ids := make([]string, 0, len(row.Roles))
+		for _, name := range uniqueStrings(row.Roles) {
+			if _, ok := roleIDs[name]; !ok {
+				if err := s.resolveRole(actorID, name, roleIDs, roleErrors); err != nil {
+					return nil, err
+				}
+			}
+			if message, ok := roleErrors[name]; ok {
+				row.Errors = append(row.Errors, message)
+				continue
+			}
+			ids = append(ids, roleIDs[name])
+		}
+
+		// the user is new, so the outcome only depends on the set of roles
+		if len(row.Errors) == 0 && len(ids) > 0 {
+			sort.Strings(ids)
+			key := strings.Join(ids, ",")
+			if _, ok := checked[key]; !ok {
+				checked[key] = s.roleConstraintService.CheckAssignment("", ids)
+			}
+			if err := checked[key]; err != nil {
+				message, rejected := rejection(err)
+				if !rejected {
+					return nil, err
+				}
+				row.Errors = append(row.Errors, message)
+			}
+		}
+
+		row.Status = domain.UserImportRowValid
+		if len(row.Errors) > 0 {
+			row.Status = domain.UserImportRowInvalid
+		}
+	}
+
+	return roleIDs, nil
+}
+
+// resolveRole looks the role up by name and makes sure the acting user may
+// grant it. Why it cannot be granted is kept in roleErrors.
+func (s *UserImportService) resolveRole(actorID string, name string, roleIDs map[string]string, roleErrors map[string]string) error {
+	role, err := s.roleRepository.GetRoleByName(name)
+	if err != nil && role == nil {
+		roleIDs[name] = ""
+		roleErrors[name] = fmt.Sprintf("role %s not exist", name)
+		return nil
+	}
+	roleIDs[name] = role.Id
+
+	if err := s.adminScopeService.CheckRoleScope(actorID, []string{role.Id}); err != nil {
+		message, rejected := rejection(err)
+		if !rejected {
+			return err
+		}
+		roleErrors[name] = message
+	}
+
+	return nil
+}
+
+// rejection tells a check that refused the row apart from one that failed.
+func rejection(err error) (string, bool) {
+	var appErr *appError.AppError
+	if errors.As(err, &appErr) && appErr.Code < http.StatusInternalServerError {
+		return appErr.Message, true
+	}
+	return "", false
+}
+
+// run writes the rows batch by batch. A batch is written in a single
+// transaction, so a failed batch leaves none of its users behind and the next
+// batches are still attempted. Progress is recorded after every batch.
+func (s *UserImportService) run(job *domain.UserImport, roleIDs map[string]string) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.fail(job, r)
+		}
+	}()
+
+	batchSize := s.config.App.UserImport.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultUserImportBatchSize
+	}
+	for start := 0; start < len(job.Rows); start += batchSize {
+		end := start + batchSize
+		if end > len(job.Rows) {
+			end = len(job.Rows)
+		}
+		batch := job.Rows[start:end]
+
+		users, grants, err := s.buildUsers(batch, roleIDs)
+		if err == nil {
+			err = s.userImportRepository.ImportUsers(users, grants)
+		}
+
+		for _, row := range batch {
+			row.Password = ""
+			if err != nil {
+				row.Status = domain.UserImportRowFailed
+				row.UserId = ""
+				row.Errors = append(row.Errors, err.Error())
+				job.Failed++
+				continue
+			}
+			row.Status = domain.UserImportRowCreated
+			job.Created++
+		}
+
+		now := time.Now()
+		job.Processed = end
+		job.UpdatedAt = now
+		if end == len(job.Rows) {
+			job.Status = domain.UserImportCompleted
+			job.FinishedAt = &now
+		}
+		if err := s.userImportRepository.UpdateUserImport(job); err != nil {
+			s.logger.WithFields(logger.FieldMap{"user_import_id": job.Id}).Error("failed to record import progress: ", err)
+		}
+
+		if err == nil {
+			for _, user := range users {
+				if err := s.userSearchService.IndexUser(user.Id); err != nil {
+					s.logger.WithFields(logger.FieldMap{"user_id": user.Id}).Warn("failed to index user: ", err)
+				}
+			}
+		}
+	}
+}
+
+// fail records an import whose job panicked as failed, so it is not polled as
+// running forever.
+func (s *UserImportService) fail(job *domain.UserImport, reason interface{}) {
+	fields := logger.FieldMap{"user_import_id": job.Id}
+	s.logger.WithFields(fields).Error("user import stopped: ", reason)
+
+	now := time.Now()
+	job.Status = domain.UserImportFailed
+	job.UpdatedAt = now
+	job.FinishedAt = &now
+	if err := s.userImportRepository.UpdateUserImport(job); err != nil {
+		s.logger.WithFields(fields).Error("failed to record import failure: ", err)
+	}
+}
+
+// buildUsers hashes the passwords of a batch. A row without a password gets a
+// random one nobody knows, the user sets their own through a reset.
+func (s *UserImportService) buildUsers(batch []*domain.UserImportRow, roleIDs map[string]string) ([]*domain.User, []*domain.UserRoleGrant, error) {
+	users := make([]*domain.User, 0, len(batch))
+	grants := make([]*domain.UserRoleGrant, 0)
+	for _, row := range batch {
+		salt, err := s.hasher.GenerateRandomSalt()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		password := row.Password
+		if password == "" {
+			random, err := s.hasher.GenerateRandomSalt()
+			if err != nil {
+				return nil, nil, err
+			}
+			password = base64.URLEncoding.EncodeToString(random)
+		}
+
+		row.UserId = uuid.New().String()
+		users = append(users, &domain.User{
+			Id:         row.UserId,
+			Name:       row.Name,
+			Email:      row.Email,
+			Attributes: row.Attributes,
+			Salt:       base64.URLEncoding.EncodeToString(salt),
+			Password:   s.hasher.HashPassword(password, salt),
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		})
+		for _, name := range uniqueStrings(row.Roles) {
+			grants = append(grants, &domain.UserRoleGrant{UserId: row.UserId, RoleId: roleIDs[name]})
+		}
+	}
+
+	return users, grants, nil
+}
+
+// parseUserImport reads the rows of a CSV or NDJSON file. A row that cannot be
+// read is reported on, only a file that cannot be read at all fails.
+func parseUserImport(format string, content []byte) ([]*domain.UserImportRow, error) {
+	if format == domain.UserImportNDJSON {
+		return parseUserImportNDJSON(content)
+	}
+	return parseUserImportCSV(content)
+}
+
+// parseUserImportCSV expects a header naming the name and email columns, the
+// password and roles columns are optional. Roles are separated by semicolons.
+// An attributes.<name> column holds the value of an attribute, an empty cell
+// leaves the attribute out.
+func parseUserImportCSV(content []byte) ([]*domain.UserImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("csv header has no name column")
+	}
+	if _, ok := columns["email"]; !ok {
+		return nil, errors.New("csv header has no email column")
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rows := make([]*domain.UserImportRow, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := &domain.UserImportRow{
+			Line:     line,
+			Name:     field(record, "name"),
+			Email:    field(record, "email"),
+			Password: field(record, "password"),
+		}
+		for _, role := range strings.Split(field(record, "roles"), ";") {
+			if role = strings.TrimSpace(role); role != "" {
+				row.Roles = append(row.Roles, role)
+			}
+		}
+		for column := range columns {
+			name := strings.TrimPrefix(column, "attributes.")
+			if name == column || name == "" {
+				continue
+			}
+			if value := field(record, column); value != "" {
+				if row.Attributes == nil {
+					row.Attributes = make(map[string]interface{})
+				}
+				row.Attributes[name] = value
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseUserImportNDJSON expects a JSON object per line, blank lines are
+// skipped.
+func parseUserImportNDJSON(content []byte) ([]*domain.UserImportRow, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	rows := make([]*domain.UserImportRow, 0)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var record struct {
+			Name       string                 `json:"name"`
+			Email      string                 `json:"email"`
+			Password   string                 `json:"password"`
+			Roles      []string               `json:"roles"`
+			Attributes map[string]interface{} `json:"attributes"`
+		}
+		row := &domain.UserImportRow{Line: line}
+		if err := json.Unmarshal(text, &record); err != nil {
+			row.Errors = []string{fmt.Sprintf("invalid JSON: %s", err)}
+			row.Status = domain.UserImportRowInvalid
+		} else {
+			row.Name = strings.TrimSpace(record.Name)
+			row.Email = strings.TrimSpace(record.Email)
+			row.Password = record.Password
+			row.Roles = record.Roles
+			row.Attributes = record.Attributes
+		}
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
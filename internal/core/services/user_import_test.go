@@ -0,0 +1,300 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	mockShared "user-svc/internal/mocks/shared/hash"
+	mockLog "user-svc/internal/mocks/shared/logger"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseUserImport(t *testing.T) {
+	t.Run("csv", func(t *testing.T) {
+		content := "email,name,roles\nalice@example.com,Alice,Editor; Viewer\n\"bob@example.com\",Bob,\n"
+		rows, err := parseUserImport(domain.UserImportCSV, []byte(content))
+		assert.NoError(t, err)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, &domain.UserImportRow{Line: 2, Name: "Alice", Email: "alice@example.com", Roles: []string{"Editor", "Viewer"}}, rows[0])
+		assert.Equal(t, 3, rows[1].Line)
+		assert.Empty(t, rows[1].Roles)
+	})
+
+	t.Run("csv attributes", func(t *testing.T) {
+		content := "name,email,attributes.department,Attributes.Level\nAlice,alice@example.com,Sales,3\nBob,bob@example.com,,\n"
+		rows, err := parseUserImport(domain.UserImportCSV, []byte(content))
+		assert.NoError(t, err)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, map[string]interface{}{"department": "Sales", "level": "3"}, rows[0].Attributes)
+		assert.Nil(t, rows[1].Attributes)
+	})
+
+	t.Run("csv without an email column", func(t *testing.T) {
+		_, err := parseUserImport(domain.UserImportCSV, []byte("name\nAlice\n"))
+		assert.Error(t, err)
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		content := `{"name":"Alice","email":"alice@example.com","password":"secret","roles":["Editor"],"attributes":{"level":3}}
+
+{"name":`
+		rows, err := parseUserImport(domain.UserImportNDJSON, []byte(content))
+		assert.NoError(t, err)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, &domain.UserImportRow{Line: 1, Name: "Alice", Email: "alice@example.com", Password: "secret", Roles: []string{"Editor"},
+			Attributes: map[string]interface{}{"level": 3.0}}, rows[0])
+		assert.Equal(t, 3, rows[1].Line)
+		assert.Equal(t, domain.UserImportRowInvalid, rows[1].Status)
+	})
+}
+
+func TestUserImportService_ImportUsers(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.UserImport.MaxRows = 100
+	cfg.App.UserImport.BatchSize = 10
+	content := []byte(`{"name":"Alice","email":"alice@example.com","roles":["Editor"],"attributes":{"department":"Sales"}}
+{"name":"Alice again","email":"ALICE@example.com"}
+{"name":"Bob","email":"bob@example.com"}
+{"name":"Carol","email":"carol"}
+{"name":"Dave","email":"dave@example.com","roles":["Ghost"]}
+{"name":"Erin","email":"erin@example.com","roles":["Editor","Auditor"]}
+{"name":"Frank","email":"frank@example.com","attributes":{"badge":"F-1"}}`)
+
+	newService := func(mockImportRepository *mockCore.UserImportRepository) *UserImportService {
+		mockUserRepository := mockCore.UserRepository{}
+		mockUserRepository.On("GetExistingEmails", []string{"alice@example.com", "bob@example.com", "dave@example.com", "erin@example.com", "frank@example.com"}).Return([]string{"bob@example.com"}, nil)
+
+		mockRoleRepository := mockCore.RoleRepository{}
+		mockRoleRepository.On("GetRoleByName", "Editor").Return(&domain.Role{Id: "role-editor", Name: "Editor"}, nil)
+		mockRoleRepository.On("GetRoleByName", "Auditor").Return(&domain.Role{Id: "role-auditor", Name: "Auditor"}, nil)
+		mockRoleRepository.On("GetRoleByName", "Ghost").Return(nil, errors.New("sql: no rows in result set"))
+
+		mockAdminScopeService := mockCore.AdminScopeService{}
+		mockAdminScopeService.On("CheckRoleScope", "actor-1", mock.Anything).Return(nil)
+
+		mockRoleConstraintService := mockCore.RoleConstraintService{}
+		mockRoleConstraintService.On("CheckAssignment", "", []string{"role-editor"}).Return(nil)
+		mockRoleConstraintService.On("CheckAssignment", "", []string{"role-auditor", "role-editor"}).
+			Return(&appError.AppError{Code: http.StatusConflict, Message: "role assignment violates sod constraint Editors do not audit"})
+
+		mockUserAttributeService := mockCore.UserAttributeService{}
+		mockUserAttributeService.On("ValidateAttributes", map[string]interface{}{"department": "Sales"}).Return(nil)
+		mockUserAttributeService.On("ValidateAttributes", map[string]interface{}(nil)).Return(nil)
+		mockUserAttributeService.On("ValidateAttributes", map[string]interface{}{"badge": "F-1"}).
+			Return(&appError.AppError{Code: http.StatusBadRequest, Message: "attributes.badge: attribute not exist"})
+
+		return NewUserImportService(cfg, validator.New(), mockImportRepository, &mockUserRepository, &mockRoleRepository,
+			&mockRoleConstraintService, &mockAdminScopeService, &mockUserAttributeService, &mockCore.UserSearchService{}, &mockShared.Hasher{}, &mockLog.Logger{})
+	}
+
+	t.Run("dry run reports every row", func(t *testing.T) {
+		mockImportRepository := mockCore.UserImportRepository{}
+		mockImportRepository.On("CreateUserImport", mock.Anything).Return(nil)
+
+		got, err := newService(&mockImportRepository).ImportUsers(&domain.ImportUsersRequest{ActorId: "actor-1", Format: domain.UserImportNDJSON, DryRun: true, Content: content})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, got.Code)
+
+		job := got.Data.(*domain.UserImport)
+		assert.Equal(t, domain.UserImportRejected, job.Status)
+		assert.Equal(t, 7, job.Total)
+		assert.Equal(t, 6, job.Invalid)
+		assert.Equal(t, domain.UserImportRowValid, job.Rows[0].Status)
+		assert.Equal(t, []string{"email ALICE@example.com is already on line 1"}, job.Rows[1].Errors)
+		assert.Equal(t, []string{"user bob@example.com already exist"}, job.Rows[2].Errors)
+		assert.Equal(t, []string{"Email: invalid value 'carol'"}, job.Rows[3].Errors)
+		assert.Equal(t, []string{"role Ghost not exist"}, job.Rows[4].Errors)
+		assert.Equal(t, []string{"role assignment violates sod constraint Editors do not audit"}, job.Rows[5].Errors)
+		assert.Equal(t, []string{"attributes.badge: attribute not exist"}, job.Rows[6].Errors)
+		mockImportRepository.AssertNotCalled(t, "ImportUsers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid rows reject the import", func(t *testing.T) {
+		mockImportRepository := mockCore.UserImportRepository{}
+		mockImportRepository.On("CreateUserImport", mock.Anything).Return(nil)
+
+		_, err := newService(&mockImportRepository).ImportUsers(&domain.ImportUsersRequest{ActorId: "actor-1", Format: domain.UserImportNDJSON, Content: content})
+		var appErr *appError.AppError
+		if assert.True(t, errors.As(err, &appErr)) {
+			assert.Equal(t, http.StatusUnprocessableEntity, appErr.Code)
+			assert.Equal(t, 6, appErr.Data.(*domain.UserImport).Invalid)
+		}
+		mockImportRepository.AssertNotCalled(t, "ImportUsers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("csv attributes are read as their type", func(t *testing.T) {
+		csv := []byte("name,email,attributes.level,attributes.remote,attributes.team\nAnn,ann@example.com,3,yes,7\n")
+		attributes := map[string]interface{}{"level": 3.0, "remote": "yes", "team": "7"}
+
+		mockImportRepository := mockCore.UserImportRepository{}
+		mockImportRepository.On("CreateUserImport", mock.Anything).Return(nil)
+
+		mockUserRepository := mockCore.UserRepository{}
+		mockUserRepository.On("GetExistingEmails", []string{"ann@example.com"}).Return([]string{}, nil)
+
+		mockUserAttributeService := mockCore.UserAttributeService{}
+		mockUserAttributeService.On("GetUserAttributes").Return(&domain.Response{Data: []*domain.UserAttribute{
+			{Name: "level", Type: domain.UserAttributeInteger},
+			{Name: "remote", Type: domain.UserAttributeBoolean},
+			{Name: "team", Type: domain.UserAttributeString},
+		}}, nil)
+		mockUserAttributeService.On("ValidateAttributes", attributes).
+			Return(&appError.AppError{Code: http.StatusBadRequest, Message: "attributes.remote: must be of type boolean"})
+
+		s := NewUserImportService(cfg, validator.New(), &mockImportRepository, &mockUserRepository, &mockCore.RoleRepository{},
+			&mockCore.RoleConstraintService{}, &mockCore.AdminScopeService{}, &mockUserAttributeService, &mockCore.UserSearchService{}, &mockShared.Hasher{}, &mockLog.Logger{})
+
+		got, err := s.ImportUsers(&domain.ImportUsersRequest{Format: domain.UserImportCSV, DryRun: true, Content: csv})
+		assert.NoError(t, err)
+		job := got.Data.(*domain.UserImport)
+		assert.Equal(t, attributes, job.Rows[0].Attributes)
+		assert.Equal(t, []string{"attributes.remote: must be of type boolean"}, job.Rows[0].Errors)
+	})
+
+	t.Run("too many rows", func(t *testing.T) {
+		small := &config.Config{}
+		small.App.UserImport.MaxRows = 1
+		s := NewUserImportService(small, validator.New(), &mockCore.UserImportRepository{}, &mockCore.UserRepository{}, &mockCore.RoleRepository{},
+			&mockCore.RoleConstraintService{}, &mockCore.AdminScopeService{}, &mockCore.UserAttributeService{}, &mockCore.UserSearchService{}, &mockShared.Hasher{}, &mockLog.Logger{})
+
+		_, err := s.ImportUsers(&domain.ImportUsersRequest{Format: domain.UserImportNDJSON, Content: content})
+		var appErr *appError.AppError
+		if assert.True(t, errors.As(err, &appErr)) {
+			assert.Equal(t, http.StatusRequestEntityTooLarge, appErr.Code)
+		}
+	})
+}
+
+func TestUserImportService_run(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.UserImport.BatchSize = 2
+	job := &domain.UserImport{
+		Id:     "import-1",
+		Status: domain.UserImportRunning,
+		Total:  3,
+		Rows: []*domain.UserImportRow{
+			{Line: 1, Name: "Alice", Email: "alice@example.com", Password: "secret", Roles: []string{"Editor"}, Attributes: map[string]interface{}{"level": 3.0}, Status: domain.UserImportRowValid},
+			{Line: 2, Name: "Bob", Email: "bob@example.com", Status: domain.UserImportRowValid},
+			{Line: 3, Name: "Carol", Email: "carol@example.com", Status: domain.UserImportRowValid},
+		},
+	}
+
+	mockHasher := mockShared.Hasher{}
+	mockHasher.On("GenerateRandomSalt").Return([]byte("salt"), nil)
+	mockHasher.On("HashPassword", mock.Anything, mock.Anything).Return("hashed")
+
+	mockImportRepository := mockCore.UserImportRepository{}
+	mockImportRepository.On("ImportUsers", mock.MatchedBy(func(users []*domain.User) bool { return len(users) == 2 }), mock.Anything).Return(nil)
+	mockImportRepository.On("ImportUsers", mock.MatchedBy(func(users []*domain.User) bool { return len(users) == 1 }), mock.Anything).Return(errors.New("duplicate key value violates unique constraint"))
+	mockImportRepository.On("UpdateUserImport", job).Return(nil)
+
+	mockUserSearchService := mockCore.UserSearchService{}
+	mockUserSearchService.On("IndexUser", mock.Anything).Return(nil)
+
+	s := NewUserImportService(cfg, validator.New(), &mockImportRepository, &mockCore.UserRepository{}, &mockCore.RoleRepository{},
+		&mockCore.RoleConstraintService{}, &mockCore.AdminScopeService{}, &mockCore.UserAttributeService{}, &mockUserSearchService, &mockHasher, &mockLog.Logger{})
+	s.run(job, map[string]string{"Editor": "role-editor"})
+
+	assert.Equal(t, domain.UserImportCompleted, job.Status)
+	assert.Equal(t, 3, job.Processed)
+	assert.Equal(t, 2, job.Created)
+	assert.Equal(t, 1, job.Failed)
+	assert.NotNil(t, job.FinishedAt)
+	assert.Equal(t, domain.UserImportRowCreated, job.Rows[0].Status)
+	assert.NotEmpty(t, job.Rows[0].UserId)
+	assert.Empty(t, job.Rows[0].Password)
+	assert.Equal(t, domain.UserImportRowFailed, job.Rows[2].Status)
+	assert.Empty(t, job.Rows[2].UserId)
+	mockImportRepository.AssertNumberOfCalls(t, "UpdateUserImport", 2)
+	mockUserSearchService.AssertNumberOfCalls(t, "IndexUser", 2)
+
+	users := mockImportRepository.Calls[0].Arguments.Get(0).([]*domain.User)
+	assert.Equal(t, map[string]interface{}{"level": 3.0}, users[0].Attributes)
+
+	grants := mockImportRepository.Calls[0].Arguments.Get(1).([]*domain.UserRoleGrant)
+	assert.Equal(t, []*domain.UserRoleGrant{{UserId: job.Rows[0].UserId, RoleId: "role-editor"}}, grants)
+}
+
+func TestUserImportService_run_defaultBatchSize(t *testing.T) {
+	job := &domain.UserImport{
+		Id:     "import-1",
+		Status: domain.UserImportRunning,
+		Total:  2,
+		Rows: []*domain.UserImportRow{
+			{Line: 1, Name: "Alice", Email: "alice@example.com", Status: domain.UserImportRowValid},
+			{Line: 2, Name: "Bob", Email: "bob@example.com", Status: domain.UserImportRowValid},
+		},
+	}
+
+	mockHasher := mockShared.Hasher{}
+	mockHasher.On("GenerateRandomSalt").Return([]byte("salt"), nil)
+	mockHasher.On("HashPassword", mock.Anything, mock.Anything).Return("hashed")
+
+	mockImportRepository := mockCore.UserImportRepository{}
+	mockImportRepository.On("ImportUsers", mock.Anything, mock.Anything).Return(nil)
+	mockImportRepository.On("UpdateUserImport", job).Return(nil)
+
+	mockUserSearchService := mockCore.UserSearchService{}
+	mockUserSearchService.On("IndexUser", mock.Anything).Return(nil)
+
+	s := NewUserImportService(&config.Config{}, validator.New(), &mockImportRepository, &mockCore.UserRepository{}, &mockCore.RoleRepository{},
+		&mockCore.RoleConstraintService{}, &mockCore.AdminScopeService{}, &mockCore.UserAttributeService{}, &mockUserSearchService, &mockHasher, &mockLog.Logger{})
+	s.run(job, map[string]string{})
+
+	assert.Equal(t, domain.UserImportCompleted, job.Status)
+	assert.Equal(t, 2, job.Created)
+	mockImportRepository.AssertNumberOfCalls(t, "ImportUsers", 1)
+}
+
+func TestUserImportService_run_panic(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.UserImport.BatchSize = 2
+	job := &domain.UserImport{
+		Id:     "import-1",
+		Status: domain.UserImportRunning,
+		Total:  1,
+		Rows:   []*domain.UserImportRow{{Line: 1, Name: "Alice", Email: "alice@example.com", Status: domain.UserImportRowValid}},
+	}
+
+	mockHasher := mockShared.Hasher{}
+	mockHasher.On("GenerateRandomSalt").Run(func(mock.Arguments) { panic("entropy source closed") })
+
+	mockImportRepository := mockCore.UserImportRepository{}
+	mockImportRepository.On("UpdateUserImport", job).Return(nil)
+
+	entry, hook := test.NewNullLogger()
+	mockLogger := mockLog.Logger{}
+	mockLogger.On("WithFields", mock.Anything).Return(logrus.NewEntry(entry))
+
+	s := NewUserImportService(cfg, validator.New(), &mockImportRepository, &mockCore.UserRepository{}, &mockCore.RoleRepository{},
+		&mockCore.RoleConstraintService{}, &mockCore.AdminScopeService{}, &mockCore.UserAttributeService{}, &mockCore.UserSearchService{}, &mockHasher, &mockLogger)
+	assert.NotPanics(t, func() { s.run(job, map[string]string{}) })
+
+	assert.Equal(t, domain.UserImportFailed, job.Status)
+	assert.NotNil(t, job.FinishedAt)
+	assert.Equal(t, logrus.ErrorLevel, hook.LastEntry().Level)
+	mockImportRepository.AssertCalled(t, "UpdateUserImport", job)
+}
+
+func TestUserImportService_FailStaleUserImports(t *testing.T) {
+	mockImportRepository := mockCore.UserImportRepository{}
+	mockImportRepository.On("FailStaleUserImports", mock.Anything, mock.Anything).Return(int64(0), nil)
+
+	s := NewUserImportService(&config.Config{}, validator.New(), &mockImportRepository, &mockCore.UserRepository{}, &mockCore.RoleRepository{},
+		&mockCore.RoleConstraintService{}, &mockCore.AdminScopeService{}, &mockCore.UserAttributeService{}, &mockCore.UserSearchService{}, &mockShared.Hasher{}, &mockLog.Logger{})
+	assert.NoError(t, s.FailStaleUserImports())
+
+	call := mockImportRepository.Calls[0]
+	updatedBefore, finishedAt := call.Arguments.Get(0).(time.Time), call.Arguments.Get(1).(time.Time)
+	assert.Equal(t, staleUserImportAfter, finishedAt.Sub(updatedBefore))
+}
@@ -2,22 +2,35 @@ package services
 
 import (
 	"net/http"
+	"sort"
 	"user-svc/internal/core/domain"
 	"user-svc/internal/core/ports"
 	appError "user-svc/internal/shared/error"
 )
 
 type UserRoleService struct {
-	userRoleRepository ports.UserRoleRepository
-	userService        ports.UserService
-	roleService        ports.RoleService
+	userRoleRepository    ports.UserRoleRepository
+	userService           ports.UserService
+	roleService           ports.RoleService
+	safeguardService      ports.SafeguardService
+	roleConstraintService ports.RoleConstraintService
+	adminScopeService     ports.AdminScopeService
+	accessImpactService   ports.AccessImpactService
+	userSearchService     ports.UserSearchService
+	implicationService    ports.PermissionImplicationService
 }
 
-func NewUserRoleService(userRoleRepository ports.UserRoleRepository, userService ports.UserService, roleService ports.RoleService) *UserRoleService {
+func NewUserRoleService(userRoleRepository ports.UserRoleRepository, userService ports.UserService, roleService ports.RoleService, safeguardService ports.SafeguardService, roleConstraintService ports.RoleConstraintService, adminScopeService ports.AdminScopeService, accessImpactService ports.AccessImpactService, userSearchService ports.UserSearchService, implicationService ports.PermissionImplicationService) *UserRoleService {
 	return &UserRoleService{
-		userRoleRepository: userRoleRepository,
-		userService:        userService,
-		roleService:        roleService,
+		userRoleRepository:    userRoleRepository,
+		userService:           userService,
+		roleService:           roleService,
+		safeguardService:      safeguardService,
+		roleConstraintService: roleConstraintService,
+		adminScopeService:     adminScopeService,
+		accessImpactService:   accessImpactService,
+		userSearchService:     userSearchService,
+		implicationService:    implicationService,
 	}
 }
 
@@ -52,10 +65,27 @@ func (s *UserRoleService) AssignRolesToUser(request *domain.AssignRolesToUserReq
 		}
 	}
 
-	err = s.userRoleRepository.AddUserRoles(request.UserId, request.RolesId)
+	if err := s.adminScopeService.CheckRoleScope(request.ActorId, request.RolesId); err != nil {
+		return nil, err
+	}
+
+	if err := s.roleConstraintService.CheckAssignment(request.UserId, request.RolesId); err != nil {
+		return nil, err
+	}
+
+	if request.DryRun {
+		change := &domain.AccessChange{}
+		for _, roleID := range request.RolesId {
+			change.GrantedUserRoles = append(change.GrantedUserRoles, &domain.UserRoleGrant{UserId: request.UserId, RoleId: roleID})
+		}
+		return dryRun(s.accessImpactService, nil, change)
+	}
+
+	err = s.userRoleRepository.AddUserRoles(request.UserId, request.RolesId, nil)
 	if err != nil {
 		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
 	}
+	s.userSearchService.IndexUsers([]string{request.UserId})
 	return &domain.Response{
 		Code:    http.StatusCreated,
 		Message: http.StatusText(http.StatusCreated),
@@ -76,13 +106,136 @@ func (s *UserRoleService) RemoveRolesFromUser(request *domain.RemoveRolesFromUse
 		}
 	}
 
+	if err := s.adminScopeService.CheckRoleScope(request.ActorId, request.RolesId); err != nil {
+		return nil, err
+	}
+
+	change := &domain.AccessChange{}
+	for _, roleID := range request.RolesId {
+		change.RevokedUserRoles = append(change.RevokedUserRoles, &domain.UserRoleGrant{UserId: request.UserId, RoleId: roleID})
+	}
+	if request.DryRun {
+		return dryRun(s.accessImpactService, s.safeguardService, change)
+	}
+
+	if err := s.safeguardService.CheckLockout(change); err != nil {
+		return nil, err
+	}
+
 	err = s.userRoleRepository.RemoveUserRoles(request.UserId, request.RolesId)
 	if err != nil {
 		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
 	}
+	s.userSearchService.IndexUsers([]string{request.UserId})
 	return &domain.Response{
 		Code:    http.StatusOK,
 		Message: http.StatusText(http.StatusOK),
 		Data:    nil,
 	}, nil
 }
+
+// GetUserPermissions lists the permissions granted through the roles of the
+// user along with the ones they imply, which the user holds all the same.
+func (s *UserRoleService) GetUserPermissions(request *domain.GetUserPermissionsRequest) (*domain.Response, error) {
+	user, err := s.userService.GetUser(request.UserId)
+	if err != nil && user == nil {
+		return nil, err
+	}
+
+	result, err := s.userRoleRepository.GetUserPermissions(request.UserId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	granted := make([]string, 0, len(result))
+	for _, permission := range result {
+		granted = append(granted, permission.Id)
+	}
+	implied, err := s.implicationService.TraceImpliedPermissions(granted)
+	if err != nil {
+		return nil, err
+	}
+	for _, permission := range implied {
+		result = append(result, &domain.EffectivePermission{
+			Id:        permission.Id,
+			Name:      permission.Name,
+			GrantedBy: []*domain.PermissionGrant{},
+			ImpliedBy: permission.ImpliedBy,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+func (s *UserRoleService) GetRoleUsers(request *domain.GetRoleUsersRequest) (*domain.Response, error) {
+	role, err := s.roleService.GetRole(request.RoleId)
+	if err != nil && role == nil {
+		return nil, err
+	}
+
+	users, total, err := s.userRoleRepository.GetRoleUsers(request.RoleId, request.Limit(), request.Offset())
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data: &domain.Page{
+			Items:   users,
+			Total:   total,
+			Page:    request.CurrentPage(),
+			PerPage: request.Limit(),
+		},
+	}, nil
+}
+
+// GetDirectUserRoles lists the roles granted to the user itself, leaving out
+// the ones inherited through groups.
+func (s *UserRoleService) GetDirectUserRoles(request *domain.GetUserRolesRequest) (*domain.Response, error) {
+	user, err := s.userService.GetUser(request.UserId)
+	if err != nil && user == nil {
+		return nil, err
+	}
+
+	result, err := s.userRoleRepository.GetDirectUserRoles(request.UserId)
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data:    result,
+	}, nil
+}
+
+// GetDirectRoleUsers pages the users granted the role themselves, leaving out
+// the ones holding it through a group.
+func (s *UserRoleService) GetDirectRoleUsers(request *domain.GetRoleUsersRequest) (*domain.Response, error) {
+	role, err := s.roleService.GetRole(request.RoleId)
+	if err != nil && role == nil {
+		return nil, err
+	}
+
+	users, total, err := s.userRoleRepository.GetDirectRoleUsers(request.RoleId, request.Limit(), request.Offset())
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data: &domain.Page{
+			Items:   users,
+			Total:   total,
+			Page:    request.CurrentPage(),
+			PerPage: request.Limit(),
+		},
+	}, nil
+}
@@ -1 +1,50 @@
 package services
+
+import (
+	"net/http"
+	"testing"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+)
+
+func TestUserRoleService_GetUserPermissions(t *testing.T) {
+	granted := []*domain.EffectivePermission{
+		{Id: "p-update", Name: "Update-User", GrantedBy: []*domain.PermissionGrant{{RoleId: "role-1", RoleName: "Manager"}}},
+	}
+	implied := []*domain.ImpliedPermission{
+		{Id: "p-view", Name: "View-User", ImpliedBy: []string{"Update-User"}},
+		{Id: "p-list", Name: "List-User", ImpliedBy: []string{"Update-User"}},
+	}
+
+	mockUserService := mockCore.UserService{}
+	mockUserService.On("GetUser", "user-1").Return(&domain.Response{Code: http.StatusOK}, nil)
+
+	mockUserRoleRepository := mockCore.UserRoleRepository{}
+	mockUserRoleRepository.On("GetUserPermissions", "user-1").Return(granted, nil)
+
+	mockImplicationService := mockCore.PermissionImplicationService{}
+	mockImplicationService.On("TraceImpliedPermissions", []string{"p-update"}).Return(implied, nil)
+
+	s := NewUserRoleService(&mockUserRoleRepository, &mockUserService, &mockCore.RoleService{}, &mockCore.SafeguardService{}, &mockCore.RoleConstraintService{}, &mockCore.AdminScopeService{}, &mockCore.AccessImpactService{}, &mockCore.UserSearchService{}, &mockImplicationService)
+	got, err := s.GetUserPermissions(&domain.GetUserPermissionsRequest{UserId: "user-1"})
+	if err != nil {
+		t.Fatalf("GetUserPermissions() error = %v", err)
+	}
+
+	permissions := got.Data.([]*domain.EffectivePermission)
+	want := []string{"List-User", "Update-User", "View-User"}
+	if len(permissions) != len(want) {
+		t.Fatalf("GetUserPermissions() = %d permissions, want %d", len(permissions), len(want))
+	}
+	for i, name := range want {
+		if permissions[i].Name != name {
+			t.Errorf("GetUserPermissions()[%d] = %s, want %s", i, permissions[i].Name, name)
+		}
+	}
+	if len(permissions[0].GrantedBy) != 0 || len(permissions[0].ImpliedBy) != 1 {
+		t.Errorf("GetUserPermissions() List-User = %+v, want implied only", permissions[0])
+	}
+	if len(permissions[1].ImpliedBy) != 0 {
+		t.Errorf("GetUserPermissions() Update-User implied by = %v, want none", permissions[1].ImpliedBy)
+	}
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"user-svc/internal/core/domain"
+	"user-svc/internal/core/ports"
+	"user-svc/internal/shared/config"
+	appError "user-svc/internal/shared/error"
+	"user-svc/internal/shared/logger"
+)
+
+// reindexBatchSize is how many users are read and indexed at a time.
+const reindexBatchSize = 500
+
+type UserSearchService struct {
+	config               *config.Config
+	userSearchRepository ports.UserSearchRepository
+	userRepository       ports.UserRepository
+	userRoleRepository   ports.UserRoleRepository
+	logger               logger.Logger
+}
+
+func NewUserSearchService(config *config.Config, userSearchRepository ports.UserSearchRepository, userRepository ports.UserRepository, userRoleRepository ports.UserRoleRepository, logger logger.Logger) *UserSearchService {
+	return &UserSearchService{
+		config:               config,
+		userSearchRepository: userSearchRepository,
+		userRepository:       userRepository,
+		userRoleRepository:   userRoleRepository,
+		logger:               logger,
+	}
+}
+
+// SearchUsers searches the index, or postgres when the index is disabled.
+func (s *UserSearchService) SearchUsers(request *domain.SearchUsersRequest) (*domain.Response, error) {
+	query := &domain.UserSearchQuery{
+		Query:  request.Q,
+		Active: request.Active,
+		Role:   request.Role,
+		Limit:  request.Limit(),
+		Offset: request.Offset(),
+	}
+
+	var result *domain.UserSearchResult
+	var err error
+	if s.config.App.UserSearch.Enable {
+		result, err = s.userSearchRepository.SearchUsers(query)
+	} else {
+		result, err = s.userRepository.SearchUsers(query)
+		if err == nil {
+			err = s.withRoles(result.Users)
+		}
+	}
+	if err != nil {
+		return nil, &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+
+	return &domain.Response{
+		Code:    http.StatusOK,
+		Message: http.StatusText(http.StatusOK),
+		Data: &domain.Page{
+			Items:   result.Users,
+			Total:   result.Total,
+			Page:    request.CurrentPage(),
+			PerPage: request.Limit(),
+			Facets:  map[string][]*domain.FacetCount{"roles": result.Roles},
+		},
+	}, nil
+}
+
+// IndexUser adds the user to the index, or refreshes the document.
+func (s *UserSearchService) IndexUser(userID string) error {
+	if !s.config.App.UserSearch.Enable {
+		return nil
+	}
+
+	user, err := s.userRepository.GetUserByID(userID)
+	if err != nil && user == nil {
+		return fmt.Errorf("user with id %s not exist", userID)
+	}
+
+	documents, err := s.documents([]*domain.User{user})
+	if err != nil {
+		return err
+	}
+	return s.userSearchRepository.IndexUsers(documents)
+}
+
+// IndexUsers refreshes the documents of users whose roles changed, a batch at
+// a time. Users deleted since are left out, their documents are already gone.
+func (s *UserSearchService) IndexUsers(userIDs []string) {
+	if !s.config.App.UserSearch.Enable {
+		return
+	}
+
+	for start := 0; start < len(userIDs); start += reindexBatchSize {
+		end := start + reindexBatchSize
+		if end > len(userIDs) {
+			end = len(userIDs)
+		}
+		if err := s.indexBatch(userIDs[start:end]); err != nil {
+			s.logger.WithFields(logger.FieldMap{"user_ids": userIDs[start:end]}).Warn("failed to index users: ", err)
+		}
+	}
+}
+
+// IndexRoleHolders refreshes the documents of everybody assigned the role,
+// after it was renamed, activated, deactivated, deleted or restored.
+func (s *UserSearchService) IndexRoleHolders(roleID string) {
+	if !s.config.App.UserSearch.Enable {
+		return
+	}
+
+	userIDs, err := s.userRoleRepository.GetRoleHolderIDs(roleID)
+	if err != nil {
+		s.logger.WithFields(logger.FieldMap{"role_id": roleID}).Warn("failed to index role holders: ", err)
+		return
+	}
+	s.IndexUsers(userIDs)
+}
+
+func (s *UserSearchService) indexBatch(userIDs []string) error {
+	users, err := s.userRepository.GetUsersByIDs(userIDs)
+	if err != nil {
+		return err
+	}
+
+	documents, err := s.documents(users)
+	if err != nil {
+		return err
+	}
+	return s.userSearchRepository.IndexUsers(documents)
+}
+
+func (s *UserSearchService) RemoveUser(userID string) error {
+	if !s.config.App.UserSearch.Enable {
+		return nil
+	}
+	return s.userSearchRepository.DeleteUser(userID)
+}
+
+// Reindex rebuilds the index from postgres, walking the users in creation
+// order, and returns how many were indexed.
+func (s *UserSearchService) Reindex() (int, error) {
+	if !s.config.App.UserSearch.Enable {
+		return 0, errors.New("user search is disabled")
+	}
+
+	if err := s.userSearchRepository.ResetUserIndex(); err != nil {
+		return 0, err
+	}
+
+	indexed := 0
+	filter := &domain.UserFilter{Limit: reindexBatchSize}
+	for {
+		users, err := s.userRepository.GetUsersAfter(filter)
+		if err != nil {
+			return indexed, err
+		}
+		if len(users) == 0 {
+			return indexed, nil
+		}
+
+		documents, err := s.documents(users)
+		if err != nil {
+			return indexed, err
+		}
+		if err := s.userSearchRepository.IndexUsers(documents); err != nil {
+			return indexed, err
+		}
+		indexed += len(documents)
+
+		last := users[len(users)-1]
+		filter.After = &domain.Cursor{CreatedAt: last.CreatedAt, Id: last.Id}
+	}
+}
+
+func (s *UserSearchService) documents(users []*domain.User) ([]*domain.UserDocument, error) {
+	documents := make([]*domain.UserDocument, 0, len(users))
+	for _, user := range users {
+		documents = append(documents, &domain.UserDocument{
+			Id:        user.Id,
+			Name:      user.Name,
+			Email:     user.Email,
+			Active:    user.Active,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+		})
+	}
+
+	if err := s.withRoles(documents); err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+// withRoles fills in the names of the roles each user holds.
+func (s *UserSearchService) withRoles(documents []*domain.UserDocument) error {
+	ids := make([]string, 0, len(documents))
+	for _, document := range documents {
+		ids = append(ids, document.Id)
+	}
+
+	names, err := s.userRoleRepository.GetUsersRoleNames(ids)
+	if err != nil {
+		return err
+	}
+
+	for _, document := range documents {
+		document.Roles = names[document.Id]
+		if document.Roles == nil {
+			document.Roles = make([]string, 0)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"user-svc/internal/core/domain"
+	mockCore "user-svc/internal/mocks/core/ports"
+	mockLog "user-svc/internal/mocks/shared/logger"
+	"user-svc/internal/shared/config"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserSearchService_SearchUsers(t *testing.T) {
+	request := &domain.SearchUsersRequest{Q: "ali", Role: "Admin"}
+	query := &domain.UserSearchQuery{Query: "ali", Role: "Admin", Limit: domain.DefaultPerPage}
+	roles := []*domain.FacetCount{{Value: "Admin", Count: 1}, {Value: "Viewer", Count: 4}}
+
+	t.Run("success - searched in the index", func(t *testing.T) {
+		mockSearchRepository := mockCore.UserSearchRepository{}
+		mockSearchRepository.On("SearchUsers", query).Return(&domain.UserSearchResult{
+			Users: []*domain.UserDocument{{Id: "user-1", Roles: []string{"Admin"}}},
+			Total: 1,
+			Roles: roles,
+		}, nil)
+
+		cfg := &config.Config{}
+		cfg.App.UserSearch.Enable = true
+		s := NewUserSearchService(cfg, &mockSearchRepository, &mockCore.UserRepository{}, &mockCore.UserRoleRepository{}, &mockLog.Logger{})
+		got, err := s.SearchUsers(request)
+		assert.NoError(t, err)
+
+		page := got.Data.(*domain.Page)
+		assert.Equal(t, int64(1), page.Total)
+		assert.Equal(t, roles, page.Facets["roles"])
+	})
+
+	t.Run("success - postgres fallback when the index is disabled", func(t *testing.T) {
+		mockUserRepository := mockCore.UserRepository{}
+		mockUserRepository.On("SearchUsers", query).Return(&domain.UserSearchResult{
+			Users: []*domain.UserDocument{{Id: "user-1"}, {Id: "user-2"}},
+			Total: 2,
+			Roles: roles,
+		}, nil)
+		mockUserRoleRepository := mockCore.UserRoleRepository{}
+		mockUserRoleRepository.On("GetUsersRoleNames", []string{"user-1", "user-2"}).
+			Return(map[string][]string{"user-1": {"Admin", "Viewer"}}, nil)
+
+		mockSearchRepository := mockCore.UserSearchRepository{}
+		s := NewUserSearchService(&config.Config{}, &mockSearchRepository, &mockUserRepository, &mockUserRoleRepository, &mockLog.Logger{})
+		got, err := s.SearchUsers(request)
+		assert.NoError(t, err)
+
+		users := got.Data.(*domain.Page).Items.([]*domain.UserDocument)
+		assert.Equal(t, []string{"Admin", "Viewer"}, users[0].Roles)
+		assert.Empty(t, users[1].Roles)
+		mockSearchRepository.AssertNotCalled(t, "SearchUsers", mock.Anything)
+	})
+}
+
+func TestUserSearchService_IndexUser(t *testing.T) {
+	t.Run("success - nothing indexed when disabled", func(t *testing.T) {
+		mockSearchRepository := mockCore.UserSearchRepository{}
+		s := NewUserSearchService(&config.Config{}, &mockSearchRepository, &mockCore.UserRepository{}, &mockCore.UserRoleRepository{}, &mockLog.Logger{})
+		assert.NoError(t, s.IndexUser("user-1"))
+		assert.NoError(t, s.RemoveUser("user-1"))
+		mockSearchRepository.AssertNotCalled(t, "IndexUsers", mock.Anything)
+	})
+
+	t.Run("success - user indexed with roles", func(t *testing.T) {
+		mockUserRepository := mockCore.UserRepository{}
+		mockUserRepository.On("GetUserByID", "user-1").Return(&domain.User{Id: "user-1", Name: "Alice", Active: true}, nil)
+		mockUserRoleRepository := mockCore.UserRoleRepository{}
+		mockUserRoleRepository.On("GetUsersRoleNames", []string{"user-1"}).Return(map[string][]string{"user-1": {"Admin"}}, nil)
+		mockSearchRepository := mockCore.UserSearchRepository{}
+		mockSearchRepository.On("IndexUsers", []*domain.UserDocument{{Id: "user-1", Name: "Alice", Active: true, Roles: []string{"Admin"}}}).Return(nil)
+
+		cfg := &config.Config{}
+		cfg.App.UserSearch.Enable = true
+		s := NewUserSearchService(cfg, &mockSearchRepository, &mockUserRepository, &mockUserRoleRepository, &mockLog.Logger{})
+		assert.NoError(t, s.IndexUser("user-1"))
+		mockSearchRepository.AssertExpectations(t)
+	})
+}
+
+func TestUserSearchService_Reindex(t *testing.T) {
+	createdAt := time.Now()
+	mockUserRepository := mockCore.UserRepository{}
+	mockUserRepository.On("GetUsersAfter", &domain.UserFilter{Limit: reindexBatchSize}).
+		Return([]*domain.User{{Id: "user-1", CreatedAt: createdAt}, {Id: "user-2", CreatedAt: createdAt}}, nil)
+	mockUserRepository.On("GetUsersAfter", &domain.UserFilter{Limit: reindexBatchSize, After: &domain.Cursor{CreatedAt: createdAt, Id: "user-2"}}).
+		Return([]*domain.User{}, nil)
+	mockUserRoleRepository := mockCore.UserRoleRepository{}
+	mockUserRoleRepository.On("GetUsersRoleNames", []string{"user-1", "user-2"}).Return(map[string][]string{}, nil)
+	mockSearchRepository := mockCore.UserSearchRepository{}
+	mockSearchRepository.On("ResetUserIndex").Return(nil)
+	mockSearchRepository.On("IndexUsers", mock.Anything).Return(nil)
+
+	cfg := &config.Config{}
+	cfg.App.UserSearch.Enable = true
+	s := NewUserSearchService(cfg, &mockSearchRepository, &mockUserRepository, &mockUserRoleRepository, &mockLog.Logger{})
+	indexed, err := s.Reindex()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, indexed)
+	mockSearchRepository.AssertNumberOfCalls(t, "IndexUsers", 1)
+
+	_, err = NewUserSearchService(&config.Config{}, &mockSearchRepository, &mockUserRepository, &mockUserRoleRepository, &mockLog.Logger{}).Reindex()
+	assert.Error(t, err)
+}
+
+func TestUserSearchService_IndexUsers(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.UserSearch.Enable = true
+
+	t.Run("success - role holders indexed", func(t *testing.T) {
+		mockUserRepository := mockCore.UserRepository{}
+		mockUserRepository.On("GetUsersByIDs", []string{"user-1", "user-2"}).
+			Return([]*domain.User{{Id: "user-1", Name: "Alice"}, {Id: "user-2", Name: "Bob"}}, nil)
+		mockUserRoleRepository := mockCore.UserRoleRepository{}
+		mockUserRoleRepository.On("GetRoleHolderIDs", "role-1").Return([]string{"user-1", "user-2"}, nil)
+		mockUserRoleRepository.On("GetUsersRoleNames", []string{"user-1", "user-2"}).
+			Return(map[string][]string{"user-1": {"Auditor"}}, nil)
+		mockSearchRepository := mockCore.UserSearchRepository{}
+		mockSearchRepository.On("IndexUsers", []*domain.UserDocument{
+			{Id: "user-1", Name: "Alice", Roles: []string{"Auditor"}},
+			{Id: "user-2", Name: "Bob", Roles: []string{}},
+		}).Return(nil)
+
+		s := NewUserSearchService(cfg, &mockSearchRepository, &mockUserRepository, &mockUserRoleRepository, &mockLog.Logger{})
+		s.IndexRoleHolders("role-1")
+		mockSearchRepository.AssertExpectations(t)
+	})
+
+	t.Run("success - failure logged, not returned", func(t *testing.T) {
+		mockUserRepository := mockCore.UserRepository{}
+		mockUserRepository.On("GetUsersByIDs", []string{"user-1"}).Return(nil, errors.New("connection refused"))
+		entry, hook := test.NewNullLogger()
+		mockLogger := mockLog.Logger{}
+		mockLogger.On("WithFields", mock.Anything).Return(logrus.NewEntry(entry))
+		mockSearchRepository := mockCore.UserSearchRepository{}
+
+		s := NewUserSearchService(cfg, &mockSearchRepository, &mockUserRepository, &mockCore.UserRoleRepository{}, &mockLogger)
+		s.IndexUsers([]string{"user-1"})
+		assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+		mockSearchRepository.AssertNotCalled(t, "IndexUsers", mock.Anything)
+	})
+
+	t.Run("success - nothing indexed when disabled", func(t *testing.T) {
+		mockUserRoleRepository := mockCore.UserRoleRepository{}
+		s := NewUserSearchService(&config.Config{}, &mockCore.UserSearchRepository{}, &mockCore.UserRepository{}, &mockUserRoleRepository, &mockLog.Logger{})
+		s.IndexRoleHolders("role-1")
+		mockUserRoleRepository.AssertNotCalled(t, "GetRoleHolderIDs", mock.Anything)
+	})
+}
+
+// indexingSearchService accepts the best-effort reindexing the services do
+// after a write.
+func indexingSearchService() *mockCore.UserSearchService {
+	mockUserSearchService := mockCore.UserSearchService{}
+	mockUserSearchService.On("IndexUsers", mock.Anything).Return()
+	mockUserSearchService.On("IndexRoleHolders", mock.Anything).Return()
+	return &mockUserSearchService
+}
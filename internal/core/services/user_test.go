@@ -6,20 +6,37 @@ import (
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 	"user-svc/internal/core/domain"
 	"user-svc/internal/core/ports"
 	mockCore "user-svc/internal/mocks/core/ports"
+	mockCursor "user-svc/internal/mocks/shared/cursor"
 	mockShared "user-svc/internal/mocks/shared/hash"
+	mockLog "user-svc/internal/mocks/shared/logger"
+	"user-svc/internal/shared/config"
+	"user-svc/internal/shared/cursor"
+	appError "user-svc/internal/shared/error"
 	"user-svc/internal/shared/hash"
+	"user-svc/internal/shared/logger"
 )
 
 func TestNewUserService(t *testing.T) {
 	mockUserRepository := mockCore.UserRepository{}
 	mockHasher := mockShared.Hasher{}
+	mockLogger := mockLog.Logger{}
+	mockSafeguardService := mockCore.SafeguardService{}
+	mockCursorCodec := mockCursor.Codec{}
+	mockUserSearchService := mockCore.UserSearchService{}
+	mockUserAttributeService := mockCore.UserAttributeService{}
 	type args struct {
-		repo  ports.UserRepository
-		cache ports.CacheRepository
-		hash  hash.Hasher
+		repo      ports.UserRepository
+		cache     ports.CacheRepository
+		hash      hash.Hasher
+		logger    logger.Logger
+		safeguard ports.SafeguardService
+		cursor    cursor.Codec
+		search    ports.UserSearchService
+		attribute ports.UserAttributeService
 	}
 	tests := []struct {
 		name string
@@ -29,18 +46,28 @@ func TestNewUserService(t *testing.T) {
 		{
 			name: "success",
 			args: args{
-				repo: &mockUserRepository,
-				hash: &mockHasher,
+				repo:      &mockUserRepository,
+				hash:      &mockHasher,
+				logger:    &mockLogger,
+				safeguard: &mockSafeguardService,
+				cursor:    &mockCursorCodec,
+				search:    &mockUserSearchService,
+				attribute: &mockUserAttributeService,
 			},
 			want: NewUserService(
 				&mockUserRepository,
 				&mockHasher,
+				&mockLogger,
+				&mockSafeguardService,
+				&mockCursorCodec,
+				&mockUserSearchService,
+				&mockUserAttributeService,
 			),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewUserService(tt.args.repo, tt.args.hash); !reflect.DeepEqual(got, tt.want) {
+			if got := NewUserService(tt.args.repo, tt.args.hash, tt.args.logger, tt.args.safeguard, tt.args.cursor, tt.args.search, tt.args.attribute); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewUserService() = %v, want %v", got, tt.want)
 			}
 		})
@@ -165,9 +192,17 @@ func TestUserService_Create(t *testing.T) {
 			mockHasher := mockShared.Hasher{}
 			mockHasher.On("GenerateRandomSalt").Return(tt.saltResult...)
 			mockHasher.On("HashPassword", mock.Anything, mock.Anything).Return(tt.hasherResult)
+
+			mockUserSearchService := mockCore.UserSearchService{}
+			mockUserSearchService.On("IndexUser", mock.Anything).Return(nil)
+
+			mockUserAttributeService := mockCore.UserAttributeService{}
+			mockUserAttributeService.On("ValidateAttributes", mock.Anything).Return(nil)
 			u := UserService{
-				userRepository: &mockUserRepository,
-				hasher:         &mockHasher,
+				userRepository:       &mockUserRepository,
+				hasher:               &mockHasher,
+				userSearchService:    &mockUserSearchService,
+				userAttributeService: &mockUserAttributeService,
 			}
 			got, err := u.CreateUser(tt.args.user)
 			if (err != nil) != tt.wantErr {
@@ -180,3 +215,256 @@ func TestUserService_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_GetUsers(t *testing.T) {
+	active := true
+	mockUserRepository := mockCore.UserRepository{}
+	mockUserRepository.On("GetUsers", &domain.UserFilter{Active: &active, EmailPrefix: "ali", Sort: "email", Order: domain.SortDesc, Limit: 10, Offset: 20}).
+		Return([]*domain.User{{Id: "1", Email: "alice@mail.com"}}, int64(21), nil)
+	mockUserAttributeService := mockCore.UserAttributeService{}
+	mockUserAttributeService.On("ParseAttributeFilters", []string(nil)).Return(nil, nil)
+
+	s := NewUserService(&mockUserRepository, &mockShared.Hasher{}, &mockLog.Logger{}, &mockCore.SafeguardService{}, &mockCursor.Codec{}, &mockCore.UserSearchService{}, &mockUserAttributeService)
+	got, err := s.GetUsers(&domain.GetUsersRequest{
+		PageRequest: domain.PageRequest{Page: 3, PerPage: 10},
+		Active:      &active,
+		Email:       "ali",
+		Sort:        "email",
+		Order:       domain.SortDesc,
+	})
+	if err != nil {
+		t.Fatalf("GetUsers() error = %v", err)
+	}
+
+	page := got.Data.(*domain.Page)
+	if page.Total != 21 || page.Page != 3 || page.PerPage != 10 {
+		t.Errorf("GetUsers() page = %+v", page)
+	}
+	if page.HasNext() {
+		t.Errorf("GetUsers() last page has a next page")
+	}
+}
+
+func TestUserService_GetUsersCursor(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.App.Key = "secret"
+	codec := cursor.NewCodec(cfg)
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	users := []*domain.User{
+		{Id: "1", CreatedAt: first},
+		{Id: "2", CreatedAt: first.Add(time.Hour)},
+		{Id: "3", CreatedAt: first.Add(2 * time.Hour)},
+	}
+
+	mockUserRepository := mockCore.UserRepository{}
+	mockUserRepository.On("GetUsersAfter", &domain.UserFilter{Limit: 3, Offset: 0}).
+		Return(users, nil)
+	mockUserRepository.On("GetUsersAfter", &domain.UserFilter{Limit: 3, Offset: 0, After: &domain.Cursor{CreatedAt: users[1].CreatedAt, Id: "2"}}).
+		Return(users[2:], nil)
+	mockUserAttributeService := mockCore.UserAttributeService{}
+	mockUserAttributeService.On("ParseAttributeFilters", []string(nil)).Return(nil, nil)
+	s := NewUserService(&mockUserRepository, &mockShared.Hasher{}, &mockLog.Logger{}, &mockCore.SafeguardService{}, codec, &mockCore.UserSearchService{}, &mockUserAttributeService)
+
+	got, err := s.GetUsers(&domain.GetUsersRequest{CursorRequest: domain.CursorRequest{Size: 2}})
+	if err != nil {
+		t.Fatalf("GetUsers() error = %v", err)
+	}
+	page := got.Data.(*domain.CursorPage)
+	if len(page.Items.([]*domain.User)) != 2 || page.NextCursor == "" {
+		t.Fatalf("GetUsers() first page = %+v", page)
+	}
+
+	got, err = s.GetUsers(&domain.GetUsersRequest{CursorRequest: domain.CursorRequest{Cursor: page.NextCursor, Size: 2}})
+	if err != nil {
+		t.Fatalf("GetUsers() error = %v", err)
+	}
+	page = got.Data.(*domain.CursorPage)
+	if len(page.Items.([]*domain.User)) != 1 || page.NextCursor != "" {
+		t.Errorf("GetUsers() last page = %+v", page)
+	}
+
+	tests := []struct {
+		name    string
+		request *domain.GetUsersRequest
+	}{
+		{name: "tampered cursor", request: &domain.GetUsersRequest{CursorRequest: domain.CursorRequest{Cursor: "eyJrIjoidXNlciJ9.c2lnbmF0dXJl"}}},
+		{name: "cursor of another list", request: &domain.GetUsersRequest{CursorRequest: domain.CursorRequest{Cursor: codec.Encode(roleCursor, first, "1")}}},
+		{name: "sort with cursor", request: &domain.GetUsersRequest{CursorRequest: domain.CursorRequest{Size: 2}, Sort: "email"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.GetUsers(tt.request)
+			if err == nil || err.(*appError.AppError).Code != http.StatusBadRequest {
+				t.Errorf("GetUsers() error = %v, want bad request", err)
+			}
+		})
+	}
+}
+
+func TestUserService_RestoreUser(t *testing.T) {
+	deletedAt := time.Now().Add(-time.Hour)
+	deleted := &domain.User{Id: "user-1", Email: "alice@example.com", DeletedAt: &deletedAt}
+
+	tests := []struct {
+		name     string
+		user     *domain.User
+		exist    bool
+		wantCode int
+	}{
+		{name: "success", user: deleted, wantCode: http.StatusOK},
+		{name: "not deleted", wantCode: http.StatusNotFound},
+		{name: "email taken", user: deleted, exist: true, wantCode: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := mockCore.UserRepository{}
+			if tt.user == nil {
+				mockUserRepository.On("GetDeletedUserByID", "user-1").Return(nil, errors.New("sql: no rows in result set"))
+			} else {
+				mockUserRepository.On("GetDeletedUserByID", "user-1").Return(tt.user, nil)
+			}
+			mockUserRepository.On("UserIsExist", "alice@example.com").Return(tt.exist, nil)
+			mockUserRepository.On("RestoreUser", "user-1", mock.Anything).Return(nil)
+			mockUserSearchService := mockCore.UserSearchService{}
+			mockUserSearchService.On("IndexUser", "user-1").Return(nil)
+
+			s := NewUserService(&mockUserRepository, &mockShared.Hasher{}, &mockLog.Logger{}, &mockCore.SafeguardService{}, &mockCursor.Codec{}, &mockUserSearchService, &mockCore.UserAttributeService{})
+			got, err := s.RestoreUser(&domain.RestoreUserRequest{Id: "user-1"})
+			if tt.wantCode != http.StatusOK {
+				var appErr *appError.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Fatalf("RestoreUser() error = %v, want code %d", err, tt.wantCode)
+				}
+				mockUserRepository.AssertNotCalled(t, "RestoreUser", mock.Anything, mock.Anything)
+				return
+			}
+			if err != nil || got.Code != http.StatusOK {
+				t.Fatalf("RestoreUser() = %v, %v", got, err)
+			}
+			mockUserSearchService.AssertCalled(t, "IndexUser", "user-1")
+		})
+	}
+}
+
+func TestUserService_PatchUser(t *testing.T) {
+	user := &domain.User{Id: "user-1", Name: "Alice", Email: "alice@example.com", Active: true, Version: 2}
+
+	t.Run("success - only supplied members written", func(t *testing.T) {
+		name := "Alice Smith"
+		mockUserRepository := mockCore.UserRepository{}
+		mockUserRepository.On("GetUserByID", "user-1").Return(user, nil)
+		mockUserRepository.On("PatchUser", "user-1", int64(2), mock.Anything).Return(nil)
+		mockUserSearchService := mockCore.UserSearchService{}
+		mockUserSearchService.On("IndexUser", "user-1").Return(nil)
+
+		s := NewUserService(&mockUserRepository, &mockShared.Hasher{}, &mockLog.Logger{}, &mockCore.SafeguardService{}, &mockCursor.Codec{}, &mockUserSearchService, &mockCore.UserAttributeService{})
+		got, err := s.PatchUser(&domain.PatchUserRequest{Id: "user-1", Name: &name})
+		if err != nil || got.Code != http.StatusOK {
+			t.Fatalf("PatchUser() = %v, %v", got, err)
+		}
+
+		patch := mockUserRepository.Calls[1].Arguments.Get(2).(*domain.UserPatch)
+		if patch.Name == nil || *patch.Name != name || patch.Email != nil || patch.Active != nil || patch.Password != nil {
+			t.Errorf("PatchUser() patch = %+v, want the name only", patch)
+		}
+		mockUserRepository.AssertNotCalled(t, "GetUserByEmail", mock.Anything)
+	})
+
+	t.Run("failed - email taken", func(t *testing.T) {
+		email := "bob@example.com"
+		mockUserRepository := mockCore.UserRepository{}
+		mockUserRepository.On("GetUserByID", "user-1").Return(user, nil)
+		mockUserRepository.On("GetUserByEmail", email).Return(&domain.User{Id: "user-2"}, nil)
+
+		s := NewUserService(&mockUserRepository, &mockShared.Hasher{}, &mockLog.Logger{}, &mockCore.SafeguardService{}, &mockCursor.Codec{}, &mockCore.UserSearchService{}, &mockCore.UserAttributeService{})
+		_, err := s.PatchUser(&domain.PatchUserRequest{Id: "user-1", Email: &email})
+		var appErr *appError.AppError
+		if !errors.As(err, &appErr) || appErr.Code != http.StatusConflict {
+			t.Fatalf("PatchUser() error = %v, want conflict", err)
+		}
+		mockUserRepository.AssertNotCalled(t, "PatchUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_Attributes(t *testing.T) {
+	invalid := &appError.AppError{Code: http.StatusBadRequest, Message: "attributes.department: attribute is required"}
+
+	t.Run("create refused with invalid attributes", func(t *testing.T) {
+		mockUserRepository := mockCore.UserRepository{}
+		mockUserRepository.On("UserIsExist", "alice@example.com").Return(false, nil)
+		mockUserAttributeService := mockCore.UserAttributeService{}
+		mockUserAttributeService.On("ValidateAttributes", map[string]interface{}{"phone": "555"}).Return(invalid)
+
+		s := NewUserService(&mockUserRepository, &mockShared.Hasher{}, &mockLog.Logger{}, &mockCore.SafeguardService{}, &mockCursor.Codec{}, &mockCore.UserSearchService{}, &mockUserAttributeService)
+		_, err := s.CreateUser(&domain.CreateUserRequest{Name: "Alice", Email: "alice@example.com", Password: "secret", Attributes: map[string]interface{}{"phone": "555"}})
+		if err != invalid {
+			t.Fatalf("CreateUser() error = %v, want %v", err, invalid)
+		}
+		mockUserRepository.AssertNotCalled(t, "CreateUser", mock.Anything)
+	})
+
+	t.Run("patch merges attributes, null removes one", func(t *testing.T) {
+		user := &domain.User{Id: "user-1", Version: 3, Attributes: map[string]interface{}{"department": "sales", "phone": "555"}}
+		merged := map[string]interface{}{"department": "support", "locale": "en"}
+		mockUserRepository := mockCore.UserRepository{}
+		mockUserRepository.On("GetUserByID", "user-1").Return(user, nil)
+		mockUserRepository.On("PatchUser", "user-1", int64(3), mock.Anything).Return(nil)
+		mockUserSearchService := mockCore.UserSearchService{}
+		mockUserSearchService.On("IndexUser", "user-1").Return(nil)
+		mockUserAttributeService := mockCore.UserAttributeService{}
+		mockUserAttributeService.On("ValidateAttributes", merged).Return(nil)
+
+		s := NewUserService(&mockUserRepository, &mockShared.Hasher{}, &mockLog.Logger{}, &mockCore.SafeguardService{}, &mockCursor.Codec{}, &mockUserSearchService, &mockUserAttributeService)
+		_, err := s.PatchUser(&domain.PatchUserRequest{Id: "user-1", Attributes: map[string]interface{}{"department": "support", "phone": nil, "locale": "en"}})
+		if err != nil {
+			t.Fatalf("PatchUser() error = %v", err)
+		}
+
+		patch := mockUserRepository.Calls[1].Arguments.Get(2).(*domain.UserPatch)
+		if !reflect.DeepEqual(patch.Attributes, merged) {
+			t.Errorf("PatchUser() attributes = %v, want %v", patch.Attributes, merged)
+		}
+		if user.Attributes["phone"] != "555" {
+			t.Errorf("PatchUser() changed the attributes read")
+		}
+	})
+
+	t.Run("update keeps attributes left out", func(t *testing.T) {
+		active := true
+		user := &domain.User{Id: "user-1", Email: "alice@example.com", Active: true, Attributes: map[string]interface{}{"department": "sales"}}
+		mockUserRepository := mockCore.UserRepository{}
+		mockUserRepository.On("GetUserByID", "user-1").Return(user, nil)
+		mockUserRepository.On("GetUserByEmail", "alice@example.com").Return(user, nil)
+		mockUserRepository.On("UpdateUser", mock.Anything).Return(nil)
+		mockUserSearchService := mockCore.UserSearchService{}
+		mockUserSearchService.On("IndexUser", "user-1").Return(nil)
+		mockUserAttributeService := mockCore.UserAttributeService{}
+
+		s := NewUserService(&mockUserRepository, &mockShared.Hasher{}, &mockLog.Logger{}, &mockCore.SafeguardService{}, &mockCursor.Codec{}, &mockUserSearchService, &mockUserAttributeService)
+		_, err := s.UpdateUser(&domain.UpdateUserRequest{Id: "user-1", Name: "Alice", Email: "alice@example.com", Active: &active})
+		if err != nil {
+			t.Fatalf("UpdateUser() error = %v", err)
+		}
+
+		updated := mockUserRepository.Calls[2].Arguments.Get(0).(*domain.User)
+		if updated.Attributes["department"] != "sales" {
+			t.Errorf("UpdateUser() attributes = %v, want them kept", updated.Attributes)
+		}
+		mockUserAttributeService.AssertNotCalled(t, "ValidateAttributes", mock.Anything)
+	})
+
+	t.Run("list filtered on attributes", func(t *testing.T) {
+		filters := []*domain.AttributeFilter{{Name: "department", Value: "sales:emea"}}
+		mockUserRepository := mockCore.UserRepository{}
+		mockUserRepository.On("GetUsers", &domain.UserFilter{Attributes: filters, Limit: 20, Offset: 0}).Return([]*domain.User{}, int64(0), nil)
+		mockUserAttributeService := mockCore.UserAttributeService{}
+		mockUserAttributeService.On("ParseAttributeFilters", []string{"department:sales:emea"}).Return(filters, nil)
+
+		s := NewUserService(&mockUserRepository, &mockShared.Hasher{}, &mockLog.Logger{}, &mockCore.SafeguardService{}, &mockCursor.Codec{}, &mockCore.UserSearchService{}, &mockUserAttributeService)
+		_, err := s.GetUsers(&domain.GetUsersRequest{Attributes: []string{"department:sales:emea"}})
+		if err != nil {
+			t.Fatalf("GetUsers() error = %v", err)
+		}
+		mockUserRepository.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"user-svc/internal/core/domain"
+	appError "user-svc/internal/shared/error"
+)
+
+// checkVersion refuses a write whose If-Match the record no longer matches.
+func checkVersion(kind string, id string, ifMatch []int64, version int64) error {
+	if domain.VersionMatches(ifMatch, version) {
+		return nil
+	}
+	return &appError.AppError{Code: http.StatusPreconditionFailed, Message: fmt.Sprintf("%s with id %s is at version %d", kind, id, version)}
+}
+
+// writeError reports a failed conditional write, a record changed between the
+// read and the write is a failed precondition rather than a server error.
+func writeError(kind string, id string, err error) error {
+	if errors.Is(err, domain.ErrVersionMismatch) {
+		return &appError.AppError{Code: http.StatusPreconditionFailed, Message: fmt.Sprintf("%s with id %s was changed concurrently", kind, id)}
+	}
+	return &appError.AppError{Code: http.StatusInternalServerError, Message: err.Error()}
+}
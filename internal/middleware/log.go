@@ -4,6 +4,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"time"
+	"user-svc/internal/shared/constants"
 	"user-svc/internal/shared/logger"
 )
 
@@ -13,11 +14,20 @@ type LoggingMiddleware interface {
 
 type loggingMiddleware struct {
 	logger *logger.LoggerWrapper
+	// omitBody holds the routes whose bodies are never dumped
+	omitBody map[string]bool
 }
 
-func NewLoggingMiddleware(logger *logger.LoggerWrapper) LoggingMiddleware {
+// NewLoggingMiddleware logs every request with its bodies, except for the
+// given routes, which are logged without them.
+func NewLoggingMiddleware(logger *logger.LoggerWrapper, omitBodyRoutes ...string) LoggingMiddleware {
+	omitBody := make(map[string]bool, len(omitBodyRoutes))
+	for _, route := range omitBodyRoutes {
+		omitBody[route] = true
+	}
 	return &loggingMiddleware{
-		logger: logger,
+		logger:   logger,
+		omitBody: omitBody,
 	}
 }
 
@@ -26,25 +36,19 @@ func (l *loggingMiddleware) LogRequestAndResponse(next echo.HandlerFunc) echo.Ha
 		// Capture start time
 		startTime := time.Now()
 
+		// Bodies that are large or carry secrets are left out, without
+		// buffering them
+		if l.omitBody[c.Path()] {
+			err := next(c)
+			l.log(c, startTime, "[omitted]", "[omitted]")
+			return err
+		}
+
 		// Wrap the next handler with the body dump middleware
 		bodyDumpMiddleware := middleware.BodyDumpWithConfig(middleware.BodyDumpConfig{
 			Skipper: nil,
 			Handler: func(c echo.Context, reqBody, resBody []byte) {
-				// Combine request and response into a single log entry
-				logEntry := logger.FieldMap{
-					"log_type":      "TDR",
-					"method":        c.Request().Method,
-					"uri":           c.Request().RequestURI,
-					"remote_ip":     c.RealIP(),
-					"user_agent":    c.Request().UserAgent(),
-					"headers":       c.Request().Header,
-					"request_body":  string(reqBody),
-					"response_body": string(resBody),
-					"response_time": time.Since(startTime).Milliseconds(),
-				}
-
-				// Log the combined entry
-				l.logger.WithFields(logEntry).Info("Request and response")
+				l.log(c, startTime, string(reqBody), string(resBody))
 			},
 		})
 
@@ -52,3 +56,26 @@ func (l *loggingMiddleware) LogRequestAndResponse(next echo.HandlerFunc) echo.Ha
 		return bodyDumpMiddleware(next)(c)
 	}
 }
+
+func (l *loggingMiddleware) log(c echo.Context, startTime time.Time, reqBody, resBody string) {
+	// Combine request and response into a single log entry
+	logEntry := logger.FieldMap{
+		"log_type":      "TDR",
+		"method":        c.Request().Method,
+		"uri":           c.Request().RequestURI,
+		"remote_ip":     c.RealIP(),
+		"user_agent":    c.Request().UserAgent(),
+		"headers":       c.Request().Header,
+		"request_body":  reqBody,
+		"response_body": resBody,
+		"response_time": time.Since(startTime).Milliseconds(),
+	}
+
+	// Tag requests made during a break-glass session
+	if sessionID, ok := c.Get(constants.KeyBreakGlassID).(string); ok {
+		logEntry["break_glass_session"] = sessionID
+	}
+
+	// Log the combined entry
+	l.logger.WithFields(logEntry).Info("Request and response")
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
 	"user-svc/internal/core/domain"
 	"user-svc/internal/core/ports"
@@ -16,8 +17,12 @@ type PermissionChecker interface {
 }
 
 type PermissionCheckerImpl struct {
-	AuthRepository        ports.AuthRepository
-	RolePermissionService services.RolePermissionService
+	AuthRepository               ports.AuthRepository
+	UserRoleService              services.UserRoleService
+	RolePermissionService        services.RolePermissionService
+	PermissionImplicationService services.PermissionImplicationService
+	PermissionUsageService       services.PermissionUsageService
+	BreakGlassService            services.BreakGlassService
 }
 
 func (p *PermissionCheckerImpl) Check(c echo.Context, requiredPermission string) (bool, error) {
@@ -26,7 +31,23 @@ func (p *PermissionCheckerImpl) Check(c echo.Context, requiredPermission string)
 	if err != nil {
 		return false, err
 	}
-	for _, role := range tokenInfo.Roles {
+
+	p.tagBreakGlass(c, tokenInfo.UserID)
+
+	// roles are resolved on every check instead of trusting the login snapshot,
+	// so deactivating a role takes effect for sessions that are already open
+	userRoles, err := p.UserRoleService.GetUserRoles(&domain.GetUserRolesRequest{
+		UserId: tokenInfo.UserID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	held := make([]string, 0)
+	for _, role := range userRoles.Data.([]*domain.Role) {
+		if !role.Active {
+			continue
+		}
 		d := &domain.GetRolePermissionRequest{
 			RoleId: role.Id,
 		}
@@ -37,22 +58,85 @@ func (p *PermissionCheckerImpl) Check(c echo.Context, requiredPermission string)
 		for _, permission := range permissions.Data.([]*domain.Permission) {
 			if permission.Name == requiredPermission {
 				c.Set(constants.KeyUserID, tokenInfo.UserID)
+				p.recordUsage(tokenInfo.UserID, permission.Name)
 				return true, nil
 			}
+			held = append(held, permission.Name)
+		}
+	}
+
+	// implications are only expanded when no permission matched directly
+	if len(held) == 0 {
+		return false, nil
+	}
+	expanded, err := p.PermissionImplicationService.ExpandPermissions(held)
+	if err != nil {
+		return false, err
+	}
+	for _, permission := range expanded {
+		if permission == requiredPermission {
+			c.Set(constants.KeyUserID, tokenInfo.UserID)
+			p.recordImpliedUsage(tokenInfo.UserID, held, requiredPermission)
+			return true, nil
 		}
 	}
 
 	return false, nil
 }
 
+// tagBreakGlass marks requests made during a break-glass session, so the
+// request log can be traced back to it. Like usage, it never fails a request.
+func (p *PermissionCheckerImpl) tagBreakGlass(c echo.Context, userID string) {
+	sessionID, err := p.BreakGlassService.GetActiveBreakGlassSession(userID)
+	if err == nil && sessionID != "" {
+		c.Set(constants.KeyBreakGlassID, sessionID)
+	}
+}
+
+// recordUsage is best effort, a request is never refused because its usage
+// could not be recorded.
+func (p *PermissionCheckerImpl) recordUsage(userID, permission string) {
+	_ = p.PermissionUsageService.RecordUsage(userID, permission)
+}
+
+// recordImpliedUsage credits the held permissions that imply the required one,
+// since those are the grants the request actually used.
+func (p *PermissionCheckerImpl) recordImpliedUsage(userID string, held []string, requiredPermission string) {
+	for _, permission := range held {
+		expanded, err := p.PermissionImplicationService.ExpandPermissions([]string{permission})
+		if err != nil {
+			return
+		}
+		for _, implied := range expanded {
+			if implied == requiredPermission {
+				p.recordUsage(userID, permission)
+				break
+			}
+		}
+	}
+}
+
 type PermissionMiddleware struct {
 	Checker PermissionChecker
+	Routes  *domain.RouteIndex
 }
 
-func (m *PermissionMiddleware) Handle(requiredPermission string) echo.MiddlewareFunc {
+// Route adds to g the route guarded by the permission and records in Routes
+// that the route requires it.
+func (m *PermissionMiddleware) Route(g *echo.Group, method, path string, handler echo.HandlerFunc, requiredPermission domain.PermissionName) {
+	route := g.Add(method, path, handler, m.Handle(requiredPermission))
+	m.Routes.Add(requiredPermission, route.Method+" "+route.Path)
+}
+
+// Handle panics when the permission is not in the registry, so a route that
+// names an unknown permission stops the service at startup.
+func (m *PermissionMiddleware) Handle(requiredPermission domain.PermissionName) echo.MiddlewareFunc {
+	if !domain.IsRegisteredPermission(requiredPermission) {
+		panic(fmt.Sprintf("route requires unknown permission %s", requiredPermission))
+	}
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			if hasPermission, err := m.Checker.Check(c, requiredPermission); err != nil {
+			if hasPermission, err := m.Checker.Check(c, string(requiredPermission)); err != nil {
 				return err
 			} else if !hasPermission {
 				return c.JSON(http.StatusForbidden, &appError.AppError{
@@ -0,0 +1,55 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccessImpactService is an autogenerated mock type for the AccessImpactService type
+type AccessImpactService struct {
+	mock.Mock
+}
+
+// Simulate provides a mock function with given fields: change
+func (_m *AccessImpactService) Simulate(change *domain.AccessChange) (*domain.AccessImpact, error) {
+	ret := _m.Called(change)
+
+	var r0 *domain.AccessImpact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.AccessChange) (*domain.AccessImpact, error)); ok {
+		return rf(change)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.AccessChange) *domain.AccessImpact); ok {
+		r0 = rf(change)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AccessImpact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.AccessChange) error); ok {
+		r1 = rf(change)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAccessImpactService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAccessImpactService creates a new instance of AccessImpactService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAccessImpactService(t mockConstructorTestingTNewAccessImpactService) *AccessImpactService {
+	mock := &AccessImpactService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
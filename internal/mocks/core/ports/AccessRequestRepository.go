@@ -0,0 +1,163 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// AccessRequestRepository is an autogenerated mock type for the AccessRequestRepository type
+type AccessRequestRepository struct {
+	mock.Mock
+}
+
+// ApproveAccessRequest provides a mock function with given fields: accessRequest
+func (_m *AccessRequestRepository) ApproveAccessRequest(accessRequest *domain.AccessRequest) error {
+	ret := _m.Called(accessRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.AccessRequest) error); ok {
+		r0 = rf(accessRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateAccessRequest provides a mock function with given fields: accessRequest
+func (_m *AccessRequestRepository) CreateAccessRequest(accessRequest *domain.AccessRequest) error {
+	ret := _m.Called(accessRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.AccessRequest) error); ok {
+		r0 = rf(accessRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExpireAccessRequests provides a mock function with given fields: now, pendingBefore
+func (_m *AccessRequestRepository) ExpireAccessRequests(now time.Time, pendingBefore time.Time) (int64, error) {
+	ret := _m.Called(now, pendingBefore)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) (int64, error)); ok {
+		return rf(now, pendingBefore)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) int64); ok {
+		r0 = rf(now, pendingBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time) error); ok {
+		r1 = rf(now, pendingBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccessRequestByID provides a mock function with given fields: id
+func (_m *AccessRequestRepository) GetAccessRequestByID(id string) (*domain.AccessRequest, error) {
+	ret := _m.Called(id)
+
+	var r0 *domain.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.AccessRequest, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.AccessRequest); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccessRequests provides a mock function with given fields: filter
+func (_m *AccessRequestRepository) GetAccessRequests(filter *domain.AccessRequestFilter) ([]*domain.AccessRequest, error) {
+	ret := _m.Called(filter)
+
+	var r0 []*domain.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.AccessRequestFilter) ([]*domain.AccessRequest, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.AccessRequestFilter) []*domain.AccessRequest); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.AccessRequestFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAccessRequest provides a mock function with given fields: accessRequest
+func (_m *AccessRequestRepository) RevokeAccessRequest(accessRequest *domain.AccessRequest) error {
+	ret := _m.Called(accessRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.AccessRequest) error); ok {
+		r0 = rf(accessRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAccessRequest provides a mock function with given fields: accessRequest
+func (_m *AccessRequestRepository) UpdateAccessRequest(accessRequest *domain.AccessRequest) error {
+	ret := _m.Called(accessRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.AccessRequest) error); ok {
+		r0 = rf(accessRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewAccessRequestRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAccessRequestRepository creates a new instance of AccessRequestRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAccessRequestRepository(t mockConstructorTestingTNewAccessRequestRepository) *AccessRequestRepository {
+	mock := &AccessRequestRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,199 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccessRequestService is an autogenerated mock type for the AccessRequestService type
+type AccessRequestService struct {
+	mock.Mock
+}
+
+// ApproveAccessRequest provides a mock function with given fields: request
+func (_m *AccessRequestService) ApproveAccessRequest(request *domain.ReviewAccessRequestRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.ReviewAccessRequestRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.ReviewAccessRequestRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.ReviewAccessRequestRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAccessRequest provides a mock function with given fields: request
+func (_m *AccessRequestService) CreateAccessRequest(request *domain.CreateAccessRequestRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.CreateAccessRequestRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.CreateAccessRequestRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.CreateAccessRequestRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExpireAccessRequests provides a mock function with given fields:
+func (_m *AccessRequestService) ExpireAccessRequests() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAccessRequest provides a mock function with given fields: id
+func (_m *AccessRequestService) GetAccessRequest(id string) (*domain.Response, error) {
+	ret := _m.Called(id)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Response, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Response); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccessRequests provides a mock function with given fields: request
+func (_m *AccessRequestService) GetAccessRequests(request *domain.GetAccessRequestsRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.GetAccessRequestsRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.GetAccessRequestsRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.GetAccessRequestsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectAccessRequest provides a mock function with given fields: request
+func (_m *AccessRequestService) RejectAccessRequest(request *domain.ReviewAccessRequestRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.ReviewAccessRequestRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.ReviewAccessRequestRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.ReviewAccessRequestRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAccessRequest provides a mock function with given fields: request
+func (_m *AccessRequestService) RevokeAccessRequest(request *domain.ReviewAccessRequestRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.ReviewAccessRequestRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.ReviewAccessRequestRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.ReviewAccessRequestRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAccessRequestService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAccessRequestService creates a new instance of AccessRequestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAccessRequestService(t mockConstructorTestingTNewAccessRequestService) *AccessRequestService {
+	mock := &AccessRequestService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,177 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccessReviewRepository is an autogenerated mock type for the AccessReviewRepository type
+type AccessReviewRepository struct {
+	mock.Mock
+}
+
+// CloseAccessReview provides a mock function with given fields: review
+func (_m *AccessReviewRepository) CloseAccessReview(review *domain.AccessReview) error {
+	ret := _m.Called(review)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.AccessReview) error); ok {
+		r0 = rf(review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateAccessReview provides a mock function with given fields: review
+func (_m *AccessReviewRepository) CreateAccessReview(review *domain.AccessReview) error {
+	ret := _m.Called(review)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.AccessReview) error); ok {
+		r0 = rf(review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAccessReviewAssignments provides a mock function with given fields: roles
+func (_m *AccessReviewRepository) GetAccessReviewAssignments(roles []string) ([]*domain.AccessReviewItem, error) {
+	ret := _m.Called(roles)
+
+	var r0 []*domain.AccessReviewItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]*domain.AccessReviewItem, error)); ok {
+		return rf(roles)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []*domain.AccessReviewItem); ok {
+		r0 = rf(roles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AccessReviewItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(roles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccessReviewByID provides a mock function with given fields: id
+func (_m *AccessReviewRepository) GetAccessReviewByID(id string) (*domain.AccessReview, error) {
+	ret := _m.Called(id)
+
+	var r0 *domain.AccessReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.AccessReview, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.AccessReview); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AccessReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllAccessReview provides a mock function with given fields:
+func (_m *AccessReviewRepository) GetAllAccessReview() ([]*domain.AccessReview, error) {
+	ret := _m.Called()
+
+	var r0 []*domain.AccessReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*domain.AccessReview, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*domain.AccessReview); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AccessReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartClosingAccessReview provides a mock function with given fields: review
+func (_m *AccessReviewRepository) StartClosingAccessReview(review *domain.AccessReview) error {
+	ret := _m.Called(review)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.AccessReview) error); ok {
+		r0 = rf(review)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAccessReviewItem provides a mock function with given fields: item
+func (_m *AccessReviewRepository) UpdateAccessReviewItem(item *domain.AccessReviewItem) error {
+	ret := _m.Called(item)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.AccessReviewItem) error); ok {
+		r0 = rf(item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAccessReviewItemRevocation provides a mock function with given fields: item
+func (_m *AccessReviewRepository) UpdateAccessReviewItemRevocation(item *domain.AccessReviewItem) error {
+	ret := _m.Called(item)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.AccessReviewItem) error); ok {
+		r0 = rf(item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewAccessReviewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAccessReviewRepository creates a new instance of AccessReviewRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAccessReviewRepository(t mockConstructorTestingTNewAccessReviewRepository) *AccessReviewRepository {
+	mock := &AccessReviewRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
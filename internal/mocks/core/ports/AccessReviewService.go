@@ -0,0 +1,185 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AccessReviewService is an autogenerated mock type for the AccessReviewService type
+type AccessReviewService struct {
+	mock.Mock
+}
+
+// CloseAccessReview provides a mock function with given fields: request
+func (_m *AccessReviewService) CloseAccessReview(request *domain.CloseAccessReviewRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.CloseAccessReviewRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.CloseAccessReviewRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.CloseAccessReviewRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAccessReview provides a mock function with given fields: request
+func (_m *AccessReviewService) CreateAccessReview(request *domain.CreateAccessReviewRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.CreateAccessReviewRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.CreateAccessReviewRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.CreateAccessReviewRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DecideAccessReviewItem provides a mock function with given fields: request
+func (_m *AccessReviewService) DecideAccessReviewItem(request *domain.DecideAccessReviewItemRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.DecideAccessReviewItemRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.DecideAccessReviewItemRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.DecideAccessReviewItemRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportAccessReview provides a mock function with given fields: request
+func (_m *AccessReviewService) ExportAccessReview(request *domain.ExportAccessReviewRequest) (*domain.AccessReviewExport, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.AccessReviewExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.ExportAccessReviewRequest) (*domain.AccessReviewExport, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.ExportAccessReviewRequest) *domain.AccessReviewExport); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AccessReviewExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.ExportAccessReviewRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccessReview provides a mock function with given fields: id
+func (_m *AccessReviewService) GetAccessReview(id string) (*domain.Response, error) {
+	ret := _m.Called(id)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Response, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Response); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccessReviews provides a mock function with given fields:
+func (_m *AccessReviewService) GetAccessReviews() (*domain.Response, error) {
+	ret := _m.Called()
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*domain.Response, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *domain.Response); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAccessReviewService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAccessReviewService creates a new instance of AccessReviewService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAccessReviewService(t mockConstructorTestingTNewAccessReviewService) *AccessReviewService {
+	mock := &AccessReviewService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,121 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AdminScopeRepository is an autogenerated mock type for the AdminScopeRepository type
+type AdminScopeRepository struct {
+	mock.Mock
+}
+
+// GetAdminScope provides a mock function with given fields: roleID
+func (_m *AdminScopeRepository) GetAdminScope(roleID string) (*domain.AdminScope, error) {
+	ret := _m.Called(roleID)
+
+	var r0 *domain.AdminScope
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.AdminScope, error)); ok {
+		return rf(roleID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.AdminScope); ok {
+		r0 = rf(roleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AdminScope)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(roleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetScopedPermissions provides a mock function with given fields: adminRoles
+func (_m *AdminScopeRepository) GetScopedPermissions(adminRoles []string) ([]string, error) {
+	ret := _m.Called(adminRoles)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]string, error)); ok {
+		return rf(adminRoles)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []string); ok {
+		r0 = rf(adminRoles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(adminRoles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetScopedRoles provides a mock function with given fields: adminRoles
+func (_m *AdminScopeRepository) GetScopedRoles(adminRoles []string) ([]string, error) {
+	ret := _m.Called(adminRoles)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]string, error)); ok {
+		return rf(adminRoles)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []string); ok {
+		r0 = rf(adminRoles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(adminRoles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateAdminScope provides a mock function with given fields: scope
+func (_m *AdminScopeRepository) UpdateAdminScope(scope *domain.AdminScope) error {
+	ret := _m.Called(scope)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.AdminScope) error); ok {
+		r0 = rf(scope)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewAdminScopeRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAdminScopeRepository creates a new instance of AdminScopeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAdminScopeRepository(t mockConstructorTestingTNewAdminScopeRepository) *AdminScopeRepository {
+	mock := &AdminScopeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,109 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AdminScopeService is an autogenerated mock type for the AdminScopeService type
+type AdminScopeService struct {
+	mock.Mock
+}
+
+// CheckPermissionScope provides a mock function with given fields: actorID, permissions
+func (_m *AdminScopeService) CheckPermissionScope(actorID string, permissions []string) error {
+	ret := _m.Called(actorID, permissions)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(actorID, permissions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckRoleScope provides a mock function with given fields: actorID, roles
+func (_m *AdminScopeService) CheckRoleScope(actorID string, roles []string) error {
+	ret := _m.Called(actorID, roles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(actorID, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAdminScope provides a mock function with given fields: roleID
+func (_m *AdminScopeService) GetAdminScope(roleID string) (*domain.Response, error) {
+	ret := _m.Called(roleID)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Response, error)); ok {
+		return rf(roleID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Response); ok {
+		r0 = rf(roleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(roleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateAdminScope provides a mock function with given fields: request
+func (_m *AdminScopeService) UpdateAdminScope(request *domain.UpdateAdminScopeRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.UpdateAdminScopeRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.UpdateAdminScopeRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.UpdateAdminScopeRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAdminScopeService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAdminScopeService creates a new instance of AdminScopeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAdminScopeService(t mockConstructorTestingTNewAdminScopeService) *AdminScopeService {
+	mock := &AdminScopeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
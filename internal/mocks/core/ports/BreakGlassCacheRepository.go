@@ -0,0 +1,153 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// BreakGlassCacheRepository is an autogenerated mock type for the BreakGlassCacheRepository type
+type BreakGlassCacheRepository struct {
+	mock.Mock
+}
+
+// CountBreakGlassFailure provides a mock function with given fields: userID, expiration
+func (_m *BreakGlassCacheRepository) CountBreakGlassFailure(userID string, expiration time.Duration) (int64, error) {
+	ret := _m.Called(userID, expiration)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration) (int64, error)); ok {
+		return rf(userID, expiration)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Duration) int64); ok {
+		r0 = rf(userID, expiration)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = rf(userID, expiration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteBreakGlassSession provides a mock function with given fields: userID
+func (_m *BreakGlassCacheRepository) DeleteBreakGlassSession(userID string) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBreakGlassFailures provides a mock function with given fields: userID
+func (_m *BreakGlassCacheRepository) GetBreakGlassFailures(userID string) (int64, error) {
+	ret := _m.Called(userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBreakGlassSession provides a mock function with given fields: userID
+func (_m *BreakGlassCacheRepository) GetBreakGlassSession(userID string) (string, error) {
+	ret := _m.Called(userID)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveBreakGlassSession provides a mock function with given fields: userID, sessionID, expiration
+func (_m *BreakGlassCacheRepository) SaveBreakGlassSession(userID string, sessionID string, expiration time.Duration) error {
+	ret := _m.Called(userID, sessionID, expiration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, time.Duration) error); ok {
+		r0 = rf(userID, sessionID, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseBreakGlassCode provides a mock function with given fields: userID, counter, expiration
+func (_m *BreakGlassCacheRepository) UseBreakGlassCode(userID string, counter int64, expiration time.Duration) (bool, error) {
+	ret := _m.Called(userID, counter, expiration)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64, time.Duration) (bool, error)); ok {
+		return rf(userID, counter, expiration)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64, time.Duration) bool); ok {
+		r0 = rf(userID, counter, expiration)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64, time.Duration) error); ok {
+		r1 = rf(userID, counter, expiration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewBreakGlassCacheRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewBreakGlassCacheRepository creates a new instance of BreakGlassCacheRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewBreakGlassCacheRepository(t mockConstructorTestingTNewBreakGlassCacheRepository) *BreakGlassCacheRepository {
+	mock := &BreakGlassCacheRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
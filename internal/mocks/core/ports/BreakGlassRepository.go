@@ -0,0 +1,189 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// BreakGlassRepository is an autogenerated mock type for the BreakGlassRepository type
+type BreakGlassRepository struct {
+	mock.Mock
+}
+
+// CreateBreakGlassSession provides a mock function with given fields: session
+func (_m *BreakGlassRepository) CreateBreakGlassSession(session *domain.BreakGlassSession) error {
+	ret := _m.Called(session)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.BreakGlassSession) error); ok {
+		r0 = rf(session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteBreakGlassEnrollment provides a mock function with given fields: userID
+func (_m *BreakGlassRepository) DeleteBreakGlassEnrollment(userID string) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBreakGlassSecret provides a mock function with given fields: userID
+func (_m *BreakGlassRepository) GetBreakGlassSecret(userID string) (string, error) {
+	ret := _m.Called(userID)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBreakGlassSessionByID provides a mock function with given fields: id
+func (_m *BreakGlassRepository) GetBreakGlassSessionByID(id string) (*domain.BreakGlassSession, error) {
+	ret := _m.Called(id)
+
+	var r0 *domain.BreakGlassSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.BreakGlassSession, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.BreakGlassSession); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.BreakGlassSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBreakGlassSessions provides a mock function with given fields: status
+func (_m *BreakGlassRepository) GetBreakGlassSessions(status string) ([]*domain.BreakGlassSession, error) {
+	ret := _m.Called(status)
+
+	var r0 []*domain.BreakGlassSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*domain.BreakGlassSession, error)); ok {
+		return rf(status)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*domain.BreakGlassSession); ok {
+		r0 = rf(status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.BreakGlassSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpiredBreakGlassSessions provides a mock function with given fields: now
+func (_m *BreakGlassRepository) GetExpiredBreakGlassSessions(now time.Time) ([]*domain.BreakGlassSession, error) {
+	ret := _m.Called(now)
+
+	var r0 []*domain.BreakGlassSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]*domain.BreakGlassSession, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []*domain.BreakGlassSession); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.BreakGlassSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveBreakGlassEnrollment provides a mock function with given fields: enrollment
+func (_m *BreakGlassRepository) SaveBreakGlassEnrollment(enrollment *domain.BreakGlassEnrollment) error {
+	ret := _m.Called(enrollment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.BreakGlassEnrollment) error); ok {
+		r0 = rf(enrollment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateBreakGlassSession provides a mock function with given fields: session
+func (_m *BreakGlassRepository) UpdateBreakGlassSession(session *domain.BreakGlassSession) error {
+	ret := _m.Called(session)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.BreakGlassSession) error); ok {
+		r0 = rf(session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewBreakGlassRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewBreakGlassRepository creates a new instance of BreakGlassRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewBreakGlassRepository(t mockConstructorTestingTNewBreakGlassRepository) *BreakGlassRepository {
+	mock := &BreakGlassRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,223 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BreakGlassService is an autogenerated mock type for the BreakGlassService type
+type BreakGlassService struct {
+	mock.Mock
+}
+
+// ActivateBreakGlass provides a mock function with given fields: request
+func (_m *BreakGlassService) ActivateBreakGlass(request *domain.ActivateBreakGlassRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.ActivateBreakGlassRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.ActivateBreakGlassRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.ActivateBreakGlassRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EndBreakGlass provides a mock function with given fields: request
+func (_m *BreakGlassService) EndBreakGlass(request *domain.EndBreakGlassRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.EndBreakGlassRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.EndBreakGlassRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.EndBreakGlassRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnrollBreakGlass provides a mock function with given fields: request
+func (_m *BreakGlassService) EnrollBreakGlass(request *domain.EnrollBreakGlassRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.EnrollBreakGlassRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.EnrollBreakGlassRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.EnrollBreakGlassRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExpireBreakGlassSessions provides a mock function with given fields:
+func (_m *BreakGlassService) ExpireBreakGlassSessions() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActiveBreakGlassSession provides a mock function with given fields: userID
+func (_m *BreakGlassService) GetActiveBreakGlassSession(userID string) (string, error) {
+	ret := _m.Called(userID)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBreakGlassSession provides a mock function with given fields: id
+func (_m *BreakGlassService) GetBreakGlassSession(id string) (*domain.Response, error) {
+	ret := _m.Called(id)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Response, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Response); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBreakGlassSessions provides a mock function with given fields: request
+func (_m *BreakGlassService) GetBreakGlassSessions(request *domain.GetBreakGlassSessionsRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.GetBreakGlassSessionsRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.GetBreakGlassSessionsRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.GetBreakGlassSessionsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnenrollBreakGlass provides a mock function with given fields: request
+func (_m *BreakGlassService) UnenrollBreakGlass(request *domain.UnenrollBreakGlassRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.UnenrollBreakGlassRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.UnenrollBreakGlassRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.UnenrollBreakGlassRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewBreakGlassService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewBreakGlassService creates a new instance of BreakGlassService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewBreakGlassService(t mockConstructorTestingTNewBreakGlassService) *BreakGlassService {
+	mock := &BreakGlassService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
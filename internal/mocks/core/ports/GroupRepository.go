@@ -0,0 +1,173 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// GroupRepository is an autogenerated mock type for the GroupRepository type
+type GroupRepository struct {
+	mock.Mock
+}
+
+// CreateGroup provides a mock function with given fields: group
+func (_m *GroupRepository) CreateGroup(group *domain.Group) error {
+	ret := _m.Called(group)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.Group) error); ok {
+		r0 = rf(group)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteGroup provides a mock function with given fields: id
+func (_m *GroupRepository) DeleteGroup(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAllGroup provides a mock function with given fields:
+func (_m *GroupRepository) GetAllGroup() ([]*domain.Group, error) {
+	ret := _m.Called()
+
+	var r0 []*domain.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*domain.Group, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*domain.Group); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupByID provides a mock function with given fields: id
+func (_m *GroupRepository) GetGroupByID(id string) (*domain.Group, error) {
+	ret := _m.Called(id)
+
+	var r0 *domain.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Group, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Group); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupByName provides a mock function with given fields: name
+func (_m *GroupRepository) GetGroupByName(name string) (*domain.Group, error) {
+	ret := _m.Called(name)
+
+	var r0 *domain.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Group, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Group); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GroupIsExist provides a mock function with given fields: name
+func (_m *GroupRepository) GroupIsExist(name string) (bool, error) {
+	ret := _m.Called(name)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateGroup provides a mock function with given fields: group
+func (_m *GroupRepository) UpdateGroup(group *domain.Group) error {
+	ret := _m.Called(group)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.Group) error); ok {
+		r0 = rf(group)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewGroupRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewGroupRepository creates a new instance of GroupRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewGroupRepository(t mockConstructorTestingTNewGroupRepository) *GroupRepository {
+	mock := &GroupRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
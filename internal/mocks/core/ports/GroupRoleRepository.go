@@ -0,0 +1,83 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// GroupRoleRepository is an autogenerated mock type for the GroupRoleRepository type
+type GroupRoleRepository struct {
+	mock.Mock
+}
+
+// AddGroupRoles provides a mock function with given fields: groupID, roles
+func (_m *GroupRoleRepository) AddGroupRoles(groupID string, roles []string) error {
+	ret := _m.Called(groupID, roles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(groupID, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetGroupRoles provides a mock function with given fields: groupID
+func (_m *GroupRoleRepository) GetGroupRoles(groupID string) ([]*domain.Role, error) {
+	ret := _m.Called(groupID)
+
+	var r0 []*domain.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*domain.Role, error)); ok {
+		return rf(groupID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*domain.Role); ok {
+		r0 = rf(groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(groupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveGroupRoles provides a mock function with given fields: groupID, roles
+func (_m *GroupRoleRepository) RemoveGroupRoles(groupID string, roles []string) error {
+	ret := _m.Called(groupID, roles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(groupID, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewGroupRoleRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewGroupRoleRepository creates a new instance of GroupRoleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewGroupRoleRepository(t mockConstructorTestingTNewGroupRoleRepository) *GroupRoleRepository {
+	mock := &GroupRoleRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
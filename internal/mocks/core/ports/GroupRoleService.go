@@ -0,0 +1,107 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// GroupRoleService is an autogenerated mock type for the GroupRoleService type
+type GroupRoleService struct {
+	mock.Mock
+}
+
+// AssignRolesToGroup provides a mock function with given fields: request
+func (_m *GroupRoleService) AssignRolesToGroup(request *domain.AssignRolesToGroupRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.AssignRolesToGroupRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.AssignRolesToGroupRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.AssignRolesToGroupRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupRoles provides a mock function with given fields: request
+func (_m *GroupRoleService) GetGroupRoles(request *domain.GetGroupRolesRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.GetGroupRolesRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.GetGroupRolesRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.GetGroupRolesRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveRolesFromGroup provides a mock function with given fields: request
+func (_m *GroupRoleService) RemoveRolesFromGroup(request *domain.RemoveRolesFromGroupRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.RemoveRolesFromGroupRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.RemoveRolesFromGroupRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.RemoveRolesFromGroupRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewGroupRoleService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewGroupRoleService creates a new instance of GroupRoleService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewGroupRoleService(t mockConstructorTestingTNewGroupRoleService) *GroupRoleService {
+	mock := &GroupRoleService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
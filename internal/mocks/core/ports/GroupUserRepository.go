@@ -0,0 +1,83 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// GroupUserRepository is an autogenerated mock type for the GroupUserRepository type
+type GroupUserRepository struct {
+	mock.Mock
+}
+
+// AddGroupUsers provides a mock function with given fields: groupID, users
+func (_m *GroupUserRepository) AddGroupUsers(groupID string, users []string) error {
+	ret := _m.Called(groupID, users)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(groupID, users)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetGroupUsers provides a mock function with given fields: groupID
+func (_m *GroupUserRepository) GetGroupUsers(groupID string) ([]*domain.User, error) {
+	ret := _m.Called(groupID)
+
+	var r0 []*domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*domain.User, error)); ok {
+		return rf(groupID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*domain.User); ok {
+		r0 = rf(groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(groupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveGroupUsers provides a mock function with given fields: groupID, users
+func (_m *GroupUserRepository) RemoveGroupUsers(groupID string, users []string) error {
+	ret := _m.Called(groupID, users)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(groupID, users)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewGroupUserRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewGroupUserRepository creates a new instance of GroupUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewGroupUserRepository(t mockConstructorTestingTNewGroupUserRepository) *GroupUserRepository {
+	mock := &GroupUserRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,43 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ManifestRepository is an autogenerated mock type for the ManifestRepository type
+type ManifestRepository struct {
+	mock.Mock
+}
+
+// ApplyManifestPlan provides a mock function with given fields: plan, dryRun
+func (_m *ManifestRepository) ApplyManifestPlan(plan *domain.ManifestPlan, dryRun bool) error {
+	ret := _m.Called(plan, dryRun)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.ManifestPlan, bool) error); ok {
+		r0 = rf(plan, dryRun)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewManifestRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewManifestRepository creates a new instance of ManifestRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewManifestRepository(t mockConstructorTestingTNewManifestRepository) *ManifestRepository {
+	mock := &ManifestRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
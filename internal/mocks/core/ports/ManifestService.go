@@ -0,0 +1,81 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ManifestService is an autogenerated mock type for the ManifestService type
+type ManifestService struct {
+	mock.Mock
+}
+
+// Apply provides a mock function with given fields: manifest, options
+func (_m *ManifestService) Apply(manifest *domain.Manifest, options *domain.ManifestOptions) (*domain.ManifestPlan, error) {
+	ret := _m.Called(manifest, options)
+
+	var r0 *domain.ManifestPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.Manifest, *domain.ManifestOptions) (*domain.ManifestPlan, error)); ok {
+		return rf(manifest, options)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.Manifest, *domain.ManifestOptions) *domain.ManifestPlan); ok {
+		r0 = rf(manifest, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ManifestPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.Manifest, *domain.ManifestOptions) error); ok {
+		r1 = rf(manifest, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Plan provides a mock function with given fields: manifest, options
+func (_m *ManifestService) Plan(manifest *domain.Manifest, options *domain.ManifestOptions) (*domain.ManifestPlan, error) {
+	ret := _m.Called(manifest, options)
+
+	var r0 *domain.ManifestPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.Manifest, *domain.ManifestOptions) (*domain.ManifestPlan, error)); ok {
+		return rf(manifest, options)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.Manifest, *domain.ManifestOptions) *domain.ManifestPlan); ok {
+		r0 = rf(manifest, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ManifestPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.Manifest, *domain.ManifestOptions) error); ok {
+		r1 = rf(manifest, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewManifestService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewManifestService creates a new instance of ManifestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewManifestService(t mockConstructorTestingTNewManifestService) *ManifestService {
+	mock := &ManifestService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,43 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Notifier is an autogenerated mock type for the Notifier type
+type Notifier struct {
+	mock.Mock
+}
+
+// Notify provides a mock function with given fields: notification
+func (_m *Notifier) Notify(notification *domain.Notification) error {
+	ret := _m.Called(notification)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.Notification) error); ok {
+		r0 = rf(notification)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewNotifier interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewNotifier creates a new instance of Notifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewNotifier(t mockConstructorTestingTNewNotifier) *Notifier {
+	mock := &Notifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,109 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PermissionImplicationRepository is an autogenerated mock type for the PermissionImplicationRepository type
+type PermissionImplicationRepository struct {
+	mock.Mock
+}
+
+// AddPermissionImplications provides a mock function with given fields: permissionID, implied
+func (_m *PermissionImplicationRepository) AddPermissionImplications(permissionID string, implied []string) error {
+	ret := _m.Called(permissionID, implied)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(permissionID, implied)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAllPermissionImplications provides a mock function with given fields:
+func (_m *PermissionImplicationRepository) GetAllPermissionImplications() ([]*domain.PermissionImplication, error) {
+	ret := _m.Called()
+
+	var r0 []*domain.PermissionImplication
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*domain.PermissionImplication, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*domain.PermissionImplication); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.PermissionImplication)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPermissionImplications provides a mock function with given fields: permissionID
+func (_m *PermissionImplicationRepository) GetPermissionImplications(permissionID string) ([]*domain.Permission, error) {
+	ret := _m.Called(permissionID)
+
+	var r0 []*domain.Permission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*domain.Permission, error)); ok {
+		return rf(permissionID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*domain.Permission); ok {
+		r0 = rf(permissionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(permissionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemovePermissionImplications provides a mock function with given fields: permissionID, implied
+func (_m *PermissionImplicationRepository) RemovePermissionImplications(permissionID string, implied []string) error {
+	ret := _m.Called(permissionID, implied)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(permissionID, implied)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPermissionImplicationRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPermissionImplicationRepository creates a new instance of PermissionImplicationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPermissionImplicationRepository(t mockConstructorTestingTNewPermissionImplicationRepository) *PermissionImplicationRepository {
+	mock := &PermissionImplicationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,211 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PermissionImplicationService is an autogenerated mock type for the PermissionImplicationService type
+type PermissionImplicationService struct {
+	mock.Mock
+}
+
+// AddPermissionImplications provides a mock function with given fields: request
+func (_m *PermissionImplicationService) AddPermissionImplications(request *domain.AddPermissionImplicationsRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.AddPermissionImplicationsRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.AddPermissionImplicationsRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.AddPermissionImplicationsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExpandPermissions provides a mock function with given fields: permissions
+func (_m *PermissionImplicationService) ExpandPermissions(permissions []string) ([]string, error) {
+	ret := _m.Called(permissions)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]string, error)); ok {
+		return rf(permissions)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []string); ok {
+		r0 = rf(permissions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(permissions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetImpliedPermissions provides a mock function with given fields: permissionsID
+func (_m *PermissionImplicationService) GetImpliedPermissions(permissionsID []string) ([]*domain.Permission, error) {
+	ret := _m.Called(permissionsID)
+
+	var r0 []*domain.Permission
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]*domain.Permission, error)); ok {
+		return rf(permissionsID)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []*domain.Permission); ok {
+		r0 = rf(permissionsID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(permissionsID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetImplyingPermissions provides a mock function with given fields: permissionID
+func (_m *PermissionImplicationService) GetImplyingPermissions(permissionID string) ([]*domain.Permission, error) {
+	ret := _m.Called(permissionID)
+
+	var r0 []*domain.Permission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*domain.Permission, error)); ok {
+		return rf(permissionID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*domain.Permission); ok {
+		r0 = rf(permissionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(permissionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPermissionImplications provides a mock function with given fields: request
+func (_m *PermissionImplicationService) GetPermissionImplications(request *domain.GetPermissionImplicationsRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.GetPermissionImplicationsRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.GetPermissionImplicationsRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.GetPermissionImplicationsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemovePermissionImplications provides a mock function with given fields: request
+func (_m *PermissionImplicationService) RemovePermissionImplications(request *domain.RemovePermissionImplicationsRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.RemovePermissionImplicationsRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.RemovePermissionImplicationsRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.RemovePermissionImplicationsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TraceImpliedPermissions provides a mock function with given fields: permissionsID
+func (_m *PermissionImplicationService) TraceImpliedPermissions(permissionsID []string) ([]*domain.ImpliedPermission, error) {
+	ret := _m.Called(permissionsID)
+
+	var r0 []*domain.ImpliedPermission
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]*domain.ImpliedPermission, error)); ok {
+		return rf(permissionsID)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []*domain.ImpliedPermission); ok {
+		r0 = rf(permissionsID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ImpliedPermission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(permissionsID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPermissionImplicationService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPermissionImplicationService creates a new instance of PermissionImplicationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPermissionImplicationService(t mockConstructorTestingTNewPermissionImplicationService) *PermissionImplicationService {
+	mock := &PermissionImplicationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -6,6 +6,8 @@ import (
 	domain "user-svc/internal/core/domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // PermissionRepository is an autogenerated mock type for the PermissionRepository type
@@ -27,13 +29,13 @@ func (_m *PermissionRepository) CreatePermission(role *domain.Permission) error
 	return r0
 }
 
-// DeletePermission provides a mock function with given fields: id
-func (_m *PermissionRepository) DeletePermission(id string) error {
-	ret := _m.Called(id)
+// DeletePermission provides a mock function with given fields: id, version, deletedAt
+func (_m *PermissionRepository) DeletePermission(id string, version int64, deletedAt time.Time) error {
+	ret := _m.Called(id, version, deletedAt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(string, int64, time.Time) error); ok {
+		r0 = rf(id, version, deletedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -67,6 +69,32 @@ func (_m *PermissionRepository) GetAllPermission() ([]*domain.Permission, error)
 	return r0, r1
 }
 
+// GetDeletedPermissionByID provides a mock function with given fields: id
+func (_m *PermissionRepository) GetDeletedPermissionByID(id string) (*domain.Permission, error) {
+	ret := _m.Called(id)
+
+	var r0 *domain.Permission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Permission, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Permission); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPermissionByID provides a mock function with given fields: id
 func (_m *PermissionRepository) GetPermissionByID(id string) (*domain.Permission, error) {
 	ret := _m.Called(id)
@@ -119,6 +147,93 @@ func (_m *PermissionRepository) GetPermissionByName(name string) (*domain.Permis
 	return r0, r1
 }
 
+// GetPermissions provides a mock function with given fields: filter
+func (_m *PermissionRepository) GetPermissions(filter *domain.PermissionFilter) ([]*domain.Permission, int64, error) {
+	ret := _m.Called(filter)
+
+	var r0 []*domain.Permission
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*domain.PermissionFilter) ([]*domain.Permission, int64, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.PermissionFilter) []*domain.Permission); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.PermissionFilter) int64); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(*domain.PermissionFilter) error); ok {
+		r2 = rf(filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetPermissionsAfter provides a mock function with given fields: filter
+func (_m *PermissionRepository) GetPermissionsAfter(filter *domain.PermissionFilter) ([]*domain.Permission, error) {
+	ret := _m.Called(filter)
+
+	var r0 []*domain.Permission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.PermissionFilter) ([]*domain.Permission, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.PermissionFilter) []*domain.Permission); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Permission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.PermissionFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkSystemPermissions provides a mock function with given fields: ids, updatedAt
+func (_m *PermissionRepository) MarkSystemPermissions(ids []string, updatedAt time.Time) error {
+	ret := _m.Called(ids, updatedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string, time.Time) error); ok {
+		r0 = rf(ids, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PatchPermission provides a mock function with given fields: id, version, patch
+func (_m *PermissionRepository) PatchPermission(id string, version int64, patch *domain.PermissionPatch) error {
+	ret := _m.Called(id, version, patch)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64, *domain.PermissionPatch) error); ok {
+		r0 = rf(id, version, patch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PermissionIsExist provides a mock function with given fields: name
 func (_m *PermissionRepository) PermissionIsExist(name string) (bool, error) {
 	ret := _m.Called(name)
@@ -143,6 +258,44 @@ func (_m *PermissionRepository) PermissionIsExist(name string) (bool, error) {
 	return r0, r1
 }
 
+// PurgePermissions provides a mock function with given fields: before
+func (_m *PermissionRepository) PurgePermissions(before time.Time) (int64, error) {
+	ret := _m.Called(before)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestorePermission provides a mock function with given fields: id, restoredAt
+func (_m *PermissionRepository) RestorePermission(id string, restoredAt time.Time) error {
+	ret := _m.Called(id, restoredAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(id, restoredAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdatePermission provides a mock function with given fields: role
 func (_m *PermissionRepository) UpdatePermission(role *domain.Permission) error {
 	ret := _m.Called(role)
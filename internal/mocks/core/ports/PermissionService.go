@@ -39,25 +39,25 @@ func (_m *PermissionService) CreatePermission(request *domain.CreatePermissionRe
 	return r0, r1
 }
 
-// DeletePermission provides a mock function with given fields: id
-func (_m *PermissionService) DeletePermission(id string) (*domain.Response, error) {
-	ret := _m.Called(id)
+// DeletePermission provides a mock function with given fields: request
+func (_m *PermissionService) DeletePermission(request *domain.DeletePermissionRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
 
 	var r0 *domain.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*domain.Response, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(*domain.DeletePermissionRequest) (*domain.Response, error)); ok {
+		return rf(request)
 	}
-	if rf, ok := ret.Get(0).(func(string) *domain.Response); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(*domain.DeletePermissionRequest) *domain.Response); ok {
+		r0 = rf(request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Response)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(*domain.DeletePermissionRequest) error); ok {
+		r1 = rf(request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -91,25 +91,103 @@ func (_m *PermissionService) GetPermission(id string) (*domain.Response, error)
 	return r0, r1
 }
 
-// GetPermissions provides a mock function with given fields:
-func (_m *PermissionService) GetPermissions() (*domain.Response, error) {
-	ret := _m.Called()
+// GetPermissions provides a mock function with given fields: request
+func (_m *PermissionService) GetPermissions(request *domain.GetPermissionsRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.GetPermissionsRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.GetPermissionsRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.GetPermissionsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchPermission provides a mock function with given fields: request
+func (_m *PermissionService) PatchPermission(request *domain.PatchPermissionRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.PatchPermissionRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.PatchPermissionRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.PatchPermissionRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestorePermission provides a mock function with given fields: request
+func (_m *PermissionService) RestorePermission(request *domain.RestorePermissionRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
 
 	var r0 *domain.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*domain.Response, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(*domain.RestorePermissionRequest) (*domain.Response, error)); ok {
+		return rf(request)
 	}
-	if rf, ok := ret.Get(0).(func() *domain.Response); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(*domain.RestorePermissionRequest) *domain.Response); ok {
+		r0 = rf(request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Response)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(*domain.RestorePermissionRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncPermissions provides a mock function with given fields: registry
+func (_m *PermissionService) SyncPermissions(registry []domain.PermissionName) (*domain.PermissionSync, error) {
+	ret := _m.Called(registry)
+
+	var r0 *domain.PermissionSync
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]domain.PermissionName) (*domain.PermissionSync, error)); ok {
+		return rf(registry)
+	}
+	if rf, ok := ret.Get(0).(func([]domain.PermissionName) *domain.PermissionSync); ok {
+		r0 = rf(registry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PermissionSync)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]domain.PermissionName) error); ok {
+		r1 = rf(registry)
 	} else {
 		r1 = ret.Error(1)
 	}
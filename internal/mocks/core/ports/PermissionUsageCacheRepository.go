@@ -0,0 +1,85 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// PermissionUsageCacheRepository is an autogenerated mock type for the PermissionUsageCacheRepository type
+type PermissionUsageCacheRepository struct {
+	mock.Mock
+}
+
+// ClearPendingPermissionUsage provides a mock function with given fields:
+func (_m *PermissionUsageCacheRepository) ClearPendingPermissionUsage() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetPendingPermissionUsage provides a mock function with given fields:
+func (_m *PermissionUsageCacheRepository) GetPendingPermissionUsage() ([]*domain.PermissionUsage, error) {
+	ret := _m.Called()
+
+	var r0 []*domain.PermissionUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*domain.PermissionUsage, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*domain.PermissionUsage); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.PermissionUsage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordPermissionUsage provides a mock function with given fields: userID, permission, usedAt
+func (_m *PermissionUsageCacheRepository) RecordPermissionUsage(userID string, permission string, usedAt time.Time) error {
+	ret := _m.Called(userID, permission, usedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) error); ok {
+		r0 = rf(userID, permission, usedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPermissionUsageCacheRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPermissionUsageCacheRepository creates a new instance of PermissionUsageCacheRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPermissionUsageCacheRepository(t mockConstructorTestingTNewPermissionUsageCacheRepository) *PermissionUsageCacheRepository {
+	mock := &PermissionUsageCacheRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,69 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PermissionUsageRepository is an autogenerated mock type for the PermissionUsageRepository type
+type PermissionUsageRepository struct {
+	mock.Mock
+}
+
+// GetGrantUsage provides a mock function with given fields: roleID
+func (_m *PermissionUsageRepository) GetGrantUsage(roleID string) ([]*domain.GrantUsage, error) {
+	ret := _m.Called(roleID)
+
+	var r0 []*domain.GrantUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*domain.GrantUsage, error)); ok {
+		return rf(roleID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*domain.GrantUsage); ok {
+		r0 = rf(roleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.GrantUsage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(roleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SavePermissionUsage provides a mock function with given fields: usages
+func (_m *PermissionUsageRepository) SavePermissionUsage(usages []*domain.PermissionUsage) error {
+	ret := _m.Called(usages)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*domain.PermissionUsage) error); ok {
+		r0 = rf(usages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPermissionUsageRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPermissionUsageRepository creates a new instance of PermissionUsageRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPermissionUsageRepository(t mockConstructorTestingTNewPermissionUsageRepository) *PermissionUsageRepository {
+	mock := &PermissionUsageRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
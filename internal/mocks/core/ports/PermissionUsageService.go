@@ -0,0 +1,83 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PermissionUsageService is an autogenerated mock type for the PermissionUsageService type
+type PermissionUsageService struct {
+	mock.Mock
+}
+
+// FlushUsage provides a mock function with given fields:
+func (_m *PermissionUsageService) FlushUsage() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetUnusedGrants provides a mock function with given fields: request
+func (_m *PermissionUsageService) GetUnusedGrants(request *domain.GetUnusedGrantsRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.GetUnusedGrantsRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.GetUnusedGrantsRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.GetUnusedGrantsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordUsage provides a mock function with given fields: userID, permission
+func (_m *PermissionUsageService) RecordUsage(userID string, permission string) error {
+	ret := _m.Called(userID, permission)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(userID, permission)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPermissionUsageService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPermissionUsageService creates a new instance of PermissionUsageService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPermissionUsageService(t mockConstructorTestingTNewPermissionUsageService) *PermissionUsageService {
+	mock := &PermissionUsageService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,55 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PurgeService is an autogenerated mock type for the PurgeService type
+type PurgeService struct {
+	mock.Mock
+}
+
+// PurgeDeleted provides a mock function with given fields:
+func (_m *PurgeService) PurgeDeleted() (*domain.PurgeResult, error) {
+	ret := _m.Called()
+
+	var r0 *domain.PurgeResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*domain.PurgeResult, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *domain.PurgeResult); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PurgeResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPurgeService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPurgeService creates a new instance of PurgeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPurgeService(t mockConstructorTestingTNewPurgeService) *PurgeService {
+	mock := &PurgeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
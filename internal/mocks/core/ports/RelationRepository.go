@@ -0,0 +1,127 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RelationRepository is an autogenerated mock type for the RelationRepository type
+type RelationRepository struct {
+	mock.Mock
+}
+
+// GetRelationRevision provides a mock function with given fields:
+func (_m *RelationRepository) GetRelationRevision() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRelationTuples provides a mock function with given fields: filter, revision
+func (_m *RelationRepository) GetRelationTuples(filter *domain.RelationTupleFilter, revision int64) ([]*domain.RelationTuple, error) {
+	ret := _m.Called(filter, revision)
+
+	var r0 []*domain.RelationTuple
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.RelationTupleFilter, int64) ([]*domain.RelationTuple, error)); ok {
+		return rf(filter, revision)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.RelationTupleFilter, int64) []*domain.RelationTuple); ok {
+		r0 = rf(filter, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.RelationTuple)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.RelationTupleFilter, int64) error); ok {
+		r1 = rf(filter, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PruneRelationTuples provides a mock function with given fields:
+func (_m *RelationRepository) PruneRelationTuples() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WriteRelationTuples provides a mock function with given fields: writes, deletes
+func (_m *RelationRepository) WriteRelationTuples(writes []*domain.RelationTuple, deletes []*domain.RelationTuple) (int64, error) {
+	ret := _m.Called(writes, deletes)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]*domain.RelationTuple, []*domain.RelationTuple) (int64, error)); ok {
+		return rf(writes, deletes)
+	}
+	if rf, ok := ret.Get(0).(func([]*domain.RelationTuple, []*domain.RelationTuple) int64); ok {
+		r0 = rf(writes, deletes)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func([]*domain.RelationTuple, []*domain.RelationTuple) error); ok {
+		r1 = rf(writes, deletes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewRelationRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRelationRepository creates a new instance of RelationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRelationRepository(t mockConstructorTestingTNewRelationRepository) *RelationRepository {
+	mock := &RelationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,161 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RoleConstraintRepository is an autogenerated mock type for the RoleConstraintRepository type
+type RoleConstraintRepository struct {
+	mock.Mock
+}
+
+// CreateRoleConstraint provides a mock function with given fields: constraint
+func (_m *RoleConstraintRepository) CreateRoleConstraint(constraint *domain.RoleConstraint) error {
+	ret := _m.Called(constraint)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.RoleConstraint) error); ok {
+		r0 = rf(constraint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRoleConstraint provides a mock function with given fields: id
+func (_m *RoleConstraintRepository) DeleteRoleConstraint(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAllRoleConstraint provides a mock function with given fields:
+func (_m *RoleConstraintRepository) GetAllRoleConstraint() ([]*domain.RoleConstraint, error) {
+	ret := _m.Called()
+
+	var r0 []*domain.RoleConstraint
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*domain.RoleConstraint, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*domain.RoleConstraint); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.RoleConstraint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleConstraintByID provides a mock function with given fields: id
+func (_m *RoleConstraintRepository) GetRoleConstraintByID(id string) (*domain.RoleConstraint, error) {
+	ret := _m.Called(id)
+
+	var r0 *domain.RoleConstraint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.RoleConstraint, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.RoleConstraint); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.RoleConstraint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleConstraintsByRoles provides a mock function with given fields: roles
+func (_m *RoleConstraintRepository) GetRoleConstraintsByRoles(roles []string) ([]*domain.RoleConstraint, error) {
+	ret := _m.Called(roles)
+
+	var r0 []*domain.RoleConstraint
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]*domain.RoleConstraint, error)); ok {
+		return rf(roles)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []*domain.RoleConstraint); ok {
+		r0 = rf(roles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.RoleConstraint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(roles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleGrants provides a mock function with given fields: roles
+func (_m *RoleConstraintRepository) GetRoleGrants(roles []string) ([]*domain.UserRoleGrant, error) {
+	ret := _m.Called(roles)
+
+	var r0 []*domain.UserRoleGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]*domain.UserRoleGrant, error)); ok {
+		return rf(roles)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []*domain.UserRoleGrant); ok {
+		r0 = rf(roles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.UserRoleGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(roles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewRoleConstraintRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRoleConstraintRepository creates a new instance of RoleConstraintRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRoleConstraintRepository(t mockConstructorTestingTNewRoleConstraintRepository) *RoleConstraintRepository {
+	mock := &RoleConstraintRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,173 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RoleConstraintService is an autogenerated mock type for the RoleConstraintService type
+type RoleConstraintService struct {
+	mock.Mock
+}
+
+// CheckAssignment provides a mock function with given fields: userID, roles
+func (_m *RoleConstraintService) CheckAssignment(userID string, roles []string) error {
+	ret := _m.Called(userID, roles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(userID, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRoleConstraint provides a mock function with given fields: request
+func (_m *RoleConstraintService) CreateRoleConstraint(request *domain.CreateRoleConstraintRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.CreateRoleConstraintRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.CreateRoleConstraintRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.CreateRoleConstraintRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRoleConstraint provides a mock function with given fields: id
+func (_m *RoleConstraintService) DeleteRoleConstraint(id string) (*domain.Response, error) {
+	ret := _m.Called(id)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Response, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Response); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleConstraint provides a mock function with given fields: id
+func (_m *RoleConstraintService) GetRoleConstraint(id string) (*domain.Response, error) {
+	ret := _m.Called(id)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Response, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Response); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleConstraints provides a mock function with given fields:
+func (_m *RoleConstraintService) GetRoleConstraints() (*domain.Response, error) {
+	ret := _m.Called()
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*domain.Response, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *domain.Response); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetViolations provides a mock function with given fields:
+func (_m *RoleConstraintService) GetViolations() (*domain.Response, error) {
+	ret := _m.Called()
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*domain.Response, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *domain.Response); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewRoleConstraintService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRoleConstraintService creates a new instance of RoleConstraintService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRoleConstraintService(t mockConstructorTestingTNewRoleConstraintService) *RoleConstraintService {
+	mock := &RoleConstraintService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
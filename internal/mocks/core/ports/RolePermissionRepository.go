@@ -27,6 +27,39 @@ func (_m *RolePermissionRepository) AddRolePermissions(roleId string, permission
 	return r0
 }
 
+// GetPermissionUsers provides a mock function with given fields: permissionIDs, limit, offset
+func (_m *RolePermissionRepository) GetPermissionUsers(permissionIDs []string, limit int, offset int) ([]*domain.PermissionUser, int64, error) {
+	ret := _m.Called(permissionIDs, limit, offset)
+
+	var r0 []*domain.PermissionUser
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func([]string, int, int) ([]*domain.PermissionUser, int64, error)); ok {
+		return rf(permissionIDs, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func([]string, int, int) []*domain.PermissionUser); ok {
+		r0 = rf(permissionIDs, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.PermissionUser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string, int, int) int64); ok {
+		r1 = rf(permissionIDs, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func([]string, int, int) error); ok {
+		r2 = rf(permissionIDs, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetRolePermissions provides a mock function with given fields: roleId
 func (_m *RolePermissionRepository) GetRolePermissions(roleId string) ([]*domain.Permission, error) {
 	ret := _m.Called(roleId)
@@ -39,6 +39,32 @@ func (_m *RolePermissionService) AssignPermissionsToRole(request *domain.AssignP
 	return r0, r1
 }
 
+// GetPermissionUsers provides a mock function with given fields: request
+func (_m *RolePermissionService) GetPermissionUsers(request *domain.GetPermissionUsersRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.GetPermissionUsersRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.GetPermissionUsersRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.GetPermissionUsersRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRolePermissions provides a mock function with given fields: request
 func (_m *RolePermissionService) GetRolePermissions(request *domain.GetRolePermissionRequest) (*domain.Response, error) {
 	ret := _m.Called(request)
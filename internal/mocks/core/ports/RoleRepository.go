@@ -6,6 +6,8 @@ import (
 	domain "user-svc/internal/core/domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // RoleRepository is an autogenerated mock type for the RoleRepository type
@@ -27,13 +29,13 @@ func (_m *RoleRepository) CreateRole(role *domain.Role) error {
 	return r0
 }
 
-// DeleteRole provides a mock function with given fields: id
-func (_m *RoleRepository) DeleteRole(id string) error {
-	ret := _m.Called(id)
+// DeleteRole provides a mock function with given fields: id, version, deletedAt
+func (_m *RoleRepository) DeleteRole(id string, version int64, deletedAt time.Time) error {
+	ret := _m.Called(id, version, deletedAt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(string, int64, time.Time) error); ok {
+		r0 = rf(id, version, deletedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -67,6 +69,32 @@ func (_m *RoleRepository) GetAllRole() ([]*domain.Role, error) {
 	return r0, r1
 }
 
+// GetDeletedRoleByID provides a mock function with given fields: id
+func (_m *RoleRepository) GetDeletedRoleByID(id string) (*domain.Role, error) {
+	ret := _m.Called(id)
+
+	var r0 *domain.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Role, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Role); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRoleByID provides a mock function with given fields: id
 func (_m *RoleRepository) GetRoleByID(id string) (*domain.Role, error) {
 	ret := _m.Called(id)
@@ -119,6 +147,117 @@ func (_m *RoleRepository) GetRoleByName(name string) (*domain.Role, error) {
 	return r0, r1
 }
 
+// GetRoles provides a mock function with given fields: filter
+func (_m *RoleRepository) GetRoles(filter *domain.RoleFilter) ([]*domain.Role, int64, error) {
+	ret := _m.Called(filter)
+
+	var r0 []*domain.Role
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*domain.RoleFilter) ([]*domain.Role, int64, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.RoleFilter) []*domain.Role); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.RoleFilter) int64); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(*domain.RoleFilter) error); ok {
+		r2 = rf(filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetRolesAfter provides a mock function with given fields: filter
+func (_m *RoleRepository) GetRolesAfter(filter *domain.RoleFilter) ([]*domain.Role, error) {
+	ret := _m.Called(filter)
+
+	var r0 []*domain.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.RoleFilter) ([]*domain.Role, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.RoleFilter) []*domain.Role); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.RoleFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchRole provides a mock function with given fields: id, version, patch
+func (_m *RoleRepository) PatchRole(id string, version int64, patch *domain.RolePatch) error {
+	ret := _m.Called(id, version, patch)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64, *domain.RolePatch) error); ok {
+		r0 = rf(id, version, patch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PurgeRoles provides a mock function with given fields: before
+func (_m *RoleRepository) PurgeRoles(before time.Time) (int64, error) {
+	ret := _m.Called(before)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreRole provides a mock function with given fields: id, restoredAt
+func (_m *RoleRepository) RestoreRole(id string, restoredAt time.Time) error {
+	ret := _m.Called(id, restoredAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(id, restoredAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RoleIsExist provides a mock function with given fields: name
 func (_m *RoleRepository) RoleIsExist(name string) (bool, error) {
 	ret := _m.Called(name)
@@ -39,25 +39,25 @@ func (_m *RoleService) CreateRole(request *domain.CreateRoleRequest) (*domain.Re
 	return r0, r1
 }
 
-// DeleteRole provides a mock function with given fields: id
-func (_m *RoleService) DeleteRole(id string) (*domain.Response, error) {
-	ret := _m.Called(id)
+// DeleteRole provides a mock function with given fields: request
+func (_m *RoleService) DeleteRole(request *domain.DeleteRoleRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
 
 	var r0 *domain.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*domain.Response, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(*domain.DeleteRoleRequest) (*domain.Response, error)); ok {
+		return rf(request)
 	}
-	if rf, ok := ret.Get(0).(func(string) *domain.Response); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(*domain.DeleteRoleRequest) *domain.Response); ok {
+		r0 = rf(request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Response)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(*domain.DeleteRoleRequest) error); ok {
+		r1 = rf(request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -91,25 +91,77 @@ func (_m *RoleService) GetRole(id string) (*domain.Response, error) {
 	return r0, r1
 }
 
-// GetRoles provides a mock function with given fields:
-func (_m *RoleService) GetRoles() (*domain.Response, error) {
-	ret := _m.Called()
+// GetRoles provides a mock function with given fields: request
+func (_m *RoleService) GetRoles(request *domain.GetRolesRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
 
 	var r0 *domain.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*domain.Response, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(*domain.GetRolesRequest) (*domain.Response, error)); ok {
+		return rf(request)
 	}
-	if rf, ok := ret.Get(0).(func() *domain.Response); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(*domain.GetRolesRequest) *domain.Response); ok {
+		r0 = rf(request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Response)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(*domain.GetRolesRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchRole provides a mock function with given fields: request
+func (_m *RoleService) PatchRole(request *domain.PatchRoleRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.PatchRoleRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.PatchRoleRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.PatchRoleRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreRole provides a mock function with given fields: request
+func (_m *RoleService) RestoreRole(request *domain.RestoreRoleRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.RestoreRoleRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.RestoreRoleRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.RestoreRoleRequest) error); ok {
+		r1 = rf(request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,43 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SafeguardService is an autogenerated mock type for the SafeguardService type
+type SafeguardService struct {
+	mock.Mock
+}
+
+// CheckLockout provides a mock function with given fields: change
+func (_m *SafeguardService) CheckLockout(change *domain.AccessChange) error {
+	ret := _m.Called(change)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.AccessChange) error); ok {
+		r0 = rf(change)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewSafeguardService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSafeguardService creates a new instance of SafeguardService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSafeguardService(t mockConstructorTestingTNewSafeguardService) *SafeguardService {
+	mock := &SafeguardService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,147 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserAttributeRepository is an autogenerated mock type for the UserAttributeRepository type
+type UserAttributeRepository struct {
+	mock.Mock
+}
+
+// CountUsersWithoutAttribute provides a mock function with given fields: name
+func (_m *UserAttributeRepository) CountUsersWithoutAttribute(name string) (int64, error) {
+	ret := _m.Called(name)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUserAttribute provides a mock function with given fields: attribute
+func (_m *UserAttributeRepository) CreateUserAttribute(attribute *domain.UserAttribute) error {
+	ret := _m.Called(attribute)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.UserAttribute) error); ok {
+		r0 = rf(attribute)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUserAttribute provides a mock function with given fields: name
+func (_m *UserAttributeRepository) DeleteUserAttribute(name string) error {
+	ret := _m.Called(name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetUserAttributeByName provides a mock function with given fields: name
+func (_m *UserAttributeRepository) GetUserAttributeByName(name string) (*domain.UserAttribute, error) {
+	ret := _m.Called(name)
+
+	var r0 *domain.UserAttribute
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.UserAttribute, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.UserAttribute); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserAttribute)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserAttributes provides a mock function with given fields:
+func (_m *UserAttributeRepository) GetUserAttributes() ([]*domain.UserAttribute, error) {
+	ret := _m.Called()
+
+	var r0 []*domain.UserAttribute
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*domain.UserAttribute, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*domain.UserAttribute); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.UserAttribute)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUserAttribute provides a mock function with given fields: attribute
+func (_m *UserAttributeRepository) UpdateUserAttribute(attribute *domain.UserAttribute) error {
+	ret := _m.Called(attribute)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.UserAttribute) error); ok {
+		r0 = rf(attribute)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewUserAttributeRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUserAttributeRepository creates a new instance of UserAttributeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUserAttributeRepository(t mockConstructorTestingTNewUserAttributeRepository) *UserAttributeRepository {
+	mock := &UserAttributeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
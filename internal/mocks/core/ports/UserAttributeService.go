@@ -0,0 +1,225 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserAttributeService is an autogenerated mock type for the UserAttributeService type
+type UserAttributeService struct {
+	mock.Mock
+}
+
+// ClaimAttributes provides a mock function with given fields: attributes
+func (_m *UserAttributeService) ClaimAttributes(attributes map[string]interface{}) (map[string]interface{}, error) {
+	ret := _m.Called(attributes)
+
+	var r0 map[string]interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (map[string]interface{}, error)); ok {
+		return rf(attributes)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) map[string]interface{}); ok {
+		r0 = rf(attributes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(attributes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUserAttribute provides a mock function with given fields: request
+func (_m *UserAttributeService) CreateUserAttribute(request *domain.CreateUserAttributeRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.CreateUserAttributeRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.CreateUserAttributeRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.CreateUserAttributeRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUserAttribute provides a mock function with given fields: name
+func (_m *UserAttributeService) DeleteUserAttribute(name string) (*domain.Response, error) {
+	ret := _m.Called(name)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Response, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Response); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserAttribute provides a mock function with given fields: name
+func (_m *UserAttributeService) GetUserAttribute(name string) (*domain.Response, error) {
+	ret := _m.Called(name)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Response, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Response); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserAttributes provides a mock function with given fields:
+func (_m *UserAttributeService) GetUserAttributes() (*domain.Response, error) {
+	ret := _m.Called()
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*domain.Response, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *domain.Response); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParseAttributeFilters provides a mock function with given fields: filters
+func (_m *UserAttributeService) ParseAttributeFilters(filters []string) ([]*domain.AttributeFilter, error) {
+	ret := _m.Called(filters)
+
+	var r0 []*domain.AttributeFilter
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]*domain.AttributeFilter, error)); ok {
+		return rf(filters)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []*domain.AttributeFilter); ok {
+		r0 = rf(filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AttributeFilter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUserAttribute provides a mock function with given fields: request
+func (_m *UserAttributeService) UpdateUserAttribute(request *domain.UpdateUserAttributeRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.UpdateUserAttributeRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.UpdateUserAttributeRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.UpdateUserAttributeRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateAttributes provides a mock function with given fields: attributes
+func (_m *UserAttributeService) ValidateAttributes(attributes map[string]interface{}) error {
+	ret := _m.Called(attributes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(attributes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewUserAttributeService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUserAttributeService creates a new instance of UserAttributeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUserAttributeService(t mockConstructorTestingTNewUserAttributeService) *UserAttributeService {
+	mock := &UserAttributeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
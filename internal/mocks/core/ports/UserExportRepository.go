@@ -0,0 +1,43 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserExportRepository is an autogenerated mock type for the UserExportRepository type
+type UserExportRepository struct {
+	mock.Mock
+}
+
+// ExportUsers provides a mock function with given fields: query, write
+func (_m *UserExportRepository) ExportUsers(query *domain.UserExportQuery, write func(*domain.UserExport) error) error {
+	ret := _m.Called(query, write)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.UserExportQuery, func(*domain.UserExport) error) error); ok {
+		r0 = rf(query, write)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewUserExportRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUserExportRepository creates a new instance of UserExportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUserExportRepository(t mockConstructorTestingTNewUserExportRepository) *UserExportRepository {
+	mock := &UserExportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,43 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserExportService is an autogenerated mock type for the UserExportService type
+type UserExportService struct {
+	mock.Mock
+}
+
+// ExportUsers provides a mock function with given fields: request, write
+func (_m *UserExportService) ExportUsers(request *domain.ExportUsersRequest, write func(*domain.UserExport) error) error {
+	ret := _m.Called(request, write)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.ExportUsersRequest, func(*domain.UserExport) error) error); ok {
+		r0 = rf(request, write)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewUserExportService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUserExportService creates a new instance of UserExportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUserExportService(t mockConstructorTestingTNewUserExportService) *UserExportService {
+	mock := &UserExportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
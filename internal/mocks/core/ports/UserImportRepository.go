@@ -0,0 +1,123 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// UserImportRepository is an autogenerated mock type for the UserImportRepository type
+type UserImportRepository struct {
+	mock.Mock
+}
+
+// CreateUserImport provides a mock function with given fields: userImport
+func (_m *UserImportRepository) CreateUserImport(userImport *domain.UserImport) error {
+	ret := _m.Called(userImport)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.UserImport) error); ok {
+		r0 = rf(userImport)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FailStaleUserImports provides a mock function with given fields: updatedBefore, finishedAt
+func (_m *UserImportRepository) FailStaleUserImports(updatedBefore time.Time, finishedAt time.Time) (int64, error) {
+	ret := _m.Called(updatedBefore, finishedAt)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) (int64, error)); ok {
+		return rf(updatedBefore, finishedAt)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) int64); ok {
+		r0 = rf(updatedBefore, finishedAt)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time) error); ok {
+		r1 = rf(updatedBefore, finishedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserImportByID provides a mock function with given fields: id
+func (_m *UserImportRepository) GetUserImportByID(id string) (*domain.UserImport, error) {
+	ret := _m.Called(id)
+
+	var r0 *domain.UserImport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.UserImport, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.UserImport); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserImport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportUsers provides a mock function with given fields: users, grants
+func (_m *UserImportRepository) ImportUsers(users []*domain.User, grants []*domain.UserRoleGrant) error {
+	ret := _m.Called(users, grants)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*domain.User, []*domain.UserRoleGrant) error); ok {
+		r0 = rf(users, grants)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserImport provides a mock function with given fields: userImport
+func (_m *UserImportRepository) UpdateUserImport(userImport *domain.UserImport) error {
+	ret := _m.Called(userImport)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.UserImport) error); ok {
+		r0 = rf(userImport)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewUserImportRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUserImportRepository creates a new instance of UserImportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUserImportRepository(t mockConstructorTestingTNewUserImportRepository) *UserImportRepository {
+	mock := &UserImportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
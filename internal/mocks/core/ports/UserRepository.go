@@ -6,6 +6,8 @@ import (
 	domain "user-svc/internal/core/domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // UserRepository is an autogenerated mock type for the UserRepository type
@@ -27,13 +29,13 @@ func (_m *UserRepository) CreateUser(user *domain.User) error {
 	return r0
 }
 
-// DeleteUser provides a mock function with given fields: id
-func (_m *UserRepository) DeleteUser(id string) error {
-	ret := _m.Called(id)
+// DeleteUser provides a mock function with given fields: id, version, deletedAt
+func (_m *UserRepository) DeleteUser(id string, version int64, deletedAt time.Time) error {
+	ret := _m.Called(id, version, deletedAt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(string, int64, time.Time) error); ok {
+		r0 = rf(id, version, deletedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -41,25 +43,51 @@ func (_m *UserRepository) DeleteUser(id string) error {
 	return r0
 }
 
-// GetAllUsers provides a mock function with given fields:
-func (_m *UserRepository) GetAllUsers() ([]*domain.User, error) {
-	ret := _m.Called()
+// GetDeletedUserByID provides a mock function with given fields: id
+func (_m *UserRepository) GetDeletedUserByID(id string) (*domain.User, error) {
+	ret := _m.Called(id)
 
-	var r0 []*domain.User
+	var r0 *domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]*domain.User, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(string) (*domain.User, error)); ok {
+		return rf(id)
 	}
-	if rf, ok := ret.Get(0).(func() []*domain.User); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string) *domain.User); ok {
+		r0 = rf(id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*domain.User)
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExistingEmails provides a mock function with given fields: emails
+func (_m *UserRepository) GetExistingEmails(emails []string) ([]string, error) {
+	ret := _m.Called(emails)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]string, error)); ok {
+		return rf(emails)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []string); ok {
+		r0 = rf(emails)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(emails)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -119,6 +147,169 @@ func (_m *UserRepository) GetUserByID(id string) (*domain.User, error) {
 	return r0, r1
 }
 
+// GetUsers provides a mock function with given fields: filter
+func (_m *UserRepository) GetUsers(filter *domain.UserFilter) ([]*domain.User, int64, error) {
+	ret := _m.Called(filter)
+
+	var r0 []*domain.User
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*domain.UserFilter) ([]*domain.User, int64, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.UserFilter) []*domain.User); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.UserFilter) int64); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(*domain.UserFilter) error); ok {
+		r2 = rf(filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetUsersAfter provides a mock function with given fields: filter
+func (_m *UserRepository) GetUsersAfter(filter *domain.UserFilter) ([]*domain.User, error) {
+	ret := _m.Called(filter)
+
+	var r0 []*domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.UserFilter) ([]*domain.User, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.UserFilter) []*domain.User); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.UserFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsersByIDs provides a mock function with given fields: ids
+func (_m *UserRepository) GetUsersByIDs(ids []string) ([]*domain.User, error) {
+	ret := _m.Called(ids)
+
+	var r0 []*domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]*domain.User, error)); ok {
+		return rf(ids)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []*domain.User); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchUser provides a mock function with given fields: id, version, patch
+func (_m *UserRepository) PatchUser(id string, version int64, patch *domain.UserPatch) error {
+	ret := _m.Called(id, version, patch)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64, *domain.UserPatch) error); ok {
+		r0 = rf(id, version, patch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PurgeUsers provides a mock function with given fields: before
+func (_m *UserRepository) PurgeUsers(before time.Time) (int64, error) {
+	ret := _m.Called(before)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreUser provides a mock function with given fields: id, restoredAt
+func (_m *UserRepository) RestoreUser(id string, restoredAt time.Time) error {
+	ret := _m.Called(id, restoredAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(id, restoredAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchUsers provides a mock function with given fields: query
+func (_m *UserRepository) SearchUsers(query *domain.UserSearchQuery) (*domain.UserSearchResult, error) {
+	ret := _m.Called(query)
+
+	var r0 *domain.UserSearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.UserSearchQuery) (*domain.UserSearchResult, error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.UserSearchQuery) *domain.UserSearchResult); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserSearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.UserSearchQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateUser provides a mock function with given fields: user
 func (_m *UserRepository) UpdateUser(user *domain.User) error {
 	ret := _m.Called(user)
@@ -6,6 +6,8 @@ import (
 	domain "user-svc/internal/core/domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // UserRoleRepository is an autogenerated mock type for the UserRoleRepository type
@@ -13,13 +15,13 @@ type UserRoleRepository struct {
 	mock.Mock
 }
 
-// AddUserRoles provides a mock function with given fields: userID, roles
-func (_m *UserRoleRepository) AddUserRoles(userID string, roles []string) error {
-	ret := _m.Called(userID, roles)
+// AddUserRoles provides a mock function with given fields: userID, roles, expiresAt
+func (_m *UserRoleRepository) AddUserRoles(userID string, roles []string, expiresAt *time.Time) error {
+	ret := _m.Called(userID, roles, expiresAt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
-		r0 = rf(userID, roles)
+	if rf, ok := ret.Get(0).(func(string, []string, *time.Time) error); ok {
+		r0 = rf(userID, roles, expiresAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,6 +29,176 @@ func (_m *UserRoleRepository) AddUserRoles(userID string, roles []string) error
 	return r0
 }
 
+// GetDirectRoleUsers provides a mock function with given fields: roleID, limit, offset
+func (_m *UserRoleRepository) GetDirectRoleUsers(roleID string, limit int, offset int) ([]*domain.User, int64, error) {
+	ret := _m.Called(roleID, limit, offset)
+
+	var r0 []*domain.User
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]*domain.User, int64, error)); ok {
+		return rf(roleID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []*domain.User); ok {
+		r0 = rf(roleID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) int64); ok {
+		r1 = rf(roleID, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, int, int) error); ok {
+		r2 = rf(roleID, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetDirectUserRoles provides a mock function with given fields: userID
+func (_m *UserRoleRepository) GetDirectUserRoles(userID string) ([]*domain.Role, error) {
+	ret := _m.Called(userID)
+
+	var r0 []*domain.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*domain.Role, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*domain.Role); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPermissionHolders provides a mock function with given fields: permissions
+func (_m *UserRoleRepository) GetPermissionHolders(permissions []string) ([]*domain.PermissionHolder, error) {
+	ret := _m.Called(permissions)
+
+	var r0 []*domain.PermissionHolder
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]*domain.PermissionHolder, error)); ok {
+		return rf(permissions)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []*domain.PermissionHolder); ok {
+		r0 = rf(permissions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.PermissionHolder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(permissions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleHolderIDs provides a mock function with given fields: roleID
+func (_m *UserRoleRepository) GetRoleHolderIDs(roleID string) ([]string, error) {
+	ret := _m.Called(roleID)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(roleID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(roleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(roleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoleUsers provides a mock function with given fields: roleID, limit, offset
+func (_m *UserRoleRepository) GetRoleUsers(roleID string, limit int, offset int) ([]*domain.User, int64, error) {
+	ret := _m.Called(roleID, limit, offset)
+
+	var r0 []*domain.User
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]*domain.User, int64, error)); ok {
+		return rf(roleID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []*domain.User); ok {
+		r0 = rf(roleID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) int64); ok {
+		r1 = rf(roleID, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, int, int) error); ok {
+		r2 = rf(roleID, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetUserPermissions provides a mock function with given fields: userID
+func (_m *UserRoleRepository) GetUserPermissions(userID string) ([]*domain.EffectivePermission, error) {
+	ret := _m.Called(userID)
+
+	var r0 []*domain.EffectivePermission
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*domain.EffectivePermission, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*domain.EffectivePermission); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.EffectivePermission)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserRoles provides a mock function with given fields: userID
 func (_m *UserRoleRepository) GetUserRoles(userID string) ([]*domain.Role, error) {
 	ret := _m.Called(userID)
@@ -53,6 +225,58 @@ func (_m *UserRoleRepository) GetUserRoles(userID string) ([]*domain.Role, error
 	return r0, r1
 }
 
+// GetUsersRoleNames provides a mock function with given fields: userIDs
+func (_m *UserRoleRepository) GetUsersRoleNames(userIDs []string) (map[string][]string, error) {
+	ret := _m.Called(userIDs)
+
+	var r0 map[string][]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) (map[string][]string, error)); ok {
+		return rf(userIDs)
+	}
+	if rf, ok := ret.Get(0).(func([]string) map[string][]string); ok {
+		r0 = rf(userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveExpiredUserRoles provides a mock function with given fields: now
+func (_m *UserRoleRepository) RemoveExpiredUserRoles(now time.Time) ([]string, error) {
+	ret := _m.Called(now)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]string, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []string); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveUserRoles provides a mock function with given fields: userID, roles
 func (_m *UserRoleRepository) RemoveUserRoles(userID string, roles []string) error {
 	ret := _m.Called(userID, roles)
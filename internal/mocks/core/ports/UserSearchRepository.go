@@ -0,0 +1,97 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserSearchRepository is an autogenerated mock type for the UserSearchRepository type
+type UserSearchRepository struct {
+	mock.Mock
+}
+
+// DeleteUser provides a mock function with given fields: id
+func (_m *UserSearchRepository) DeleteUser(id string) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IndexUsers provides a mock function with given fields: users
+func (_m *UserSearchRepository) IndexUsers(users []*domain.UserDocument) error {
+	ret := _m.Called(users)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*domain.UserDocument) error); ok {
+		r0 = rf(users)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetUserIndex provides a mock function with given fields:
+func (_m *UserSearchRepository) ResetUserIndex() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchUsers provides a mock function with given fields: query
+func (_m *UserSearchRepository) SearchUsers(query *domain.UserSearchQuery) (*domain.UserSearchResult, error) {
+	ret := _m.Called(query)
+
+	var r0 *domain.UserSearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.UserSearchQuery) (*domain.UserSearchResult, error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.UserSearchQuery) *domain.UserSearchResult); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserSearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.UserSearchQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewUserSearchRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUserSearchRepository creates a new instance of UserSearchRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUserSearchRepository(t mockConstructorTestingTNewUserSearchRepository) *UserSearchRepository {
+	mock := &UserSearchRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
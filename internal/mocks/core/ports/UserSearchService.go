@@ -0,0 +1,117 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "user-svc/internal/core/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserSearchService is an autogenerated mock type for the UserSearchService type
+type UserSearchService struct {
+	mock.Mock
+}
+
+// IndexRoleHolders provides a mock function with given fields: roleID
+func (_m *UserSearchService) IndexRoleHolders(roleID string) {
+	_m.Called(roleID)
+}
+
+// IndexUser provides a mock function with given fields: userID
+func (_m *UserSearchService) IndexUser(userID string) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IndexUsers provides a mock function with given fields: userIDs
+func (_m *UserSearchService) IndexUsers(userIDs []string) {
+	_m.Called(userIDs)
+}
+
+// Reindex provides a mock function with given fields:
+func (_m *UserSearchService) Reindex() (int, error) {
+	ret := _m.Called()
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveUser provides a mock function with given fields: userID
+func (_m *UserSearchService) RemoveUser(userID string) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchUsers provides a mock function with given fields: request
+func (_m *UserSearchService) SearchUsers(request *domain.SearchUsersRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.SearchUsersRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.SearchUsersRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.SearchUsersRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewUserSearchService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUserSearchService creates a new instance of UserSearchService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUserSearchService(t mockConstructorTestingTNewUserSearchService) *UserSearchService {
+	mock := &UserSearchService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -39,25 +39,25 @@ func (_m *UserService) CreateUser(request *domain.CreateUserRequest) (*domain.Re
 	return r0, r1
 }
 
-// DeleteUser provides a mock function with given fields: id
-func (_m *UserService) DeleteUser(id string) (*domain.Response, error) {
-	ret := _m.Called(id)
+// DeleteUser provides a mock function with given fields: request
+func (_m *UserService) DeleteUser(request *domain.DeleteUserRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
 
 	var r0 *domain.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*domain.Response, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(*domain.DeleteUserRequest) (*domain.Response, error)); ok {
+		return rf(request)
 	}
-	if rf, ok := ret.Get(0).(func(string) *domain.Response); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(*domain.DeleteUserRequest) *domain.Response); ok {
+		r0 = rf(request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Response)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(*domain.DeleteUserRequest) error); ok {
+		r1 = rf(request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -91,25 +91,77 @@ func (_m *UserService) GetUser(id string) (*domain.Response, error) {
 	return r0, r1
 }
 
-// GetUsers provides a mock function with given fields:
-func (_m *UserService) GetUsers() (*domain.Response, error) {
-	ret := _m.Called()
+// GetUsers provides a mock function with given fields: request
+func (_m *UserService) GetUsers(request *domain.GetUsersRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
 
 	var r0 *domain.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*domain.Response, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(*domain.GetUsersRequest) (*domain.Response, error)); ok {
+		return rf(request)
 	}
-	if rf, ok := ret.Get(0).(func() *domain.Response); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(*domain.GetUsersRequest) *domain.Response); ok {
+		r0 = rf(request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Response)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(*domain.GetUsersRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchUser provides a mock function with given fields: request
+func (_m *UserService) PatchUser(request *domain.PatchUserRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.PatchUserRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.PatchUserRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.PatchUserRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreUser provides a mock function with given fields: request
+func (_m *UserService) RestoreUser(request *domain.RestoreUserRequest) (*domain.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *domain.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.RestoreUserRequest) (*domain.Response, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.RestoreUserRequest) *domain.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.RestoreUserRequest) error); ok {
+		r1 = rf(request)
 	} else {
 		r1 = ret.Error(1)
 	}
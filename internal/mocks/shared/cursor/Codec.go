@@ -0,0 +1,74 @@
+// Code generated by mockery v2.27.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Codec is an autogenerated mock type for the Codec type
+type Codec struct {
+	mock.Mock
+}
+
+// Decode provides a mock function with given fields: kind, token
+func (_m *Codec) Decode(kind string, token string) (time.Time, string, error) {
+	ret := _m.Called(kind, token)
+
+	var r0 time.Time
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string) (time.Time, string, error)); ok {
+		return rf(kind, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) time.Time); ok {
+		r0 = rf(kind, token)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) string); ok {
+		r1 = rf(kind, token)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(kind, token)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Encode provides a mock function with given fields: kind, createdAt, id
+func (_m *Codec) Encode(kind string, createdAt time.Time, id string) string {
+	ret := _m.Called(kind, createdAt, id)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, time.Time, string) string); ok {
+		r0 = rf(kind, createdAt, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewCodec interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewCodec creates a new instance of Codec. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewCodec(t mockConstructorTestingTNewCodec) *Codec {
+	mock := &Codec{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -20,6 +20,116 @@ type (
 		Debug       bool   `json:"debug" validate:"required"`
 		Key         string `json:"key" validate:"required"`
 		Auth        auth   `json:"auth" validate:"required"`
+		// SuperAdminPermissions must always be held by at least one active user
+		SuperAdminPermissions []string        `json:"superAdminPermissions"`
+		AccessRequest         accessRequest   `json:"accessRequest"`
+		AdminScope            adminScope      `json:"adminScope"`
+		Rebac                 rebac           `json:"rebac"`
+		PermissionUsage       permissionUsage `json:"permissionUsage"`
+		BreakGlass            breakGlass      `json:"breakGlass"`
+		Notification          notification    `json:"notification"`
+		UserSearch            userSearch      `json:"userSearch"`
+		SoftDelete            softDelete      `json:"softDelete"`
+		UserImport            userImport      `json:"userImport"`
+		UserExport            userExport      `json:"userExport"`
+		Scim                  scim            `json:"scim"`
+	}
+
+	scim struct {
+		// Token is the bearer token the identity provider authenticates with,
+		// SCIM is disabled while it is empty
+		Token string `json:"token"`
+		// ActorId is the user provisioning acts as, role assignments are
+		// checked against its admin scope
+		ActorId string `json:"actorId"`
+	}
+
+	userExport struct {
+		// FetchSize is the number of rows read from the cursor at a time, 500 when not positive
+		FetchSize int `json:"fetchSize"`
+	}
+
+	userImport struct {
+		// MaxRows is the largest file accepted by an import, in rows
+		MaxRows int `json:"maxRows"`
+		// MaxBytes is the largest file accepted by an import, in bytes
+		MaxBytes int64 `json:"maxBytes"`
+		// BatchSize is the number of users written per transaction, 500 when not positive
+		BatchSize int `json:"batchSize"`
+	}
+
+	softDelete struct {
+		// RetentionDays keeps deleted users, roles and permissions restorable for the given days before they are purged
+		RetentionDays int `json:"retentionDays"`
+		// PurgeInterval looks for deletions past the retention every given minutes, 0 disables the purge
+		PurgeInterval int64 `json:"purgeInterval"`
+	}
+
+	userSearch struct {
+		// Enable searches users in OpenSearch, postgres is searched with ILIKE otherwise
+		Enable bool `json:"enable"`
+		// Index is the OpenSearch index users are kept in
+		Index string `json:"index"`
+	}
+
+	breakGlass struct {
+		// Role is the name of the role granted in an emergency, break-glass is disabled when empty
+		Role string `json:"role"`
+		// Duration of the grant in minutes
+		Duration int64 `json:"duration"`
+		// MaxAttempts is the number of failed second factors within the code window that locks activation, 5 when not positive
+		MaxAttempts int64 `json:"maxAttempts"`
+	}
+
+	notification struct {
+		// WebhookURL receives notifications as JSON, they are only logged when empty
+		WebhookURL string `json:"webhookUrl"`
+	}
+
+	permissionUsage struct {
+		// FlushInterval moves the usage aggregated in redis to postgres every given seconds, 0 disables the flush
+		FlushInterval int64 `json:"flushInterval"`
+		// UnusedDays is how long a grant may go unused before it is reported, unless the report asks otherwise
+		UnusedDays int `json:"unusedDays"`
+	}
+
+	rebac struct {
+		// MaxDepth bounds how many rewrites and usersets a check may follow
+		MaxDepth   int              `json:"maxDepth"`
+		Namespaces []rebacNamespace `json:"namespaces"`
+	}
+
+	rebacNamespace struct {
+		Name      string          `json:"name"`
+		Relations []rebacRelation `json:"relations"`
+	}
+
+	// rebacRelation is granted by its own tuples, and by the union of its rewrites
+	rebacRelation struct {
+		Name string `json:"name"`
+		// ComputedUsersets lists relations on the same object that imply this one
+		ComputedUsersets []string `json:"computedUsersets"`
+		// TupleToUsersets follow a relation to other objects and check a relation there
+		TupleToUsersets []rebacTupleToUserset `json:"tupleToUsersets"`
+	}
+
+	rebacTupleToUserset struct {
+		Tupleset        string `json:"tupleset"`
+		ComputedUserset string `json:"computedUserset"`
+	}
+
+	adminScope struct {
+		// UnrestrictedRoles lists the role names that may grant any role and attach any permission
+		UnrestrictedRoles []string `json:"unrestrictedRoles"`
+	}
+
+	accessRequest struct {
+		// ApproverRoles lists the role names allowed to review access requests, any approver when empty
+		ApproverRoles []string `json:"approverRoles"`
+		// MaxDuration caps a granted role in minutes, grants are permanent when zero
+		MaxDuration int64 `json:"maxDuration"`
+		// PendingLifetime expires unreviewed requests after the given minutes
+		PendingLifetime int64 `json:"pendingLifetime"`
 	}
 
 	auth struct {
@@ -87,6 +197,22 @@ func New() *Config {
 	viper.AddConfigPath("../../../../../../configs")
 	viper.WatchConfig()
 
+	viper.SetDefault("App.SuperAdminPermissions", []string{"Update-Role", "Update-Permission"})
+	viper.SetDefault("App.AccessRequest.MaxDuration", 10080)
+	viper.SetDefault("App.AccessRequest.PendingLifetime", 4320)
+	viper.SetDefault("App.AdminScope.UnrestrictedRoles", []string{"Admin"})
+	viper.SetDefault("App.Rebac.MaxDepth", 25)
+	viper.SetDefault("App.PermissionUsage.FlushInterval", 60)
+	viper.SetDefault("App.PermissionUsage.UnusedDays", 90)
+	viper.SetDefault("App.BreakGlass.Duration", 60)
+	viper.SetDefault("App.BreakGlass.MaxAttempts", 5)
+	viper.SetDefault("App.UserSearch.Index", "users")
+	viper.SetDefault("App.SoftDelete.RetentionDays", 30)
+	viper.SetDefault("App.SoftDelete.PurgeInterval", 60)
+	viper.SetDefault("App.UserImport.MaxRows", 10000)
+	viper.SetDefault("App.UserImport.BatchSize", 500)
+	viper.SetDefault("App.UserImport.MaxBytes", 10<<20)
+	viper.SetDefault("App.UserExport.FetchSize", 500)
 	viper.SetDefault("Database.Pgsql.Host", "127.0.0.1")
 	viper.SetDefault("Database.Pgsql.Port", 5432)
 	viper.SetDefault("Database.Pgsql.Database", "postgres")
@@ -5,7 +5,9 @@ const (
 	KeyBearer        = "Bearer"
 	KeyAuthID        = "authID"
 	KeyUserID        = "userID"
+	KeyBreakGlassID  = "breakGlassID"
 	KeyGenerateTime  = "generateTime"
 	KeyExp           = "exp"
 	KeyTokenType     = "tokenType"
+	KeyAttributes    = "attributes"
 )
@@ -0,0 +1,74 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+	"user-svc/internal/shared/config"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Codec turns a keyset position into an opaque token and back. Tokens are
+// signed, so clients cannot craft positions, and bound to the kind of list
+// they were issued for.
+type Codec interface {
+	Encode(kind string, createdAt time.Time, id string) string
+	Decode(kind string, token string) (time.Time, string, error)
+}
+
+type codec struct {
+	key []byte
+}
+
+type position struct {
+	Kind      string    `json:"k"`
+	CreatedAt time.Time `json:"t"`
+	Id        string    `json:"i"`
+}
+
+func NewCodec(config *config.Config) Codec {
+	return &codec{
+		key: []byte(config.App.Key),
+	}
+}
+
+func (c *codec) Encode(kind string, createdAt time.Time, id string) string {
+	payload, _ := json.Marshal(&position{Kind: kind, CreatedAt: createdAt, Id: id})
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded))
+}
+
+func (c *codec) Decode(kind string, token string) (time.Time, string, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+
+	expected, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, c.sign(encoded)) {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+
+	var decoded position
+	if err := json.Unmarshal(payload, &decoded); err != nil || decoded.Kind != kind {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+
+	return decoded.CreatedAt, decoded.Id, nil
+}
+
+func (c *codec) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,85 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+	"user-svc/internal/shared/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCodec(key string) Codec {
+	cfg := &config.Config{}
+	cfg.App.Key = key
+	return NewCodec(cfg)
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	c := newTestCodec("secret")
+	createdAt := time.Date(2024, 3, 1, 12, 30, 0, 123456789, time.UTC)
+
+	token := c.Encode("users", createdAt, "user-1")
+	gotCreatedAt, gotID, err := c.Decode("users", token)
+	assert.NoError(t, err)
+	assert.True(t, createdAt.Equal(gotCreatedAt))
+	assert.Equal(t, "user-1", gotID)
+}
+
+func TestCodec_Decode(t *testing.T) {
+	c := newTestCodec("secret")
+	token := c.Encode("users", time.Now(), "user-1")
+	encoded, signature, _ := strings.Cut(token, ".")
+
+	forged, _ := base64.RawURLEncoding.DecodeString(encoded)
+	forged = []byte(strings.Replace(string(forged), "user-1", "user-2", 1))
+
+	tests := []struct {
+		name  string
+		kind  string
+		token string
+	}{
+		{
+			name:  "tampered payload",
+			kind:  "users",
+			token: base64.RawURLEncoding.EncodeToString(forged) + "." + signature,
+		},
+		{
+			name:  "tampered signature",
+			kind:  "users",
+			token: encoded + "." + base64.RawURLEncoding.EncodeToString([]byte("not the signature")),
+		},
+		{
+			name:  "signature not base64",
+			kind:  "users",
+			token: encoded + ".!!!",
+		},
+		{
+			name:  "signed with another key",
+			kind:  "users",
+			token: newTestCodec("other").Encode("users", time.Now(), "user-1"),
+		},
+		{
+			name:  "token of another kind",
+			kind:  "roles",
+			token: token,
+		},
+		{
+			name:  "no signature",
+			kind:  "users",
+			token: encoded,
+		},
+		{
+			name:  "empty token",
+			kind:  "users",
+			token: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := c.Decode(tt.kind, tt.token)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import "fmt"
 type AppError struct {
 	Code    int
 	Message string
+	Data    interface{} `json:"data,omitempty"`
 }
 
 func (e *AppError) Error() string {
@@ -0,0 +1,85 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	period = 30
+	digits = 6
+	// skew accepts codes from the neighbouring periods to absorb clock drift
+	skew = 1
+)
+
+// Generate returns the RFC 6238 code of a base32 secret at the given time.
+func Generate(secret string, at time.Time) (string, error) {
+	key, err := decode(secret)
+	if err != nil {
+		return "", err
+	}
+	return code(key, uint64(at.Unix()/period)), nil
+}
+
+// Window is how long a code is accepted, a used code has to be remembered at
+// least this long to refuse it again.
+const Window = (2*skew + 1) * period * time.Second
+
+// NewSecret returns a random base32 secret of 160 bits, the size RFC 4226
+// recommends.
+func NewSecret() (string, error) {
+	key := make([]byte, 20)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(key), nil
+}
+
+// Validate reports whether the code matches the secret at the given time.
+func Validate(secret string, value string, at time.Time) bool {
+	_, ok := Match(secret, value, at)
+	return ok
+}
+
+// Match returns the counter of the period the code matches the secret in, so
+// a caller can refuse a code that was already used.
+func Match(secret string, value string, at time.Time) (int64, bool) {
+	key, err := decode(secret)
+	if err != nil || len(value) != digits {
+		return 0, false
+	}
+
+	counter := at.Unix() / period
+	for i := -skew; i <= skew; i++ {
+		expected := code(key, uint64(counter+int64(i)))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(value)) == 1 {
+			return counter + int64(i), true
+		}
+	}
+	return 0, false
+}
+
+func decode(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	return base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+}
+
+func code(key []byte, counter uint64) string {
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", digits, value%1000000)
+}
@@ -0,0 +1,118 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	rootRelationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	workbookRelationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	workbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	sheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	sheetFooter = `</sheetData></worksheet>`
+)
+
+// Writer streams a workbook with a single sheet of text cells. The sheet is
+// the last part of the package, so rows go straight to the underlying writer
+// and are never held in memory.
+type Writer struct {
+	zip       *zip.Writer
+	sheetName string
+	sheet     io.Writer
+}
+
+func NewWriter(w io.Writer, sheetName string) *Writer {
+	return &Writer{
+		zip:       zip.NewWriter(w),
+		sheetName: sheetName,
+	}
+}
+
+// WriteRow appends a row to the sheet. Nothing is written before the first
+// row, so a writer can still be dropped until then.
+func (w *Writer) WriteRow(cells []string) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	var row strings.Builder
+	row.WriteString("<row>")
+	for _, cell := range cells {
+		row.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		xml.EscapeText(&row, []byte(cell))
+		row.WriteString("</t></is></c>")
+	}
+	row.WriteString("</row>")
+
+	_, err := io.WriteString(w.sheet, row.String())
+	return err
+}
+
+// Close ends the sheet and the package, it does not close the underlying
+// writer.
+func (w *Writer) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w.sheet, sheetFooter); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+func (w *Writer) start() error {
+	if w.sheet != nil {
+		return nil
+	}
+
+	var sheetName strings.Builder
+	xml.EscapeText(&sheetName, []byte(w.sheetName))
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRelationships},
+		{"xl/workbook.xml", fmt.Sprintf(workbook, sheetName.String())},
+		{"xl/_rels/workbook.xml.rels", workbookRelationships},
+	}
+	for _, part := range parts {
+		f, err := w.zip.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+
+	sheet, err := w.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(sheet, sheetHeader); err != nil {
+		return err
+	}
+	w.sheet = sheet
+	return nil
+}
@@ -0,0 +1,100 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Type string `xml:"t,attr"`
+			Text string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type testWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// readPackage opens the workbook as a zip and returns its parts by name, in
+// the order they were written.
+func readPackage(t *testing.T, content []byte) ([]string, map[string][]byte) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	names := make([]string, 0, len(reader.File))
+	parts := make(map[string][]byte, len(reader.File))
+	for _, file := range reader.File {
+		f, err := file.Open()
+		assert.NoError(t, err)
+		part, err := io.ReadAll(f)
+		assert.NoError(t, err)
+		f.Close()
+
+		names = append(names, file.Name)
+		parts[file.Name] = part
+	}
+	return names, parts
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, "Users & Roles")
+	assert.NoError(t, w.WriteRow([]string{"email", "roles"}))
+	assert.NoError(t, w.WriteRow([]string{"ann@example.com", "<Admin> & Viewer"}))
+	assert.NoError(t, w.Close())
+
+	names, parts := readPackage(t, buf.Bytes())
+	assert.Equal(t, []string{
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"xl/workbook.xml",
+		"xl/_rels/workbook.xml.rels",
+		"xl/worksheets/sheet1.xml",
+	}, names)
+
+	for name, part := range parts {
+		var node struct{}
+		assert.NoError(t, xml.Unmarshal(part, &node), name)
+	}
+
+	var book testWorkbook
+	assert.NoError(t, xml.Unmarshal(parts["xl/workbook.xml"], &book))
+	if assert.Len(t, book.Sheets, 1) {
+		assert.Equal(t, "Users & Roles", book.Sheets[0].Name)
+	}
+
+	var sheet testSheet
+	assert.NoError(t, xml.Unmarshal(parts["xl/worksheets/sheet1.xml"], &sheet))
+	if assert.Len(t, sheet.Rows, 2) {
+		cells := sheet.Rows[1].Cells
+		if assert.Len(t, cells, 2) {
+			assert.Equal(t, "inlineStr", cells[1].Type)
+			assert.Equal(t, "ann@example.com", cells[0].Text)
+			assert.Equal(t, "<Admin> & Viewer", cells[1].Text)
+		}
+	}
+}
+
+func TestWriter_NoRows(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, NewWriter(&buf, "Users").Close())
+
+	names, parts := readPackage(t, buf.Bytes())
+	assert.Len(t, names, 5)
+
+	var sheet testSheet
+	assert.NoError(t, xml.Unmarshal(parts["xl/worksheets/sheet1.xml"], &sheet))
+	assert.Empty(t, sheet.Rows)
+}